@@ -5,6 +5,7 @@ go 1.22.8
 toolchain go1.22.10
 
 require (
+	cloud.google.com/go/storage v1.43.0
 	github.com/blang/semver/v4 v4.0.0
 	github.com/go-resty/resty/v2 v2.16.2
 	github.com/google/go-cmp v0.6.0
@@ -14,10 +15,12 @@ require (
 	github.com/spf13/cobra v1.8.1
 	github.com/spf13/pflag v1.0.5
 	golang.org/x/sync v0.10.0
+	golang.org/x/term v0.26.0
 	google.golang.org/api v0.210.0
 	k8s.io/klog/v2 v2.130.1
 	k8s.io/release v0.17.12
 	sigs.k8s.io/boskos v0.0.0-20241205030959-9f79a9e4406a
+	sigs.k8s.io/yaml v1.4.0
 )
 
 require (
@@ -26,7 +29,6 @@ require (
 	cloud.google.com/go/auth/oauth2adapt v0.2.6 // indirect
 	cloud.google.com/go/compute/metadata v0.5.2 // indirect
 	cloud.google.com/go/iam v1.2.2 // indirect
-	cloud.google.com/go/storage v1.43.0 // indirect
 	cuelabs.dev/go/oci/ociregistry v0.0.0-20240314152124-224736b49f2e // indirect
 	cuelang.org/go v0.8.1 // indirect
 	dario.cat/mergo v1.0.0 // indirect
@@ -253,7 +255,6 @@ require (
 	golang.org/x/net v0.31.0 // indirect
 	golang.org/x/oauth2 v0.24.0 // indirect
 	golang.org/x/sys v0.27.0 // indirect
-	golang.org/x/term v0.26.0 // indirect
 	golang.org/x/text v0.20.0 // indirect
 	golang.org/x/time v0.8.0 // indirect
 	golang.org/x/tools/go/vcs v0.1.0-deprecated // indirect
@@ -286,5 +287,4 @@ require (
 	sigs.k8s.io/release-sdk v0.12.1 // indirect
 	sigs.k8s.io/release-utils v0.8.4 // indirect
 	sigs.k8s.io/structured-merge-diff/v4 v4.4.1 // indirect
-	sigs.k8s.io/yaml v1.4.0 // indirect
 )
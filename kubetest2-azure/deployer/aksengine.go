@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// upAKSEngine generates the ARM templates of --api-model with aks-engine,
+// deploys them to the resource group and copies the kubeconfig aks-engine
+// writes for the location
+func (d *deployer) upAKSEngine() error {
+	sshPublicKey, err := d.sshPublicKey()
+	if err != nil {
+		return err
+	}
+	outputDir := filepath.Join(d.commonOptions.RunDir(), "aks-engine")
+	klog.V(0).Infof("Up(): deploying %s to resource group %s...\n", d.APIModel, d.resourceGroup())
+	// aks-engine uses the az CLI login like the rest of the deployer
+	cmd := exec.Command("aks-engine", "deploy",
+		"--api-model="+d.APIModel,
+		"--location="+d.Location,
+		"--subscription-id="+d.SubscriptionID,
+		"--resource-group="+d.resourceGroup(),
+		"--dns-prefix="+d.ClusterName,
+		"--output-directory="+outputDir,
+		"--auth-method=cli",
+		"--set="+aksEngineSetValues(d.ControlPlaneCount, d.WorkerCount, d.ControlPlaneMachineType, d.NodeMachineType, d.KubernetesVersion, strings.TrimSpace(string(sshPublicKey))),
+	)
+	exec.InheritOutput(cmd)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to deploy the cluster: %w", err)
+	}
+
+	klog.V(0).Infof("Up(): exporting kubeconfig to %s...\n", d.KubeconfigPath)
+	kubeconfig, err := os.ReadFile(filepath.Join(outputDir, "kubeconfig", "kubeconfig."+d.Location+".json"))
+	if err != nil {
+		return fmt.Errorf("failed to read the kubeconfig written by aks-engine: %w", err)
+	}
+	return os.WriteFile(d.KubeconfigPath, kubeconfig, 0600)
+}
+
+// aksEngineSetValues returns the aks-engine --set overrides of the API model
+// for the flags of the cluster shape, the first agent pool holds the workers
+func aksEngineSetValues(controlPlaneCount, workerCount int, controlPlaneMachineType, nodeMachineType, kubernetesVersion, sshPublicKey string) string {
+	values := []string{
+		"masterProfile.count=" + strconv.Itoa(controlPlaneCount),
+		"agentPoolProfiles[0].count=" + strconv.Itoa(workerCount),
+	}
+	if controlPlaneMachineType != "" {
+		values = append(values, "masterProfile.vmSize="+controlPlaneMachineType)
+	}
+	if nodeMachineType != "" {
+		values = append(values, "agentPoolProfiles[0].vmSize="+nodeMachineType)
+	}
+	if kubernetesVersion != "" {
+		// aks-engine versions have no v prefix
+		values = append(values, "orchestratorProfile.orchestratorVersion="+strings.TrimPrefix(kubernetesVersion, "v"))
+	}
+	if sshPublicKey != "" {
+		values = append(values, "linuxProfile.ssh.publicKeys[0].keyData="+sshPublicKey)
+	}
+	return strings.Join(values, ",")
+}
+
+// downAKSEngine deletes the resource group of the cluster
+func (d *deployer) downAKSEngine() error {
+	exists, err := exec.Output(exec.Command("az", "group", "exists",
+		"--name="+d.resourceGroup(),
+		"--subscription="+d.SubscriptionID))
+	if err != nil {
+		return fmt.Errorf("failed to check resource group %s: %w", d.resourceGroup(), err)
+	}
+	if strings.TrimSpace(string(exists)) != "true" {
+		klog.V(0).Infof("Down(): resource group %s does not exist\n", d.resourceGroup())
+		return nil
+	}
+	klog.V(0).Infof("Down(): deleting resource group %s...\n", d.resourceGroup())
+	return d.runAz("group", "delete", "--name="+d.resourceGroup(), "--yes")
+}
+
+// runAz executes az in the subscription of the cluster, streaming the
+// output to the console
+func (d *deployer) runAz(args ...string) error {
+	cmd := exec.Command("az", append(args, "--subscription="+d.SubscriptionID)...)
+	exec.InheritOutput(cmd)
+	return cmd.Run()
+}
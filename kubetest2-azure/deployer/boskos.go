@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/boskos"
+)
+
+// acquireSubscription acquires a subscription from boskos when
+// --subscription-id is unset, the name of the resource is the subscription ID
+func (d *deployer) acquireSubscription() error {
+	if d.SubscriptionID != "" {
+		return nil
+	}
+	klog.V(1).Info("No subscription provided, acquiring from Boskos")
+	boskosClient, err := boskos.NewClient(d.BoskosLocation)
+	if err != nil {
+		return fmt.Errorf("failed to make boskos client: %w", err)
+	}
+	resource, err := boskos.Acquire(
+		boskosClient,
+		d.BoskosResourceType,
+		d.BoskosAcquireTimeout,
+		d.BoskosHeartbeatInterval,
+		d.boskosHeartbeatClose,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to acquire a subscription from boskos: %w", err)
+	}
+	d.boskos = boskosClient
+	d.SubscriptionID = resource.Name
+	klog.V(1).Infof("Got subscription %s from boskos", d.SubscriptionID)
+	if err := os.WriteFile(d.subscriptionLeasePath(), []byte(d.SubscriptionID), 0644); err != nil {
+		return fmt.Errorf("failed to record the subscription acquired from boskos: %w", err)
+	}
+	return nil
+}
+
+// subscriptionLeasePath is where the subscription acquired from boskos is
+// recorded, so that a later --down of the same run tears down the cluster in
+// it and releases it
+func (d *deployer) subscriptionLeasePath() string {
+	return filepath.Join(d.commonOptions.RunDir(), "boskos-subscription")
+}
+
+// subscriptionFromLease sets the subscription acquired from boskos by the
+// --up of the same run when --subscription-id is unset.
+func (d *deployer) subscriptionFromLease() error {
+	if d.SubscriptionID != "" {
+		return nil
+	}
+	b, err := os.ReadFile(d.subscriptionLeasePath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("--subscription-id is required by down when the cluster was not created by the same run")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read the subscription acquired from boskos: %w", err)
+	}
+	boskosClient, err := boskos.NewClient(d.BoskosLocation)
+	if err != nil {
+		return fmt.Errorf("failed to make boskos client: %w", err)
+	}
+	d.boskos = boskosClient
+	d.SubscriptionID = strings.TrimSpace(string(b))
+	klog.V(1).Infof("Using subscription %s acquired from boskos by the same run", d.SubscriptionID)
+	return nil
+}
+
+// releaseSubscription releases the subscription if it was acquired from
+// boskos, stopping its heartbeat. It is safe to call more than once.
+func (d *deployer) releaseSubscription() error {
+	if d.boskos == nil {
+		return nil
+	}
+	klog.V(2).Info("releasing boskos subscription")
+	if err := boskos.Release(d.boskos, []string{d.SubscriptionID}, d.boskosHeartbeatClose); err != nil {
+		return fmt.Errorf("failed to release boskos subscription: %w", err)
+	}
+	d.boskos = nil
+	if err := os.Remove(d.subscriptionLeasePath()); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		klog.Warningf("failed to remove the released subscription lease: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+func (d *deployer) Build() error {
+	// the Kubernetes version is installed from the published release packages
+	return fmt.Errorf("the %s deployer does not support --build", Name)
+}
+
+// Unsupported implements types.DeployerWithCapabilities
+func (d *deployer) Unsupported() []types.Capability {
+	return []types.Capability{types.CapabilityBuild}
+}
+
+// assert that deployer implements types.DeployerWithCapabilities
+var _ types.DeployerWithCapabilities = &deployer{}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// capzEnv returns the environment of clusterctl generate cluster, the
+// variables of the azure cluster templates
+func (d *deployer) capzEnv(sshPublicKey []byte) []string {
+	env := os.Environ()
+	for name, value := range map[string]string{
+		"AZURE_SUBSCRIPTION_ID":            d.SubscriptionID,
+		"AZURE_LOCATION":                   d.Location,
+		"AZURE_RESOURCE_GROUP":             d.resourceGroup(),
+		"AZURE_CONTROL_PLANE_MACHINE_TYPE": d.ControlPlaneMachineType,
+		"AZURE_NODE_MACHINE_TYPE":          d.NodeMachineType,
+		"AZURE_SSH_PUBLIC_KEY_B64":         base64.StdEncoding.EncodeToString(sshPublicKey),
+	} {
+		env = append(env, name+"="+value)
+	}
+	return env
+}
+
+// upCAPZ applies the cluster generated by clusterctl to the management
+// cluster and retrieves its kubeconfig once it is ready
+func (d *deployer) upCAPZ() error {
+	sshPublicKey, err := d.sshPublicKey()
+	if err != nil {
+		return err
+	}
+	klog.V(0).Infof("Up(): generating the Cluster API cluster %s...\n", d.ClusterName)
+	args := []string{"generate", "cluster", d.ClusterName,
+		"--kubeconfig=" + d.ManagementKubeconfig,
+		"--infrastructure=azure",
+		"--kubernetes-version=" + d.KubernetesVersion,
+		"--control-plane-machine-count=" + strconv.Itoa(d.ControlPlaneCount),
+		"--worker-machine-count=" + strconv.Itoa(d.WorkerCount),
+	}
+	if d.Flavor != "" {
+		args = append(args, "--flavor="+d.Flavor)
+	}
+	generate := exec.Command("clusterctl", args...)
+	generate.SetEnv(d.capzEnv(sshPublicKey)...)
+	generate.SetStderr(os.Stderr)
+	manifest, err := exec.Output(generate)
+	if err != nil {
+		return fmt.Errorf("failed to generate the cluster: %w", err)
+	}
+	// kept in the run dir to inspect the cluster
+	manifestPath := filepath.Join(d.commonOptions.RunDir(), d.ClusterName+".yaml")
+	if err := os.WriteFile(manifestPath, manifest, 0644); err != nil {
+		return err
+	}
+
+	klog.V(0).Infof("Up(): creating the Cluster API cluster %s...\n", d.ClusterName)
+	if err := d.runManagementKubectl("apply", "-f", manifestPath); err != nil {
+		return fmt.Errorf("failed to create the cluster: %w", err)
+	}
+	if err := d.runManagementKubectl("wait", "--for=condition=Ready", "cluster/"+d.ClusterName, "--timeout="+d.Wait.String()); err != nil {
+		return fmt.Errorf("failed waiting for the cluster to be ready: %w", err)
+	}
+	klog.V(0).Infof("Up(): exporting kubeconfig to %s...\n", d.KubeconfigPath)
+	kubeconfig, err := exec.Output(exec.Command("clusterctl", "get", "kubeconfig", d.ClusterName,
+		"--kubeconfig="+d.ManagementKubeconfig))
+	if err != nil {
+		return fmt.Errorf("failed to export kubeconfig: %w", err)
+	}
+	return os.WriteFile(d.KubeconfigPath, kubeconfig, 0600)
+}
+
+// downCAPZ deletes the cluster from the management cluster, which deletes
+// its VMs and the resource group CAPZ created for it
+func (d *deployer) downCAPZ() error {
+	return d.runManagementKubectl("delete", "cluster", d.ClusterName, "--ignore-not-found", "--wait", "--timeout="+d.Wait.String())
+}
+
+// sshPublicKey returns the contents of --ssh-public-key-file, empty if unset
+func (d *deployer) sshPublicKey() ([]byte, error) {
+	if d.SSHPublicKeyFile == "" {
+		return nil, nil
+	}
+	key, err := os.ReadFile(d.SSHPublicKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read --ssh-public-key-file: %w", err)
+	}
+	return key, nil
+}
+
+// runManagementKubectl executes kubectl against the management cluster,
+// streaming the output to the console
+func (d *deployer) runManagementKubectl(args ...string) error {
+	cmd := exec.Command("kubectl", append([]string{"--kubeconfig=" + d.ManagementKubeconfig}, args...)...)
+	exec.InheritOutput(cmd)
+	return cmd.Run()
+}
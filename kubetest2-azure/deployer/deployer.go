@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package deployer implements the kubetest2 azure deployer, which creates
+// self-managed clusters of Azure VMs either with Cluster API Provider Azure
+// or from ARM templates generated by aks-engine, for tests that need access
+// to the control plane
+package deployer
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/octago/sflags/gen/gpflag"
+	"github.com/spf13/pflag"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/boskos/client"
+
+	"sigs.k8s.io/kubetest2/pkg/artifacts"
+	"sigs.k8s.io/kubetest2/pkg/types"
+	"sigs.k8s.io/kubetest2/pkg/util"
+)
+
+// Name is the name of the deployer
+const Name = "azure"
+
+var GitTag string
+
+const (
+	// modeCAPZ creates a Cluster API cluster on a management cluster
+	modeCAPZ = "capz"
+	// modeAKSEngine deploys the ARM templates generated by aks-engine
+	modeAKSEngine = "aks-engine"
+)
+
+// New implements deployer.New for azure
+func New(opts types.Options) (types.Deployer, *pflag.FlagSet) {
+	// create a deployer object and set fields that are not flag controlled
+	d := &deployer{
+		commonOptions:        opts,
+		logsDir:              filepath.Join(artifacts.BaseDir(), "logs"),
+		boskosHeartbeatClose: make(chan struct{}),
+		Mode:                 modeCAPZ,
+		// names need to start with an alphabet
+		ClusterName:             "kt2-" + util.PseudoUniqueSubstring(opts.RunID()),
+		Location:                "eastus",
+		KubeconfigPath:          filepath.Join(opts.RunDir(), "kubetest2-kubeconfig"),
+		ControlPlaneCount:       1,
+		WorkerCount:             2,
+		ControlPlaneMachineType: "Standard_D2s_v3",
+		NodeMachineType:         "Standard_D2s_v3",
+		BoskosLocation:          "http://boskos.test-pods.svc.cluster.local",
+		BoskosResourceType:      "azure-subscription",
+		BoskosAcquireTimeout:    5 * time.Minute,
+		BoskosHeartbeatInterval: 5 * time.Minute,
+		Wait:                    30 * time.Minute,
+	}
+	// register flags and return
+	return d, bindFlags(d)
+}
+
+// assert that New implements types.NewDeployer
+var _ types.NewDeployer = New
+
+type deployer struct {
+	// generic parts
+	commonOptions types.Options
+
+	Mode        string `desc:"how the cluster is created, \"capz\" creates a Cluster API cluster on the --management-kubeconfig cluster with Cluster API Provider Azure, \"aks-engine\" deploys the ARM templates aks-engine generates from --api-model"`
+	ClusterName string `flag:"cluster-name" desc:"the cluster name, also the DNS prefix of the cluster, defaults to a name derived from the run id"`
+	// Azure placement, the az CLI, aks-engine and the CAPZ cluster identity
+	// must be logged in with access to the subscription
+	SubscriptionID string `flag:"subscription-id" desc:"the Azure subscription the cluster is created in. If unset, a subscription is acquired from boskos, and down uses the one acquired by the up of the same run."`
+	Location       string `desc:"the Azure region of the cluster"`
+	ResourceGroup  string `desc:"the resource group of the cluster, created by up and deleted by down, defaults to --cluster-name"`
+	// cluster shape
+	ControlPlaneCount       int    `desc:"the number of control plane nodes"`
+	WorkerCount             int    `desc:"the number of linux worker nodes"`
+	ControlPlaneMachineType string `desc:"the VM size of the control plane nodes"`
+	NodeMachineType         string `desc:"the VM size of the worker nodes"`
+	KubernetesVersion       string `desc:"the Kubernetes version of the cluster, e.g. v1.30.2. Required by --mode=capz, --mode=aks-engine defaults to the version of --api-model"`
+	CNIManifest             string `flag:"cni-manifest" desc:"the manifest of the pod network applied to the cluster once its kubeconfig is retrieved. Empty skips it, e.g. when the flavor or the api model installs one"`
+	SSHPublicKeyFile        string `flag:"ssh-public-key-file" desc:"path of a public key authorized for SSH access to the nodes"`
+	// Cluster API details
+	ManagementKubeconfig string `desc:"the kubeconfig of the Cluster API management cluster with the Azure provider and its cluster identity installed, required by --mode=capz"`
+	Flavor               string `desc:"the CAPZ cluster template flavor, e.g. external-cloud-provider for cloud-provider-azure"`
+	// aks-engine details
+	APIModel string `flag:"api-model" desc:"path of the aks-engine API model of the cluster, required by --mode=aks-engine. The flags of the cluster shape override it."`
+
+	BoskosLocation          string        `desc:"the location of the boskos server, used when --subscription-id is unset"`
+	BoskosResourceType      string        `desc:"the type of resource to acquire from boskos when --subscription-id is unset, the resource name is the subscription ID"`
+	BoskosAcquireTimeout    time.Duration `desc:"how long to wait for boskos to have a subscription available"`
+	BoskosHeartbeatInterval time.Duration `desc:"how often to send a heartbeat to boskos to hold the acquired subscription, 0 means no heartbeat"`
+
+	KubeconfigPath string        `flag:"kubeconfig" desc:"path the cluster kubeconfig is exported to, defaults to a file in the run dir"`
+	Wait           time.Duration `desc:"how long to wait for the cluster to be ready (e.g. 30m)"`
+
+	logsDir string
+
+	// boskos is non-nil when the subscription was acquired from boskos
+	boskos               *client.Client
+	boskosHeartbeatClose chan struct{}
+}
+
+func (d *deployer) Kubeconfig() (string, error) {
+	return d.KubeconfigPath, nil
+}
+
+// resourceGroup returns --resource-group, defaulting to the cluster name
+func (d *deployer) resourceGroup() string {
+	if d.ResourceGroup != "" {
+		return d.ResourceGroup
+	}
+	return d.ClusterName
+}
+
+func (d *deployer) verifyUpFlags() error {
+	if d.ClusterName == "" {
+		return fmt.Errorf("--cluster-name must not be empty")
+	}
+	if d.Location == "" {
+		return fmt.Errorf("--location must be set")
+	}
+	if d.ControlPlaneCount < 1 {
+		return fmt.Errorf("--control-plane-count must be at least 1, got %d", d.ControlPlaneCount)
+	}
+	if d.WorkerCount < 0 {
+		return fmt.Errorf("--worker-count must not be negative, got %d", d.WorkerCount)
+	}
+	if d.Wait <= 0 {
+		return fmt.Errorf("--wait must be positive, got %v", d.Wait)
+	}
+	switch d.Mode {
+	case modeCAPZ:
+		if d.ManagementKubeconfig == "" || d.KubernetesVersion == "" {
+			return fmt.Errorf("--mode=%s requires --management-kubeconfig and --kubernetes-version", modeCAPZ)
+		}
+	case modeAKSEngine:
+		if d.APIModel == "" {
+			return fmt.Errorf("--mode=%s requires --api-model", modeAKSEngine)
+		}
+		if d.Flavor != "" {
+			return fmt.Errorf("--flavor is only supported with --mode=%s", modeCAPZ)
+		}
+	default:
+		return fmt.Errorf("--mode must be one of %q or %q, got %q", modeCAPZ, modeAKSEngine, d.Mode)
+	}
+	return nil
+}
+
+func (d *deployer) Version() string {
+	return GitTag
+}
+
+// helper used to create & bind a flagset to the deployer
+func bindFlags(d *deployer) *pflag.FlagSet {
+	flags, err := gpflag.Parse(d)
+	if err != nil {
+		klog.Fatalf("unable to generate flags from deployer")
+		return nil
+	}
+
+	// initing the klog flags adds them to goflag.CommandLine
+	// they can then be added to the built pflag set
+	klog.InitFlags(nil)
+	flags.AddGoFlagSet(flag.CommandLine)
+
+	return flags
+}
+
+// assert that deployer implements types.DeployerWithKubeconfig
+var _ types.DeployerWithKubeconfig = &deployer{}
@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+func TestAKSEngineSetValues(t *testing.T) {
+	testCases := []struct {
+		name     string
+		actual   string
+		expected string
+	}{
+		{
+			name:     "counts only",
+			actual:   aksEngineSetValues(1, 2, "", "", "", ""),
+			expected: "masterProfile.count=1,agentPoolProfiles[0].count=2",
+		},
+		{
+			name:   "all overrides",
+			actual: aksEngineSetValues(3, 0, "Standard_D4s_v3", "Standard_D2s_v3", "v1.24.10", "ssh-ed25519 AAAA user"),
+			expected: "masterProfile.count=3,agentPoolProfiles[0].count=0," +
+				"masterProfile.vmSize=Standard_D4s_v3,agentPoolProfiles[0].vmSize=Standard_D2s_v3," +
+				"orchestratorProfile.orchestratorVersion=1.24.10," +
+				"linuxProfile.ssh.publicKeys[0].keyData=ssh-ed25519 AAAA user",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.actual != tc.expected {
+				t.Errorf("expected --set %q but got %q", tc.expected, tc.actual)
+			}
+		})
+	}
+}
+
+func TestVerifyUpFlags(t *testing.T) {
+	valid := func() deployer {
+		return deployer{
+			Mode:                 modeCAPZ,
+			ClusterName:          "kt2-abc",
+			Location:             "eastus",
+			ControlPlaneCount:    1,
+			WorkerCount:          2,
+			KubernetesVersion:    "v1.30.2",
+			ManagementKubeconfig: "mgmt.kubeconfig",
+			Wait:                 time.Minute,
+		}
+	}
+	testCases := []struct {
+		name   string
+		modify func(d *deployer)
+		valid  bool
+	}{
+		{
+			name:   "capz",
+			modify: func(d *deployer) {},
+			valid:  true,
+		},
+		{
+			name:   "capz without management kubeconfig",
+			modify: func(d *deployer) { d.ManagementKubeconfig = "" },
+		},
+		{
+			name:   "aks-engine",
+			modify: func(d *deployer) { d.Mode = modeAKSEngine; d.APIModel = "cluster.json" },
+			valid:  true,
+		},
+		{
+			name:   "aks-engine without api model",
+			modify: func(d *deployer) { d.Mode = modeAKSEngine },
+		},
+		{
+			name: "aks-engine with flavor",
+			modify: func(d *deployer) {
+				d.Mode = modeAKSEngine
+				d.APIModel = "cluster.json"
+				d.Flavor = "external-cloud-provider"
+			},
+		},
+		{
+			name:   "no control plane",
+			modify: func(d *deployer) { d.ControlPlaneCount = 0 },
+		},
+		{
+			name:   "unknown mode",
+			modify: func(d *deployer) { d.Mode = "aks" },
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := valid()
+			tc.modify(&d)
+			err := d.verifyUpFlags()
+			if tc.valid && err != nil {
+				t.Errorf("expected no error but got: %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestResourceGroup(t *testing.T) {
+	d := &deployer{ClusterName: "kt2-abc"}
+	if actual := d.resourceGroup(); actual != "kt2-abc" {
+		t.Errorf("expected the cluster name as resource group but got %q", actual)
+	}
+	d.ResourceGroup = "e2e"
+	if actual := d.resourceGroup(); actual != "e2e" {
+		t.Errorf("expected --resource-group but got %q", actual)
+	}
+}
+
+// runDirOptions are the common options of a run with the given run dir
+type runDirOptions struct {
+	types.Options
+	runDir string
+}
+
+func (o runDirOptions) RunDir() string { return o.runDir }
+
+func TestSubscriptionFromLease(t *testing.T) {
+	testCases := []struct {
+		name             string
+		subscriptionID   string
+		lease            string
+		expectedID       string
+		expectedReleased bool
+		expectError      bool
+	}{
+		{
+			name:           "--subscription-id",
+			subscriptionID: "sub-flag",
+			lease:          "sub-boskos",
+			expectedID:     "sub-flag",
+		},
+		{
+			name:             "acquired by the up of the same run",
+			lease:            "sub-boskos\n",
+			expectedID:       "sub-boskos",
+			expectedReleased: true,
+		},
+		{
+			name:        "neither",
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &deployer{
+				commonOptions:  runDirOptions{runDir: t.TempDir()},
+				SubscriptionID: tc.subscriptionID,
+				BoskosLocation: "http://boskos.test-pods.svc.cluster.local",
+			}
+			if tc.lease != "" {
+				if err := os.WriteFile(d.subscriptionLeasePath(), []byte(tc.lease), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			err := d.subscriptionFromLease()
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error but got subscription %q", d.SubscriptionID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d.SubscriptionID != tc.expectedID {
+				t.Errorf("expected subscription %q but got %q", tc.expectedID, d.SubscriptionID)
+			}
+			// the subscription is released by down only if it came from boskos
+			if released := d.boskos != nil; released != tc.expectedReleased {
+				t.Errorf("expected the subscription to be released %v but got %v", tc.expectedReleased, released)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/klog/v2"
+)
+
+func (d *deployer) Down() error {
+	if err := d.subscriptionFromLease(); err != nil {
+		return err
+	}
+	if err := d.DumpClusterLogs(); err != nil {
+		klog.Warningf("Dumping cluster logs at the start of Down() failed: %v", err)
+	}
+
+	var errs []error
+	if d.Mode == modeCAPZ {
+		if d.ManagementKubeconfig == "" {
+			return fmt.Errorf("--mode=%s requires --management-kubeconfig", modeCAPZ)
+		}
+		klog.V(0).Infof("Down(): deleting the Cluster API cluster %s...\n", d.ClusterName)
+		if err := d.downCAPZ(); err != nil {
+			errs = append(errs, err)
+		}
+	} else if err := d.downAKSEngine(); err != nil {
+		errs = append(errs, err)
+	}
+
+	// hand the subscription back to the janitor even if the deletion
+	// failed instead of holding the lease until it is reaped
+	if err := d.releaseSubscription(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// DumpClusterLogs downloads the boot diagnostics serial log of every VM in
+// the resource group of the cluster to the logs dir. The instances of scale
+// sets, like the default aks-engine agent pools, are not covered.
+func (d *deployer) DumpClusterLogs() error {
+	klog.V(0).Infof("DumpClusterLogs(): downloading boot diagnostics logs...\n")
+	vms, err := exec.OutputLines(exec.Command("az", "vm", "list",
+		"--resource-group="+d.resourceGroup(),
+		"--subscription="+d.SubscriptionID,
+		"--query=[].name",
+		"--output=tsv"))
+	if err != nil {
+		return fmt.Errorf("failed to list the VMs of the cluster: %w", err)
+	}
+	if err := os.MkdirAll(d.logsDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, vm := range vms {
+		if vm = strings.TrimSpace(vm); vm == "" {
+			continue
+		}
+		if err := d.downloadBootLog(vm); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d *deployer) downloadBootLog(vm string) error {
+	log, err := os.Create(filepath.Join(d.logsDir, vm+"-boot.log"))
+	if err != nil {
+		return err
+	}
+	defer log.Close()
+	cmd := exec.Command("az", "vm", "boot-diagnostics", "get-boot-log",
+		"--resource-group="+d.resourceGroup(),
+		"--name="+vm,
+		"--subscription="+d.SubscriptionID)
+	cmd.SetStdout(log)
+	cmd.SetStderr(os.Stderr)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to download the boot log of %s: %w", vm, err)
+	}
+	return nil
+}
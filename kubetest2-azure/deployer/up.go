@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/kubectl"
+	"sigs.k8s.io/kubetest2/pkg/metadata"
+)
+
+func (d *deployer) IsUp() (up bool, err error) {
+	// naively assume that if the api server reports nodes, the cluster is up
+	lines, err := exec.CombinedOutputLines(
+		exec.Command("kubectl", "--kubeconfig="+d.KubeconfigPath, "get", "nodes", "-o=name"),
+	)
+	if err != nil {
+		return false, metadata.NewJUnitError(err, strings.Join(lines, "\n"))
+	}
+	return len(lines) > 0, nil
+}
+
+func (d *deployer) Up() error {
+	if err := d.verifyUpFlags(); err != nil {
+		return err
+	}
+	if err := d.acquireSubscription(); err != nil {
+		return err
+	}
+	deadline := time.Now().Add(d.Wait)
+
+	var err error
+	if d.Mode == modeAKSEngine {
+		err = d.upAKSEngine()
+	} else {
+		err = d.upCAPZ()
+	}
+	if err != nil {
+		return err
+	}
+
+	if d.CNIManifest != "" {
+		klog.V(0).Infof("Up(): applying the pod network %s...\n", d.CNIManifest)
+		if err := kubectl.Run(d.KubeconfigPath, "apply", "-f", d.CNIManifest); err != nil {
+			return fmt.Errorf("failed to apply the pod network: %w", err)
+		}
+	}
+	return kubectl.WaitForReadyNodes(d.KubeconfigPath, d.ControlPlaneCount+d.WorkerCount, deadline)
+}
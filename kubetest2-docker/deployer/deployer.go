@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package deployer implements the kubetest2 docker deployer, which uses k3d
+// to run lightweight k3s clusters in docker
+package deployer
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/octago/sflags/gen/gpflag"
+	"github.com/spf13/pflag"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/artifacts"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/types"
+	"sigs.k8s.io/kubetest2/pkg/util"
+)
+
+// Name is the name of the deployer
+const Name = "docker"
+
+var GitTag string
+
+// New implements deployer.New for docker
+func New(opts types.Options) (types.Deployer, *pflag.FlagSet) {
+	// create a deployer object and set fields that are not flag controlled
+	d := &deployer{
+		commonOptions: opts,
+		cmder:         exec.DefaultCmder,
+		logsDir:       filepath.Join(artifacts.BaseDir(), "logs"),
+		// names need to start with an alphabet
+		ClusterName:    "kt2-" + util.PseudoUniqueSubstring(opts.RunID()),
+		KubeconfigPath: filepath.Join(opts.RunDir(), "kubetest2-kubeconfig"),
+		Servers:        1,
+		Wait:           5 * time.Minute,
+	}
+	// register flags and return
+	return d, bindFlags(d)
+}
+
+// assert that New implements types.NewDeployer
+var _ types.NewDeployer = New
+
+type deployer struct {
+	// generic parts
+	commonOptions types.Options
+	// cmder creates the commands run by the deployer, faked in tests
+	cmder exec.Cmder
+	// k3d specific details
+	ClusterName    string        `flag:"cluster-name" desc:"the k3d cluster name, defaults to a name derived from the run id"`
+	Servers        int           `desc:"--servers for k3d cluster create, the number of control plane nodes"`
+	Agents         int           `desc:"--agents for k3d cluster create, the number of worker nodes"`
+	K3sVersion     string        `flag:"k3s-version" desc:"the k3s version to use, e.g. v1.30.2-k3s2. Selects the rancher/k3s image of that tag. Defaults to the k3d default."`
+	Image          string        `flag:"image-name" desc:"--image for k3d cluster create, takes precedence over --k3s-version"`
+	ConfigPath     string        `flag:"config" desc:"--config for k3d cluster create"`
+	KubeconfigPath string        `flag:"kubeconfig" desc:"path the cluster kubeconfig is exported to, defaults to a file in the run dir"`
+	Wait           time.Duration `desc:"--timeout for k3d cluster create, how long to wait for the cluster to be ready (e.g. 5m). Disabled if zero."`
+
+	logsDir string
+}
+
+func (d *deployer) Kubeconfig() (string, error) {
+	return d.KubeconfigPath, nil
+}
+
+func (d *deployer) verifyUpFlags() error {
+	if d.ClusterName == "" {
+		return fmt.Errorf("--cluster-name must not be empty")
+	}
+	if d.Servers < 1 {
+		return fmt.Errorf("--servers must be at least 1, got %d", d.Servers)
+	}
+	if d.Agents < 0 {
+		return fmt.Errorf("--agents must not be negative, got %d", d.Agents)
+	}
+	if d.Wait < 0 {
+		return fmt.Errorf("--wait must not be negative, got %v", d.Wait)
+	}
+	return nil
+}
+
+// image returns the k3s image for k3d cluster create, empty for the default
+func (d *deployer) image() string {
+	if d.Image != "" {
+		return d.Image
+	}
+	if d.K3sVersion != "" {
+		return k3sImageRepository + ":" + d.K3sVersion
+	}
+	return ""
+}
+
+func (d *deployer) Version() string {
+	return GitTag
+}
+
+// helper used to create & bind a flagset to the deployer
+func bindFlags(d *deployer) *pflag.FlagSet {
+	flags, err := gpflag.Parse(d)
+	if err != nil {
+		klog.Fatalf("unable to generate flags from deployer")
+		return nil
+	}
+
+	// initing the klog flags adds them to goflag.CommandLine
+	// they can then be added to the built pflag set
+	klog.InitFlags(nil)
+	flags.AddGoFlagSet(flag.CommandLine)
+
+	return flags
+}
+
+// assert that deployer implements types.DeployerWithKubeconfig
+var _ types.DeployerWithKubeconfig = &deployer{}
+
+// well-known k3d related constants
+const k3sImageRepository = "rancher/k3s"
@@ -0,0 +1,281 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+func TestVerifyUpFlags(t *testing.T) {
+	testCases := []struct {
+		name        string
+		d           *deployer
+		expectError bool
+	}{
+		{
+			name: "defaults",
+			d:    &deployer{ClusterName: "kt2-abc", Servers: 1, Wait: 5 * time.Minute},
+		},
+		{
+			name:        "no cluster name",
+			d:           &deployer{Servers: 1},
+			expectError: true,
+		},
+		{
+			name:        "no servers",
+			d:           &deployer{ClusterName: "kt2-abc"},
+			expectError: true,
+		},
+		{
+			name:        "negative agents",
+			d:           &deployer{ClusterName: "kt2-abc", Servers: 1, Agents: -1},
+			expectError: true,
+		},
+		{
+			name:        "negative wait",
+			d:           &deployer{ClusterName: "kt2-abc", Servers: 1, Wait: -time.Minute},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.d.verifyUpFlags()
+			if tc.expectError != (err != nil) {
+				t.Errorf("expected error %v but got %v", tc.expectError, err)
+			}
+		})
+	}
+}
+
+func TestImage(t *testing.T) {
+	testCases := []struct {
+		name     string
+		d        *deployer
+		expected string
+	}{
+		{
+			name: "k3d default",
+			d:    &deployer{},
+		},
+		{
+			name:     "k3s version",
+			d:        &deployer{K3sVersion: "v1.30.2-k3s2"},
+			expected: "rancher/k3s:v1.30.2-k3s2",
+		},
+		{
+			name:     "image takes precedence",
+			d:        &deployer{K3sVersion: "v1.30.2-k3s2", Image: "registry.example.com/k3s:dev"},
+			expected: "registry.example.com/k3s:dev",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.d.image(); actual != tc.expected {
+				t.Errorf("expected image %q but got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestUp(t *testing.T) {
+	testCases := []struct {
+		name             string
+		d                *deployer
+		responses        []exec.FakeResponse
+		expectedCommands []string
+		expectError      bool
+	}{
+		{
+			name: "defaults",
+			d:    &deployer{ClusterName: "kt2-abc", KubeconfigPath: "/run/kubeconfig", Servers: 1},
+			expectedCommands: []string{
+				"k3d cluster create kt2-abc --servers 1 --agents 0 --kubeconfig-update-default=false --kubeconfig-switch-context=false",
+				"k3d kubeconfig write kt2-abc --output /run/kubeconfig",
+			},
+		},
+		{
+			name: "all flags",
+			d: &deployer{
+				ClusterName:    "kt2-abc",
+				KubeconfigPath: "/run/kubeconfig",
+				Servers:        3,
+				Agents:         2,
+				K3sVersion:     "v1.30.2-k3s2",
+				ConfigPath:     "k3d.yaml",
+				Wait:           5 * time.Minute,
+			},
+			expectedCommands: []string{
+				"k3d cluster create kt2-abc --servers 3 --agents 2 --kubeconfig-update-default=false --kubeconfig-switch-context=false --image rancher/k3s:v1.30.2-k3s2 --config k3d.yaml --wait --timeout 5m0s",
+				"k3d kubeconfig write kt2-abc --output /run/kubeconfig",
+			},
+		},
+		{
+			name:             "invalid flags",
+			d:                &deployer{ClusterName: "kt2-abc"},
+			expectedCommands: []string{},
+			expectError:      true,
+		},
+		{
+			name:      "create failed",
+			d:         &deployer{ClusterName: "kt2-abc", KubeconfigPath: "/run/kubeconfig", Servers: 1},
+			responses: []exec.FakeResponse{{Prefix: "k3d cluster create", Err: errors.New("exit status 1")}},
+			expectedCommands: []string{
+				"k3d cluster create kt2-abc --servers 1 --agents 0 --kubeconfig-update-default=false --kubeconfig-switch-context=false",
+			},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := &exec.FakeCmder{Responses: tc.responses}
+			tc.d.cmder = cmder
+			err := tc.d.Up()
+			if tc.expectError != (err != nil) {
+				t.Errorf("expected error %v but got %v", tc.expectError, err)
+			}
+			if commands := cmder.Commands(); !reflect.DeepEqual(commands, tc.expectedCommands) {
+				t.Errorf("expected commands %v but got %v", tc.expectedCommands, commands)
+			}
+		})
+	}
+}
+
+func TestIsUp(t *testing.T) {
+	testCases := []struct {
+		name        string
+		response    exec.FakeResponse
+		expectedUp  bool
+		expectError bool
+	}{
+		{
+			name:       "nodes",
+			response:   exec.FakeResponse{Prefix: "kubectl", Stdout: "node/k3d-kt2-abc-server-0\n"},
+			expectedUp: true,
+		},
+		{
+			name:     "no nodes",
+			response: exec.FakeResponse{Prefix: "kubectl"},
+		},
+		{
+			name:        "api server unreachable",
+			response:    exec.FakeResponse{Prefix: "kubectl", Err: errors.New("exit status 1")},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := &exec.FakeCmder{Responses: []exec.FakeResponse{tc.response}}
+			d := &deployer{cmder: cmder, KubeconfigPath: "/run/kubeconfig"}
+			up, err := d.IsUp()
+			if tc.expectError != (err != nil) {
+				t.Errorf("expected error %v but got %v", tc.expectError, err)
+			}
+			if up != tc.expectedUp {
+				t.Errorf("expected up to be %v but got %v", tc.expectedUp, up)
+			}
+			expected := []string{"kubectl --kubeconfig=/run/kubeconfig get nodes -o=name"}
+			if commands := cmder.Commands(); !reflect.DeepEqual(commands, expected) {
+				t.Errorf("expected commands %v but got %v", expected, commands)
+			}
+		})
+	}
+}
+
+func TestDown(t *testing.T) {
+	list := "docker ps --all --filter label=k3d.cluster=kt2-abc --format {{.Names}}"
+	testCases := []struct {
+		name             string
+		responses        []exec.FakeResponse
+		expectedCommands []string
+		expectError      bool
+	}{
+		{
+			name:      "logs are dumped before the cluster is deleted",
+			responses: []exec.FakeResponse{{Prefix: list, Stdout: "k3d-kt2-abc-server-0\n"}},
+			expectedCommands: []string{
+				list,
+				"docker logs --timestamps k3d-kt2-abc-server-0",
+				"k3d cluster delete kt2-abc",
+			},
+		},
+		{
+			name:      "the cluster is deleted when dumping the logs failed",
+			responses: []exec.FakeResponse{{Prefix: list, Err: errors.New("exit status 1")}},
+			expectedCommands: []string{
+				list,
+				"k3d cluster delete kt2-abc",
+			},
+		},
+		{
+			name:      "delete failed",
+			responses: []exec.FakeResponse{{Prefix: "k3d cluster delete", Err: errors.New("exit status 1")}},
+			expectedCommands: []string{
+				list,
+				"k3d cluster delete kt2-abc",
+			},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := &exec.FakeCmder{Responses: tc.responses}
+			d := &deployer{cmder: cmder, ClusterName: "kt2-abc", logsDir: t.TempDir()}
+			err := d.Down()
+			if tc.expectError != (err != nil) {
+				t.Errorf("expected error %v but got %v", tc.expectError, err)
+			}
+			if commands := cmder.Commands(); !reflect.DeepEqual(commands, tc.expectedCommands) {
+				t.Errorf("expected commands %v but got %v", tc.expectedCommands, commands)
+			}
+		})
+	}
+}
+
+func TestDumpClusterLogs(t *testing.T) {
+	list := "docker ps --all --filter label=k3d.cluster=kt2-abc --format {{.Names}}"
+	cmder := &exec.FakeCmder{
+		Responses: []exec.FakeResponse{
+			{Prefix: list, Stdout: "k3d-kt2-abc-server-0\nk3d-kt2-abc-agent-0\n"},
+			{Prefix: "docker logs --timestamps k3d-kt2-abc-server-0", Stdout: "server logs\n"},
+			{Prefix: "docker logs --timestamps k3d-kt2-abc-agent-0", Err: errors.New("exit status 1")},
+		},
+	}
+	logsDir := filepath.Join(t.TempDir(), "logs")
+	d := &deployer{cmder: cmder, ClusterName: "kt2-abc", logsDir: logsDir}
+
+	// the failure is reported after dumping the logs of the other nodes
+	if err := d.DumpClusterLogs(); err == nil {
+		t.Error("expected the failure to get the agent logs to be reported")
+	}
+	contents, err := os.ReadFile(filepath.Join(logsDir, "k3d-kt2-abc-server-0.log"))
+	if err != nil {
+		t.Fatalf("expected the server logs: %v", err)
+	}
+	if string(contents) != "server logs\n" {
+		t.Errorf("expected the server logs but got %q", contents)
+	}
+	if _, err := os.Stat(filepath.Join(logsDir, "k3d-kt2-abc-agent-0.log")); err != nil {
+		t.Errorf("expected the agent log file: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// DumpClusterLogs writes the docker logs of every k3d node container,
+// which include the k3s server and agent logs, to the logs dir.
+func (d *deployer) DumpClusterLogs() error {
+	klog.V(0).Infof("DumpClusterLogs(): exporting k3d node logs...\n")
+	containers, err := exec.OutputLines(d.cmder.Command("docker",
+		"ps", "--all",
+		"--filter", "label=k3d.cluster="+d.ClusterName,
+		"--format", "{{.Names}}",
+	))
+	if err != nil {
+		return fmt.Errorf("failed to list k3d node containers: %w", err)
+	}
+	if err := os.MkdirAll(d.logsDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, container := range containers {
+		if err := d.dumpContainerLogs(container, filepath.Join(d.logsDir, container+".log")); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d *deployer) dumpContainerLogs(container, path string) error {
+	logFile, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+
+	cmd := d.cmder.Command("docker", "logs", "--timestamps", container)
+	cmd.SetStdout(logFile)
+	cmd.SetStderr(logFile)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to get logs of container %s: %w", container, err)
+	}
+	return nil
+}
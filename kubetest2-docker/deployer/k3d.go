@@ -0,0 +1,31 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"os"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// runK3d executes k3d with the given args, streaming the output to the console
+func (d *deployer) runK3d(args ...string) error {
+	cmd := d.cmder.Command("k3d", args...)
+	cmd.SetEnv(os.Environ()...)
+	exec.InheritOutput(cmd)
+	return cmd.Run()
+}
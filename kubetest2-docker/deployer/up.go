@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/metadata"
+)
+
+func (d *deployer) IsUp() (up bool, err error) {
+	// naively assume that if the api server reports nodes, the cluster is up
+	lines, err := exec.CombinedOutputLines(
+		d.cmder.Command("kubectl", "--kubeconfig="+d.KubeconfigPath, "get", "nodes", "-o=name"),
+	)
+	if err != nil {
+		return false, metadata.NewJUnitError(err, strings.Join(lines, "\n"))
+	}
+	return len(lines) > 0, nil
+}
+
+func (d *deployer) Up() error {
+	if err := d.verifyUpFlags(); err != nil {
+		return err
+	}
+
+	args := []string{
+		"cluster", "create", d.ClusterName,
+		"--servers", strconv.Itoa(d.Servers),
+		"--agents", strconv.Itoa(d.Agents),
+		// the kubeconfig is exported explicitly below, leave the user's alone
+		"--kubeconfig-update-default=false",
+		"--kubeconfig-switch-context=false",
+	}
+	if image := d.image(); image != "" {
+		args = append(args, "--image", image)
+	}
+	if d.ConfigPath != "" {
+		args = append(args, "--config", d.ConfigPath)
+	}
+	if d.Wait > 0 {
+		args = append(args, "--wait", "--timeout", d.Wait.String())
+	}
+
+	klog.V(0).Infof("Up(): creating k3d cluster %s...\n", d.ClusterName)
+	if err := d.runK3d(args...); err != nil {
+		return err
+	}
+
+	klog.V(0).Infof("Up(): exporting kubeconfig to %s...\n", d.KubeconfigPath)
+	if err := d.runK3d("kubeconfig", "write", d.ClusterName, "--output", d.KubeconfigPath); err != nil {
+		return fmt.Errorf("failed to export kubeconfig: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	osexec "os/exec"
+
+	shell "github.com/kballard/go-shellquote"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/artifacts"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// isUpNotUpExitCode is the exit code of --is-up-command when the cluster is
+// not up, any other failure is an error
+const isUpNotUpExitCode = 1
+
+func (d *deployer) Up() error {
+	if d.UpCommand == "" {
+		return fmt.Errorf("--up-command must be set for --up")
+	}
+	klog.V(0).Infof("Up(): running %s", d.UpCommand)
+	if err := d.runCommand(d.UpCommand); err != nil {
+		return fmt.Errorf("--up-command failed: %w", err)
+	}
+	if _, err := os.Stat(d.KubeconfigPath); err != nil {
+		return fmt.Errorf("--up-command did not write the kubeconfig of the cluster to $KUBETEST2_KUBECONFIG: %w", err)
+	}
+	return nil
+}
+
+func (d *deployer) Down() error {
+	if d.DownCommand == "" {
+		return fmt.Errorf("--down-command must be set for --down")
+	}
+	if err := d.DumpClusterLogs(); err != nil {
+		klog.Warningf("Dumping cluster logs at the start of Down() failed: %v", err)
+	}
+	klog.V(0).Infof("Down(): running %s", d.DownCommand)
+	if err := d.runCommand(d.DownCommand); err != nil {
+		return fmt.Errorf("--down-command failed: %w", err)
+	}
+	return nil
+}
+
+func (d *deployer) IsUp() (up bool, err error) {
+	if d.IsUpCommand == "" {
+		return false, fmt.Errorf("--is-up-command must be set to check if the cluster is up")
+	}
+	return isUp(d.runCommand(d.IsUpCommand))
+}
+
+// isUp interprets the result of --is-up-command
+func isUp(err error) (bool, error) {
+	var exitErr *osexec.ExitError
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == isUpNotUpExitCode:
+		return false, nil
+	default:
+		return false, fmt.Errorf("--is-up-command failed: %w", err)
+	}
+}
+
+func (d *deployer) DumpClusterLogs() error {
+	if d.DumpLogsCommand == "" {
+		return nil
+	}
+	if err := os.MkdirAll(d.logsDir, os.ModePerm); err != nil {
+		return err
+	}
+	klog.V(0).Infof("DumpClusterLogs(): running %s", d.DumpLogsCommand)
+	if err := d.runCommand(d.DumpLogsCommand); err != nil {
+		return fmt.Errorf("--dump-logs-command failed: %w", err)
+	}
+	return nil
+}
+
+func (d *deployer) Build() error {
+	if d.BuildCommand == "" {
+		return fmt.Errorf("--build-command must be set for --build")
+	}
+	klog.V(0).Infof("Build(): running %s", d.BuildCommand)
+	if err := d.runCommand(d.BuildCommand); err != nil {
+		return fmt.Errorf("--build-command failed: %w", err)
+	}
+	return nil
+}
+
+// runCommand runs one of the configured commands with the output inherited
+func (d *deployer) runCommand(command string) error {
+	args, err := shell.Split(command)
+	// like exec.RawCommand, run the raw string if it fails to split
+	if len(args) == 0 || err != nil {
+		args = []string{command}
+	}
+	cmd := d.cmder.Command(args[0], args[1:]...)
+	cmd.SetEnv(d.commandEnv()...)
+	exec.InheritOutput(cmd)
+	return cmd.Run()
+}
+
+// commandEnv returns the environment of the commands, the contract between
+// the deployer and the scripts: the up command writes the kubeconfig of the
+// cluster to $KUBETEST2_KUBECONFIG, which is also $KUBECONFIG so that tools
+// like kind write it there by default, and the dump logs command writes to
+// $KUBETEST2_LOGS_DIR. The run id can name the resources of the cluster.
+func (d *deployer) commandEnv() []string {
+	return append(os.Environ(),
+		"KUBETEST2_KUBECONFIG="+d.KubeconfigPath,
+		"KUBECONFIG="+d.KubeconfigPath,
+		"KUBETEST2_LOGS_DIR="+d.logsDir,
+		"KUBETEST2_RUN_ID="+d.commonOptions.RunID(),
+		"KUBETEST2_RUN_DIR="+d.commonOptions.RunDir(),
+		"ARTIFACTS="+artifacts.BaseDir(),
+	)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package deployer implements the kubetest2 external deployer, which delegates
+// the cluster lifecycle to user provided commands, e.g. the scripts a team
+// used before adopting kubetest2
+package deployer
+
+import (
+	"flag"
+	"path/filepath"
+
+	"github.com/octago/sflags/gen/gpflag"
+	"github.com/spf13/pflag"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/artifacts"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+// Name is the name of the deployer
+const Name = "external"
+
+var GitTag string
+
+// New implements deployer.New for external
+func New(opts types.Options) (types.Deployer, *pflag.FlagSet) {
+	// create a deployer object and set fields that are not flag controlled
+	d := &deployer{
+		commonOptions:  opts,
+		cmder:          exec.DefaultCmder,
+		logsDir:        filepath.Join(artifacts.BaseDir(), "logs"),
+		KubeconfigPath: filepath.Join(opts.RunDir(), "kubetest2-kubeconfig"),
+	}
+	// register flags and return
+	return d, bindFlags(d)
+}
+
+// assert that New implements types.NewDeployer
+var _ types.NewDeployer = New
+
+type deployer struct {
+	// generic parts
+	commonOptions types.Options
+	// cmder creates the commands run by the deployer, faked in tests
+	cmder exec.Cmder
+
+	// the commands are split into arguments like by a shell, but not run by
+	// one, e.g. "bash -c '...'" for pipes and redirections
+	UpCommand       string `flag:"up-command" desc:"the command creating the cluster, it must write the kubeconfig of the cluster to $KUBETEST2_KUBECONFIG"`
+	DownCommand     string `flag:"down-command" desc:"the command deleting the cluster"`
+	IsUpCommand     string `flag:"is-up-command" desc:"the command checking if the cluster is up, exiting with 0 when it is and 1 when it is not. Empty if not supported"`
+	DumpLogsCommand string `flag:"dump-logs-command" desc:"the command dumping the cluster logs to $KUBETEST2_LOGS_DIR, also run before --down-command. Empty skips dumping the logs"`
+	BuildCommand    string `flag:"build-command" desc:"the command building kubernetes for --build. Empty if not supported"`
+
+	KubeconfigPath string `flag:"kubeconfig" desc:"path the kubeconfig of the cluster is written to by --up-command, defaults to a file in the run dir"`
+
+	logsDir string
+}
+
+func (d *deployer) Kubeconfig() (string, error) {
+	return d.KubeconfigPath, nil
+}
+
+// Unsupported implements types.DeployerWithCapabilities, --build and IsUp
+// are only supported if a command is configured for them
+func (d *deployer) Unsupported() []types.Capability {
+	var unsupported []types.Capability
+	if d.BuildCommand == "" {
+		unsupported = append(unsupported, types.CapabilityBuild)
+	}
+	if d.IsUpCommand == "" {
+		unsupported = append(unsupported, types.CapabilityIsUp)
+	}
+	return unsupported
+}
+
+func (d *deployer) Version() string {
+	return GitTag
+}
+
+// helper used to create & bind a flagset to the deployer
+func bindFlags(d *deployer) *pflag.FlagSet {
+	flags, err := gpflag.Parse(d)
+	if err != nil {
+		klog.Fatalf("unable to generate flags from deployer")
+		return nil
+	}
+
+	// initing the klog flags adds them to goflag.CommandLine
+	// they can then be added to the built pflag set
+	klog.InitFlags(nil)
+	flags.AddGoFlagSet(flag.CommandLine)
+
+	return flags
+}
+
+// assert that deployer implements types.DeployerWithKubeconfig
+var _ types.DeployerWithKubeconfig = &deployer{}
+
+// assert that deployer implements types.DeployerWithCapabilities
+var _ types.DeployerWithCapabilities = &deployer{}
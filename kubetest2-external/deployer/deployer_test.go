@@ -0,0 +1,282 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+// runOptions are the common options of a run with the given id and dir
+type runOptions struct {
+	types.Options
+	runID  string
+	runDir string
+}
+
+func (o runOptions) RunID() string  { return o.runID }
+func (o runOptions) RunDir() string { return o.runDir }
+
+// newTestDeployer returns a deployer running its commands with cmder, in a
+// temporary run dir and artifacts dir
+func newTestDeployer(t *testing.T, cmder exec.Cmder) *deployer {
+	runDir := t.TempDir()
+	artifactsDir := t.TempDir()
+	t.Setenv("ARTIFACTS", artifactsDir)
+	return &deployer{
+		commonOptions:   runOptions{runID: "run-1", runDir: runDir},
+		cmder:           cmder,
+		logsDir:         filepath.Join(artifactsDir, "logs"),
+		KubeconfigPath:  filepath.Join(runDir, "kubetest2-kubeconfig"),
+		UpCommand:       "./up.sh --name 'my cluster'",
+		DownCommand:     "./down.sh",
+		DumpLogsCommand: "./dump.sh",
+	}
+}
+
+// envValue returns the value of key in env, the last one wins like in exec
+func envValue(env []string, key string) string {
+	value := ""
+	for _, kv := range env {
+		if k, v, ok := strings.Cut(kv, "="); ok && k == key {
+			value = v
+		}
+	}
+	return value
+}
+
+func TestCommandEnv(t *testing.T) {
+	cmder := &exec.FakeCmder{}
+	d := newTestDeployer(t, cmder)
+	if err := d.runCommand(d.DownCommand); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	calls := cmder.Calls()
+	if len(calls) != 1 {
+		t.Fatalf("expected one command but got %v", cmder.Commands())
+	}
+	expected := map[string]string{
+		"KUBETEST2_KUBECONFIG": d.KubeconfigPath,
+		"KUBECONFIG":           d.KubeconfigPath,
+		"KUBETEST2_LOGS_DIR":   d.logsDir,
+		"KUBETEST2_RUN_ID":     "run-1",
+		"KUBETEST2_RUN_DIR":    d.commonOptions.RunDir(),
+		"ARTIFACTS":            os.Getenv("ARTIFACTS"),
+	}
+	for key, value := range expected {
+		if actual := envValue(calls[0].Env, key); actual != value {
+			t.Errorf("expected %s=%s in the command env but got %q", key, value, actual)
+		}
+	}
+}
+
+func TestUp(t *testing.T) {
+	testCases := []struct {
+		name             string
+		noUpCommand      bool
+		upErr            error
+		writeKubeconfig  bool
+		expectedCommands []string
+		expectError      bool
+	}{
+		{
+			name:             "up",
+			writeKubeconfig:  true,
+			expectedCommands: []string{"./up.sh --name my cluster"},
+		},
+		{
+			name:             "no kubeconfig written",
+			expectedCommands: []string{"./up.sh --name my cluster"},
+			expectError:      true,
+		},
+		{
+			name:             "up command failed",
+			upErr:            errors.New("exit status 1"),
+			writeKubeconfig:  true,
+			expectedCommands: []string{"./up.sh --name my cluster"},
+			expectError:      true,
+		},
+		{
+			name:             "no up command",
+			noUpCommand:      true,
+			expectedCommands: []string{},
+			expectError:      true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := &exec.FakeCmder{Responses: []exec.FakeResponse{{Prefix: "./up.sh", Err: tc.upErr}}}
+			d := newTestDeployer(t, cmder)
+			if tc.noUpCommand {
+				d.UpCommand = ""
+			}
+			if tc.writeKubeconfig {
+				if err := os.WriteFile(d.KubeconfigPath, []byte("apiVersion: v1\n"), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			err := d.Up()
+			if tc.expectError != (err != nil) {
+				t.Errorf("expected error %v but got %v", tc.expectError, err)
+			}
+			if commands := cmder.Commands(); !reflect.DeepEqual(commands, tc.expectedCommands) {
+				t.Errorf("expected commands %v but got %v", tc.expectedCommands, commands)
+			}
+		})
+	}
+}
+
+func TestDown(t *testing.T) {
+	testCases := []struct {
+		name             string
+		responses        []exec.FakeResponse
+		dumpLogsCommand  string
+		expectedCommands []string
+		expectError      bool
+	}{
+		{
+			name:             "logs are dumped before down",
+			dumpLogsCommand:  "./dump.sh",
+			expectedCommands: []string{"./dump.sh", "./down.sh"},
+		},
+		{
+			name:             "no dump logs command",
+			expectedCommands: []string{"./down.sh"},
+		},
+		{
+			name:             "down after dumping the logs failed",
+			responses:        []exec.FakeResponse{{Prefix: "./dump.sh", Err: errors.New("exit status 1")}},
+			dumpLogsCommand:  "./dump.sh",
+			expectedCommands: []string{"./dump.sh", "./down.sh"},
+		},
+		{
+			name:             "down command failed",
+			responses:        []exec.FakeResponse{{Prefix: "./down.sh", Err: errors.New("exit status 1")}},
+			expectedCommands: []string{"./down.sh"},
+			expectError:      true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := &exec.FakeCmder{Responses: tc.responses}
+			d := newTestDeployer(t, cmder)
+			d.DumpLogsCommand = tc.dumpLogsCommand
+			err := d.Down()
+			if tc.expectError != (err != nil) {
+				t.Errorf("expected error %v but got %v", tc.expectError, err)
+			}
+			if commands := cmder.Commands(); !reflect.DeepEqual(commands, tc.expectedCommands) {
+				t.Errorf("expected commands %v but got %v", tc.expectedCommands, commands)
+			}
+		})
+	}
+}
+
+func TestDumpClusterLogs(t *testing.T) {
+	cmder := &exec.FakeCmder{}
+	d := newTestDeployer(t, cmder)
+	if err := d.DumpClusterLogs(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if commands := cmder.Commands(); !reflect.DeepEqual(commands, []string{"./dump.sh"}) {
+		t.Errorf("expected the dump logs command but got %v", commands)
+	}
+	if _, err := os.Stat(d.logsDir); err != nil {
+		t.Errorf("expected the logs dir to be created for the dump logs command: %v", err)
+	}
+
+	cmder = &exec.FakeCmder{Responses: []exec.FakeResponse{{Prefix: "./dump.sh", Err: errors.New("exit status 1")}}}
+	d.cmder = cmder
+	if err := d.DumpClusterLogs(); err == nil {
+		t.Error("expected an error when the dump logs command fails")
+	}
+}
+
+func TestIsUp(t *testing.T) {
+	testCases := []struct {
+		name        string
+		script      string
+		expectedUp  bool
+		expectError bool
+	}{
+		{
+			name:       "up",
+			script:     "exit 0",
+			expectedUp: true,
+		},
+		{
+			name:   "not up",
+			script: "exit 1",
+		},
+		{
+			name:        "failed",
+			script:      "exit 2",
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			up, err := isUp(osexec.Command("sh", "-c", tc.script).Run())
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if up != tc.expectedUp {
+				t.Errorf("expected up to be %v but got %v", tc.expectedUp, up)
+			}
+		})
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	testCases := []struct {
+		name     string
+		d        *deployer
+		expected []types.Capability
+	}{
+		{
+			name:     "up and down only",
+			d:        &deployer{UpCommand: "./up.sh", DownCommand: "./down.sh"},
+			expected: []types.Capability{types.CapabilityBuild, types.CapabilityIsUp},
+		},
+		{
+			name:     "all commands",
+			d:        &deployer{UpCommand: "./up.sh", DownCommand: "./down.sh", IsUpCommand: "./is-up.sh", BuildCommand: "make"},
+			expected: nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.d.Unsupported(); !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
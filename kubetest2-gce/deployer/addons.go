@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+)
+
+const (
+	clusterDNSCoreDNS = "coredns"
+	clusterDNSKubeDNS = "kube-dns"
+)
+
+// verifyAddonFlags validates the flags of the optional cluster addons
+func (d *deployer) verifyAddonFlags() error {
+	switch d.ClusterDNS {
+	case "", clusterDNSCoreDNS, clusterDNSKubeDNS:
+	default:
+		return fmt.Errorf("--cluster-dns must be %q or %q, got %q", clusterDNSCoreDNS, clusterDNSKubeDNS, d.ClusterDNS)
+	}
+	if d.DisableClusterDNS && (d.ClusterDNS != "" || d.EnableNodeLocalDNS) {
+		return fmt.Errorf("--disable-cluster-dns cannot be used with --cluster-dns or --enable-node-local-dns")
+	}
+	return nil
+}
+
+// addonEnv returns the kube-up.sh env of the addon flags, the kube-up.sh
+// defaults apply to the unset ones
+func (d *deployer) addonEnv() []string {
+	var env []string
+	if d.DisableMetricsServer {
+		env = append(env, "ENABLE_METRICS_SERVER=false")
+	}
+	if d.DisableClusterDNS {
+		env = append(env, "ENABLE_CLUSTER_DNS=false")
+	}
+	if d.ClusterDNS != "" {
+		env = append(env, fmt.Sprintf("CLUSTER_DNS_CORE_DNS=%t", d.ClusterDNS == clusterDNSCoreDNS))
+	}
+	if d.EnableNodeLocalDNS {
+		env = append(env, "KUBE_ENABLE_NODELOCAL_DNS=true")
+	}
+	return env
+}
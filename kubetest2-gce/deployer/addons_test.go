@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAddonEnv(t *testing.T) {
+	cases := []struct {
+		name                 string
+		disableMetricsServer bool
+		disableClusterDNS    bool
+		clusterDNS           string
+		enableNodeLocalDNS   bool
+		expected             []string
+		expectError          bool
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name:                 "no metrics server",
+			disableMetricsServer: true,
+			expected:             []string{"ENABLE_METRICS_SERVER=false"},
+		},
+		{
+			name:               "kube-dns with node local dns",
+			clusterDNS:         clusterDNSKubeDNS,
+			enableNodeLocalDNS: true,
+			expected:           []string{"CLUSTER_DNS_CORE_DNS=false", "KUBE_ENABLE_NODELOCAL_DNS=true"},
+		},
+		{
+			name:       "coredns",
+			clusterDNS: clusterDNSCoreDNS,
+			expected:   []string{"CLUSTER_DNS_CORE_DNS=true"},
+		},
+		{
+			name:              "no cluster dns",
+			disableClusterDNS: true,
+			expected:          []string{"ENABLE_CLUSTER_DNS=false"},
+		},
+		{
+			name:        "unknown cluster dns",
+			clusterDNS:  "skydns",
+			expectError: true,
+		},
+		{
+			name:               "node local dns without cluster dns",
+			disableClusterDNS:  true,
+			enableNodeLocalDNS: true,
+			expectError:        true,
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &deployer{
+				DisableMetricsServer: c.disableMetricsServer,
+				DisableClusterDNS:    c.disableClusterDNS,
+				ClusterDNS:           c.clusterDNS,
+				EnableNodeLocalDNS:   c.enableNodeLocalDNS,
+			}
+			err := d.verifyAddonFlags()
+			if c.expectError {
+				if err == nil {
+					t.Error("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := d.addonEnv(); !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected env %v but got %v", c.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+)
+
+// auditLogPath is where kube-apiserver writes the audit log on the master
+// with the log backend
+const auditLogPath = "/var/log/kube-apiserver-audit.log"
+
+// loadAPIServerConfig reads the audit policy and encryption provider config
+// files into the form kube-up.sh expects them in
+func (d *deployer) loadAPIServerConfig() error {
+	if d.AuditPolicyFile != "" {
+		if !d.EnableAuditLog {
+			return fmt.Errorf("--audit-policy-file requires --enable-audit-log")
+		}
+		policy, err := os.ReadFile(d.AuditPolicyFile)
+		if err != nil {
+			return fmt.Errorf("failed to read audit policy file: %s", err)
+		}
+		d.auditPolicy = string(policy)
+	}
+
+	if d.EncryptionProviderConfigFile != "" {
+		config, err := os.ReadFile(d.EncryptionProviderConfigFile)
+		if err != nil {
+			return fmt.Errorf("failed to read encryption provider config file: %s", err)
+		}
+		// the master startup scripts base64 decode the config
+		d.encryptionProviderConfig = base64.StdEncoding.EncodeToString(config)
+	}
+
+	return nil
+}
+
+// apiServerEnv returns the kube-up.sh env configuring audit logging and
+// encryption at rest of the apiserver
+func (d *deployer) apiServerEnv() []string {
+	var env []string
+	if d.EnableAuditLog {
+		env = append(env, "ENABLE_APISERVER_ADVANCED_AUDIT=true", "ADVANCED_AUDIT_BACKEND=log")
+		// kube-up.sh uses its default policy when unset
+		if d.auditPolicy != "" {
+			env = append(env, fmt.Sprintf("ADVANCED_AUDIT_POLICY=%s", d.auditPolicy))
+		}
+	}
+	if d.encryptionProviderConfig != "" {
+		env = append(env, fmt.Sprintf("ENCRYPTION_PROVIDER_CONFIG=%s", d.encryptionProviderConfig))
+	}
+	return env
+}
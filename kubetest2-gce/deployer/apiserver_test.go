@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestAPIServerEnv(t *testing.T) {
+	dir := t.TempDir()
+	policyFile := filepath.Join(dir, "policy.yaml")
+	if err := os.WriteFile(policyFile, []byte("kind: Policy\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	encryptionFile := filepath.Join(dir, "encryption.yaml")
+	if err := os.WriteFile(encryptionFile, []byte("kind: EncryptionConfiguration\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name           string
+		enableAuditLog bool
+		policyFile     string
+		encryptionFile string
+		expected       []string
+		expectError    bool
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:           "audit log with the default policy",
+			enableAuditLog: true,
+			expected:       []string{"ENABLE_APISERVER_ADVANCED_AUDIT=true", "ADVANCED_AUDIT_BACKEND=log"},
+		},
+		{
+			name:           "audit log with a policy",
+			enableAuditLog: true,
+			policyFile:     policyFile,
+			expected:       []string{"ENABLE_APISERVER_ADVANCED_AUDIT=true", "ADVANCED_AUDIT_BACKEND=log", "ADVANCED_AUDIT_POLICY=kind: Policy\n"},
+		},
+		{
+			name:        "policy without audit log",
+			policyFile:  policyFile,
+			expectError: true,
+		},
+		{
+			name:           "missing policy file",
+			enableAuditLog: true,
+			policyFile:     filepath.Join(dir, "missing.yaml"),
+			expectError:    true,
+		},
+		{
+			name:           "encryption provider config",
+			encryptionFile: encryptionFile,
+			expected:       []string{"ENCRYPTION_PROVIDER_CONFIG=a2luZDogRW5jcnlwdGlvbkNvbmZpZ3VyYXRpb24K"},
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &deployer{
+				EnableAuditLog:               c.enableAuditLog,
+				AuditPolicyFile:              c.policyFile,
+				EncryptionProviderConfigFile: c.encryptionFile,
+			}
+			err := d.loadAPIServerConfig()
+			if c.expectError {
+				if err == nil {
+					t.Error("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := d.apiServerEnv(); !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected env %q but got %q", c.expected, actual)
+			}
+		})
+	}
+}
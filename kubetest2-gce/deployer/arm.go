@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/gcp"
+)
+
+const (
+	// defaultARM64NodeImageFamily and defaultARM64NodeImageProject select
+	// the node image for ARM node machine types when --node-image is unset
+	defaultARM64NodeImageFamily  = "cos-arm64-stable"
+	defaultARM64NodeImageProject = "cos-cloud"
+)
+
+// machineTypeRe matches GCE machine types, e.g. t2a-standard-4 or e2-custom-4-8192
+var machineTypeRe = regexp.MustCompile(`^([a-z][a-z0-9]*)-[a-z0-9-]+$`)
+
+// isARMMachineType returns true if the GCE machine type has ARM CPUs.
+func isARMMachineType(machineType string) bool {
+	return gcp.IsARMMachineType(machineType)
+}
+
+// nodeArch returns the architecture of the node machine type, as expected
+// by KUBE_NODE_ARCH.
+func (d *deployer) nodeArch() string {
+	if isARMMachineType(d.NodeMachineType) {
+		return "arm64"
+	}
+	return "amd64"
+}
+
+// verifyNodeMachineType validates --node-machine-type, ARM nodes need arm64
+// binaries so a build must include them.
+func (d *deployer) verifyNodeMachineType() error {
+	if d.NodeMachineType == "" {
+		return nil
+	}
+	if d.NodeSize != "" {
+		return fmt.Errorf("--node-machine-type and --node-size are mutually exclusive")
+	}
+	if !machineTypeRe.MatchString(d.NodeMachineType) {
+		return fmt.Errorf("invalid --node-machine-type %q, expected a GCE machine type like e2-standard-4", d.NodeMachineType)
+	}
+	if d.nodeArch() == "arm64" && d.commonOptions.ShouldBuild() &&
+		!strings.Contains(d.BuildOptions.CommonBuildOptions.TargetBuildArch, "linux/arm64") {
+		return fmt.Errorf("--target-build-arch must include linux/arm64 for --node-machine-type %s, e.g. \"linux/amd64 linux/arm64\"", d.NodeMachineType)
+	}
+	return nil
+}
+
+// resolveNodeImage sets the node image for kube-up, defaulting to the
+// latest arm64 COS image for ARM node machine types.
+func (d *deployer) resolveNodeImage() error {
+	if d.NodeImage != "" {
+		d.nodeImage = d.NodeImage
+		d.nodeImageProject = d.NodeImageProject
+		return nil
+	}
+	if d.nodeArch() != "arm64" {
+		return nil
+	}
+	cmd := d.cmder.Command("gcloud", "compute", "images", "describe-from-family", defaultARM64NodeImageFamily,
+		"--project="+defaultARM64NodeImageProject,
+		"--format=value(name)")
+	out, err := exec.Output(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to resolve the latest %s image: %w", defaultARM64NodeImageFamily, err)
+	}
+	d.nodeImage = strings.TrimSpace(string(out))
+	d.nodeImageProject = defaultARM64NodeImageProject
+	klog.V(1).Infof("Using node image %s/%s for ARM nodes", d.nodeImageProject, d.nodeImage)
+	return nil
+}
@@ -26,6 +26,7 @@ import (
 
 	"sigs.k8s.io/kubetest2/pkg/build"
 	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/types"
 )
 
 func (d *deployer) Build() error {
@@ -58,19 +59,26 @@ func (d *deployer) Build() error {
 				return fmt.Errorf("error staging build: %v", err)
 			}
 		}
+		if err := d.BuildOptions.CommonBuildOptions.Push(d.commonOptions.RunID(), d.commonOptions.RunDir()); err != nil {
+			return fmt.Errorf("error pushing images: %v", err)
+		}
+		d.buildVersion = version
 		build.StoreCommonBinaries(d.RepoRoot, d.commonOptions.RunDir())
 	} else {
 		// this code path supports the kubernetes/cloud-provider-gcp build
 		klog.V(2).Info("starting the build")
+		if d.BuildOptions.CommonBuildOptions.PushImages != "" {
+			return fmt.Errorf("--push-images requires --legacy-mode, the kubernetes/kubernetes build")
+		}
 
 		var cmd exec.Cmd
 		// determine the build system for kubernetes/cloud-provider-gcp
 		if _, err := os.Stat(path.Join(d.RepoRoot, "Makefile")); err == nil {
 			// For releases that uses Makefile
-			cmd = exec.Command("make", "release-tars")
+			cmd = d.cmder.Command("make", "release-tars")
 		} else if _, err := os.Stat(path.Join(d.RepoRoot, "BUILD")); err == nil {
 			// For releases that uses Bazel
-			cmd = exec.Command("bazel", "build", "//release:release-tars")
+			cmd = d.cmder.Command("bazel", "build", "//release:release-tars")
 		} else {
 			return fmt.Errorf("cannot determine build system")
 		}
@@ -91,6 +99,28 @@ func (d *deployer) Build() error {
 	return nil
 }
 
+// BuildManifest implements types.DeployerWithBuildManifest. The version is
+// read from the release tars outside of legacy mode, where the build does not
+// report it.
+func (d *deployer) BuildManifest() (*types.BuildManifest, error) {
+	tars, err := build.ReleaseTars(d.RepoRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the release tars: %w", err)
+	}
+	version := d.buildVersion
+	if version == "" {
+		if version, err = build.ReleaseVersion(tars); err != nil {
+			return nil, err
+		}
+	}
+	return &types.BuildManifest{
+		Version:   version,
+		Tars:      tars,
+		Images:    d.BuildOptions.CommonBuildOptions.Images(version),
+		StagedURL: build.StagedURL(d.BuildOptions.CommonBuildOptions.StageLocation, d.buildVersion),
+	}, nil
+}
+
 func (d *deployer) setRepoPathIfNotSet() error {
 	if d.RepoRoot != "" {
 		return nil
@@ -17,9 +17,16 @@ limitations under the License.
 package deployer
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gce/deployer/options"
+	"sigs.k8s.io/kubetest2/pkg/build"
+	"sigs.k8s.io/kubetest2/pkg/types"
 )
 
 func TestSetRepoPathIfNotSet(t *testing.T) {
@@ -68,3 +75,86 @@ func TestSetRepoPathIfNotSet(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildManifest(t *testing.T) {
+	repoRoot := t.TempDir()
+	tarsDir := filepath.Join(repoRoot, "_output", "release-tars")
+	if err := os.MkdirAll(tarsDir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	tarball := filepath.Join(tarsDir, "kubernetes.tar.gz")
+	writeVersionTarball(t, tarball, "v1.31.0-alpha.1+abc")
+
+	cases := []struct {
+		name         string
+		buildVersion string
+		buildOptions build.Options
+		expected     types.BuildManifest
+	}{
+		{
+			name: "version from the tars",
+			expected: types.BuildManifest{
+				Version: "v1.31.0-alpha.1+abc",
+				Tars:    []string{tarball},
+			},
+		},
+		{
+			name:         "legacy mode staged with images",
+			buildVersion: "v1.31.0-alpha.1+run-1",
+			buildOptions: build.Options{Strategy: "make", StageLocation: "gs://b/ci", ImageLocation: "gcr.io/p"},
+			expected: types.BuildManifest{
+				Version: "v1.31.0-alpha.1+run-1",
+				Tars:    []string{tarball},
+				Images: []string{
+					"gcr.io/p/kube-apiserver-amd64:v1.31.0-alpha.1_run-1",
+					"gcr.io/p/kube-controller-manager-amd64:v1.31.0-alpha.1_run-1",
+					"gcr.io/p/kube-scheduler-amd64:v1.31.0-alpha.1_run-1",
+					"gcr.io/p/kube-proxy-amd64:v1.31.0-alpha.1_run-1",
+				},
+				StagedURL: "gs://b/ci/v1.31.0-alpha.1+run-1",
+			},
+		},
+	}
+	for i := range cases {
+		c := &cases[i]
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			d := &deployer{
+				RepoRoot:     repoRoot,
+				buildVersion: c.buildVersion,
+				BuildOptions: &options.BuildOptions{CommonBuildOptions: &c.buildOptions},
+			}
+			manifest, err := d.BuildManifest()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(*manifest, c.expected) {
+				t.Errorf("expected manifest %+v but got %+v", c.expected, *manifest)
+			}
+		})
+	}
+}
+
+// writeVersionTarball writes a release tarball recording version at path
+func writeVersionTarball(t *testing.T, path, version string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	w := tar.NewWriter(gz)
+	if err := w.WriteHeader(&tar.Header{Name: "kubernetes/version", Mode: 0644, Size: int64(len(version))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte(version)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
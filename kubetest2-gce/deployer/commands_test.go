@@ -0,0 +1,405 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gce/deployer/options"
+	"sigs.k8s.io/kubetest2/pkg/build"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+var listCommandRe = regexp.MustCompile(`^gcloud compute [a-z -]+ list `)
+
+func newFakeDeployer(cmder *exec.FakeCmder) *deployer {
+	return &deployer{
+		cmder: cmder,
+		BuildOptions: &options.BuildOptions{
+			CommonBuildOptions: &build.Options{TargetBuildArch: "linux/amd64"},
+		},
+		GCPProject:     "p",
+		instancePrefix: "kt2-abc",
+		Network:        "kt2-abc",
+	}
+}
+
+func TestEnsureFirewallRules(t *testing.T) {
+	describeNodePorts := "gcloud compute firewall-rules describe --project p --format=json(allowed,sourceRanges,targetTags) kt2-abc-minion-nodeports"
+	describeSSH := "gcloud compute firewall-rules describe --project p --format=json(allowed,sourceRanges,targetTags) kt2-abc-minion-ssh"
+	createNodePorts := "gcloud compute firewall-rules create --project p --target-tags kt2-abc-minion " +
+		"--allow tcp:30000-32767,udp:30000-32767 --network kt2-abc kt2-abc-minion-nodeports"
+	createSSH := "gcloud compute firewall-rules create --project p --target-tags kt2-abc-minion " +
+		"--allow tcp:22 --network kt2-abc --source-ranges 10.0.0.0/8 kt2-abc-minion-ssh"
+	updateSSH := "gcloud compute firewall-rules update --project p --target-tags kt2-abc-minion " +
+		"--allow tcp:22 --source-ranges 10.0.0.0/8 kt2-abc-minion-ssh"
+	nodePortsRule := `{"allowed": [{"IPProtocol": "udp", "ports": ["30000-32767"]}, {"IPProtocol": "tcp", "ports": ["30000-32767"]}], ` +
+		`"sourceRanges": ["0.0.0.0/0"], "targetTags": ["kt2-abc-minion"]}`
+	sshRule := `{"allowed": [{"IPProtocol": "tcp", "ports": ["22"]}], "sourceRanges": ["10.0.0.0/8"], "targetTags": ["kt2-abc-minion"]}`
+	openSSHRule := `{"allowed": [{"IPProtocol": "tcp", "ports": ["22"]}], "sourceRanges": ["0.0.0.0/0"], "targetTags": ["kt2-abc-minion"]}`
+	describeExtra := "gcloud compute firewall-rules describe --project p --format=json(allowed,sourceRanges,targetTags) kt2-abc-minion-extra"
+	createExtra := "gcloud compute firewall-rules create --project p --target-tags kt2-abc-minion " +
+		"--allow tcp:9400 --network kt2-abc --source-ranges 10.128.0.0/9 kt2-abc-minion-extra"
+	notFound := errors.New("exit status 1")
+
+	cases := []struct {
+		name                     string
+		sshSourceRanges          string
+		nodeFirewallAllow        string
+		nodeFirewallSourceRanges string
+		responses                []exec.FakeResponse
+		expected                 []string
+		expectError              bool
+	}{
+		{
+			name:            "created",
+			sshSourceRanges: "10.0.0.0/8",
+			responses: []exec.FakeResponse{
+				{Prefix: describeNodePorts, Err: notFound},
+				{Prefix: describeSSH, Err: notFound},
+			},
+			expected: []string{describeNodePorts, createNodePorts, describeSSH, createSSH},
+		},
+		{
+			name:      "no ssh rule without ssh source ranges",
+			responses: []exec.FakeResponse{{Prefix: describeNodePorts, Err: notFound}},
+			expected:  []string{describeNodePorts, createNodePorts},
+		},
+		{
+			name:                     "node firewall rule",
+			nodeFirewallAllow:        "tcp:9400",
+			nodeFirewallSourceRanges: "10.128.0.0/9",
+			responses: []exec.FakeResponse{
+				{Prefix: describeNodePorts, Stdout: nodePortsRule},
+				{Prefix: describeExtra, Err: notFound},
+			},
+			expected: []string{describeNodePorts, describeExtra, createExtra},
+		},
+		{
+			name:            "existing nodeports rule",
+			sshSourceRanges: "10.0.0.0/8",
+			responses: []exec.FakeResponse{
+				{Prefix: describeNodePorts, Stdout: nodePortsRule},
+				{Prefix: describeSSH, Err: notFound},
+			},
+			expected: []string{describeNodePorts, describeSSH, createSSH},
+		},
+		{
+			name:            "existing rules",
+			sshSourceRanges: "10.0.0.0/8",
+			responses: []exec.FakeResponse{
+				{Prefix: describeNodePorts, Stdout: nodePortsRule},
+				{Prefix: describeSSH, Stdout: sshRule},
+			},
+			expected: []string{describeNodePorts, describeSSH},
+		},
+		{
+			name:            "existing ssh rule open to any source",
+			sshSourceRanges: "10.0.0.0/8",
+			responses: []exec.FakeResponse{
+				{Prefix: describeNodePorts, Stdout: nodePortsRule},
+				{Prefix: describeSSH, Stdout: openSSHRule},
+			},
+			expected: []string{describeNodePorts, describeSSH, updateSSH},
+		},
+		{
+			name: "gcloud fails",
+			responses: []exec.FakeResponse{
+				{Prefix: describeNodePorts, Err: notFound},
+				{Prefix: "gcloud compute firewall-rules create", Err: errors.New("exit status 1")},
+			},
+			expected:    []string{describeNodePorts, createNodePorts},
+			expectError: true,
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmder := &exec.FakeCmder{Responses: c.responses}
+			d := newFakeDeployer(cmder)
+			d.SSHSourceRanges = c.sshSourceRanges
+			d.NodeFirewallAllow = c.nodeFirewallAllow
+			d.NodeFirewallSourceRanges = c.nodeFirewallSourceRanges
+			err := d.ensureFirewallRules()
+			if c.expectError != (err != nil) {
+				t.Errorf("expected error %v but got %v", c.expectError, err)
+			}
+			if actual := cmder.Commands(); !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected commands %v but got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestFirewallRuleMatches(t *testing.T) {
+	rule := firewallRule{name: "kt2-abc-minion-ssh", allow: "tcp:22", sourceRanges: "10.0.0.0/8,192.168.0.0/16"}
+	cases := []struct {
+		name      string
+		described string
+		matches   bool
+	}{
+		{
+			name:      "same rule",
+			described: `{"allowed": [{"IPProtocol": "tcp", "ports": ["22"]}], "sourceRanges": ["192.168.0.0/16", "10.0.0.0/8"], "targetTags": ["kt2-abc-minion"]}`,
+			matches:   true,
+		},
+		{
+			name:      "other ports",
+			described: `{"allowed": [{"IPProtocol": "tcp", "ports": ["22", "80"]}], "sourceRanges": ["10.0.0.0/8", "192.168.0.0/16"], "targetTags": ["kt2-abc-minion"]}`,
+		},
+		{
+			name:      "other source ranges",
+			described: `{"allowed": [{"IPProtocol": "tcp", "ports": ["22"]}], "sourceRanges": ["0.0.0.0/0"], "targetTags": ["kt2-abc-minion"]}`,
+		},
+		{
+			name:      "other target tags",
+			described: `{"allowed": [{"IPProtocol": "tcp", "ports": ["22"]}], "sourceRanges": ["10.0.0.0/8", "192.168.0.0/16"]}`,
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			var described describedFirewallRule
+			if err := json.Unmarshal([]byte(c.described), &described); err != nil {
+				t.Fatal(err)
+			}
+			diff := rule.matches(described, "kt2-abc-minion")
+			if c.matches != (diff == "") {
+				t.Errorf("expected the rule to match %v but got differences %q", c.matches, diff)
+			}
+		})
+	}
+}
+
+func TestVerifyDownFlagsNAT(t *testing.T) {
+	d := newFakeDeployer(&exec.FakeCmder{})
+	d.RepoRoot = t.TempDir()
+	d.CreateNAT = true
+	d.networkFlag = &pflag.Flag{Changed: true}
+	if err := d.verifyDownFlags(); err == nil {
+		t.Error("expected an error for --create-nat without --gcp-zone but got none")
+	}
+	d.GCPZone = "us-central1-b"
+	if err := d.verifyDownFlags(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestEnsureCloudNAT(t *testing.T) {
+	describe := "gcloud compute routers describe --project p --region us-central1 --format=value(name) kt2-abc-nat-router"
+	cases := []struct {
+		name      string
+		createNAT bool
+		responses []exec.FakeResponse
+		expected  []string
+	}{
+		{
+			name:     "no NAT",
+			expected: []string{},
+		},
+		{
+			name:      "created",
+			createNAT: true,
+			responses: []exec.FakeResponse{{Prefix: describe, Err: errors.New("exit status 1")}},
+			expected: []string{
+				describe,
+				"gcloud compute routers create --project p --region us-central1 --network kt2-abc kt2-abc-nat-router",
+				"gcloud compute routers nats create --project p --region us-central1 --router kt2-abc-nat-router " +
+					"--auto-allocate-nat-external-ips --nat-all-subnet-ip-ranges kt2-abc-nat-router",
+			},
+		},
+		{
+			name:      "existing router",
+			createNAT: true,
+			expected:  []string{describe},
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmder := &exec.FakeCmder{Responses: c.responses}
+			d := newFakeDeployer(cmder)
+			d.GCPZone = "us-central1-b"
+			d.CreateNAT = c.createNAT
+			if err := d.ensureCloudNAT(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if actual := cmder.Commands(); !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected commands %v but got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSweepLeftovers(t *testing.T) {
+	cmder := &exec.FakeCmder{
+		Responses: []exec.FakeResponse{
+			{Prefix: "gcloud compute instances list", Stdout: "kt2-abc-master\tus-central1-b\n"},
+			{Prefix: "gcloud compute disks list", Err: errors.New("exit status 1")},
+			{Prefix: "gcloud compute networks list", Stdout: "kt2-abc\n"},
+			{Prefix: "gcloud compute networks delete", Err: errors.New("exit status 1")},
+		},
+	}
+	if err := newFakeDeployer(cmder).sweepLeftovers(); err == nil {
+		t.Errorf("expected an error for the failed list and delete but got none")
+	}
+
+	// every kind is listed even after failures, only the leftovers are deleted
+	expectedDeletes := []string{
+		"gcloud compute instances delete kt2-abc-master --project=p --quiet --zone=us-central1-b",
+		"gcloud compute networks delete kt2-abc --project=p --quiet",
+	}
+	var lists, deletes []string
+	for _, command := range cmder.Commands() {
+		switch {
+		case listCommandRe.MatchString(command):
+			lists = append(lists, command)
+		default:
+			deletes = append(deletes, command)
+		}
+	}
+	if len(lists) != 10 {
+		t.Errorf("expected 10 list commands but got %d: %v", len(lists), lists)
+	}
+	if !reflect.DeepEqual(deletes, expectedDeletes) {
+		t.Errorf("expected delete commands %v but got %v", expectedDeletes, deletes)
+	}
+}
+
+func TestDeleteNodes(t *testing.T) {
+	cmder := &exec.FakeCmder{
+		Responses: []exec.FakeResponse{
+			{Prefix: "gcloud compute instance-groups managed list", Stdout: "kt2-abc-minion-group\tus-central1-b\n"},
+			{Prefix: "gcloud compute instance-templates list", Stdout: "kt2-abc-minion-template\n"},
+		},
+	}
+	if err := newFakeDeployer(cmder).deleteNodes(); err != nil {
+		t.Errorf("expected no error but got %v", err)
+	}
+
+	// the master and the network are left alone
+	expected := []string{
+		"gcloud compute instance-groups managed list --project=p --filter=name ~ ^kt2-abc-minion- --format=value(name,zone.basename())",
+		"gcloud compute instance-groups managed delete kt2-abc-minion-group --project=p --quiet --zone=us-central1-b",
+		"gcloud compute instance-templates list --project=p --filter=name ~ ^kt2-abc-minion- --format=value(name)",
+		"gcloud compute instance-templates delete kt2-abc-minion-template --project=p --quiet",
+	}
+	if actual := cmder.Commands(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected commands %v but got %v", expected, actual)
+	}
+}
+
+func TestSSHDump(t *testing.T) {
+	cases := []struct {
+		name       string
+		gcsLogsDir string
+		expected   []string
+	}{
+		{
+			name:     "local logs dir",
+			expected: []string{"/k/cluster/log-dump/log-dump.sh /logs"},
+		},
+		{
+			name:       "gcs logs dir",
+			gcsLogsDir: "gs://bucket/run/cluster-logs",
+			expected:   []string{"/k/cluster/log-dump/log-dump.sh /logs gs://bucket/run/cluster-logs"},
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmder := &exec.FakeCmder{}
+			d := newFakeDeployer(cmder)
+			d.RepoRoot = "/k"
+			d.logsDir = "/logs"
+			d.GCSLogsDir = c.gcsLogsDir
+			if err := d.sshDump(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if actual := cmder.Commands(); !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected commands %v but got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSweepLeftoversSharedNetwork(t *testing.T) {
+	cases := []struct {
+		name        string
+		keepNetwork bool
+		network     bool
+	}{
+		{
+			name:        "keep network",
+			keepNetwork: true,
+			network:     true,
+		},
+		{
+			name:    "network set without keep network",
+			network: true,
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmder := &exec.FakeCmder{}
+			d := newFakeDeployer(cmder)
+			d.KeepNetwork = c.keepNetwork
+			d.networkFlag = &pflag.Flag{Changed: c.network}
+			if err := d.sweepLeftovers(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			// the network and its subnets are kept, the firewall rules and
+			// routes of other clusters in the network too
+			expected := []string{
+				"gcloud compute instance-groups managed list --project=p --filter=name ~ ^kt2-abc- --format=value(name,zone.basename())",
+				"gcloud compute instances list --project=p --filter=name ~ ^kt2-abc- --format=value(name,zone.basename())",
+				"gcloud compute instance-templates list --project=p --filter=name ~ ^kt2-abc- --format=value(name)",
+				"gcloud compute disks list --project=p --filter=name ~ ^kt2-abc- --format=value(name,zone.basename())",
+				"gcloud compute addresses list --project=p --filter=name ~ ^kt2-abc- --format=value(name,region.basename())",
+				"gcloud compute routers list --project=p --filter=name ~ ^kt2-abc- --format=value(name,region.basename())",
+				"gcloud compute firewall-rules list --project=p --filter=network ~ /networks/kt2-abc$ AND name ~ ^kt2-abc- --format=value(name)",
+				"gcloud compute routes list --project=p --filter=network ~ /networks/kt2-abc$ AND name ~ ^kt2-abc- --format=value(name)",
+			}
+			if actual := cmder.Commands(); !reflect.DeepEqual(actual, expected) {
+				t.Errorf("expected commands %v but got %v", expected, actual)
+			}
+		})
+	}
+}
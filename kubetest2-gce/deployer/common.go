@@ -27,10 +27,15 @@ import (
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/kubetest2/pkg/boskos"
+	"sigs.k8s.io/kubetest2/pkg/gcp"
 )
 
 const (
 	gceProjectResourceType = "gce-project"
+
+	// defaultNvidiaDriverInstallerURL installs the driver on COS nodes, the
+	// default image of kube-up
+	defaultNvidiaDriverInstallerURL = "https://raw.githubusercontent.com/GoogleCloudPlatform/container-engine-accelerators/master/nvidia-driver-installer/cos/daemonset-preloaded.yaml"
 )
 
 func (d *deployer) init() error {
@@ -41,6 +46,16 @@ func (d *deployer) init() error {
 
 // initialize should only be called by init(), behind a sync.Once
 func (d *deployer) initialize() error {
+	// the repo type decides how to build, check it before acquiring anything
+	if d.commonOptions.ShouldBuild() || d.commonOptions.ShouldUp() || d.commonOptions.ShouldDown() {
+		if err := d.setRepoPathIfNotSet(); err != nil {
+			return err
+		}
+		if err := d.detectLegacyMode(); err != nil {
+			return fmt.Errorf("init failed to detect the repo type: %s", err)
+		}
+	}
+
 	if d.commonOptions.ShouldBuild() {
 		if err := d.verifyBuildFlags(); err != nil {
 			return fmt.Errorf("init failed to check build flags: %s", err)
@@ -57,22 +72,23 @@ func (d *deployer) initialize() error {
 
 			boskosClient, err := boskos.NewClient(d.BoskosLocation)
 			if err != nil {
-				return fmt.Errorf("failed to make boskos client: %s", err)
+				return fmt.Errorf("init failed: %s", err)
 			}
-			d.boskos = boskosClient
-
-			resource, err := boskos.Acquire(
+			d.boskos, err = boskos.NewPool(boskosClient, time.Duration(d.BoskosHeartbeatIntervalSeconds)*time.Second)
+			if err != nil {
+				return fmt.Errorf("init failed: %s", err)
+			}
+			project, err := gcp.AcquirePoolProject(
 				d.boskos,
-				gceProjectResourceType,
+				d.BoskosResourceType,
 				time.Duration(d.BoskosAcquireTimeoutSeconds)*time.Second,
-				time.Duration(d.BoskosHeartbeatIntervalSeconds)*time.Second,
-				d.boskosHeartbeatClose,
+				d.projectVerification(),
+				d.BoskosVerifyAttempts,
 			)
-
 			if err != nil {
-				return fmt.Errorf("init failed to get project from boskos: %s", err)
+				return fmt.Errorf("init failed: %s", err)
 			}
-			d.GCPProject = resource.Name
+			d.GCPProject = project
 			klog.V(1).Infof("Got project %s from boskos", d.GCPProject)
 		}
 
@@ -87,6 +103,37 @@ func (d *deployer) initialize() error {
 	return nil
 }
 
+// projectVerification returns how to verify the project acquired from boskos,
+// nil if it should not be verified.
+func (d *deployer) projectVerification() *gcp.ProjectVerification {
+	if !d.BoskosVerifyProject {
+		return nil
+	}
+	verification := &gcp.ProjectVerification{
+		Services: []string{"compute.googleapis.com"},
+		Fix:      d.BoskosFixProject,
+	}
+	// a network set with --network may be shared with other runs
+	if !d.networkSet() {
+		verification.Networks = []string{d.Network}
+	}
+	return verification
+}
+
+// networkSet returns true if --network was set explicitly, to a network which
+// may exist before the run and be shared with other runs.
+func (d *deployer) networkSet() bool {
+	return d.networkFlag != nil && d.networkFlag.Changed
+}
+
+// verifyNetworkFlags validates the network flags.
+func (d *deployer) verifyNetworkFlags() error {
+	if d.KeepNetwork && !d.networkSet() {
+		return fmt.Errorf("--keep-network requires --network, the network derived from --run-id is not reused by later runs")
+	}
+	return nil
+}
+
 func (d *deployer) buildEnv() []string {
 	// The base env currently does not inherit the current os env (except for PATH)
 	// because (for now) it doesn't have to. In future, this may have to change when
@@ -110,11 +157,15 @@ func (d *deployer) buildEnv() []string {
 	// for the deployer to work without fuss when run as root (like it
 	// does by default in Prow) we can simply change USER to be something
 	// non-root.
-	if user, ok := os.LookupEnv("USER"); ok && user != "root" {
-		env = append(env, fmt.Sprintf("USER=%s", user))
-	} else {
-		env = append(env, fmt.Sprintf("USER=%s", "kubetest2"))
+	env = append(env, fmt.Sprintf("USER=%s", sshUser()))
+
+	// KUBE_SSH_USER and KUBE_SSH_KEY_PATH are used by e2e tests SSHing into
+	// the nodes
+	keyPath, err := gcp.SSHKeyPath()
+	if err != nil {
+		klog.Warningf("not setting KUBE_SSH_KEY_PATH: %v", err)
 	}
+	env = append(env, sshEnv(keyPath)...)
 
 	// kube-up.sh, kube-down.sh etc. use PROJECT as a parameter
 	// for gcloud commands
@@ -136,7 +187,7 @@ func (d *deployer) buildEnv() []string {
 
 	// kube-up and kube-down get this as a default ("default" / "e2e-test-${USER}")
 	// but log-dump does not, set it explicitly here for maximum consistency
-	env = append(env, fmt.Sprintf("KUBE_GCE_NETWORK=%s", d.network))
+	env = append(env, fmt.Sprintf("KUBE_GCE_NETWORK=%s", d.Network))
 
 	// NUM_NODES is used by kube-up.sh script to decide what is expected shape
 	// of the cluster. It's already set on default on 3.
@@ -148,7 +199,11 @@ func (d *deployer) buildEnv() []string {
 
 	// NETWORK has to be manually specified to ensure created firewall rules
 	// target the right network
-	env = append(env, fmt.Sprintf("NETWORK=%s", d.network))
+	env = append(env, fmt.Sprintf("NETWORK=%s", d.Network))
+
+	if d.KeepNetwork {
+		env = append(env, "KUBE_DELETE_NETWORK=false")
+	}
 
 	if d.EnableCacheMutationDetector {
 		env = append(env, "ENABLE_CACHE_MUTATION_DETECTOR=true")
@@ -166,6 +221,10 @@ func (d *deployer) buildEnv() []string {
 		env = append(env, "CREATE_CUSTOM_NETWORK=true")
 	}
 
+	env = append(env, d.haEnv()...)
+	env = append(env, d.apiServerEnv()...)
+	env = append(env, d.addonEnv()...)
+
 	// MASTER_SIZE and NODE_SIZE are used by kube-up script to decide on the
 	// shape of the cluster. We want to overwrite them only when they are set.
 	// Otherwise, let's use script default.
@@ -175,6 +234,16 @@ func (d *deployer) buildEnv() []string {
 	if d.NodeSize != "" {
 		env = append(env, fmt.Sprintf("NODE_SIZE=%s", d.NodeSize))
 	}
+	if d.NodeMachineType != "" {
+		env = append(env, fmt.Sprintf("NODE_SIZE=%s", d.NodeMachineType))
+		env = append(env, fmt.Sprintf("KUBE_NODE_ARCH=%s", d.nodeArch()))
+	}
+	if d.nodeImage != "" {
+		env = append(env, fmt.Sprintf("KUBE_GCE_NODE_IMAGE=%s", d.nodeImage))
+	}
+	if d.nodeImageProject != "" {
+		env = append(env, fmt.Sprintf("KUBE_GCE_NODE_PROJECT=%s", d.nodeImageProject))
+	}
 
 	// KUBECTL_PATH points to the kubectl existing in $PATH
 	// used by the cluster/ scripts
@@ -210,6 +279,15 @@ func (d *deployer) buildEnv() []string {
 		env = append(env, fmt.Sprintf("KUBE_FEATURE_GATES=%s", d.FeatureGates))
 	}
 
+	// NODE_LOCAL_SSDS and NODE_ACCELERATORS are used by kube-up to attach
+	// local SSDs and GPUs to the nodes
+	if d.NodeLocalSSDs > 0 {
+		env = append(env, fmt.Sprintf("NODE_LOCAL_SSDS=%d", d.NodeLocalSSDs))
+	}
+	if d.NodeAcceleratorType != "" {
+		env = append(env, fmt.Sprintf("NODE_ACCELERATORS=type=%s,count=%d", d.NodeAcceleratorType, d.NodeAcceleratorCount))
+	}
+
 	if d.BuildOptions.CommonBuildOptions.TargetBuildArch != "" {
 		env = append(env, fmt.Sprintf("KUBE_BUILD_PLATFORMS=%s", d.BuildOptions.CommonBuildOptions.TargetBuildArch))
 	}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubetest2/pkg/gcp"
+)
+
+func TestProjectVerification(t *testing.T) {
+	cases := []struct {
+		name     string
+		verify   bool
+		fix      bool
+		network  bool
+		expected *gcp.ProjectVerification
+	}{
+		{
+			name: "not verified",
+		},
+		{
+			name:   "network of the run",
+			verify: true,
+			expected: &gcp.ProjectVerification{
+				Services: []string{"compute.googleapis.com"},
+				Networks: []string{"kt2-abc"},
+			},
+		},
+		{
+			name:    "network set with --network may be shared",
+			verify:  true,
+			fix:     true,
+			network: true,
+			expected: &gcp.ProjectVerification{
+				Services: []string{"compute.googleapis.com"},
+				Fix:      true,
+			},
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &deployer{}
+			d.Network = "kt2-abc"
+			d.BoskosVerifyProject = c.verify
+			d.BoskosFixProject = c.fix
+			d.networkFlag = &pflag.Flag{Changed: c.network}
+			if actual := d.projectVerification(); !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected verification %+v but got %+v", c.expected, actual)
+			}
+		})
+	}
+}
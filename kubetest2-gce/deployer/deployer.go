@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"sync"
 
 	"github.com/octago/sflags/gen/gpflag"
@@ -29,11 +30,12 @@ import (
 
 	"k8s.io/klog/v2"
 
-	"sigs.k8s.io/boskos/client"
-
 	"sigs.k8s.io/kubetest2/kubetest2-gce/deployer/options"
 	"sigs.k8s.io/kubetest2/pkg/artifacts"
+	"sigs.k8s.io/kubetest2/pkg/boskos"
 	"sigs.k8s.io/kubetest2/pkg/build"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/gcp"
 	"sigs.k8s.io/kubetest2/pkg/types"
 	"sigs.k8s.io/kubetest2/pkg/util"
 )
@@ -56,34 +58,68 @@ type deployer struct {
 	logsDir        string
 
 	// boskos struct field will be non-nil when the deployer is
-	// using boskos to acquire a GCP project
-	boskos *client.Client
+	// using boskos to acquire a GCP project, the pool keeps the project
+	// reserved until it is released
+	boskos *boskos.Pool
+
+	// buildVersion is the version built in legacy mode, for BuildManifest()
+	buildVersion string
+
+	// legacyModeFlag tells whether --legacy-mode was set explicitly or is
+	// detected from the repo root, see detectLegacyMode()
+	legacyModeFlag *pflag.Flag
+	// networkFlag tells whether --network was set explicitly, to a network
+	// that may exist before the run
+	networkFlag *pflag.Flag
 
-	// this channel serves as a signal channel for the hearbeat goroutine
-	// so that it can be explicitly closed
-	boskosHeartbeatClose chan struct{}
+	// stepRunner records the phases of Down as individual junit steps
+	stepRunner types.StepRunner
+
+	// auditPolicy and encryptionProviderConfig are read from
+	// --audit-policy-file and --encryption-provider-config-file for kube-up.sh
+	auditPolicy              string
+	encryptionProviderConfig string
+
+	// cmder creates the commands run by the deployer, faked in tests
+	cmder exec.Cmder
 
 	// instancePrefix is set for a mandatory env and for firewall rule creation
 	// see buildEnv() and nodeTag()
 	instancePrefix string
-	// network is set for firewall rule creation, see buildEnv() and firewall.go
-	network string
+	// nodeImage and nodeImageProject are set by resolveNodeImage() for buildEnv()
+	nodeImage        string
+	nodeImageProject string
 
 	// env is passed to buildEnv() function, many env variables are set by other flags
 	Env []string `desc:"A list on env variables to pass to the kube-*.sh scripts"`
 
 	BoskosAcquireTimeoutSeconds    int    `desc:"How long (in seconds) to hang on a request to Boskos to acquire a resource before erroring."`
 	BoskosHeartbeatIntervalSeconds int    `desc:"How often (in seconds) to send a heartbeat to Boskos to hold the acquired resource. 0 means no heartbeat."`
+	BoskosResourceType             string `desc:"The type of resource to acquire from Boskos when --gcp-project is unset."`
+	BoskosVerifyProject            bool   `desc:"If set, the project acquired from Boskos is checked before use: the compute API must be enabled and the network of the run must not exist. Projects failing the check are released and replaced."`
+	BoskosFixProject               bool   `desc:"If set, projects failing --boskos-verify-project are fixed, by enabling the compute API and deleting the leftover network, before being replaced."`
+	BoskosVerifyAttempts           int    `desc:"How many projects to acquire from Boskos before giving up on finding one that passes --boskos-verify-project."`
 	RepoRoot                       string `desc:"The path to the root of the local kubernetes/cloud-provider-gcp repo. Necessary to call certain scripts. Defaults to the current directory. If operating in legacy mode, this should be set to the local kubernetes/kubernetes repo."`
 	GCPProject                     string `desc:"GCP Project to create VMs in. If unset, the deployer will attempt to get a project from boskos."`
 	GCPZone                        string `desc:"GCP Zone to create VMs in. If unset, kube-up.sh and kube-down.sh defaults apply."`
 	EnableComputeAPI               bool   `desc:"If set, the deployer will enable the compute API for the project during the Up phase. This is necessary if the project has not been used before. WARNING: The currently configured GCP account must have permission to enable this API on the configured project."`
 	OverwriteLogsDir               bool   `desc:"If set, will overwrite an existing logs directory if one is encountered during dumping of logs. Useful when runnning tests locally."`
+	GCSLogsDir                     string `desc:"If set, log-dump.sh uploads the node logs directly to this gs:// path with logexporter instead of copying them to the local logs directory over SSH, for large clusters whose logs don't fit on the local disk."`
 	BoskosLocation                 string `desc:"If set, manually specifies the location of the boskos server. If unset and boskos is needed, defaults to http://boskos.test-pods.svc.cluster.local."`
-	LegacyMode                     bool   `desc:"Set if the provided repo root is the kubernetes/kubernetes repo and not kubernetes/cloud-provider-gcp."`
+	LegacyMode                     bool   `desc:"Set if the provided repo root is the kubernetes/kubernetes repo and not kubernetes/cloud-provider-gcp. Detected from the go.mod of the repo root when not set explicitly, repos without a go.mod are built in legacy mode."`
 	NumNodes                       int    `desc:"The number of nodes in the cluster."`
 	KubernetesVersion              string `desc:"The kubernetes version to use in the cluster"`
 
+	Network         string `desc:"Name of the network of the cluster, set as KUBE_GCE_NETWORK and NETWORK during deployment. Defaults to a name derived from --run-id. kube-up.sh uses the network if it already exists, e.g. one pre-created in a project with a tight network quota or custom network policies."`
+	KeepNetwork     bool   `desc:"If set, Down keeps the network set with --network and only deletes the resources of the cluster in it, so that later runs can reuse it. Requires --network. The leftovers swept after a failed down never include a network set with --network, which may be shared."`
+	SSHSourceRanges string `desc:"Comma separated CIDRs allowed to SSH into the nodes by a firewall rule created by Up, e.g. the egress range of the CI running e2e tests with SSH. If unset, no SSH rule is created and the nodes are only reachable through the SSH rule kube-up.sh creates in the networks it creates."`
+	CreateNAT       bool   `desc:"If set, Up creates a Cloud Router with Cloud NAT in the network for the nodes without external IPs, e.g. with KUBE_GCE_NODES_WITHOUT_EXTERNAL_IP=true in --env, to pull images, and Down deletes it. Requires --gcp-zone and --network set to a network existing before the run, kube-up.sh already creates a NAT in the networks it creates with KUBE_GCE_PRIVATE_CLUSTER=true."`
+
+	UseExistingMaster bool `desc:"If set, Up only recreates the nodes against the master of the cluster brought up by a previous run with the same --run-id, by deleting its node instance groups and running kube-up.sh with KUBE_USE_EXISTING_MASTER=true. Speeds up iterating on node components, skip --down to keep the master for the next run. Requires --gcp-project."`
+
+	NumMasters  int      `desc:"The number of master replicas of the cluster, larger than 1 for an HA control plane. The replicas are added one after the other by running kube-up.sh with KUBE_REPLICATE_EXISTING_MASTER=true, and removed at Down. The logs of their master components are collected into the logs dir."`
+	MasterZones []string `desc:"Comma separated zones of the master replicas added with --num-masters, cycled through, e.g. us-central1-a,us-central1-c for replicas in other zones than the first master. Defaults to --gcp-zone."`
+
 	EnableCacheMutationDetector bool   `desc:"Sets the environment variable ENABLE_CACHE_MUTATION_DETECTOR=true during deployment. This should cause a panic if anything mutates a shared informer cache."`
 	RuntimeConfig               string `desc:"Sets the KUBE_RUNTIME_CONFIG environment variable during deployment."`
 	EnablePodSecurityPolicy     bool   `desc:"Sets the environment variable ENABLE_POD_SECURITY_POLICY=true during deployment."`
@@ -93,16 +129,38 @@ type deployer struct {
 	CloudProvider               string `desc:"Sets the CLOUD_PROVIDER environment variable during deployment."`
 	FeatureGates                string `desc:"Sets the KUBE_FEATURE_GATES environment variable during deployment."`
 
-	MasterSize string `desc:"Sets the MASTER_SIZE environment variable during deployment."`
-	NodeSize   string `desc:"Sets the NODE_SIZE environment variable during deployment."`
+	MasterSize       string `desc:"Sets the MASTER_SIZE environment variable during deployment."`
+	NodeSize         string `desc:"Sets the NODE_SIZE environment variable during deployment."`
+	NodeMachineType  string `desc:"The GCE machine type of the nodes, e.g. t2a-standard-4. Sets the NODE_SIZE and KUBE_NODE_ARCH environment variables during deployment, ARM machine types (t2a, c4a) get arm64 nodes with the latest arm64 COS image unless --node-image is set. A --build must include linux/arm64 in --target-build-arch. Cannot be used with --node-size."`
+	NodeImage        string `desc:"Sets the KUBE_GCE_NODE_IMAGE environment variable during deployment."`
+	NodeImageProject string `desc:"Sets the KUBE_GCE_NODE_PROJECT environment variable during deployment, the project of --node-image."`
+
+	NodeLocalSSDs            int    `desc:"Sets the NODE_LOCAL_SSDS environment variable during deployment, the number of local SSDs attached to each node."`
+	NodeAcceleratorType      string `desc:"The GPU accelerator type attached to each node, e.g. nvidia-tesla-t4. Sets the NODE_ACCELERATORS environment variable during deployment together with --node-accelerator-count."`
+	NodeAcceleratorCount     int    `desc:"The number of GPU accelerators attached to each node, used with --node-accelerator-type."`
+	NodeFirewallAllow        string `desc:"Comma separated protocols and ports allowed to the nodes from --node-firewall-source-ranges by a firewall rule created by Up and deleted by Down, e.g. tcp:9400 to scrape the DCGM exporter of GPU nodes."`
+	NodeFirewallSourceRanges string `desc:"Comma separated CIDRs the ports of --node-firewall-allow are allowed from, required with --node-firewall-allow."`
+	NvidiaDriverInstallerURL string `desc:"The manifest of the NVIDIA driver installer daemonset applied after Up when --node-accelerator-type is set. Empty skips the install."`
 
 	IngressGCEImage string `desc:"Sets the ingress-gce image used for the Ingress and Loadbalancer controller."`
+
+	DisableMetricsServer bool   `desc:"Sets the environment variable ENABLE_METRICS_SERVER=false during deployment, the metrics-server addon is installed by default."`
+	DisableClusterDNS    bool   `desc:"Sets the environment variable ENABLE_CLUSTER_DNS=false during deployment, the cluster DNS addon is installed by default."`
+	ClusterDNS           string `desc:"The cluster DNS addon, coredns or kube-dns. Sets the CLUSTER_DNS_CORE_DNS environment variable during deployment. If unset, the kube-up.sh default applies."`
+	EnableNodeLocalDNS   bool   `desc:"Sets the environment variable KUBE_ENABLE_NODELOCAL_DNS=true during deployment, for the NodeLocal DNSCache addon."`
+
+	EnableAuditLog               bool   `desc:"Enables the apiserver audit log with the log backend by setting ENABLE_APISERVER_ADVANCED_AUDIT=true during deployment. The audit log of the master is collected into the logs dir by log-dump.sh at Down."`
+	AuditPolicyFile              string `desc:"The audit policy file passed as the ADVANCED_AUDIT_POLICY environment variable during deployment. If unset, the default policy of kube-up.sh applies. Requires --enable-audit-log."`
+	EncryptionProviderConfigFile string `desc:"The apiserver encryption provider config file for encryption at rest, passed base64 encoded as the ENCRYPTION_PROVIDER_CONFIG environment variable during deployment. KMS providers need their plugin running on the master."`
+
+	UserClusterRole string `desc:"The cluster role bound to the service account of the limited user kubeconfig written to the run dir next to the admin kubeconfig after Up. Empty skips the user kubeconfig. Requires kubectl create token, i.e. a cluster and kubectl of 1.24 or later, e.g. edit."`
 }
 
 // New implements deployer.New for gce
 func New(opts types.Options) (types.Deployer, *pflag.FlagSet) {
 	d := &deployer{
 		commonOptions: opts,
+		cmder:         exec.DefaultCmder,
 		BuildOptions: &options.BuildOptions{
 			CommonBuildOptions: &build.Options{
 				Builder:         &build.NoopBuilder{},
@@ -111,23 +169,29 @@ func New(opts types.Options) (types.Deployer, *pflag.FlagSet) {
 				TargetBuildArch: "linux/amd64",
 			},
 		},
-		kubeconfigPath:       filepath.Join(opts.RunDir(), "kubetest2-kubeconfig"),
-		logsDir:              filepath.Join(artifacts.BaseDir(), "cluster-logs"),
-		boskosHeartbeatClose: make(chan struct{}),
+		kubeconfigPath: filepath.Join(opts.RunDir(), "kubetest2-kubeconfig"),
+		logsDir:        filepath.Join(artifacts.BaseDir(), "cluster-logs"),
 		// names need to start with an alphabet
 		instancePrefix:                 "kt2-" + util.PseudoUniqueSubstring(opts.RunID()),
-		network:                        "kt2-" + util.PseudoUniqueSubstring(opts.RunID()),
+		Network:                        "kt2-" + util.PseudoUniqueSubstring(opts.RunID()),
 		BoskosAcquireTimeoutSeconds:    5 * 60,
 		BoskosHeartbeatIntervalSeconds: 5 * 60,
+		BoskosResourceType:             gceProjectResourceType,
+		BoskosVerifyAttempts:           3,
 		KubernetesVersion:              "https://dl.k8s.io/release/latest.txt",
 		BoskosLocation:                 "http://boskos.test-pods.svc.cluster.local.",
 		NumNodes:                       3,
+		NumMasters:                     1,
+		NodeAcceleratorCount:           1,
+		NvidiaDriverInstallerURL:       defaultNvidiaDriverInstallerURL,
 	}
 
 	flagSet, err := gpflag.Parse(d)
 	if err != nil {
 		klog.Fatalf("couldn't parse flagset for deployer struct: %s", err)
 	}
+	d.legacyModeFlag = flagSet.Lookup("legacy-mode")
+	d.networkFlag = flagSet.Lookup("network")
 
 	// initing the klog flags adds them to goflag.CommandLine
 	// they can then be added to the built pflag set
@@ -144,10 +208,44 @@ var _ types.NewDeployer = New
 // assert that deployer implements types.Deployer
 var _ types.Deployer = &deployer{}
 
+// assert that deployer implements types.DeployerWithSteps
+var _ types.DeployerWithSteps = &deployer{}
+
+// assert that deployer implements types.DeployerWithBuildManifest
+var _ types.DeployerWithBuildManifest = &deployer{}
+
+// assert that deployer implements types.DeployerWithVerifyDown
+var _ types.DeployerWithVerifyDown = &deployer{}
+
+// SetStepRunner implements types.DeployerWithSteps
+func (d *deployer) SetStepRunner(run types.StepRunner) {
+	d.stepRunner = run
+}
+
 func (d *deployer) Provider() string {
 	return Name
 }
 
+// assert that deployer implements types.DeployerWithProviderConfig
+var _ types.DeployerWithProviderConfig = &deployer{}
+
+// ProviderConfig implements types.DeployerWithProviderConfig
+func (d *deployer) ProviderConfig() (map[string]string, error) {
+	config := map[string]string{
+		"gce-project": d.GCPProject,
+		"network":     d.Network,
+		// kube-up.sh names the node instance group after NODE_TAG
+		"node-instance-group": d.nodeTag() + "-group",
+		"num-nodes":           strconv.Itoa(d.NumNodes),
+	}
+	// otherwise the kube-up.sh default zone applies, which e2e.test doesn't know
+	if d.GCPZone != "" {
+		config["gce-zone"] = d.GCPZone
+		config["gce-region"] = gcp.RegionFromZone(d.GCPZone)
+	}
+	return config, nil
+}
+
 func (d *deployer) Version() string {
 	return GitTag
 }
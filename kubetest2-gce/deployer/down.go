@@ -17,11 +17,11 @@ limitations under the License.
 package deployer
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 
 	"k8s.io/klog/v2"
-	"sigs.k8s.io/kubetest2/pkg/boskos"
 	"sigs.k8s.io/kubetest2/pkg/exec"
 )
 
@@ -43,34 +43,54 @@ func (d *deployer) Down() error {
 	d.kubectlPath = path
 
 	env := d.buildEnv()
+	// the master replicas are removed first, kube-down.sh only deletes the
+	// rest of the cluster with the last master
+	errs := d.removeMasterReplicas(env)
+
 	script := filepath.Join(d.RepoRoot, "cluster", "kube-down.sh")
 	klog.V(2).Infof("About to run script at: %s", script)
 
-	cmd := exec.Command(script)
+	cmd := d.cmder.Command(script)
 	cmd.SetEnv(env...)
 	exec.InheritOutput(cmd)
 
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("error encountered during %s: %s", script, err)
+		// keep going, kube-down stops at the first failure, e.g. failing to
+		// delete an instance leaks the network, which the sweep below deletes
+		errs = append(errs, fmt.Errorf("error encountered during %s: %s", script, err))
 	}
 
-	klog.V(2).Info("about to delete nodeport firewall rule")
+	klog.V(2).Info("about to delete the nodeport and ssh firewall rules")
 	// best-effort try to delete the explicitly created firewall rules
 	// ideally these should already be deleted by kube-down
-	d.deleteFirewallRuleNodePort()
-
-	if d.boskos != nil {
-		klog.V(2).Info("releasing boskos project")
-		err := boskos.Release(
-			d.boskos,
-			[]string{d.GCPProject},
-			d.boskosHeartbeatClose,
-		)
-		if err != nil {
-			return fmt.Errorf("down failed to release boskos project: %s", err)
-		}
+	d.deleteFirewallRules()
+	d.deleteCloudNAT()
+
+	// the leftovers are reported as a failed junit step without failing Down,
+	// the project is dirty either way and cleaned up by the janitor
+	if err := d.stepRunner.Run("SweepLeftovers", d.sweepLeftovers); err != nil {
+		klog.Warningf("failed to sweep leftover resources: %s", err)
 	}
 
+	// hand the project back to the janitor even if kube-down failed instead
+	// of holding the lease until it is reaped
+	if err := d.releaseBoskosProject(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// releaseBoskosProject releases the project if it was acquired from boskos,
+// stopping its heartbeat. It is safe to call more than once.
+func (d *deployer) releaseBoskosProject() error {
+	if d.boskos == nil {
+		return nil
+	}
+	klog.V(2).Info("releasing boskos project")
+	if err := d.boskos.ReleaseAll(); err != nil {
+		return fmt.Errorf("down failed to release boskos project: %s", err)
+	}
+	d.boskos = nil
 	return nil
 }
 
@@ -82,6 +102,10 @@ func (d *deployer) verifyDownFlags() error {
 	if d.GCPProject == "" {
 		return fmt.Errorf("gcp project must be set")
 	}
+	// the Cloud NAT is deleted from the region of --gcp-zone
+	if err := d.verifyNATFlags(); err != nil {
+		return err
+	}
 
-	return nil
+	return d.verifyNetworkFlags()
 }
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"k8s.io/klog/v2"
 
@@ -33,6 +34,10 @@ func (d *deployer) DumpClusterLogs() error {
 		return fmt.Errorf("dump cluster logs failed to init: %s", err)
 	}
 
+	if d.GCSLogsDir != "" && !strings.HasPrefix(d.GCSLogsDir, "gs://") {
+		return fmt.Errorf("--gcs-logs-dir must be a gs:// path, got %q", d.GCSLogsDir)
+	}
+
 	klog.V(2).Info("making logs directory")
 	if err := d.makeLogsDir(); err != nil {
 		return fmt.Errorf("couldn't make logs dir: %s", err)
@@ -46,6 +51,12 @@ func (d *deployer) DumpClusterLogs() error {
 		return fmt.Errorf("failed to dump logs from instance log files: %s", err)
 	}
 
+	if d.NumMasters > 1 {
+		if err := d.dumpMasterReplicaLogs(); err != nil {
+			klog.Warningf("failed to dump the logs of the master replicas: %s", err)
+		}
+	}
+
 	if err := d.kubectlDump(); err != nil {
 		return fmt.Errorf("failed to dump cluster info with kubectl: %s", err)
 	}
@@ -93,9 +104,14 @@ func (d *deployer) sshDump() error {
 		filepath.Join(d.RepoRoot, "cluster", "log-dump", "log-dump.sh"),
 		d.logsDir,
 	}
+	if d.GCSLogsDir != "" {
+		// log-dump.sh uploads the node logs with logexporter when given
+		// a GCS path
+		args = append(args, d.GCSLogsDir)
+	}
 	klog.V(2).Infof("About to run: %s", args)
 
-	cmd := exec.Command(args[0], args[1:]...)
+	cmd := d.cmder.Command(args[0], args[1:]...)
 	cmd.SetEnv(env...)
 	exec.InheritOutput(cmd)
 	if err := cmd.Run(); err != nil {
@@ -120,7 +136,7 @@ func (d *deployer) kubectlDump() error {
 	}
 	klog.V(2).Infof("About to run: %s", args)
 
-	cmd := exec.Command(args[0], args[1:]...)
+	cmd := d.cmder.Command(args[0], args[1:]...)
 	cmd.SetEnv(env...)
 	cmd.SetStderr(os.Stderr)
 	cmd.SetStdout(outfile)
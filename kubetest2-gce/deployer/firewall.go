@@ -17,7 +17,10 @@ limitations under the License.
 package deployer
 
 import (
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/kubetest2/pkg/exec"
@@ -34,31 +37,167 @@ func (d *deployer) nodePortRuleName() string {
 	return fmt.Sprintf("%s-nodeports", d.nodeTag())
 }
 
-func (d *deployer) createFirewallRuleNodePort() error {
-	cmd := exec.Command(
-		"gcloud", "compute", "firewall-rules", "create",
-		"--project", d.GCPProject,
-		"--target-tags", d.nodeTag(),
-		"--allow", "tcp:30000-32767,udp:30000-32767",
-		"--network", d.network,
-		d.nodePortRuleName(),
-	)
-	exec.InheritOutput(cmd)
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to create nodeports firewall rule: %s", err)
+func (d *deployer) sshRuleName() string {
+	return fmt.Sprintf("%s-ssh", d.nodeTag())
+}
+
+func (d *deployer) nodeFirewallRuleName() string {
+	return fmt.Sprintf("%s-extra", d.nodeTag())
+}
+
+// firewallRule is a firewall rule e2e tests need on the nodes, which a
+// custom network may not have
+type firewallRule struct {
+	name  string
+	allow string
+	// sourceRanges is a comma separated list of CIDRs, empty for any source
+	sourceRanges string
+}
+
+// firewallRules returns the rules for the node port services, which e2e
+// tests need on any network, for SSH into the nodes from
+// --ssh-source-ranges, and for the ports of --node-firewall-allow, e.g. of
+// the GPU node agents. Without the SSH rule the nodes are only reachable
+// through the SSH rule kube-up.sh creates in the networks it creates.
+func (d *deployer) firewallRules() []firewallRule {
+	rules := []firewallRule{
+		{name: d.nodePortRuleName(), allow: "tcp:30000-32767,udp:30000-32767"},
+	}
+	if d.SSHSourceRanges != "" {
+		rules = append(rules, firewallRule{name: d.sshRuleName(), allow: "tcp:22", sourceRanges: d.SSHSourceRanges})
+	}
+	if d.NodeFirewallAllow != "" {
+		rules = append(rules, firewallRule{name: d.nodeFirewallRuleName(), allow: d.NodeFirewallAllow, sourceRanges: d.NodeFirewallSourceRanges})
+	}
+	return rules
+}
+
+// describedFirewallRule is the part of gcloud compute firewall-rules describe
+// compared with the firewallRule expected
+type describedFirewallRule struct {
+	Allowed []struct {
+		IPProtocol string   `json:"IPProtocol"`
+		Ports      []string `json:"ports"`
+	} `json:"allowed"`
+	SourceRanges []string `json:"sourceRanges"`
+	TargetTags   []string `json:"targetTags"`
+}
+
+// sortedList splits a comma separated list and sorts it
+func sortedList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	slices.Sort(items)
+	return items
+}
+
+// matches returns a description of the differences of the described rule
+// with the expected rule targeting tag, empty if there are none
+func (r firewallRule) matches(described describedFirewallRule, tag string) string {
+	var allowed []string
+	for _, a := range described.Allowed {
+		if len(a.Ports) == 0 {
+			allowed = append(allowed, a.IPProtocol)
+		}
+		for _, port := range a.Ports {
+			allowed = append(allowed, a.IPProtocol+":"+port)
+		}
+	}
+	slices.Sort(allowed)
+	sourceRanges := r.sourceRanges
+	if sourceRanges == "" {
+		// GCE defaults the rules without source ranges to any source
+		sourceRanges = "0.0.0.0/0"
+	}
+	var diffs []string
+	if expected := sortedList(r.allow); !slices.Equal(allowed, expected) {
+		diffs = append(diffs, fmt.Sprintf("allows %v instead of %v", allowed, expected))
+	}
+	describedSourceRanges := slices.Clone(described.SourceRanges)
+	slices.Sort(describedSourceRanges)
+	if expected := sortedList(sourceRanges); !slices.Equal(describedSourceRanges, expected) {
+		diffs = append(diffs, fmt.Sprintf("has source ranges %v instead of %v", described.SourceRanges, expected))
+	}
+	if !slices.Equal(described.TargetTags, []string{tag}) {
+		diffs = append(diffs, fmt.Sprintf("targets %v instead of %v", described.TargetTags, []string{tag}))
+	}
+	return strings.Join(diffs, ", ")
+}
+
+// ensureFirewallRules creates the firewall rules of firewallRules() which
+// don't exist yet. An existing rule, e.g. left by a previous run with the
+// same --run-id, is updated if its ports, source ranges or target tags
+// differ from the expected ones.
+func (d *deployer) ensureFirewallRules() error {
+	for _, rule := range d.firewallRules() {
+		describe := d.cmder.Command(
+			"gcloud", "compute", "firewall-rules", "describe",
+			"--project", d.GCPProject,
+			"--format=json(allowed,sourceRanges,targetTags)",
+			rule.name,
+		)
+		if out, err := exec.Output(describe); err == nil {
+			var described describedFirewallRule
+			if err := json.Unmarshal(out, &described); err != nil {
+				return fmt.Errorf("failed to parse firewall rule %s: %s", rule.name, err)
+			}
+			diff := rule.matches(described, d.nodeTag())
+			if diff == "" {
+				klog.V(2).Infof("firewall rule %s already exists", rule.name)
+				continue
+			}
+			klog.V(1).Infof("updating firewall rule %s, it %s", rule.name, diff)
+			if err := d.writeFirewallRule("update", rule); err != nil {
+				return fmt.Errorf("failed to update firewall rule %s: %s", rule.name, err)
+			}
+			continue
+		}
+		if err := d.writeFirewallRule("create", rule); err != nil {
+			return fmt.Errorf("failed to create firewall rule %s: %s", rule.name, err)
+		}
 	}
 
 	return nil
 }
 
-func (d *deployer) deleteFirewallRuleNodePort() {
-	cmd := exec.Command(
-		"gcloud", "compute", "firewall-rules", "delete",
+// writeFirewallRule creates or updates the firewall rule, the network of an
+// existing rule cannot be changed
+func (d *deployer) writeFirewallRule(action string, rule firewallRule) error {
+	args := []string{
+		"compute", "firewall-rules", action,
 		"--project", d.GCPProject,
-		d.nodePortRuleName(),
-	)
+		"--target-tags", d.nodeTag(),
+		"--allow", rule.allow,
+	}
+	sourceRanges := rule.sourceRanges
+	if action == "create" {
+		args = append(args, "--network", d.Network)
+	} else if sourceRanges == "" {
+		sourceRanges = "0.0.0.0/0"
+	}
+	if sourceRanges != "" {
+		args = append(args, "--source-ranges", sourceRanges)
+	}
+	cmd := d.cmder.Command("gcloud", append(args, rule.name)...)
 	exec.InheritOutput(cmd)
-	if err := cmd.Run(); err != nil {
-		klog.Warning("failed to delete nodeports firewall rules: might be deleted already?")
+	return cmd.Run()
+}
+
+func (d *deployer) deleteFirewallRules() {
+	for _, rule := range d.firewallRules() {
+		cmd := d.cmder.Command(
+			"gcloud", "compute", "firewall-rules", "delete",
+			"--project", d.GCPProject,
+			"--quiet",
+			rule.name,
+		)
+		exec.InheritOutput(cmd)
+		if err := cmd.Run(); err != nil {
+			klog.Warningf("failed to delete firewall rule %s: might be deleted already?", rule.name)
+		}
 	}
 }
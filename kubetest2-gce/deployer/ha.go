@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// masterReplicaLogFiles are the logs of the master components copied from
+// the master replicas, log-dump.sh only dumps the first master
+var masterReplicaLogFiles = []string{
+	"kube-apiserver.log",
+	"kube-controller-manager.log",
+	"kube-scheduler.log",
+	"etcd.log",
+	"etcd-events.log",
+}
+
+// verifyHAFlags validates the flags of an HA control plane
+func (d *deployer) verifyHAFlags() error {
+	if d.NumMasters < 1 {
+		return fmt.Errorf("--num-masters must be at least 1")
+	}
+	if d.NumMasters == 1 && len(d.MasterZones) > 0 {
+		return fmt.Errorf("--master-zones requires --num-masters larger than 1")
+	}
+	if d.NumMasters > 1 && d.UseExistingMaster {
+		return fmt.Errorf("--num-masters cannot be used with --use-existing-master")
+	}
+	return nil
+}
+
+// haEnv returns the kube-up.sh and kube-down.sh env of an HA control plane
+func (d *deployer) haEnv() []string {
+	if d.NumMasters <= 1 {
+		return nil
+	}
+	return []string{"MULTIZONE=true"}
+}
+
+// masterReplicaZones returns the zones of the master replicas added to the
+// first master, cycling through --master-zones. Empty zones are in the zone
+// of the first master.
+func (d *deployer) masterReplicaZones() []string {
+	var zones []string
+	for i := 1; i < d.NumMasters; i++ {
+		zone := d.GCPZone
+		if len(d.MasterZones) > 0 {
+			zone = d.MasterZones[(i-1)%len(d.MasterZones)]
+		}
+		zones = append(zones, zone)
+	}
+	return zones
+}
+
+// zoneEnv returns env running kube-up.sh or kube-down.sh in zone, or in the
+// zone of the first master if zone is empty
+func zoneEnv(env []string, zone string) []string {
+	env = append([]string{}, env...)
+	if zone != "" {
+		// the last value wins, overriding the KUBE_GCE_ZONE of the first master
+		env = append(env, "KUBE_GCE_ZONE="+zone)
+	}
+	return env
+}
+
+// addMasterReplicas adds the master replicas to the cluster brought up by
+// kube-up.sh, one after the other as each one joins the etcd cluster
+func (d *deployer) addMasterReplicas(env []string) error {
+	script := filepath.Join(d.RepoRoot, "cluster", "kube-up.sh")
+	for i, zone := range d.masterReplicaZones() {
+		klog.V(2).Infof("adding master replica %d/%d in zone %q", i+2, d.NumMasters, zone)
+		cmd := d.cmder.Command(script)
+		cmd.SetEnv(append(zoneEnv(env, zone), "KUBE_REPLICATE_EXISTING_MASTER=true")...)
+		exec.InheritOutput(cmd)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("error adding master replica %d in zone %q: %s", i+2, zone, err)
+		}
+	}
+	return nil
+}
+
+// removeMasterReplicas deletes the master replicas in the reverse order they
+// were added, kube-down.sh only deletes the master replica in its zone while
+// other replicas remain. The first master and the rest of the cluster are
+// deleted by the final kube-down.sh.
+func (d *deployer) removeMasterReplicas(env []string) []error {
+	script := filepath.Join(d.RepoRoot, "cluster", "kube-down.sh")
+	zones := d.masterReplicaZones()
+	var errs []error
+	for i := len(zones) - 1; i >= 0; i-- {
+		klog.V(2).Infof("removing master replica %d/%d in zone %q", i+2, d.NumMasters, zones[i])
+		cmd := d.cmder.Command(script)
+		cmd.SetEnv(zoneEnv(env, zones[i])...)
+		exec.InheritOutput(cmd)
+		if err := cmd.Run(); err != nil {
+			errs = append(errs, fmt.Errorf("error removing master replica %d in zone %q: %s", i+2, zones[i], err))
+		}
+	}
+	return errs
+}
+
+// masterReplicaNameRe matches the names kube-up.sh gives to the master
+// replicas, the name of the first master with a random suffix
+func (d *deployer) masterReplicaNameRe() *regexp.Regexp {
+	return regexp.MustCompile("^" + regexp.QuoteMeta(d.instancePrefix+"-master") + "-[a-z0-9]{3}$")
+}
+
+// dumpMasterReplicaLogs copies the logs of the master components of the
+// master replicas into a directory per replica in the logs dir
+func (d *deployer) dumpMasterReplicaLogs() error {
+	cmd := d.cmder.Command("gcloud", "compute", "instances", "list",
+		"--project="+d.GCPProject,
+		"--filter=name ~ ^"+d.instancePrefix+"-master-",
+		"--format=value(name,zone.basename())",
+	)
+	cmd.SetEnv(d.buildEnv()...)
+	lines, err := exec.OutputLines(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to list the master replicas: %s", err)
+	}
+
+	logFiles := masterReplicaLogFiles
+	if d.EnableAuditLog {
+		logFiles = append(append([]string{}, logFiles...), filepath.Base(auditLogPath))
+	}
+	nameRe := d.masterReplicaNameRe()
+	var errs []string
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || !nameRe.MatchString(fields[0]) {
+			continue
+		}
+		name, zone := fields[0], fields[1]
+		dir := filepath.Join(d.logsDir, name)
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return fmt.Errorf("failed to create logs dir of master replica %s: %s", name, err)
+		}
+		for _, logFile := range logFiles {
+			if err := d.copyMasterLog(name, zone, logFile, dir); err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to copy logs of the master replicas: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// copyMasterLog copies /var/log/<logFile> of the master instance into dir
+func (d *deployer) copyMasterLog(instance, zone, logFile, dir string) error {
+	outfile, err := os.Create(filepath.Join(dir, logFile))
+	if err != nil {
+		return fmt.Errorf("failed to create log file: %s", err)
+	}
+	defer outfile.Close()
+
+	cmd := d.cmder.Command("gcloud", "compute", "ssh", instance,
+		"--project="+d.GCPProject,
+		"--zone="+zone,
+		"--command=sudo cat /var/log/"+logFile,
+	)
+	cmd.SetEnv(d.buildEnv()...)
+	cmd.SetStderr(os.Stderr)
+	cmd.SetStdout(outfile)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to copy %s from %s: %s", logFile, instance, err)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+func TestVerifyHAFlags(t *testing.T) {
+	cases := []struct {
+		name              string
+		numMasters        int
+		masterZones       []string
+		useExistingMaster bool
+		expectError       bool
+	}{
+		{
+			name:       "single master",
+			numMasters: 1,
+		},
+		{
+			name:        "replicas in other zones",
+			numMasters:  3,
+			masterZones: []string{"us-central1-a", "us-central1-c"},
+		},
+		{
+			name:        "no master",
+			expectError: true,
+		},
+		{
+			name:        "master zones without replicas",
+			numMasters:  1,
+			masterZones: []string{"us-central1-a"},
+			expectError: true,
+		},
+		{
+			name:              "replicas of an existing master",
+			numMasters:        3,
+			useExistingMaster: true,
+			expectError:       true,
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &deployer{
+				NumMasters:        c.numMasters,
+				MasterZones:       c.masterZones,
+				UseExistingMaster: c.useExistingMaster,
+			}
+			err := d.verifyHAFlags()
+			if c.expectError != (err != nil) {
+				t.Errorf("expected error %v but got %v", c.expectError, err)
+			}
+		})
+	}
+}
+
+func TestMasterReplicaZones(t *testing.T) {
+	cases := []struct {
+		name        string
+		numMasters  int
+		zone        string
+		masterZones []string
+		expected    []string
+	}{
+		{
+			name:       "single master",
+			numMasters: 1,
+			zone:       "us-central1-b",
+		},
+		{
+			name:       "zone of the first master",
+			numMasters: 3,
+			zone:       "us-central1-b",
+			expected:   []string{"us-central1-b", "us-central1-b"},
+		},
+		{
+			name:        "master zones cycled through",
+			numMasters:  4,
+			zone:        "us-central1-b",
+			masterZones: []string{"us-central1-a", "us-central1-c"},
+			expected:    []string{"us-central1-a", "us-central1-c", "us-central1-a"},
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &deployer{
+				NumMasters:  c.numMasters,
+				GCPZone:     c.zone,
+				MasterZones: c.masterZones,
+			}
+			if actual := d.masterReplicaZones(); !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected zones %v but got %v", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRemoveMasterReplicas(t *testing.T) {
+	cmder := &exec.FakeCmder{
+		Responses: []exec.FakeResponse{{Prefix: "/k/cluster/kube-down.sh", Err: errors.New("exit status 1")}},
+	}
+	d := newFakeDeployer(cmder)
+	d.RepoRoot = "/k"
+	d.NumMasters = 3
+	errs := d.removeMasterReplicas(nil)
+	// every replica is removed even if one fails
+	if len(errs) != 2 {
+		t.Errorf("expected 2 errors but got %v", errs)
+	}
+	expected := []string{"/k/cluster/kube-down.sh", "/k/cluster/kube-down.sh"}
+	if actual := cmder.Commands(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected commands %v but got %v", expected, actual)
+	}
+}
+
+func TestDumpMasterReplicaLogs(t *testing.T) {
+	cmder := &exec.FakeCmder{
+		Responses: []exec.FakeResponse{
+			{Prefix: "gcloud compute instances list", Stdout: "kt2-abc-master\tus-central1-b\nkt2-abc-master-x7f\tus-central1-a\nkt2-abc-master-pd-copy\tus-central1-a\n"},
+		},
+	}
+	d := newFakeDeployer(cmder)
+	d.logsDir = t.TempDir()
+	d.NumMasters = 2
+	if err := d.dumpMasterReplicaLogs(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expected := []string{"gcloud compute instances list --project=p --filter=name ~ ^kt2-abc-master- --format=value(name,zone.basename())"}
+	for _, logFile := range masterReplicaLogFiles {
+		expected = append(expected, "gcloud compute ssh kt2-abc-master-x7f --project=p --zone=us-central1-a --command=sudo cat /var/log/"+logFile)
+	}
+	if actual := cmder.Commands(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected commands %v but got %v", expected, actual)
+	}
+	// the commands get the env of the deployer, including the build arch
+	for _, call := range cmder.Calls() {
+		if !slices.Contains(call.Env, "KUBE_BUILD_PLATFORMS=linux/amd64") {
+			t.Errorf("expected the env of %q to set KUBE_BUILD_PLATFORMS but got %v", call.Line, call.Env)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/artifacts"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/metadata"
+)
+
+// userServiceAccount is the service account in the default namespace
+// backing the limited user kubeconfig
+const userServiceAccount = "kubetest2-user"
+
+func (d *deployer) adminKubeconfigPath() string {
+	return filepath.Join(d.commonOptions.RunDir(), "kubeconfig-admin")
+}
+
+func (d *deployer) userKubeconfigPath() string {
+	return filepath.Join(d.commonOptions.RunDir(), "kubeconfig-user")
+}
+
+// exportKubeconfigs writes a standalone admin kubeconfig and, unless
+// --user-cluster-role is empty, a kubeconfig of a service account bound to
+// that role into the run dir, and records their paths in the metadata
+func (d *deployer) exportKubeconfigs() error {
+	adminContext := d.instancePrefix + "-admin"
+	admin, err := d.kubectlOutput("config", "view", "--raw", "--minify", "--flatten")
+	if err != nil {
+		return fmt.Errorf("failed to read the admin kubeconfig: %s", err)
+	}
+	if err := os.WriteFile(d.adminKubeconfigPath(), []byte(admin), 0600); err != nil {
+		return err
+	}
+	current, err := d.kubectlOutput("config", "current-context")
+	if err != nil {
+		return fmt.Errorf("failed to get the current context: %s", err)
+	}
+	if _, err := d.kubectlOutput("--kubeconfig", d.adminKubeconfigPath(),
+		"config", "rename-context", strings.TrimSpace(current), adminContext); err != nil {
+		return fmt.Errorf("failed to rename the admin context: %s", err)
+	}
+	paths := map[string]string{"kubeconfig-admin": d.adminKubeconfigPath()}
+
+	if d.UserClusterRole != "" {
+		if err := d.exportUserKubeconfig(); err != nil {
+			return err
+		}
+		paths["kubeconfig-user"] = d.userKubeconfigPath()
+	}
+
+	if err := metadata.AddToFile(filepath.Join(artifacts.BaseDir(), "metadata.json"), paths); err != nil {
+		klog.Warningf("failed to record the kubeconfig paths in the metadata: %s", err)
+	}
+	return nil
+}
+
+func (d *deployer) exportUserKubeconfig() error {
+	klog.V(2).Infof("binding service account %s to cluster role %s", userServiceAccount, d.UserClusterRole)
+	cmd := d.cmder.Command(d.kubectlPath, "apply", "-f", "-")
+	cmd.SetEnv(d.buildEnv()...)
+	cmd.SetStdin(strings.NewReader(userRBACManifest(d.UserClusterRole)))
+	exec.InheritOutput(cmd)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to create the user service account: %s", err)
+	}
+
+	token, err := d.kubectlOutput("create", "token", userServiceAccount, "--namespace=default", "--duration=24h")
+	if err != nil {
+		return fmt.Errorf("failed to create a token for %s: %s", userServiceAccount, err)
+	}
+	server, err := d.kubectlOutput("config", "view", "--raw", "--minify", "-o", "jsonpath={.clusters[0].cluster.server}")
+	if err != nil {
+		return fmt.Errorf("failed to get the cluster server: %s", err)
+	}
+	caData, err := d.kubectlOutput("config", "view", "--raw", "--minify", "--flatten", "-o", "jsonpath={.clusters[0].cluster.certificate-authority-data}")
+	if err != nil {
+		return fmt.Errorf("failed to get the cluster CA: %s", err)
+	}
+
+	kubeconfig := userKubeconfig(strings.TrimSpace(server), strings.TrimSpace(caData), strings.TrimSpace(token), d.instancePrefix+"-user")
+	return os.WriteFile(d.userKubeconfigPath(), []byte(kubeconfig), 0600)
+}
+
+func (d *deployer) kubectlOutput(args ...string) (string, error) {
+	cmd := d.cmder.Command(d.kubectlPath, args...)
+	cmd.SetEnv(d.buildEnv()...)
+	cmd.SetStderr(os.Stderr)
+	out, err := exec.Output(cmd)
+	return string(out), err
+}
+
+// userRBACManifest is the service account of the user kubeconfig and its
+// binding to role
+func userRBACManifest(role string) string {
+	return fmt.Sprintf(`apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: %[1]s
+  namespace: default
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: %[1]s
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: %[2]s
+subjects:
+- kind: ServiceAccount
+  name: %[1]s
+  namespace: default
+`, userServiceAccount, role)
+}
+
+// userKubeconfig renders a kubeconfig authenticating with token, TLS
+// verification is skipped when the cluster has no CA data
+func userKubeconfig(server, caData, token, context string) string {
+	tls := "insecure-skip-tls-verify: true"
+	if caData != "" {
+		tls = "certificate-authority-data: " + caData
+	}
+	return fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: %[1]s
+  cluster:
+    server: %[2]s
+    %[3]s
+users:
+- name: %[1]s
+  user:
+    token: %[4]s
+contexts:
+- name: %[1]s
+  context:
+    cluster: %[1]s
+    user: %[1]s
+    namespace: default
+current-context: %[1]s
+`, context, server, tls, token)
+}
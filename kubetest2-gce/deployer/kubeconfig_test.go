@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUserKubeconfig(t *testing.T) {
+	cases := []struct {
+		name     string
+		caData   string
+		expected []string
+		absent   string
+	}{
+		{
+			name:   "with CA",
+			caData: "Q0E=",
+			expected: []string{
+				"    server: https://1.2.3.4\n    certificate-authority-data: Q0E=\n",
+				"    token: secret\n",
+				"current-context: kt2-abc-user\n",
+			},
+			absent: "insecure-skip-tls-verify",
+		},
+		{
+			name: "without CA",
+			expected: []string{
+				"    server: https://1.2.3.4\n    insecure-skip-tls-verify: true\n",
+				"    token: secret\n",
+			},
+			absent: "certificate-authority-data",
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := userKubeconfig("https://1.2.3.4", c.caData, "secret", "kt2-abc-user")
+			for _, e := range c.expected {
+				if !strings.Contains(actual, e) {
+					t.Errorf("expected kubeconfig to contain %q but got:\n%s", e, actual)
+				}
+			}
+			if strings.Contains(actual, c.absent) {
+				t.Errorf("expected kubeconfig not to contain %q but got:\n%s", c.absent, actual)
+			}
+		})
+	}
+}
+
+func TestUserRBACManifest(t *testing.T) {
+	actual := userRBACManifest("view")
+	for _, e := range []string{"kind: ServiceAccount\nmetadata:\n  name: kubetest2-user\n", "  kind: ClusterRole\n  name: view\n"} {
+		if !strings.Contains(actual, e) {
+			t.Errorf("expected manifest to contain %q but got:\n%s", e, actual)
+		}
+	}
+}
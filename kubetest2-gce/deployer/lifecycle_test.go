@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gce/deployer/options"
+	"sigs.k8s.io/kubetest2/pkg/build"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+// lifecycleOptions are the common options of a run which runs either Up or
+// Down without building
+type lifecycleOptions struct {
+	types.Options
+	up     bool
+	runDir string
+}
+
+func (o lifecycleOptions) ShouldBuild() bool { return false }
+func (o lifecycleOptions) ShouldUp() bool    { return o.up }
+func (o lifecycleOptions) ShouldDown() bool  { return !o.up }
+func (o lifecycleOptions) RunDir() string    { return o.runDir }
+
+// newLifecycleDeployer returns a deployer of the project p running its
+// commands with cmder in a fake kubernetes repo
+func newLifecycleDeployer(t *testing.T, cmder *exec.FakeCmder, up bool) *deployer {
+	// the ssh keys and the metadata are written to the home and artifacts dirs
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("ARTIFACTS", t.TempDir())
+
+	repoRoot := t.TempDir()
+	runDir := t.TempDir()
+	for _, path := range []string{
+		filepath.Join(repoRoot, "go.mod"),
+		filepath.Join(repoRoot, "cluster", "kube-up.sh"),
+		filepath.Join(repoRoot, "cluster", "kube-down.sh"),
+		filepath.Join(runDir, "kubectl"),
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("module k8s.io/kubernetes\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return &deployer{
+		commonOptions: lifecycleOptions{up: up, runDir: runDir},
+		cmder:         cmder,
+		BuildOptions: &options.BuildOptions{
+			CommonBuildOptions: &build.Options{TargetBuildArch: "linux/amd64"},
+		},
+		RepoRoot:          repoRoot,
+		GCPProject:        "p",
+		GCPZone:           "us-central1-b",
+		KubernetesVersion: "v1.31.0",
+		NumNodes:          3,
+		NumMasters:        1,
+		kubeconfigPath:    filepath.Join(runDir, "kubetest2-kubeconfig"),
+		logsDir:           filepath.Join(t.TempDir(), "cluster-logs"),
+		instancePrefix:    "kt2-abc",
+		Network:           "kt2-abc",
+	}
+}
+
+// scriptCalls returns the calls of the cluster scripts of the repo root,
+// leaving out the gcloud and kubectl commands
+func scriptCalls(cmder *exec.FakeCmder, repoRoot string) []exec.FakeCall {
+	calls := []exec.FakeCall{}
+	for _, call := range cmder.Calls() {
+		if strings.HasPrefix(call.Line, repoRoot) {
+			call.Line = strings.TrimPrefix(call.Line, repoRoot+"/")
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// envValue returns the value of key in env, the last one wins like in exec
+func envValue(env []string, key string) string {
+	value := ""
+	for _, kv := range env {
+		if k, v, ok := strings.Cut(kv, "="); ok && k == key {
+			value = v
+		}
+	}
+	return value
+}
+
+func TestUp(t *testing.T) {
+	cmder := &exec.FakeCmder{
+		Responses: []exec.FakeResponse{
+			{Prefix: "gcloud compute firewall-rules describe", Stdout: `{"allowed": [{"IPProtocol": "udp", "ports": ["30000-32767"]}, ` +
+				`{"IPProtocol": "tcp", "ports": ["30000-32767"]}], "sourceRanges": ["0.0.0.0/0"], "targetTags": ["kt2-abc-minion"]}`},
+		},
+	}
+	d := newLifecycleDeployer(t, cmder, true)
+	// the logs are dumped if the cluster is not reported as up
+	kubectl := filepath.Join(d.commonOptions.RunDir(), "kubectl")
+	cmder.Responses = append(cmder.Responses, exec.FakeResponse{Prefix: kubectl + " get nodes", Stdout: "node/kt2-abc-minion-1\n"})
+	if err := d.Up(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	calls := scriptCalls(cmder, d.RepoRoot)
+	lines := []string{}
+	for _, call := range calls {
+		lines = append(lines, call.Line)
+	}
+	// the release is fetched before the cluster is brought up
+	expected := []string{"cluster/get-kube.sh", "cluster/kube-up.sh"}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Fatalf("expected scripts %v but got %v", expected, lines)
+	}
+	expectedEnv := map[string]string{
+		"PROJECT":                        "p",
+		"KUBE_GCE_ZONE":                  "us-central1-b",
+		"KUBE_GCE_INSTANCE_PREFIX":       "kt2-abc",
+		"KUBE_GCE_NETWORK":               "kt2-abc",
+		"NUM_NODES":                      "3",
+		"KUBECONFIG":                     d.kubeconfigPath,
+		"KUBERNETES_RELEASE":             "v1.31.0",
+		"KUBERNETES_SKIP_CREATE_CLUSTER": "y",
+	}
+	for key, value := range expectedEnv {
+		if actual := envValue(calls[1].Env, key); actual != value {
+			t.Errorf("expected kube-up.sh env %s=%q but got %q", key, value, actual)
+		}
+	}
+
+	// the kubeconfigs are exported and the firewall rules ensured after
+	// kube-up.sh
+	commands := cmder.Commands()
+	last := commands[len(commands)-1]
+	if !strings.HasPrefix(last, "gcloud compute firewall-rules describe") {
+		t.Errorf("expected the firewall rules to be ensured last but got %q", last)
+	}
+	if _, err := os.Stat(d.adminKubeconfigPath()); err != nil {
+		t.Errorf("expected the admin kubeconfig to be exported: %v", err)
+	}
+}
+
+func TestDown(t *testing.T) {
+	cmder := &exec.FakeCmder{}
+	d := newLifecycleDeployer(t, cmder, false)
+	if err := d.Down(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	calls := scriptCalls(cmder, d.RepoRoot)
+	lines := []string{}
+	for _, call := range calls {
+		lines = append(lines, call.Line)
+	}
+	// the logs are dumped before the cluster is torn down
+	expected := []string{"cluster/log-dump/log-dump.sh " + d.logsDir, "cluster/kube-down.sh"}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Fatalf("expected scripts %v but got %v", expected, lines)
+	}
+	for key, value := range map[string]string{"PROJECT": "p", "KUBE_GCE_ZONE": "us-central1-b", "KUBE_GCE_INSTANCE_PREFIX": "kt2-abc"} {
+		if actual := envValue(calls[1].Env, key); actual != value {
+			t.Errorf("expected kube-down.sh env %s=%q but got %q", key, value, actual)
+		}
+	}
+
+	// the leftovers of kube-down.sh are swept after it
+	commands := cmder.Commands()
+	last := commands[len(commands)-1]
+	if !listCommandRe.MatchString(last) {
+		t.Errorf("expected the leftovers to be swept last but got %q", last)
+	}
+}
+
+func TestDownKeepsGoingAfterKubeDown(t *testing.T) {
+	cmder := &exec.FakeCmder{}
+	d := newLifecycleDeployer(t, cmder, false)
+	cmder.Responses = []exec.FakeResponse{{Prefix: filepath.Join(d.RepoRoot, "cluster", "kube-down.sh"), Err: os.ErrDeadlineExceeded}}
+	if err := d.Down(); err == nil {
+		t.Fatal("expected an error for the failed kube-down.sh but got none")
+	}
+	commands := cmder.Commands()
+	if last := commands[len(commands)-1]; !listCommandRe.MatchString(last) {
+		t.Errorf("expected the leftovers to be swept after the failed kube-down.sh but got %q last", last)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/gcp"
+)
+
+// natRouterName returns the name of the Cloud Router created with
+// --create-nat, under the instance prefix for the sweep to find it
+func (d *deployer) natRouterName() string {
+	return fmt.Sprintf("%s-nat-router", d.instancePrefix)
+}
+
+func (d *deployer) verifyNATFlags() error {
+	if !d.CreateNAT {
+		return nil
+	}
+	if d.GCPZone == "" {
+		return fmt.Errorf("--create-nat requires --gcp-zone, the Cloud NAT is regional")
+	}
+	// the NAT is created before kube-up.sh, which creates the default network
+	if !d.networkSet() {
+		return fmt.Errorf("--create-nat requires --network, set to a network existing before the run")
+	}
+	return nil
+}
+
+// ensureCloudNAT creates a Cloud Router with Cloud NAT in the network for the
+// nodes without external IPs to pull images, unless it exists already, e.g.
+// with --use-existing-master.
+func (d *deployer) ensureCloudNAT() error {
+	if !d.CreateNAT {
+		return nil
+	}
+	router := d.natRouterName()
+	region := gcp.RegionFromZone(d.GCPZone)
+	describe := d.cmder.Command(
+		"gcloud", "compute", "routers", "describe",
+		"--project", d.GCPProject,
+		"--region", region,
+		"--format=value(name)",
+		router,
+	)
+	if err := describe.Run(); err == nil {
+		klog.V(2).Infof("Cloud NAT router %s already exists", router)
+		return nil
+	}
+
+	klog.V(2).Infof("creating Cloud NAT router %s in region %s", router, region)
+	cmd := d.cmder.Command(
+		"gcloud", "compute", "routers", "create",
+		"--project", d.GCPProject,
+		"--region", region,
+		"--network", d.Network,
+		router,
+	)
+	exec.InheritOutput(cmd)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to create Cloud NAT router: %s", err)
+	}
+	cmd = d.cmder.Command(
+		"gcloud", "compute", "routers", "nats", "create",
+		"--project", d.GCPProject,
+		"--region", region,
+		"--router", router,
+		"--auto-allocate-nat-external-ips",
+		"--nat-all-subnet-ip-ranges",
+		router,
+	)
+	exec.InheritOutput(cmd)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to create Cloud NAT: %s", err)
+	}
+	return nil
+}
+
+// deleteCloudNAT deletes the Cloud Router created by ensureCloudNAT, and the
+// Cloud NAT with it
+func (d *deployer) deleteCloudNAT() {
+	if !d.CreateNAT {
+		return
+	}
+	cmd := d.cmder.Command(
+		"gcloud", "compute", "routers", "delete",
+		"--project", d.GCPProject,
+		"--region", gcp.RegionFromZone(d.GCPZone),
+		"--quiet",
+		d.natRouterName(),
+	)
+	exec.InheritOutput(cmd)
+	if err := cmd.Run(); err != nil {
+		klog.Warning("failed to delete Cloud NAT router: might be deleted already?")
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestVerifyNATFlags(t *testing.T) {
+	cases := []struct {
+		name        string
+		createNAT   bool
+		zone        string
+		network     bool
+		expectError bool
+	}{
+		{
+			name: "no NAT",
+		},
+		{
+			name:      "NAT in an existing network",
+			createNAT: true,
+			zone:      "us-central1-b",
+			network:   true,
+		},
+		{
+			name:        "no zone",
+			createNAT:   true,
+			network:     true,
+			expectError: true,
+		},
+		{
+			name:        "network of the run",
+			createNAT:   true,
+			zone:        "us-central1-b",
+			expectError: true,
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &deployer{
+				CreateNAT:   c.createNAT,
+				GCPZone:     c.zone,
+				networkFlag: &pflag.Flag{Changed: c.network},
+			}
+			err := d.verifyNATFlags()
+			if c.expectError != (err != nil) {
+				t.Errorf("expected error %v but got %v", c.expectError, err)
+			}
+		})
+	}
+}
+
+func TestVerifyNetworkFlags(t *testing.T) {
+	cases := []struct {
+		name        string
+		keepNetwork bool
+		network     bool
+		expectError bool
+	}{
+		{
+			name: "network of the run",
+		},
+		{
+			name:    "network set",
+			network: true,
+		},
+		{
+			name:        "network set and kept",
+			keepNetwork: true,
+			network:     true,
+		},
+		{
+			name:        "network of the run kept",
+			keepNetwork: true,
+			expectError: true,
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &deployer{
+				KeepNetwork: c.keepNetwork,
+				networkFlag: &pflag.Flag{Changed: c.network},
+			}
+			err := d.verifyNetworkFlags()
+			if c.expectError != (err != nil) {
+				t.Errorf("expected error %v but got %v", c.expectError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"os"
+)
+
+// nodeKinds returns the kinds of resources kube-up.sh creates for the nodes
+// of the run, in deletion order: the managed instance groups delete their
+// instances, then the instance templates they were created from.
+func (d *deployer) nodeKinds() []sweepKind {
+	byNodePrefix := fmt.Sprintf("name ~ ^%s-", d.nodeTag())
+	return []sweepKind{
+		{group: []string{"instance-groups", "managed"}, scope: "zone", filter: byNodePrefix},
+		{group: []string{"instance-templates"}, filter: byNodePrefix},
+	}
+}
+
+// deleteNodes deletes the nodes of the cluster, leaving the master and the
+// network, so that kube-up.sh can recreate them with
+// KUBE_USE_EXISTING_MASTER.
+func (d *deployer) deleteNodes() error {
+	return d.deleteResources(d.nodeKinds())
+}
+
+// verifyExistingMaster checks that the cluster of a previous run with the
+// same --run-id can be reused by --use-existing-master
+func (d *deployer) verifyExistingMaster() error {
+	if _, err := os.Stat(d.kubeconfigPath); err != nil {
+		return fmt.Errorf("--use-existing-master requires the kubeconfig of a previous --up with the same --run-id: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+// assert that deployer implements types.DeployerWithPlan
+var _ types.DeployerWithPlan = &deployer{}
+
+// Plan implements types.DeployerWithPlan, it lists the cluster and the
+// network created by Up or deleted by Down, unless --keep-network is set
+func (d *deployer) Plan(action string) (*types.Plan, error) {
+	project := d.GCPProject
+	if project == "" {
+		project = "<acquired from boskos>"
+	}
+	plan := &types.Plan{
+		Resources: []string{
+			fmt.Sprintf("cluster %s (instances, disks, addresses and firewall rules) in project %s", d.instancePrefix, project),
+		},
+		Shared: d.GCPProject != "" && d.boskos == nil,
+	}
+	if d.CreateNAT {
+		plan.Resources = append(plan.Resources, fmt.Sprintf("Cloud NAT router %s in project %s", d.natRouterName(), project))
+	}
+	if action != "Down" || !d.KeepNetwork {
+		plan.Resources = append(plan.Resources, fmt.Sprintf("network %s in project %s", d.Network, project))
+	}
+	if action == "Down" && d.boskos != nil {
+		plan.Resources = append(plan.Resources, fmt.Sprintf("project %s is released to boskos", project))
+	}
+	return plan, nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+func TestPlan(t *testing.T) {
+	cases := []struct {
+		name        string
+		project     string
+		action      string
+		keepNetwork bool
+		createNAT   bool
+		expected    *types.Plan
+	}{
+		{
+			name:    "user project",
+			project: "p",
+			action:  "Up",
+			expected: &types.Plan{
+				Resources: []string{
+					"cluster kt2-abc (instances, disks, addresses and firewall rules) in project p",
+					"network kt2-abc in project p",
+				},
+				Shared: true,
+			},
+		},
+		{
+			name:   "boskos project",
+			action: "Up",
+			expected: &types.Plan{
+				Resources: []string{
+					"cluster kt2-abc (instances, disks, addresses and firewall rules) in project <acquired from boskos>",
+					"network kt2-abc in project <acquired from boskos>",
+				},
+			},
+		},
+		{
+			name:        "down keeping the network",
+			project:     "p",
+			action:      "Down",
+			keepNetwork: true,
+			expected: &types.Plan{
+				Resources: []string{
+					"cluster kt2-abc (instances, disks, addresses and firewall rules) in project p",
+				},
+				Shared: true,
+			},
+		},
+		{
+			name:        "down keeping the network with a NAT",
+			project:     "p",
+			action:      "Down",
+			keepNetwork: true,
+			createNAT:   true,
+			expected: &types.Plan{
+				Resources: []string{
+					"cluster kt2-abc (instances, disks, addresses and firewall rules) in project p",
+					"Cloud NAT router kt2-abc-nat-router in project p",
+				},
+				Shared: true,
+			},
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := &deployer{GCPProject: c.project, instancePrefix: "kt2-abc", Network: "kt2-abc", KeepNetwork: c.keepNetwork, CreateNAT: c.createNAT}
+			actual, err := d.Plan(c.action)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected plan %+v but got %+v", c.expected, actual)
+			}
+		})
+	}
+}
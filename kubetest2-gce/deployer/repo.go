@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// kubernetesModule is the go module of the kubernetes/kubernetes repo,
+	// built in legacy mode
+	kubernetesModule = "k8s.io/kubernetes"
+	// cloudProviderGCPModule is the go module of the
+	// kubernetes/cloud-provider-gcp repo
+	cloudProviderGCPModule = "k8s.io/cloud-provider-gcp"
+)
+
+// detectLegacyMode sets --legacy-mode from the go module of the repo root,
+// unless it was set explicitly, and checks that the repo has the cluster
+// scripts the deployer runs.
+func (d *deployer) detectLegacyMode() error {
+	if d.legacyModeFlag == nil || !d.legacyModeFlag.Changed {
+		legacy, err := isLegacyRepo(d.RepoRoot)
+		if err != nil {
+			return fmt.Errorf("%s, set --legacy-mode explicitly", err)
+		}
+		d.LegacyMode = legacy
+	}
+	klog.V(1).Infof("Using %s in %s, --legacy-mode=%t", repoName(d.LegacyMode), d.RepoRoot, d.LegacyMode)
+	if d.commonOptions.ShouldUp() || d.commonOptions.ShouldDown() {
+		return verifyClusterScripts(d.RepoRoot)
+	}
+	return nil
+}
+
+func repoName(legacy bool) string {
+	if legacy {
+		return "kubernetes/kubernetes"
+	}
+	return "kubernetes/cloud-provider-gcp"
+}
+
+// isLegacyRepo returns true if repoRoot is the kubernetes/kubernetes repo,
+// false if it is the kubernetes/cloud-provider-gcp repo. Repos without a
+// go.mod, e.g. extracted release tarballs, are built in legacy mode, as
+// before the repo type was detected.
+func isLegacyRepo(repoRoot string) (bool, error) {
+	module, err := goModule(filepath.Join(repoRoot, "go.mod"))
+	if errors.Is(err, fs.ErrNotExist) {
+		klog.Warningf("%s has no go.mod, assuming it is %s", repoRoot, repoName(true))
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("cannot tell the repo type of %s: %w", repoRoot, err)
+	}
+	switch module {
+	case kubernetesModule:
+		return true, nil
+	case cloudProviderGCPModule:
+		return false, nil
+	}
+	return false, fmt.Errorf("%s is neither %s nor %s but %s", repoRoot, repoName(true), repoName(false), module)
+}
+
+// goModule returns the module path declared in the go.mod file at path
+func goModule(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if module, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return strings.Trim(strings.TrimSpace(module), `"`), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no module directive in %s", path)
+}
+
+// verifyClusterScripts checks that the repo has the scripts run by Up and
+// Down, so that an incompatible repo fails before anything is created.
+func verifyClusterScripts(repoRoot string) error {
+	for _, script := range []string{"kube-up.sh", "kube-down.sh"} {
+		path := filepath.Join(repoRoot, "cluster", script)
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("the repo root %s has no cluster/%s: %w", repoRoot, script, err)
+		}
+	}
+	return nil
+}
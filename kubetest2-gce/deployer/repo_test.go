@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+func TestIsLegacyRepo(t *testing.T) {
+	cases := []struct {
+		name           string
+		goMod          string
+		expectedLegacy bool
+		expectError    bool
+	}{
+		{
+			name:           "kubernetes",
+			goMod:          "// This is a generated file. Do not edit directly.\n\nmodule k8s.io/kubernetes\n\ngo 1.22.0\n",
+			expectedLegacy: true,
+		},
+		{
+			name:  "cloud-provider-gcp",
+			goMod: "module k8s.io/cloud-provider-gcp\n\ngo 1.22\n",
+		},
+		{
+			name:        "other module",
+			goMod:       "module sigs.k8s.io/kubetest2\n",
+			expectError: true,
+		},
+		{
+			name:           "no go.mod",
+			expectedLegacy: true,
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			repoRoot := t.TempDir()
+			if c.goMod != "" {
+				if err := os.WriteFile(filepath.Join(repoRoot, "go.mod"), []byte(c.goMod), 0644); err != nil {
+					t.Fatalf("failed to write go.mod: %s", err)
+				}
+			}
+			legacy, err := isLegacyRepo(repoRoot)
+			if c.expectError != (err != nil) {
+				t.Fatalf("expected error %v but got %v", c.expectError, err)
+			}
+			if legacy != c.expectedLegacy {
+				t.Errorf("expected legacy %v but got %v", c.expectedLegacy, legacy)
+			}
+		})
+	}
+}
+
+func TestVerifyClusterScripts(t *testing.T) {
+	repoRoot := t.TempDir()
+	if err := verifyClusterScripts(repoRoot); err == nil {
+		t.Errorf("expected an error for a repo without cluster scripts")
+	}
+	if err := os.MkdirAll(filepath.Join(repoRoot, "cluster"), 0755); err != nil {
+		t.Fatalf("failed to create the cluster dir: %s", err)
+	}
+	for _, script := range []string{"kube-up.sh", "kube-down.sh"} {
+		if err := os.WriteFile(filepath.Join(repoRoot, "cluster", script), nil, 0755); err != nil {
+			t.Fatalf("failed to write %s: %s", script, err)
+		}
+	}
+	if err := verifyClusterScripts(repoRoot); err != nil {
+		t.Errorf("expected no error but got %s", err)
+	}
+}
+
+// buildOnlyOptions are the common options of a run which only builds
+type buildOnlyOptions struct {
+	types.Options
+}
+
+func (buildOnlyOptions) ShouldUp() bool   { return false }
+func (buildOnlyOptions) ShouldDown() bool { return false }
+
+func TestDetectLegacyMode(t *testing.T) {
+	cases := []struct {
+		name           string
+		goMod          string
+		legacyModeFlag string
+		expectedLegacy bool
+		expectError    bool
+	}{
+		{
+			name:           "detected from go.mod",
+			goMod:          "module k8s.io/kubernetes\n",
+			expectedLegacy: true,
+		},
+		{
+			name:  "cloud-provider-gcp detected from go.mod",
+			goMod: "module k8s.io/cloud-provider-gcp\n",
+		},
+		{
+			name:           "no go.mod falls back to legacy mode",
+			expectedLegacy: true,
+		},
+		{
+			name:           "set explicitly",
+			legacyModeFlag: "false",
+		},
+		{
+			name:        "other module",
+			goMod:       "module sigs.k8s.io/kubetest2\n",
+			expectError: true,
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			repoRoot := t.TempDir()
+			if c.goMod != "" {
+				if err := os.WriteFile(filepath.Join(repoRoot, "go.mod"), []byte(c.goMod), 0644); err != nil {
+					t.Fatalf("failed to write go.mod: %s", err)
+				}
+			}
+			d := &deployer{commonOptions: buildOnlyOptions{}, RepoRoot: repoRoot}
+			flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			flags.BoolVar(&d.LegacyMode, "legacy-mode", true, "")
+			d.legacyModeFlag = flags.Lookup("legacy-mode")
+			if c.legacyModeFlag != "" {
+				if err := flags.Set("legacy-mode", c.legacyModeFlag); err != nil {
+					t.Fatalf("failed to set --legacy-mode: %s", err)
+				}
+			}
+			err := d.detectLegacyMode()
+			if c.expectError != (err != nil) {
+				t.Fatalf("expected error %v but got %v", c.expectError, err)
+			}
+			if !c.expectError && d.LegacyMode != c.expectedLegacy {
+				t.Errorf("expected legacy %v but got %v", c.expectedLegacy, d.LegacyMode)
+			}
+		})
+	}
+}
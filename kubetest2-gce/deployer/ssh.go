@@ -0,0 +1,47 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import "os"
+
+// sshUser returns the USER of the scripts, see buildEnv(), which gcloud
+// compute ssh logs into the VMs as
+func sshUser() string {
+	if user, ok := os.LookupEnv("USER"); ok && user != "root" {
+		return user
+	}
+	return "kubetest2"
+}
+
+// sshEnv returns the KUBE_SSH_USER and KUBE_SSH_KEY_PATH of the scripts, see
+// buildEnv(), for the scripts and the e2e tests they run to SSH into the
+// nodes as the same user and with the same gcloud key as log-dump.sh. Values
+// set already are passed through as is, keyPath is skipped if empty.
+func sshEnv(keyPath string) []string {
+	user, ok := os.LookupEnv("KUBE_SSH_USER")
+	if !ok {
+		user = sshUser()
+	}
+	env := []string{"KUBE_SSH_USER=" + user}
+	if path, ok := os.LookupEnv("KUBE_SSH_KEY_PATH"); ok {
+		keyPath = path
+	}
+	if keyPath != "" {
+		env = append(env, "KUBE_SSH_KEY_PATH="+keyPath)
+	}
+	return env
+}
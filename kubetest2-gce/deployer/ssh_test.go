@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestSSHEnv(t *testing.T) {
+	cases := []struct {
+		name     string
+		env      map[string]string
+		keyPath  string
+		expected []string
+	}{
+		{
+			name:     "user",
+			env:      map[string]string{"USER": "alice"},
+			keyPath:  "/home/.ssh/google_compute_engine",
+			expected: []string{"KUBE_SSH_USER=alice", "KUBE_SSH_KEY_PATH=/home/.ssh/google_compute_engine"},
+		},
+		{
+			name:     "root",
+			env:      map[string]string{"USER": "root"},
+			keyPath:  "/home/.ssh/google_compute_engine",
+			expected: []string{"KUBE_SSH_USER=kubetest2", "KUBE_SSH_KEY_PATH=/home/.ssh/google_compute_engine"},
+		},
+		{
+			name:     "already set",
+			env:      map[string]string{"USER": "alice", "KUBE_SSH_USER": "core", "KUBE_SSH_KEY_PATH": "/key"},
+			keyPath:  "/home/.ssh/google_compute_engine",
+			expected: []string{"KUBE_SSH_USER=core", "KUBE_SSH_KEY_PATH=/key"},
+		},
+		{
+			name:     "unknown key path",
+			env:      map[string]string{"USER": "alice"},
+			expected: []string{"KUBE_SSH_USER=alice"},
+		},
+	}
+
+	// not parallel, the cases set the env
+	for i := range cases {
+		c := &cases[i]
+		t.Run(c.name, func(t *testing.T) {
+			t.Setenv("KUBE_SSH_USER", "")
+			t.Setenv("KUBE_SSH_KEY_PATH", "")
+			for _, key := range []string{"KUBE_SSH_USER", "KUBE_SSH_KEY_PATH"} {
+				if err := os.Unsetenv(key); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for key, value := range c.env {
+				t.Setenv(key, value)
+			}
+			if actual := sshEnv(c.keyPath); !reflect.DeepEqual(actual, c.expected) {
+				t.Errorf("expected env %v but got %v", c.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// sweepKind is a kind of compute resource the orphan sweep looks for
+type sweepKind struct {
+	// group is the gcloud compute command group, e.g. instances
+	group []string
+	// scope is "zone" or "region" for zonal and regional resources, empty for
+	// global ones
+	scope string
+	// filter is the gcloud filter matching the resources of the run
+	filter string
+}
+
+// sweepKinds returns the kinds of resources kube-up.sh and Up create for the
+// run, in deletion order: the managed instance groups first so that they don't
+// recreate the instances, the network last once nothing uses it. With
+// --network or --keep-network, only the firewall rules and routes of the
+// cluster are deleted from the network, which may be shared with other runs.
+func (d *deployer) sweepKinds() []sweepKind {
+	byPrefix := fmt.Sprintf("name ~ ^%s-", d.instancePrefix)
+	byNetwork := fmt.Sprintf("network ~ /networks/%s$", d.Network)
+	kinds := []sweepKind{
+		{group: []string{"instance-groups", "managed"}, scope: "zone", filter: byPrefix},
+		{group: []string{"instances"}, scope: "zone", filter: byPrefix},
+		{group: []string{"instance-templates"}, filter: byPrefix},
+		{group: []string{"disks"}, scope: "zone", filter: byPrefix},
+		{group: []string{"addresses"}, scope: "region", filter: byPrefix},
+		{group: []string{"routers"}, scope: "region", filter: byPrefix},
+	}
+	if d.KeepNetwork || d.networkSet() {
+		byNetworkAndPrefix := byNetwork + " AND " + byPrefix
+		return append(kinds,
+			sweepKind{group: []string{"firewall-rules"}, filter: byNetworkAndPrefix},
+			sweepKind{group: []string{"routes"}, filter: byNetworkAndPrefix},
+		)
+	}
+	return append(kinds,
+		sweepKind{group: []string{"firewall-rules"}, filter: byNetwork},
+		sweepKind{group: []string{"routes"}, filter: byNetwork},
+		sweepKind{group: []string{"networks", "subnets"}, scope: "region", filter: byNetwork},
+		sweepKind{group: []string{"networks"}, filter: fmt.Sprintf("name = %s", d.Network)},
+	)
+}
+
+func (k sweepKind) String() string {
+	return strings.Join(k.group, " ")
+}
+
+func (k sweepKind) listArgs(project string) []string {
+	format := "value(name)"
+	if k.scope != "" {
+		format = fmt.Sprintf("value(name,%s.basename())", k.scope)
+	}
+	args := append([]string{"compute"}, k.group...)
+	return append(args, "list", "--project="+project, "--filter="+k.filter, "--format="+format)
+}
+
+func (k sweepKind) deleteArgs(project, scope string, names []string) []string {
+	args := append([]string{"compute"}, k.group...)
+	args = append(args, "delete")
+	args = append(args, names...)
+	args = append(args, "--project="+project, "--quiet")
+	if scope != "" {
+		args = append(args, fmt.Sprintf("--%s=%s", k.scope, scope))
+	}
+	return args
+}
+
+// groupByScope parses the list output of k into the resource names per
+// zone or region, the empty scope for global resources.
+func (k sweepKind) groupByScope(out string) map[string][]string {
+	names := map[string][]string{}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		scope := ""
+		if k.scope != "" && len(fields) > 1 {
+			scope = fields[1]
+		}
+		names[scope] = append(names[scope], fields[0])
+	}
+	return names
+}
+
+// sweepLeftovers deletes the resources of the run left behind by a failed or
+// partial kube-down.sh, and returns an error naming those it couldn't delete.
+func (d *deployer) sweepLeftovers() error {
+	return d.deleteResources(d.sweepKinds())
+}
+
+// VerifyDown implements types.DeployerWithVerifyDown. It lists the
+// resources of the run that the sweep deletes, as "<kind> <name>" with the
+// zone or region of the zonal and regional ones.
+func (d *deployer) VerifyDown() ([]string, error) {
+	var leaks []string
+	for _, k := range d.sweepKinds() {
+		out, err := exec.Output(d.cmder.Command("gcloud", k.listArgs(d.GCPProject)...))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %s", k, err)
+		}
+		byScope := k.groupByScope(string(out))
+		scopes := make([]string, 0, len(byScope))
+		for scope := range byScope {
+			scopes = append(scopes, scope)
+		}
+		sort.Strings(scopes)
+		for _, scope := range scopes {
+			for _, name := range byScope[scope] {
+				leak := fmt.Sprintf("%s %s", k, name)
+				if scope != "" {
+					leak = fmt.Sprintf("%s (%s %s)", leak, k.scope, scope)
+				}
+				leaks = append(leaks, leak)
+			}
+		}
+	}
+	return leaks, nil
+}
+
+// deleteResources deletes the resources of kinds, in order, and returns an
+// error naming those it couldn't list or delete. It keeps going through
+// failures, so that one stuck resource doesn't leak all the others.
+func (d *deployer) deleteResources(kinds []sweepKind) error {
+	var errs []error
+	for _, k := range kinds {
+		out, err := exec.Output(d.cmder.Command("gcloud", k.listArgs(d.GCPProject)...))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to list %s: %s", k, err))
+			continue
+		}
+		byScope := k.groupByScope(string(out))
+		scopes := make([]string, 0, len(byScope))
+		for scope := range byScope {
+			scopes = append(scopes, scope)
+		}
+		sort.Strings(scopes)
+		for _, scope := range scopes {
+			names := byScope[scope]
+			klog.V(1).Infof("Deleting %s %s", k, strings.Join(names, ","))
+			cmd := d.cmder.Command("gcloud", k.deleteArgs(d.GCPProject, scope, names)...)
+			exec.InheritOutput(cmd)
+			if err := cmd.Run(); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete %s %s: %s", k, strings.Join(names, ","), err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
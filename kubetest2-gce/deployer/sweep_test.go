@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+func TestSweepKindArgs(t *testing.T) {
+	cases := []struct {
+		name               string
+		kind               sweepKind
+		scope              string
+		names              []string
+		expectedListArgs   []string
+		expectedDeleteArgs []string
+	}{
+		{
+			name:  "zonal",
+			kind:  sweepKind{group: []string{"instances"}, scope: "zone", filter: "name ~ ^kt2-abc-"},
+			scope: "us-central1-b",
+			names: []string{"kt2-abc-master", "kt2-abc-minion-group-x1"},
+			expectedListArgs: []string{"compute", "instances", "list", "--project=p",
+				"--filter=name ~ ^kt2-abc-", "--format=value(name,zone.basename())"},
+			expectedDeleteArgs: []string{"compute", "instances", "delete", "kt2-abc-master", "kt2-abc-minion-group-x1",
+				"--project=p", "--quiet", "--zone=us-central1-b"},
+		},
+		{
+			name:  "global",
+			kind:  sweepKind{group: []string{"networks"}, filter: "name = kt2-abc"},
+			names: []string{"kt2-abc"},
+			expectedListArgs: []string{"compute", "networks", "list", "--project=p",
+				"--filter=name = kt2-abc", "--format=value(name)"},
+			expectedDeleteArgs: []string{"compute", "networks", "delete", "kt2-abc", "--project=p", "--quiet"},
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			if actual := c.kind.listArgs("p"); !reflect.DeepEqual(actual, c.expectedListArgs) {
+				t.Errorf("expected list args %v but got %v", c.expectedListArgs, actual)
+			}
+			if actual := c.kind.deleteArgs("p", c.scope, c.names); !reflect.DeepEqual(actual, c.expectedDeleteArgs) {
+				t.Errorf("expected delete args %v but got %v", c.expectedDeleteArgs, actual)
+			}
+		})
+	}
+}
+
+func TestSweepKindGroupByScope(t *testing.T) {
+	k := sweepKind{group: []string{"disks"}, scope: "zone"}
+	out := "kt2-abc-master-pd\tus-central1-b\nkt2-abc-minion-1\tus-central1-c\nkt2-abc-minion-2\tus-central1-c\n"
+	expected := map[string][]string{
+		"us-central1-b": {"kt2-abc-master-pd"},
+		"us-central1-c": {"kt2-abc-minion-1", "kt2-abc-minion-2"},
+	}
+	if actual := k.groupByScope(out); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v but got %v", expected, actual)
+	}
+}
+
+func TestVerifyDown(t *testing.T) {
+	cases := []struct {
+		name          string
+		responses     []exec.FakeResponse
+		expectedLeaks []string
+		expectError   bool
+	}{
+		{
+			name: "nothing left over",
+		},
+		{
+			name: "left over",
+			responses: []exec.FakeResponse{
+				{Prefix: "gcloud compute disks list", Stdout: "kt2-abc-pd\tus-central1-c\nkt2-abc-master-pd\tus-central1-b\n"},
+				{Prefix: "gcloud compute networks list", Stdout: "kt2-abc\n"},
+			},
+			expectedLeaks: []string{
+				"disks kt2-abc-master-pd (zone us-central1-b)",
+				"disks kt2-abc-pd (zone us-central1-c)",
+				"networks kt2-abc",
+			},
+		},
+		{
+			name: "list failed",
+			responses: []exec.FakeResponse{
+				{Prefix: "gcloud compute routes list", Err: errors.New("exit status 1")},
+			},
+			expectError: true,
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := newFakeDeployer(&exec.FakeCmder{Responses: c.responses})
+			leaks, err := d.VerifyDown()
+			if c.expectError {
+				if err == nil {
+					t.Errorf("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(leaks, c.expectedLeaks) {
+				t.Errorf("expected leaks %v but got %v", c.expectedLeaks, leaks)
+			}
+		})
+	}
+}
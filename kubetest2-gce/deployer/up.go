@@ -26,15 +26,10 @@ import (
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/kubetest2/pkg/exec"
-	"sigs.k8s.io/kubetest2/pkg/fs"
+	"sigs.k8s.io/kubetest2/pkg/gcp"
 	"sigs.k8s.io/kubetest2/pkg/util"
 )
 
-const (
-	ciPrivateKeyEnv = "GCE_SSH_PRIVATE_KEY_FILE"
-	ciPublicKeyEnv  = "GCE_SSH_PUBLIC_KEY_FILE"
-)
-
 func (d *deployer) IsUp() (up bool, err error) {
 	klog.V(1).Info("GCE deployer starting IsUp()")
 
@@ -55,7 +50,7 @@ func (d *deployer) IsUp() (up bool, err error) {
 		"nodes",
 		"-o=name",
 	}
-	cmd := exec.Command(args[0], args[1:]...)
+	cmd := d.cmder.Command(args[0], args[1:]...)
 	cmd.SetEnv(env...)
 	cmd.SetStderr(os.Stderr)
 	lines, err := exec.OutputLines(cmd)
@@ -73,6 +68,10 @@ func (d *deployer) Up() error {
 		return fmt.Errorf("up failed to init: %s", err)
 	}
 
+	if err := d.resolveNodeImage(); err != nil {
+		return fmt.Errorf("up failed to resolve the node image: %s", err)
+	}
+
 	env := d.buildEnv()
 	// if --build isn't passed, fetch the kubernetes binaries
 	if !d.commonOptions.ShouldBuild() {
@@ -88,7 +87,7 @@ func (d *deployer) Up() error {
 			releaseURL = "https://dl.k8s.io/release"
 		}
 
-		cmd := exec.Command(script)
+		cmd := d.cmder.Command(script)
 		env = append(env,
 			fmt.Sprintf("KUBERNETES_RELEASE_URL=%s", releaseURL),
 			fmt.Sprintf("KUBERNETES_RELEASE=%s", version),
@@ -112,17 +111,34 @@ func (d *deployer) Up() error {
 
 	if d.EnableComputeAPI {
 		klog.V(2).Info("enabling compute API for project")
-		if err := enableComputeAPI(d.GCPProject); err != nil {
+		// In freshly created GCP projects, the compute API is not enabled.
+		if err := gcp.EnableServices(d.GCPProject, "compute.googleapis.com"); err != nil {
 			return fmt.Errorf("up couldn't enable compute API: %s", err)
 		}
 	}
 
-	maybeSetupSSHKeys()
+	gcp.MaybeSetupSSHKeys()
+
+	// the nodes without external IPs need the NAT while kube-up.sh waits for them
+	if err := d.ensureCloudNAT(); err != nil {
+		return err
+	}
+
+	if d.UseExistingMaster {
+		if err := d.verifyExistingMaster(); err != nil {
+			return err
+		}
+		klog.V(2).Info("about to delete the nodes of the existing cluster")
+		if err := d.deleteNodes(); err != nil {
+			return fmt.Errorf("failed to delete the existing nodes: %s", err)
+		}
+		env = append(env, "KUBE_USE_EXISTING_MASTER=true")
+	}
 
 	script := filepath.Join(d.RepoRoot, "cluster", "kube-up.sh")
 	klog.V(2).Infof("About to run script at: %s", script)
 
-	cmd := exec.Command(script)
+	cmd := d.cmder.Command(script)
 	cmd.SetEnv(env...)
 	exec.InheritOutput(cmd)
 
@@ -133,6 +149,13 @@ func (d *deployer) Up() error {
 		return fmt.Errorf("error encountered during %s: %s", script, err)
 	}
 
+	if err := d.addMasterReplicas(env); err != nil {
+		if err := d.DumpClusterLogs(); err != nil {
+			klog.Warningf("Dumping cluster logs at the end of Up() failed: %s", err)
+		}
+		return err
+	}
+
 	if isUp, err := d.IsUp(); err != nil {
 		if err := d.DumpClusterLogs(); err != nil {
 			klog.Warningf("Dumping cluster logs at the end of Up() failed: %s", err)
@@ -147,39 +170,48 @@ func (d *deployer) Up() error {
 		klog.Errorf("cluster reported as down")
 	}
 
-	klog.V(2).Info("about to create nodeport firewall rule")
-	if err := d.createFirewallRuleNodePort(); err != nil {
+	if d.NodeAcceleratorType != "" && d.NvidiaDriverInstallerURL != "" {
+		if err := d.installNvidiaDriver(); err != nil {
+			if err := d.DumpClusterLogs(); err != nil {
+				klog.Warningf("Dumping cluster logs at the end of Up() failed: %s", err)
+			}
+			return fmt.Errorf("failed to install NVIDIA driver: %s", err)
+		}
+	}
+
+	// the kubeconfigs and the firewall rules of an existing master are kept
+	// from the run which created it
+	if d.UseExistingMaster {
+		return nil
+	}
+
+	klog.V(2).Info("about to export the admin and user kubeconfigs")
+	if err := d.exportKubeconfigs(); err != nil {
 		if err := d.DumpClusterLogs(); err != nil {
 			klog.Warningf("Dumping cluster logs at the end of Up() failed: %s", err)
 		}
-		return fmt.Errorf("failed to create firewall rule: %s", err)
+		return fmt.Errorf("failed to export kubeconfigs: %s", err)
+	}
+
+	klog.V(2).Info("about to ensure the e2e firewall rules")
+	if err := d.ensureFirewallRules(); err != nil {
+		if err := d.DumpClusterLogs(); err != nil {
+			klog.Warningf("Dumping cluster logs at the end of Up() failed: %s", err)
+		}
+		return fmt.Errorf("failed to ensure firewall rules: %s", err)
 	}
 
 	return nil
 }
 
-func enableComputeAPI(project string) error {
-	// In freshly created GCP projects, the compute API is
-	// not enabled. We need it. Enabling it after it has
-	// already been enabled is a relatively fast no-op,
-	// so this can be called without consequence.
-
-	env := os.Environ()
-	cmd := exec.Command(
-		"gcloud",
-		"services",
-		"enable",
-		"compute.googleapis.com",
-		"--project="+project,
-	)
-	cmd.SetEnv(env...)
+// installNvidiaDriver applies the NVIDIA driver installer daemonset, GPUs
+// are not usable by pods until the driver is installed on the nodes
+func (d *deployer) installNvidiaDriver() error {
+	klog.V(2).Infof("installing NVIDIA driver from %s", d.NvidiaDriverInstallerURL)
+	cmd := d.cmder.Command(d.kubectlPath, "apply", "-f", d.NvidiaDriverInstallerURL)
+	cmd.SetEnv(d.buildEnv()...)
 	exec.InheritOutput(cmd)
-	err := cmd.Run()
-	if err != nil {
-		return fmt.Errorf("failed to enable compute API: %s", err)
-	}
-
-	return nil
+	return cmd.Run()
 }
 
 func (d *deployer) verifyUpFlags() error {
@@ -187,56 +219,57 @@ func (d *deployer) verifyUpFlags() error {
 		return fmt.Errorf("number of nodes must be at least 1")
 	}
 
-	if err := d.setRepoPathIfNotSet(); err != nil {
-		return err
+	if d.NodeLocalSSDs < 0 {
+		return fmt.Errorf("number of local SSDs must not be negative")
 	}
 
-	// verifyUpFlags does not check for a gcp project because it is
-	// assumed that one will be acquired from boskos if it is not set
+	if d.NodeAcceleratorType != "" && d.NodeAcceleratorCount < 1 {
+		return fmt.Errorf("number of accelerators must be at least 1 when --node-accelerator-type is set")
+	}
 
-	return nil
-}
+	// a rule without source ranges would open the ports to any source
+	if (d.NodeFirewallAllow == "") != (d.NodeFirewallSourceRanges == "") {
+		return fmt.Errorf("--node-firewall-allow and --node-firewall-source-ranges must be set together")
+	}
 
-// maybeSetupSSHKeys will best-effort try to setup ssh keys for gcloud to reuse
-// from existing files pointed to by "well-known" environment variables used in CI
-func maybeSetupSSHKeys() {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		klog.Warningf("failed to get user's home directory")
-		return
+	if err := d.verifyNodeMachineType(); err != nil {
+		return err
+	}
+
+	if d.BoskosFixProject && !d.BoskosVerifyProject {
+		return fmt.Errorf("--boskos-fix-project requires --boskos-verify-project")
+	}
+
+	if d.UseExistingMaster && d.GCPProject == "" {
+		return fmt.Errorf("--use-existing-master requires --gcp-project, the master is not in a project acquired from boskos")
 	}
-	// check if there are existing ssh keys, if either exist don't do anything
-	klog.V(2).Info("checking for existing gcloud ssh keys...")
-	privateKey := filepath.Join(home, ".ssh", "google_compute_engine")
-	if _, err := os.Stat(privateKey); err == nil {
-		klog.V(2).Infof("found existing private key at %s", privateKey)
-		return
+
+	if err := d.verifyAddonFlags(); err != nil {
+		return err
 	}
-	publicKey := privateKey + ".pub"
-	if _, err := os.Stat(publicKey); err == nil {
-		klog.V(2).Infof("found existing public key at %s", publicKey)
-		return
+
+	if err := d.verifyHAFlags(); err != nil {
+		return err
 	}
 
-	// no existing keys check for CI variables, create gcloud key files if both exist
-	// note only checks if relevant envs are non-empty, no actual key verification checks
-	maybePrivateKey, privateKeyEnvSet := os.LookupEnv(ciPrivateKeyEnv)
-	if !privateKeyEnvSet {
-		klog.V(2).Infof("%s is not set", ciPrivateKeyEnv)
-		return
+	if err := d.verifyNATFlags(); err != nil {
+		return err
 	}
-	maybePublicKey, publicKeyEnvSet := os.LookupEnv(ciPublicKeyEnv)
-	if !publicKeyEnvSet {
-		klog.V(2).Infof("%s is not set", ciPublicKeyEnv)
-		return
+
+	if err := d.verifyNetworkFlags(); err != nil {
+		return err
 	}
 
-	if err := fs.CopyFile(maybePrivateKey, privateKey); err != nil {
-		klog.Warningf("failed to copy %s to %s: %v", maybePrivateKey, privateKey, err)
-		return
+	if err := d.loadAPIServerConfig(); err != nil {
+		return err
 	}
 
-	if err := fs.CopyFile(maybePublicKey, publicKey); err != nil {
-		klog.Warningf("failed to copy %s to %s: %v", maybePublicKey, publicKey, err)
+	if err := d.setRepoPathIfNotSet(); err != nil {
+		return err
 	}
+
+	// verifyUpFlags does not otherwise check for a gcp project because it
+	// is assumed that one will be acquired from boskos if it is not set
+
+	return nil
 }
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	autoscalingProfileBalanced            = "balanced"
+	autoscalingProfileOptimizeUtilization = "optimize-utilization"
+
+	locationPolicyAny      = "ANY"
+	locationPolicyBalanced = "BALANCED"
+)
+
+var (
+	// autoscalingProfileMinVersion is the first GKE version with cluster
+	// autoscaler profiles
+	autoscalingProfileMinVersion = minorVersion{1, 18}
+	// locationPolicyMinVersion is the first GKE version with nodepool
+	// location policies
+	locationPolicyMinVersion = minorVersion{1, 24}
+)
+
+// validateAutoscalingFlags validates the cluster autoscaler profile against
+// the cluster mode and version.
+func (d *Deployer) validateAutoscalingFlags() error {
+	switch d.AutoscalingProfile {
+	case "":
+		return nil
+	case autoscalingProfileBalanced, autoscalingProfileOptimizeUtilization:
+	default:
+		return fmt.Errorf("--autoscaling-profile must be %q or %q, got %q", autoscalingProfileOptimizeUtilization, autoscalingProfileBalanced, d.AutoscalingProfile)
+	}
+	if d.Autopilot {
+		return fmt.Errorf("--autoscaling-profile is not supported with --autopilot, Autopilot clusters always optimize utilization")
+	}
+	return checkMinVersion(d.ClusterVersion, autoscalingProfileMinVersion, "--autoscaling-profile")
+}
+
+// autoscalingClusterArgs returns the gcloud clusters create args for the
+// cluster autoscaler profile, the GKE default applies when it is unset.
+func (d *Deployer) autoscalingClusterArgs() []string {
+	if d.AutoscalingProfile == "" {
+		return nil
+	}
+	return []string{"--autoscaling-profile=" + d.AutoscalingProfile}
+}
+
+// validateNodepoolAutoscaling validates the autoscaling options of an extra
+// nodepool, autoscaling is enabled by max-nodes.
+func validateNodepoolAutoscaling(enp *extraNodepool) error {
+	if enp.MaxNodes == 0 {
+		if enp.MinNodes > 0 || enp.LocationPolicy != "" {
+			return fmt.Errorf("min-nodes and location-policy require max-nodes")
+		}
+		return nil
+	}
+	if enp.MinNodes > enp.MaxNodes {
+		return fmt.Errorf("min-nodes must be <= max-nodes, got %d > %d", enp.MinNodes, enp.MaxNodes)
+	}
+	switch enp.LocationPolicy {
+	case "", locationPolicyAny, locationPolicyBalanced:
+		return nil
+	default:
+		return fmt.Errorf("location-policy must be %q or %q, got %q", locationPolicyAny, locationPolicyBalanced, enp.LocationPolicy)
+	}
+}
+
+// autoscalingArgs returns the gcloud node-pools create args for the
+// autoscaling of the nodepool
+func (enp *extraNodepool) autoscalingArgs() []string {
+	if enp.MaxNodes == 0 {
+		return nil
+	}
+	args := []string{
+		"--enable-autoscaling",
+		"--min-nodes=" + strconv.Itoa(enp.MinNodes),
+		"--max-nodes=" + strconv.Itoa(enp.MaxNodes),
+	}
+	if enp.LocationPolicy != "" {
+		args = append(args, "--location-policy="+enp.LocationPolicy)
+	}
+	return args
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gke/deployer/options"
+)
+
+func TestAutoscalingClusterArgs(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		opts        options.ClusterOptions
+		expected    []string
+		expectError bool
+	}{
+		{
+			desc: "defaults",
+		},
+		{
+			desc:     "optimize utilization",
+			opts:     options.ClusterOptions{AutoscalingProfile: autoscalingProfileOptimizeUtilization},
+			expected: []string{"--autoscaling-profile=optimize-utilization"},
+		},
+		{
+			desc:     "balanced on a supported version",
+			opts:     options.ClusterOptions{AutoscalingProfile: autoscalingProfileBalanced, ClusterVersion: "1.29.1-gke.100"},
+			expected: []string{"--autoscaling-profile=balanced"},
+		},
+		{
+			desc:        "unknown profile",
+			opts:        options.ClusterOptions{AutoscalingProfile: "OPTIMIZE_UTILIZATION"},
+			expectError: true,
+		},
+		{
+			desc:        "autopilot",
+			opts:        options.ClusterOptions{AutoscalingProfile: autoscalingProfileBalanced, Autopilot: true},
+			expectError: true,
+		},
+		{
+			desc:        "old version",
+			opts:        options.ClusterOptions{AutoscalingProfile: autoscalingProfileBalanced, ClusterVersion: "1.17.9-gke.1504"},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			d := &Deployer{ClusterOptions: &tc.opts}
+			err := d.validateAutoscalingFlags()
+			if tc.expectError {
+				if err == nil {
+					t.Error("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := d.autoscalingClusterArgs(); !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected args %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestNodepoolAutoscalingArgs(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		enp         extraNodepool
+		expected    []string
+		expectError bool
+	}{
+		{
+			desc: "no autoscaling",
+		},
+		{
+			desc:     "autoscaling",
+			enp:      extraNodepool{MinNodes: 1, MaxNodes: 3},
+			expected: []string{"--enable-autoscaling", "--min-nodes=1", "--max-nodes=3"},
+		},
+		{
+			desc:     "autoscaling with a location policy",
+			enp:      extraNodepool{MaxNodes: 3, LocationPolicy: locationPolicyAny},
+			expected: []string{"--enable-autoscaling", "--min-nodes=0", "--max-nodes=3", "--location-policy=ANY"},
+		},
+		{
+			desc:        "location policy without autoscaling",
+			enp:         extraNodepool{LocationPolicy: locationPolicyBalanced},
+			expectError: true,
+		},
+		{
+			desc:        "min nodes larger than max nodes",
+			enp:         extraNodepool{MinNodes: 4, MaxNodes: 3},
+			expectError: true,
+		},
+		{
+			desc:        "unknown location policy",
+			enp:         extraNodepool{MaxNodes: 3, LocationPolicy: "any"},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := validateNodepoolAutoscaling(&tc.enp)
+			if tc.expectError {
+				if err == nil {
+					t.Error("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := tc.enp.autoscalingArgs(); !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected args %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
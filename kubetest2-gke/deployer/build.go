@@ -25,6 +25,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/kubetest2/pkg/build"
+	"sigs.k8s.io/kubetest2/pkg/types"
 )
 
 var (
@@ -46,11 +47,16 @@ var (
 	defaultImageTag = "gke.gcr.io"
 )
 
-func (d *Deployer) Build() error {
-	imageTag := defaultImageTag
+// imageTag returns the registry the built images are tagged for
+func (d *Deployer) imageTag() string {
 	if d.BuildOptions.CommonBuildOptions.ImageLocation != "" {
-		imageTag = d.BuildOptions.CommonBuildOptions.ImageLocation
+		return d.BuildOptions.CommonBuildOptions.ImageLocation
 	}
+	return defaultImageTag
+}
+
+func (d *Deployer) Build() error {
+	imageTag := d.imageTag()
 
 	klog.V(2).Infof("setting KUBE_DOCKER_REGISTRY to %s for tagging images", imageTag)
 	if err := os.Setenv("KUBE_DOCKER_REGISTRY", imageTag); err != nil {
@@ -86,11 +92,28 @@ func (d *Deployer) Build() error {
 			return fmt.Errorf("error staging build: %v", err)
 		}
 	}
+	if err := d.BuildOptions.CommonBuildOptions.Push(d.Kubetest2CommonOptions.RunID(), d.Kubetest2CommonOptions.RunDir()); err != nil {
+		return fmt.Errorf("error pushing images: %v", err)
+	}
 	d.ClusterVersion = version
 	build.StoreCommonBinaries(d.RepoRoot, d.Kubetest2CommonOptions.RunDir())
 	return nil
 }
 
+// BuildManifest implements types.DeployerWithBuildManifest
+func (d *Deployer) BuildManifest() (*types.BuildManifest, error) {
+	tars, err := build.ReleaseTars(d.RepoRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the release tars: %w", err)
+	}
+	return &types.BuildManifest{
+		Version:   d.ClusterVersion,
+		Tars:      tars,
+		Images:    d.BuildOptions.CommonBuildOptions.Images(d.ClusterVersion),
+		StagedURL: build.StagedURL(d.BuildOptions.CommonBuildOptions.StageLocation, d.ClusterVersion),
+	}, nil
+}
+
 func (d *Deployer) VerifyBuildFlags() error {
 	if d.RepoRoot == "" {
 		return fmt.Errorf("required repo-root when building from source")
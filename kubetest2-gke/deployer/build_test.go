@@ -16,7 +16,13 @@ limitations under the License.
 
 package deployer
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gke/deployer/options"
+	"sigs.k8s.io/kubetest2/pkg/build"
+)
 
 func TestNormalizeVersion(t *testing.T) {
 	testCases := []struct {
@@ -140,3 +146,43 @@ func TestNormalizeVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildManifestImages(t *testing.T) {
+	testCases := []struct {
+		name         string
+		buildOptions build.Options
+		expected     []string
+	}{
+		{
+			name:         "images only tagged locally",
+			buildOptions: build.Options{Strategy: "make", StageLocation: "gs://b/ci"},
+		},
+		{
+			name:         "images staged to the image location",
+			buildOptions: build.Options{Strategy: "make", StageLocation: "gs://b/ci", ImageLocation: "gcr.io/p", TargetBuildArch: "linux/arm64"},
+			expected: []string{
+				"gcr.io/p/kube-apiserver-arm64:v1.31.0-gke.99.0_run-1",
+				"gcr.io/p/kube-controller-manager-arm64:v1.31.0-gke.99.0_run-1",
+				"gcr.io/p/kube-scheduler-arm64:v1.31.0-gke.99.0_run-1",
+				"gcr.io/p/kube-proxy-arm64:v1.31.0-gke.99.0_run-1",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		d := &Deployer{
+			CommonOptions:  &options.CommonOptions{RepoRoot: t.TempDir()},
+			ClusterOptions: &options.ClusterOptions{ClusterVersion: "1.31.0-gke.99.0+run-1"},
+			BuildOptions:   &options.BuildOptions{CommonBuildOptions: &tc.buildOptions},
+		}
+		manifest, err := d.BuildManifest()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if !reflect.DeepEqual(manifest.Images, tc.expected) {
+			t.Errorf("%s: expected images %v, but got %v", tc.name, tc.expected, manifest.Images)
+		}
+		if manifest.StagedURL != "gs://b/ci/v1.31.0-gke.99.0+run-1" {
+			t.Errorf("%s: unexpected staged URL %s", tc.name, manifest.StagedURL)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"regexp"
+
+	"sigs.k8s.io/kubetest2/pkg/metadata"
+)
+
+// creationFailureTypes classify the common cluster creation failures by the
+// gcloud output, so that capacity and quota failures of the infrastructure
+// can be told apart from regressions in the junit results and metadata
+var creationFailureTypes = []struct {
+	failureType string
+	re          *regexp.Regexp
+}{
+	{
+		failureType: "Stockout",
+		re:          regexp.MustCompile(`ZONE_RESOURCE_POOL_EXHAUSTED|GCE_STOCKOUT|does not have enough resources available`),
+	},
+	{
+		failureType: "QuotaExceeded",
+		re:          regexp.MustCompile(`QUOTA_EXCEEDED|[Qq]uota '?[A-Z_]+'? exceeded|[Ii]nsufficient (regional )?quota`),
+	},
+	{
+		failureType: "InvalidVersion",
+		re:          regexp.MustCompile(`[Nn]o valid versions with the prefix|[Uu]nsupported (master |node |cluster )?version|[Ii]nvalid (master |node |cluster )?version`),
+	},
+	{
+		failureType: "ServiceAccountPermission",
+		re:          regexp.MustCompile(`iam\.serviceAccounts\.actAs|[Ss]ervice account .* does not (exist|have)|[Ss]ervice [Aa]ccount [Uu]ser`),
+	},
+}
+
+// classifyCreationError returns err as a metadata.ClassifiedError if it
+// matches one of creationFailureTypes
+func classifyCreationError(err error) error {
+	for _, c := range creationFailureTypes {
+		if c.re.MatchString(err.Error()) {
+			return metadata.NewClassifiedError(err, c.failureType)
+		}
+	}
+	return err
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/pkg/metadata"
+)
+
+func TestClassifyCreationError(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		output       string
+		expectedType string
+	}{
+		{
+			desc:         "stockout",
+			output:       `ERROR: (gcloud.container.clusters.create) Operation [...] finished with error: ZONE_RESOURCE_POOL_EXHAUSTED: The zone 'projects/p/zones/us-central1-c' does not have enough resources available to fulfill the request.`,
+			expectedType: "Stockout",
+		},
+		{
+			desc:         "quota",
+			output:       `ERROR: (gcloud.container.clusters.create) ResponseError: code=403, message=Insufficient regional quota to satisfy request: resource "CPUS": request requires '12.0' and is short '4.0'.`,
+			expectedType: "QuotaExceeded",
+		},
+		{
+			desc:         "invalid version",
+			output:       `ERROR: (gcloud.container.clusters.create) ResponseError: code=400, message=No valid versions with the prefix "1.99" found.`,
+			expectedType: "InvalidVersion",
+		},
+		{
+			desc:         "service account permission",
+			output:       `ERROR: (gcloud.container.clusters.create) ResponseError: code=400, message=The user does not have access to service account "sa@p.iam.gserviceaccount.com". Ask a project owner to grant you the iam.serviceAccounts.actAs permission.`,
+			expectedType: "ServiceAccountPermission",
+		},
+		{
+			desc:   "unclassified",
+			output: `ERROR: (gcloud.container.clusters.create) ResponseError: code=500, message=Internal error.`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := classifyCreationError(errors.New(tc.output))
+			var classified metadata.ClassifiedError
+			if !errors.As(err, &classified) {
+				if tc.expectedType != "" {
+					t.Errorf("expected failure type %q but the error is not classified", tc.expectedType)
+				}
+				return
+			}
+			if classified.FailureType() != tc.expectedType {
+				t.Errorf("expected failure type %q but got %q", tc.expectedType, classified.FailureType())
+			}
+		})
+	}
+}
@@ -22,60 +22,42 @@ import (
 	"io"
 	"os"
 	osexec "os/exec"
-
 	"path/filepath"
-	"strings"
 
 	"k8s.io/klog/v2"
 
+	"sigs.k8s.io/kubetest2/pkg/artifacts"
 	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/gcp"
+	"sigs.k8s.io/kubetest2/pkg/metadata"
 )
 
 func (d *Deployer) PrepareGcpIfNeeded(projectID string) error {
 	// TODO(RonWeber): This is an almost direct copy/paste from kubetest's prepareGcp()
 	// It badly needs refactored.
 
-	var endpoint string
-	switch env := d.Environment; {
-	case env == "test":
-		endpoint = "https://test-container.sandbox.googleapis.com/"
-	case env == "staging":
-		endpoint = "https://staging-container.sandbox.googleapis.com/"
-	case env == "staging2":
-		endpoint = "https://staging2-container.sandbox.googleapis.com/"
-	case env == "prod":
-		endpoint = "https://container.googleapis.com/"
-	case urlRe.MatchString(env):
-		endpoint = env
-	default:
-		return fmt.Errorf("--environment must be one of {test,staging,staging2,prod} or match %v, found %q", urlRe, env)
-	}
-
-	if err := os.Setenv("CLOUDSDK_CORE_PRINT_UNHANDLED_TRACEBACKS", "1"); err != nil {
-		return fmt.Errorf("could not set CLOUDSDK_CORE_PRINT_UNHANDLED_TRACEBACKS=1: %v", err)
-	}
-	if err := os.Setenv("CLOUDSDK_API_ENDPOINT_OVERRIDES_CONTAINER", endpoint); err != nil {
+	endpoint, err := d.setGcloudEnv()
+	if err != nil {
 		return err
 	}
+	if err := metadata.AddToFile(filepath.Join(artifacts.BaseDir(), "metadata.json"),
+		map[string]string{"container-api-endpoint": endpoint}); err != nil {
+		klog.Warningf("failed to record the container API endpoint in the metadata: %v", err)
+	}
 
-	if err := runWithOutput(exec.RawCommand("gcloud config set project " + projectID)); err != nil {
+	if err := runWithOutput(d.cmder.Command("gcloud", "config", "set", "project", projectID)); err != nil {
 		return fmt.Errorf("failed to set project %s: %w", projectID, err)
 	}
 
 	// gcloud creds may have changed
-	if err := activateServiceAccount(d.GCPServiceAccount); err != nil {
+	if err := gcp.ActivateServiceAccount(d.GCPServiceAccount); err != nil {
 		return err
 	}
 
 	if !d.GCPSSHKeyIgnored {
 		// Ensure ssh keys exist
 		klog.V(1).Info("Checking existing of GCP ssh keys...")
-		k := filepath.Join(home(".ssh"), "google_compute_engine")
-		if _, err := os.Stat(k); err != nil {
-			return err
-		}
-		pk := k + ".pub"
-		if _, err := os.Stat(pk); err != nil {
+		if err := gcp.VerifySSHKeys(); err != nil {
 			return err
 		}
 	}
@@ -84,35 +66,61 @@ func (d *Deployer) PrepareGcpIfNeeded(projectID string) error {
 	return nil
 }
 
-// Activate service account if set or do nothing.
-func activateServiceAccount(path string) error {
-	if path == "" {
-		return nil
+// setGcloudEnv sets the environment variables of this process configuring
+// gcloud for --environment, without changing the gcloud config, and
+// returns the container API endpoint.
+func (d *Deployer) setGcloudEnv() (string, error) {
+	endpoint, err := containerEndpoint(d.Environment)
+	if err != nil {
+		return "", err
 	}
-	return runWithOutput(exec.RawCommand("gcloud auth activate-service-account --key-file=" + path))
-}
 
-// Get the project number for the given project ID.
-func getProjectNumber(projectID string) (string, error) {
-	// Get the service project number.
-	projectNum, err := exec.Output(exec.RawCommand(
-		fmt.Sprintf("gcloud projects describe %s --format=value(projectNumber)", projectID)))
-	if err != nil {
+	if err := os.Setenv("CLOUDSDK_CORE_PRINT_UNHANDLED_TRACEBACKS", "1"); err != nil {
+		return "", fmt.Errorf("could not set CLOUDSDK_CORE_PRINT_UNHANDLED_TRACEBACKS=1: %v", err)
+	}
+	if err := os.Setenv("CLOUDSDK_API_ENDPOINT_OVERRIDES_CONTAINER", endpoint); err != nil {
 		return "", err
 	}
+	if d.EnvironmentCACerts != "" {
+		// gcloud changes its working directory, so the path must be absolute
+		caCerts, err := filepath.Abs(d.EnvironmentCACerts)
+		if err != nil {
+			return "", fmt.Errorf("failed to convert --environment-ca-certs to absolute path: %w", err)
+		}
+		if _, err := os.Stat(caCerts); err != nil {
+			return "", fmt.Errorf("failed to validate --environment-ca-certs: %w", err)
+		}
+		if err := os.Setenv("CLOUDSDK_CORE_CUSTOM_CA_CERTS_FILE", caCerts); err != nil {
+			return "", err
+		}
+	}
+	return endpoint, nil
+}
 
-	return strings.TrimSpace(string(projectNum)), nil
+// containerEndpoints are the container API endpoints of the --environment names
+var containerEndpoints = map[string]string{
+	"test":     "https://test-container.sandbox.googleapis.com/",
+	"autopush": "https://autopush-container.sandbox.googleapis.com/",
+	"staging":  "https://staging-container.sandbox.googleapis.com/",
+	"staging2": "https://staging2-container.sandbox.googleapis.com/",
+	"prod":     "https://container.googleapis.com/",
 }
 
-// home returns $HOME/part/part/part
-func home(parts ...string) string {
-	p := append([]string{os.Getenv("HOME")}, parts...)
-	return filepath.Join(p...)
+// containerEndpoint returns the container API endpoint of env, either one of
+// containerEndpoints or a custom https:// URL
+func containerEndpoint(env string) (string, error) {
+	if endpoint, ok := containerEndpoints[env]; ok {
+		return endpoint, nil
+	}
+	if urlRe.MatchString(env) {
+		return env, nil
+	}
+	return "", fmt.Errorf("--environment must be one of {test,autopush,staging,staging2,prod} or match %v, found %q", urlRe, env)
 }
 
-func getClusterCredentials(project, loc, cluster string) error {
+func (d *Deployer) getClusterCredentials(project, loc, cluster string) error {
 	// Get gcloud to create the file.
-	if err := runWithOutput(exec.Command("gcloud",
+	if err := runWithOutput(d.cmder.Command("gcloud",
 		containerArgs("clusters", "get-credentials", cluster, "--project="+project, loc)...),
 	); err != nil {
 		return fmt.Errorf("error executing get-credentials: %v", err)
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import "testing"
+
+func TestContainerEndpoint(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		env              string
+		expectedEndpoint string
+		expectErr        bool
+	}{
+		{
+			desc:             "prod",
+			env:              "prod",
+			expectedEndpoint: "https://container.googleapis.com/",
+		},
+		{
+			desc:             "autopush",
+			env:              "autopush",
+			expectedEndpoint: "https://autopush-container.sandbox.googleapis.com/",
+		},
+		{
+			desc:             "staging2",
+			env:              "staging2",
+			expectedEndpoint: "https://staging2-container.sandbox.googleapis.com/",
+		},
+		{
+			desc:             "custom URL",
+			env:              "https://my-container.sandbox.googleapis.com/",
+			expectedEndpoint: "https://my-container.sandbox.googleapis.com/",
+		},
+		{
+			desc:      "unknown environment",
+			env:       "canary",
+			expectErr: true,
+		},
+		{
+			desc:      "plain http URL",
+			env:       "http://container.googleapis.com/",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			endpoint, err := containerEndpoint(tc.env)
+			if tc.expectErr {
+				if err == nil {
+					t.Errorf("expected an error for %q, got endpoint %q", tc.env, endpoint)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if endpoint != tc.expectedEndpoint {
+				t.Errorf("expected %q but got %q", tc.expectedEndpoint, endpoint)
+			}
+		})
+	}
+}
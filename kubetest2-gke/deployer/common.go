@@ -28,6 +28,8 @@ import (
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/kubetest2/pkg/boskos"
+	"sigs.k8s.io/kubetest2/pkg/gcp"
+	"sigs.k8s.io/kubetest2/pkg/lease"
 )
 
 const (
@@ -35,6 +37,9 @@ const (
 	defaultGKEProjectResourceType         = "gke-project"
 	defaultBoskosAcquireTimeoutSeconds    = 300
 	defaultBoskosHeartbeatIntervalSeconds = 300
+	defaultClusterReadyTimeout            = 15 * time.Minute
+	defaultDownTimeout                    = 30 * time.Minute
+	defaultWorkloadIdentityReadyTimeout   = 5 * time.Minute
 )
 
 func (d *Deployer) Init() error {
@@ -45,6 +50,12 @@ func (d *Deployer) Init() error {
 
 // Initialize should only be called by init(), behind a sync.Once
 func (d *Deployer) Initialize() error {
+	if err := d.isolateGcloudConfig(); err != nil {
+		return fmt.Errorf("init failed to isolate the gcloud configuration: %w", err)
+	}
+	if err := d.checkGcloudVersion(); err != nil {
+		return fmt.Errorf("init failed to check the gcloud version: %w", err)
+	}
 	if d.ClusterVersion == "" && d.LegacyClusterVersion != "" {
 		klog.Warningf("--version is deprecated please use --cluster-version")
 		d.ClusterVersion = d.LegacyClusterVersion
@@ -73,27 +84,34 @@ func (d *Deployer) Initialize() error {
 			if err != nil {
 				return fmt.Errorf("failed to make boskos client: %w", err)
 			}
-			d.boskos = boskosClient
-			d.boskosHeartbeatClose = make(chan struct{})
+			d.boskos, err = boskos.NewPool(boskosClient, time.Duration(d.BoskosHeartbeatIntervalSeconds)*time.Second)
+			if err != nil {
+				return fmt.Errorf("failed to make boskos pool: %w", err)
+			}
 
 			for i := 0; i < len(d.BoskosProjectsRequested); i++ {
 				for j := 0; j < d.BoskosProjectsRequested[i]; j++ {
-					resource, err := boskos.Acquire(
+					project, err := gcp.AcquirePoolProject(
 						d.boskos,
 						d.BoskosResourceType[i],
 						time.Duration(d.BoskosAcquireTimeoutSeconds)*time.Second,
-						time.Duration(d.BoskosHeartbeatIntervalSeconds)*time.Second,
-						d.boskosHeartbeatClose,
+						d.projectVerification(),
+						d.BoskosVerifyAttempts,
 					)
-
 					if err != nil {
-						return fmt.Errorf("init failed to get project from boskos: %w", err)
+						return fmt.Errorf("init failed: %w", err)
 					}
-					d.Projects = append(d.Projects, resource.Name)
-					klog.V(1).Infof("Got project %s from boskos", resource.Name)
+					d.Projects = append(d.Projects, project)
+					klog.V(1).Infof("Got project %s from boskos", project)
 				}
 			}
 		}
+
+		if d.PrivateClusterAccessLevel != "" && d.PrivateClusterMasterIPRangePool != "" && !d.SkipClusterCreate {
+			if err := d.leaseMasterIPRanges(lease.NewLeaser(d.Kubetest2CommonOptions)); err != nil {
+				return fmt.Errorf("init failed: %w", err)
+			}
+		}
 	}
 
 	if d.Kubetest2CommonOptions.ShouldDown() {
@@ -142,6 +160,27 @@ func (d *Deployer) Initialize() error {
 	return d.PrepareGcpIfNeeded(d.Projects[0])
 }
 
+// projectVerification returns how to verify the projects acquired from boskos,
+// nil if they should not be verified.
+func (d *Deployer) projectVerification() *gcp.ProjectVerification {
+	if !d.BoskosVerifyProjects {
+		return nil
+	}
+	v := &gcp.ProjectVerification{
+		Services: []string{"compute.googleapis.com", "container.googleapis.com"},
+		Fix:      d.BoskosFixProjects,
+	}
+	for _, c := range d.Clusters {
+		// strip the project index of the multi-project format
+		v.Clusters = append(v.Clusters, strings.SplitN(c, ":", 2)[0])
+	}
+	// the default network is expected to exist
+	if d.Network != "default" {
+		v.Networks = []string{d.Network}
+	}
+	return v
+}
+
 // buildProjectClustersLayout builds the projects and real cluster names mapping based on the provided --cluster-name flag.
 func buildProjectClustersLayout(projects, clusters []string, projectClustersLayout map[string][]cluster) error {
 	for i, clusterName := range clusters {
@@ -184,6 +223,27 @@ func buildExtraNodePoolOptions(np string, enp *extraNodepool) error {
 				return fmt.Errorf("num-nodes must be a positive integer, got %d", n)
 			}
 			enp.NumNodes = n
+		case "accelerator":
+			enp.Accelerator = values.Get("accelerator")
+		case "tpu-topology":
+			enp.TPUTopology = values.Get("tpu-topology")
+		case "service-account":
+			enp.ServiceAccount = values.Get("service-account")
+		case "min-nodes", "max-nodes":
+			n, err := strconv.Atoi(values.Get(k))
+			if err != nil {
+				return err
+			}
+			if n < 0 {
+				return fmt.Errorf("%s must be a positive integer, got %d", k, n)
+			}
+			if k == "min-nodes" {
+				enp.MinNodes = n
+			} else {
+				enp.MaxNodes = n
+			}
+		case "location-policy":
+			enp.LocationPolicy = values.Get("location-policy")
 		default:
 			return fmt.Errorf("unknown parameter: %q", k)
 		}
@@ -207,5 +267,14 @@ func validateExtraNodepoolOptions(enp *extraNodepool) error {
 	if enp.NumNodes <= 0 {
 		return fmt.Errorf("num-nodes must be > 0")
 	}
-	return nil
+
+	if enp.Accelerator != "" {
+		if _, ok := acceleratorProperty(enp.Accelerator, "type"); !ok {
+			return fmt.Errorf("accelerator must specify type")
+		}
+		if _, ok := acceleratorProperty(enp.Accelerator, "count"); !ok {
+			return fmt.Errorf("accelerator must specify count")
+		}
+	}
+	return validateNodepoolAutoscaling(enp)
 }
@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gke/deployer/options"
+	"sigs.k8s.io/kubetest2/pkg/gcp"
+)
+
+func TestProjectVerification(t *testing.T) {
+	testCases := []struct {
+		name     string
+		verify   bool
+		fix      bool
+		clusters []string
+		network  string
+		expected *gcp.ProjectVerification
+	}{
+		{
+			name:     "not verified",
+			clusters: []string{"c1"},
+			network:  "kt2-net",
+		},
+		{
+			name:     "clusters and network of the run",
+			verify:   true,
+			clusters: []string{"c1", "c2"},
+			network:  "kt2-net",
+			expected: &gcp.ProjectVerification{
+				Services: []string{"compute.googleapis.com", "container.googleapis.com"},
+				Clusters: []string{"c1", "c2"},
+				Networks: []string{"kt2-net"},
+			},
+		},
+		{
+			name:     "multi-project clusters in the default network are fixed",
+			verify:   true,
+			fix:      true,
+			clusters: []string{"c1:0", "c2:1"},
+			network:  "default",
+			expected: &gcp.ProjectVerification{
+				Services: []string{"compute.googleapis.com", "container.googleapis.com"},
+				Clusters: []string{"c1", "c2"},
+				Fix:      true,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &Deployer{
+				ProjectOptions: &options.ProjectOptions{BoskosVerifyProjects: tc.verify, BoskosFixProjects: tc.fix},
+				ClusterOptions: &options.ClusterOptions{Clusters: tc.clusters},
+				NetworkOptions: &options.NetworkOptions{Network: tc.network},
+			}
+			if actual := d.projectVerification(); !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected verification %+v but got %+v", tc.expected, actual)
+			}
+		})
+	}
+}
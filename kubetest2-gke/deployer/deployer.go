@@ -22,17 +22,19 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
-	"strings"
 	"sync"
+	"time"
 
 	"github.com/octago/sflags/gen/gpflag"
 	"github.com/spf13/pflag"
 	"k8s.io/klog/v2"
-	"sigs.k8s.io/boskos/client"
 
 	"sigs.k8s.io/kubetest2/kubetest2-gke/deployer/options"
 	"sigs.k8s.io/kubetest2/pkg/artifacts"
+	"sigs.k8s.io/kubetest2/pkg/boskos"
 	"sigs.k8s.io/kubetest2/pkg/build"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/gcp"
 	"sigs.k8s.io/kubetest2/pkg/types"
 )
 
@@ -102,6 +104,18 @@ type extraNodepool struct {
 	MachineType string
 	ImageType   string
 	NumNodes    int
+	// Accelerator is passed as --accelerator, e.g. type=nvidia-tesla-t4,count=1
+	Accelerator string
+	// TPUTopology is passed as --tpu-topology, e.g. 2x2
+	TPUTopology string
+	// ServiceAccount overrides the node service account of the cluster for the nodepool
+	ServiceAccount string
+	// MinNodes and MaxNodes are the autoscaling limits per zone, autoscaling
+	// is enabled when MaxNodes is set
+	MinNodes int
+	MaxNodes int
+	// LocationPolicy is passed as --location-policy, ANY or BALANCED
+	LocationPolicy string
 }
 
 type Deployer struct {
@@ -116,16 +130,25 @@ type Deployer struct {
 
 	// doInit helps to make sure the initialization is performed only once
 	doInit sync.Once
+	// stepRunner records the phases of Up as individual junit steps
+	stepRunner types.StepRunner
+	// cmder creates the commands run by the deployer, faked in tests
+	cmder exec.Cmder
 	// only used for multi-project multi-cluster profile to save the project-clusters mapping
 	projectClustersLayout map[string][]cluster
 	// project -> cluster -> instance groups
 	instanceGroups map[string]map[string][]*ig
+	// project -> cluster -> nodepools of the clusters of --skip-cluster-create
+	existingNodePools map[string]map[string][]nodePoolLayout
 
 	// extra node pools to create, per cluster.
 	extraNodePoolSpecs []*extraNodepool
 
 	kubecfgPath  string
 	testPrepared bool
+	// gcloudConfigDir is the gcloud configuration isolated for the run by
+	// --isolate-gcloud-config, removed by Finish
+	gcloudConfigDir string
 
 	localLogsDir string
 	gcsLogsDir   string
@@ -136,17 +159,16 @@ type Deployer struct {
 	retryableErrorPatternsCompiled       []*regexp.Regexp
 	subnetworkRangesInternal             [][]string
 	privateClusterMasterIPRangesInternal [][]string
+	// the deadline of the run, zero if the run has no time budget
+	upDeadline time.Time
 
 	// the total number of Boskos projects to request
 	totalBoskosProjectsRequested int
 
 	// boskos struct field will be non-nil when the deployer is
-	// using boskos to acquire a GCP project
-	boskos *client.Client
-
-	// this channel serves as a signal channel for the hearbeat goroutine
-	// so that it can be explicitly closed
-	boskosHeartbeatClose chan struct{}
+	// using boskos to acquire a GCP project, the pool keeps the projects
+	// reserved until they are released
+	boskos *boskos.Pool
 }
 
 // assert that New implements types.NewDeployer
@@ -155,10 +177,50 @@ var _ types.NewDeployer = New
 // assert that deployer implements types.Deployer
 var _ types.Deployer = &Deployer{}
 
+// assert that deployer implements types.DeployerWithSteps
+var _ types.DeployerWithSteps = &Deployer{}
+
+// assert that deployer implements types.DeployerWithBuildManifest
+var _ types.DeployerWithBuildManifest = &Deployer{}
+
+// assert that deployer implements types.DeployerWithContext
+var _ types.DeployerWithContext = &Deployer{}
+
+// SetStepRunner implements types.DeployerWithSteps
+func (d *Deployer) SetStepRunner(run types.StepRunner) {
+	d.stepRunner = run
+}
+
 func (d *Deployer) Provider() string {
 	return Name
 }
 
+// assert that deployer implements types.DeployerWithProviderConfig
+var _ types.DeployerWithProviderConfig = &Deployer{}
+
+// ProviderConfig implements types.DeployerWithProviderConfig, it describes
+// the clusters in the first project and the location they were created in.
+func (d *Deployer) ProviderConfig() (map[string]string, error) {
+	config := map[string]string{}
+	if len(d.Projects) == 0 {
+		return config, nil
+	}
+	project := d.Projects[0]
+	config["gce-project"] = project
+	config["network"] = d.Network
+	if len(d.Zones) != 0 {
+		config["gce-zone"] = d.Zones[d.retryCount]
+	}
+	if len(d.Zones) != 0 || len(d.Regions) != 0 {
+		config["gce-region"] = regionFromLocation(d.Regions, d.Zones, d.retryCount)
+	}
+	// the e2e framework describes a single cluster
+	if clusters := d.projectClustersLayout[project]; len(d.Projects) == 1 && len(clusters) == 1 {
+		config["gke-cluster"] = clusters[0].name
+	}
+	return config, nil
+}
+
 func (d *Deployer) Version() string {
 	return GitTag
 }
@@ -181,6 +243,7 @@ func New(opts types.Options) (types.Deployer, *pflag.FlagSet) {
 func NewDeployer(opts types.Options) *Deployer {
 	d := &Deployer{
 		Kubetest2CommonOptions: opts,
+		cmder:                  exec.DefaultCmder,
 		BuildOptions: &options.BuildOptions{
 			CommonBuildOptions: &build.Options{
 				Builder:  &build.NoopBuilder{},
@@ -190,6 +253,7 @@ func NewDeployer(opts types.Options) *Deployer {
 		},
 		CommonOptions: &options.CommonOptions{
 			GCPSSHKeyIgnored: true,
+			MinGcloudVersion: defaultMinGcloudVersion,
 		},
 		ProjectOptions: &options.ProjectOptions{
 			BoskosLocation:                 defaultBoskosLocation,
@@ -197,9 +261,13 @@ func NewDeployer(opts types.Options) *Deployer {
 			BoskosAcquireTimeoutSeconds:    defaultBoskosAcquireTimeoutSeconds,
 			BoskosHeartbeatIntervalSeconds: defaultBoskosHeartbeatIntervalSeconds,
 			BoskosProjectsRequested:        []int{1},
+			BoskosVerifyAttempts:           3,
 		},
 		NetworkOptions: &options.NetworkOptions{
-			Network: "default",
+			Network:                "default",
+			CreateNAT:              true,
+			PrivateGoogleAccessDNS: true,
+			StrictIAM:              true,
 		},
 		ClusterOptions: &options.ClusterOptions{
 			Environment: "prod",
@@ -214,6 +282,11 @@ func NewDeployer(opts types.Options) *Deployer {
 			WindowsMachineType: defaultWindowsNodePool.MachineType,
 
 			RetryableErrorPatterns: []string{gceStockoutErrorPattern},
+
+			ClusterReadyTimeout:          defaultClusterReadyTimeout,
+			DownTimeout:                  defaultDownTimeout,
+			DownAction:                   downActionDelete,
+			WorkloadIdentityReadyTimeout: defaultWorkloadIdentityReadyTimeout,
 		},
 		localLogsDir: filepath.Join(artifacts.BaseDir(), "logs"),
 	}
@@ -234,17 +307,16 @@ func (d *Deployer) VerifyLocationFlags() error {
 // used by gcloud commands.
 func locationFlag(regions, zones []string, retryCount int) string {
 	if len(zones) != 0 {
-		return "--zone=" + zones[retryCount]
+		return gcp.LocationFlag("", zones[retryCount])
 	}
-	return "--region=" + regions[retryCount]
+	return gcp.LocationFlag(regions[retryCount], "")
 }
 
 // regionFromLocation computes the region from the specified zone/region
 // used by some commands (such as subnets), which do not support zones.
 func regionFromLocation(regions, zones []string, retryCount int) string {
 	if len(zones) != 0 {
-		zone := zones[retryCount]
-		return zone[0:strings.LastIndex(zone, "-")]
+		return gcp.RegionFromZone(zones[retryCount])
 	}
 	return regions[retryCount]
 }
@@ -17,13 +17,12 @@ limitations under the License.
 package deployer
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sync"
 
 	"k8s.io/klog/v2"
-
-	"sigs.k8s.io/kubetest2/pkg/boskos"
-	"sigs.k8s.io/kubetest2/pkg/exec"
 )
 
 func (d *Deployer) Down() error {
@@ -39,14 +38,34 @@ func (d *Deployer) Down() error {
 	if err := d.DumpClusterLogs(); err != nil {
 		klog.Warningf("Dumping cluster logs at the end of Up() failed: %v", err)
 	}
+	if err := d.SaveNotifications(); err != nil {
+		klog.Warningf("Saving the cluster notifications failed: %v", err)
+	}
 
 	// If the GCP projects are acquired from Boskos, release the projects and
 	// rely on boskos-janitor to do clean-ups for them.
 	if d.totalBoskosProjectsRequested > 0 {
-		return boskos.Release(d.boskos, d.Projects, d.boskosHeartbeatClose)
+		if err := d.DeleteNodeServiceAccounts(); err != nil {
+			klog.Errorf("Error deleting node service accounts: %v", err)
+		}
+		if err := d.DeleteNotificationTopics(); err != nil {
+			klog.Errorf("Error deleting cluster notifications topics: %v", err)
+		}
+		return d.boskos.ReleaseAll()
 	}
 
-	d.DeleteClusters(d.retryCount)
+	if d.DownAction == downActionScaleToZero {
+		return d.hibernateClusters()
+	}
+
+	// The firewall rules of reused clusters are left in place too, they are
+	// reused by the next run and the network may have rules of its own.
+	if d.SkipClusterCreate {
+		klog.V(1).Infof("Leaving the existing clusters %v in place", d.Clusters)
+		return d.TeardownPrivateNodesAccess(d.retryCount)
+	}
+
+	errDeleteClusters := d.DeleteClusters(d.retryCount)
 
 	numDeletedFWRules, errCleanFirewalls := d.CleanupNetworkFirewalls(d.Projects[0], d.Network)
 	if errCleanFirewalls != nil {
@@ -55,18 +74,48 @@ func (d *Deployer) Down() error {
 		klog.V(1).Infof("Deleted %d network firewall rules", numDeletedFWRules)
 	}
 
+	// The network cannot be torn down while clusters are still using it.
+	if errDeleteClusters != nil {
+		return errDeleteClusters
+	}
+
+	if err := d.DeleteNodeServiceAccounts(); err != nil {
+		return err
+	}
+	if err := d.DeleteNotificationTopics(); err != nil {
+		return err
+	}
+
 	if err := d.TeardownNetwork(); err != nil {
 		return err
 	}
+	if err := d.TeardownPrivateNodesAccess(d.retryCount); err != nil {
+		return err
+	}
 	if err := d.DeleteSubnets(d.retryCount); err != nil {
 		return err
 	}
 	return d.DeleteNetwork()
 }
 
-func (d *Deployer) DeleteClusters(retryCount int) {
+// hibernateClusters scales the clusters to zero. The network and firewall
+// rules of hibernated clusters are left in place for the next run, only the
+// NAT router and DNS zones are per run, and are deleted even if some clusters
+// failed to be scaled so that they don't leak.
+func (d *Deployer) hibernateClusters() error {
+	return errors.Join(
+		d.ScaleClustersToZero(d.retryCount),
+		d.TeardownPrivateNodesAccess(d.retryCount),
+	)
+}
+
+// DeleteClusters deletes all the clusters concurrently, and returns the
+// aggregated errors of the clusters that failed to be deleted.
+func (d *Deployer) DeleteClusters(retryCount int) error {
 	// We best-effort try all of these and report errors as appropriate.
 	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
 	for i := range d.Projects {
 		project := d.Projects[i]
 		for j := range d.projectClustersLayout[project] {
@@ -76,20 +125,48 @@ func (d *Deployer) DeleteClusters(retryCount int) {
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
-				d.DeleteCluster(project, loc, cluster)
+				if err := d.DeleteCluster(project, loc, cluster); err != nil {
+					klog.Errorf("Error deleting cluster: %v", err)
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+				}
 			}()
 		}
 	}
 	wg.Wait()
+	return errors.Join(errs...)
 }
 
-func (d *Deployer) DeleteCluster(project, loc string, cluster cluster) {
-	if err := runWithOutput(exec.Command(
+// DeleteCluster deletes the cluster, giving up after --down-timeout.
+func (d *Deployer) DeleteCluster(project, loc string, cluster cluster) error {
+	ctx := context.Background()
+	if d.DownTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.DownTimeout)
+		defer cancel()
+	}
+	if d.DeletionProtection {
+		if err := runWithOutput(d.cmder.CommandContext(ctx,
+			"gcloud", containerArgs("clusters", "update", cluster.name,
+				"--project="+project,
+				loc,
+				"--remove-labels="+deletionProtectionLabel)...)); err != nil {
+			// the label only guards against other cleanup tools, which must
+			// not keep the cluster from being deleted
+			klog.Errorf("Error removing deletion protection from cluster %q in project %q: %v", cluster.name, project, err)
+		}
+	}
+	if err := runWithOutput(d.cmder.CommandContext(ctx,
 		"gcloud", containerArgs("clusters", "delete", "-q", cluster.name,
 			"--project="+project,
 			loc)...)); err != nil {
-		klog.Errorf("Error deleting cluster: %v", err)
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("timed out after %v deleting cluster %q in project %q", d.DownTimeout, cluster.name, project)
+		}
+		return fmt.Errorf("error deleting cluster %q in project %q: %w", cluster.name, project, err)
 	}
+	return nil
 }
 
 // VerifyDownFlags validates flags for down phase.
@@ -100,5 +177,8 @@ func (d *Deployer) VerifyDownFlags() error {
 	if len(d.Projects) == 0 {
 		return fmt.Errorf("--project must be set for GKE deployment")
 	}
+	if err := d.verifyDownAction(); err != nil {
+		return err
+	}
 	return d.VerifyLocationFlags()
 }
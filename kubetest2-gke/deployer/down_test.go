@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gke/deployer/options"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+func TestDeleteClusters(t *testing.T) {
+	const (
+		deleteC1 = "gcloud container clusters delete -q c1 --project=p1 --zone=us-central1-c"
+		deleteC2 = "gcloud container clusters delete -q c2 --project=p1 --zone=us-central1-c"
+		deleteC3 = "gcloud container clusters delete -q c3 --project=p2 --zone=us-central1-c"
+	)
+	testCases := []struct {
+		name           string
+		responses      []exec.FakeResponse
+		expectedErrors []string
+	}{
+		{
+			name: "all clusters deleted",
+		},
+		{
+			name:           "one cluster fails",
+			responses:      []exec.FakeResponse{{Prefix: deleteC2, Err: errors.New("exit status 1")}},
+			expectedErrors: []string{`error deleting cluster "c2" in project "p1"`},
+		},
+		{
+			name: "the errors of all failed clusters are returned",
+			responses: []exec.FakeResponse{
+				{Prefix: deleteC1, Err: errors.New("exit status 1")},
+				{Prefix: deleteC3, Err: errors.New("exit status 1")},
+			},
+			expectedErrors: []string{
+				`error deleting cluster "c1" in project "p1"`,
+				`error deleting cluster "c3" in project "p2"`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := &exec.FakeCmder{Responses: tc.responses}
+			d := &Deployer{
+				cmder:          cmder,
+				ProjectOptions: &options.ProjectOptions{Projects: []string{"p1", "p2"}},
+				ClusterOptions: &options.ClusterOptions{Zones: []string{"us-central1-c"}},
+				projectClustersLayout: map[string][]cluster{
+					"p1": {{index: 0, name: "c1"}, {index: 1, name: "c2"}},
+					"p2": {{index: 2, name: "c3"}},
+				},
+			}
+			err := d.DeleteClusters(0)
+
+			// the clusters are deleted concurrently
+			commands := cmder.Commands()
+			sort.Strings(commands)
+			if expected := []string{deleteC1, deleteC2, deleteC3}; !reflect.DeepEqual(commands, expected) {
+				t.Errorf("expected commands %q but got %q", expected, commands)
+			}
+			if len(tc.expectedErrors) == 0 {
+				if err != nil {
+					t.Errorf("did not expect an error, but got: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected errors %q but got none", tc.expectedErrors)
+			}
+			for _, expected := range tc.expectedErrors {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected the error to contain %q, got: %v", expected, err)
+				}
+			}
+		})
+	}
+}
+
+// hangingCmder creates commands which run until their context is done
+type hangingCmder struct{}
+
+func (hangingCmder) Command(string, ...string) exec.Cmd {
+	return &hangingCmd{ctx: context.Background()}
+}
+
+func (hangingCmder) CommandContext(ctx context.Context, _ string, _ ...string) exec.Cmd {
+	return &hangingCmd{ctx: ctx}
+}
+
+type hangingCmd struct {
+	ctx context.Context
+}
+
+func (c *hangingCmd) Run() error {
+	<-c.ctx.Done()
+	return c.ctx.Err()
+}
+
+func (c *hangingCmd) SetEnv(...string) exec.Cmd    { return c }
+func (c *hangingCmd) SetStdin(io.Reader) exec.Cmd  { return c }
+func (c *hangingCmd) SetStdout(io.Writer) exec.Cmd { return c }
+func (c *hangingCmd) SetStderr(io.Writer) exec.Cmd { return c }
+func (c *hangingCmd) SetDir(string) exec.Cmd       { return c }
+
+func TestDeleteClusterTimeout(t *testing.T) {
+	testCases := []struct {
+		name          string
+		cmder         exec.Cmder
+		downTimeout   time.Duration
+		expectedError string
+	}{
+		{
+			name:          "deletion times out",
+			cmder:         hangingCmder{},
+			downTimeout:   10 * time.Millisecond,
+			expectedError: `timed out after 10ms deleting cluster "c1" in project "p1"`,
+		},
+		{
+			name:          "deletion fails before the timeout",
+			cmder:         &exec.FakeCmder{Responses: []exec.FakeResponse{{Prefix: "gcloud", Err: errors.New("exit status 1")}}},
+			downTimeout:   time.Minute,
+			expectedError: `error deleting cluster "c1" in project "p1": exit status 1`,
+		},
+		{
+			name:        "deleted within the timeout",
+			cmder:       &exec.FakeCmder{},
+			downTimeout: time.Minute,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &Deployer{
+				cmder:          tc.cmder,
+				ClusterOptions: &options.ClusterOptions{DownTimeout: tc.downTimeout},
+			}
+			err := d.DeleteCluster("p1", "--zone=us-central1-c", cluster{name: "c1"})
+			if tc.expectedError == "" {
+				if err != nil {
+					t.Errorf("did not expect an error, but got: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.expectedError {
+				t.Errorf("expected error %q but got: %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestHibernateClusters(t *testing.T) {
+	cmder := &exec.FakeCmder{
+		Responses: []exec.FakeResponse{
+			{Prefix: "gcloud container clusters describe c1", Err: errors.New("exit status 1")},
+		},
+	}
+	d := &Deployer{
+		cmder:                  cmder,
+		Kubetest2CommonOptions: runIDOptions{runID: "run-1"},
+		ProjectOptions:         &options.ProjectOptions{Projects: []string{"p1"}},
+		ClusterOptions:         &options.ClusterOptions{Zones: []string{"us-central1-c"}, DownAction: downActionScaleToZero},
+		NetworkOptions: &options.NetworkOptions{
+			PrivateClusterAccessLevel: "no",
+			CreateNAT:                 true,
+			PrivateGoogleAccessDNS:    true,
+		},
+		projectClustersLayout: map[string][]cluster{"p1": {{index: 0, name: "c1"}}},
+	}
+	err := d.hibernateClusters()
+	if err == nil || !strings.Contains(err.Error(), "exit status 1") {
+		t.Errorf("expected the error scaling the cluster to zero, got: %v", err)
+	}
+
+	// the NAT router and the DNS zones of the run don't leak
+	router := natRouterName("run-1", "us-central1")
+	expectedDeletions := []string{"gcloud compute routers delete " + router}
+	for _, zone := range privateGoogleAccessZones {
+		expectedDeletions = append(expectedDeletions, "gcloud dns managed-zones delete "+privateGoogleAccessZoneName("run-1", zone.domain))
+	}
+	commands := cmder.Commands()
+	for _, expected := range expectedDeletions {
+		found := false
+		for _, command := range commands {
+			found = found || strings.HasPrefix(command, expected)
+		}
+		if !found {
+			t.Errorf("expected %q to be run, got commands %q", expected, commands)
+		}
+	}
+}
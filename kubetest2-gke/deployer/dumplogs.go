@@ -21,7 +21,7 @@ import (
 	"os"
 	"strings"
 
-	"sigs.k8s.io/kubetest2/pkg/exec"
+	"k8s.io/klog/v2"
 )
 
 // DumpClusterLogs for GKE generates a small script that wraps
@@ -33,6 +33,12 @@ import (
 //
 // TODO(RonWeber): Make this work with multizonal and regional clusters.
 func (d *Deployer) DumpClusterLogs() error {
+	// Pod logs are fetched with kubectl and are not tied to the location
+	// type, so they are dumped even if log-dump.sh below can't run.
+	if err := d.dumpSystemPodLogs(); err != nil {
+		klog.Warningf("Dumping GKE system pod logs failed: %v", err)
+	}
+
 	if len(d.Zones) <= 0 {
 		return fmt.Errorf("DumpClusterLogs is currently only supported for zonal clusters")
 	}
@@ -80,7 +86,7 @@ export KUBE_NODE_OS_DISTRIBUTION='%[3]s'
 		if d.gcsLogsDir != "" {
 			dumpCmd += " " + d.gcsLogsDir
 		}
-		cmd := exec.Command("bash", "-c", fmt.Sprintf(gkeLogDumpTemplate,
+		cmd := d.cmder.Command("bash", "-c", fmt.Sprintf(gkeLogDumpTemplate,
 			project,
 			d.Zones[d.retryCount],
 			os.Getenv("NODE_OS_DISTRIBUTION"),
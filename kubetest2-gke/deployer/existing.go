@@ -0,0 +1,174 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"google.golang.org/api/container/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// existingClusterPollInterval is how often a cluster left with the same name
+// by a previous attempt is checked while waiting for it to be deleted, it is
+// a var for tests
+var existingClusterPollInterval = 15 * time.Second
+
+// existingClusterAction is what to do before creating a cluster, depending
+// on the cluster found with the same name and location
+type existingClusterAction string
+
+const (
+	// createCluster creates the cluster, there is no cluster with its name
+	createCluster existingClusterAction = "create"
+	// waitForDeletion waits for the cluster being deleted to be gone, then
+	// creates the cluster
+	waitForDeletion existingClusterAction = "wait-for-deletion"
+	// adoptCluster uses the cluster created by a previous attempt of the run
+	// instead of creating it
+	adoptCluster existingClusterAction = "adopt"
+	// replaceCluster deletes the cluster created by a previous attempt of
+	// the run, which won't recover, then creates the cluster
+	replaceCluster existingClusterAction = "replace"
+)
+
+// chooseExistingClusterAction returns what to do with the existing cluster c,
+// which is nil if there is none. Clusters not created by the run are never
+// adopted nor deleted.
+func chooseExistingClusterAction(c *container.Cluster, runLabelValue string) (existingClusterAction, error) {
+	if c == nil {
+		return createCluster, nil
+	}
+	if c.Status == "STOPPING" {
+		return waitForDeletion, nil
+	}
+	if c.ResourceLabels[runLabel] != runLabelValue {
+		return "", fmt.Errorf("cluster %q already exists and was not created by this run, status %s", c.Name, c.Status)
+	}
+	switch c.Status {
+	case "ERROR", "DEGRADED":
+		return replaceCluster, nil
+	default:
+		return adoptCluster, nil
+	}
+}
+
+// prepareExistingCluster handles a cluster left with the same name and
+// location by a previous attempt, so that retries don't fail on the name
+// conflict. It returns the cluster if it is adopted instead of created.
+func (d *Deployer) prepareExistingCluster(project string, cluster cluster, locationArg string) (*container.Cluster, error) {
+	c, err := d.findCluster(project, cluster.name, locationArg)
+	if err != nil {
+		return nil, err
+	}
+	action, err := chooseExistingClusterAction(c, runLabelValue(d.Kubetest2CommonOptions.RunID()))
+	if err != nil {
+		return nil, err
+	}
+	switch action {
+	case adoptCluster:
+		klog.V(0).Infof("Adopting cluster %q in project %q created by a previous attempt: %s", cluster.name, project, clusterStatusSummary(c))
+		// nodepools can only be added to the cluster once it is ready, so
+		// the adopted cluster is waited for even without
+		// --cluster-ready-timeout
+		timeout := d.ClusterReadyTimeout
+		if timeout <= 0 {
+			timeout = defaultClusterReadyTimeout
+		}
+		if err := d.pollClusterReady(project, cluster, locationArg, timeout); err != nil {
+			return nil, err
+		}
+		return d.findCluster(project, cluster.name, locationArg)
+	case replaceCluster:
+		klog.V(0).Infof("Replacing cluster %q in project %q created by a previous attempt: %s", cluster.name, project, clusterStatusSummary(c))
+		if err := d.DeleteCluster(project, locationArg, cluster); err != nil {
+			return nil, err
+		}
+		return nil, d.waitForClusterDeletion(project, cluster, locationArg)
+	case waitForDeletion:
+		klog.V(0).Infof("Waiting for cluster %q in project %q to be deleted before creating it", cluster.name, project)
+		return nil, d.waitForClusterDeletion(project, cluster, locationArg)
+	}
+	return nil, nil
+}
+
+// waitForClusterDeletion polls the cluster until it is gone, or until
+// --down-timeout expires, which defaults to defaultDownTimeout when unset
+func (d *Deployer) waitForClusterDeletion(project string, cluster cluster, locationArg string) error {
+	timeout := d.DownTimeout
+	if timeout <= 0 {
+		timeout = defaultDownTimeout
+	}
+	started := time.Now()
+	for {
+		c, err := d.findCluster(project, cluster.name, locationArg)
+		if err != nil {
+			return err
+		}
+		if c == nil {
+			return nil
+		}
+		if time.Since(started) > timeout {
+			return fmt.Errorf("timed out after %v waiting for cluster %q in project %q to be deleted, last status: %s",
+				timeout, cluster.name, project, c.Status)
+		}
+		klog.V(2).Infof("Cluster %q is still being deleted: %s", cluster.name, c.Status)
+		time.Sleep(existingClusterPollInterval)
+	}
+}
+
+// findCluster returns the cluster named clusterName, or nil if there is none
+func (d *Deployer) findCluster(project, clusterName, locationArg string) (*container.Cluster, error) {
+	out, err := exec.Output(d.cmder.Command("gcloud",
+		containerArgs("clusters", "list",
+			"--project="+project,
+			locationArg,
+			"--filter=name="+clusterName,
+			"--format=json")...))
+	if err != nil {
+		return nil, fmt.Errorf("error listing clusters named %q: %s", clusterName, execError(err))
+	}
+
+	var clusters []*container.Cluster
+	if err := json.Unmarshal(out, &clusters); err != nil {
+		return nil, fmt.Errorf("error parsing clusters named %q: %w", clusterName, err)
+	}
+	for _, c := range clusters {
+		if c.Name == clusterName {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+// hasNodePool returns true if the adopted cluster c, which is nil when the
+// cluster was just created, already has the named nodepool
+func hasNodePool(c *container.Cluster, name string) bool {
+	if c == nil {
+		return false
+	}
+	for _, np := range c.NodePools {
+		if np.Name == name {
+			return true
+		}
+	}
+	return false
+}
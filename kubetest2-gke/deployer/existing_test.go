@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/api/container/v1"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gke/deployer/options"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+// runIDOptions are the common options of a run with the given id
+type runIDOptions struct {
+	types.Options
+	runID string
+}
+
+func (o runIDOptions) RunID() string {
+	return o.runID
+}
+
+func TestChooseExistingClusterAction(t *testing.T) {
+	ownLabels := map[string]string{runLabel: "2a6ca8e3"}
+	testCases := []struct {
+		name           string
+		cluster        *container.Cluster
+		expectedAction existingClusterAction
+		expectError    bool
+	}{
+		{
+			name:           "no cluster",
+			expectedAction: createCluster,
+		},
+		{
+			name:           "cluster being deleted",
+			cluster:        &container.Cluster{Name: "kt2-abc", Status: "STOPPING"},
+			expectedAction: waitForDeletion,
+		},
+		{
+			name:           "cluster of the run provisioning",
+			cluster:        &container.Cluster{Name: "kt2-abc", Status: "PROVISIONING", ResourceLabels: ownLabels},
+			expectedAction: adoptCluster,
+		},
+		{
+			name:           "cluster of the run running",
+			cluster:        &container.Cluster{Name: "kt2-abc", Status: "RUNNING", ResourceLabels: ownLabels},
+			expectedAction: adoptCluster,
+		},
+		{
+			name:           "cluster of the run in error",
+			cluster:        &container.Cluster{Name: "kt2-abc", Status: "ERROR", ResourceLabels: ownLabels},
+			expectedAction: replaceCluster,
+		},
+		{
+			name:        "cluster of another run",
+			cluster:     &container.Cluster{Name: "kt2-abc", Status: "RUNNING", ResourceLabels: map[string]string{runLabel: "00000000"}},
+			expectError: true,
+		},
+		{
+			name:        "cluster without labels in error",
+			cluster:     &container.Cluster{Name: "kt2-abc", Status: "ERROR"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			action, err := chooseExistingClusterAction(tc.cluster, runLabelValue("run-1"))
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect an error, but got: %v", err)
+			}
+			if action != tc.expectedAction {
+				t.Errorf("expected action %q but got %q", tc.expectedAction, action)
+			}
+		})
+	}
+}
+
+func TestPrepareExistingCluster(t *testing.T) {
+	defer func(ready, existing time.Duration) {
+		clusterReadyPollInterval, existingClusterPollInterval = ready, existing
+	}(clusterReadyPollInterval, existingClusterPollInterval)
+	clusterReadyPollInterval, existingClusterPollInterval = 0, 0
+
+	const (
+		list     = "gcloud container clusters list --project=p1 --zone=us-central1-c --filter=name=kt2-abc --format=json"
+		describe = "gcloud container clusters describe kt2-abc --project=p1 --zone=us-central1-c --format=json"
+		del      = "gcloud container clusters delete -q kt2-abc --project=p1 --zone=us-central1-c"
+	)
+	clusterJSON := func(status string) string {
+		return fmt.Sprintf(`{"name": "kt2-abc", "status": %q, "resourceLabels": {%q: %q}}`, status, runLabel, runLabelValue("run-1"))
+	}
+	testCases := []struct {
+		name                string
+		responses           []exec.FakeResponse
+		clusterReadyTimeout time.Duration
+		downTimeout         time.Duration
+		expectAdopted       bool
+		expectError         bool
+		expectedCommands    []string
+	}{
+		{
+			name:             "no cluster",
+			responses:        []exec.FakeResponse{{Prefix: list, Stdout: "[]"}},
+			expectedCommands: []string{list},
+		},
+		{
+			name: "adopt waits for the cluster without --cluster-ready-timeout",
+			responses: []exec.FakeResponse{
+				{Prefix: list, Stdout: "[" + clusterJSON("PROVISIONING") + "]"},
+				{Prefix: describe, Stdout: clusterJSON("RUNNING")},
+			},
+			expectAdopted:    true,
+			expectedCommands: []string{list, describe, list},
+		},
+		{
+			name: "adopt fails if the cluster does not become ready",
+			responses: []exec.FakeResponse{
+				{Prefix: list, Stdout: "[" + clusterJSON("RECONCILING") + "]"},
+				{Prefix: describe, Stdout: clusterJSON("ERROR")},
+			},
+			clusterReadyTimeout: time.Minute,
+			expectError:         true,
+			expectedCommands:    []string{list, describe},
+		},
+		{
+			name:             "recreate deletes the cluster then waits for it to be gone",
+			responses:        []exec.FakeResponse{{Prefix: list, Stdout: "[" + clusterJSON("ERROR") + "]"}},
+			downTimeout:      time.Nanosecond,
+			expectError:      true,
+			expectedCommands: []string{list, del, list},
+		},
+		{
+			name:             "wait for deletion times out",
+			responses:        []exec.FakeResponse{{Prefix: list, Stdout: `[{"name": "kt2-abc", "status": "STOPPING"}]`}},
+			downTimeout:      time.Nanosecond,
+			expectError:      true,
+			expectedCommands: []string{list, list},
+		},
+		{
+			name:             "listing fails",
+			responses:        []exec.FakeResponse{{Prefix: list, Err: fmt.Errorf("permission denied")}},
+			expectError:      true,
+			expectedCommands: []string{list},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := &exec.FakeCmder{Responses: tc.responses}
+			d := &Deployer{
+				cmder:                  cmder,
+				Kubetest2CommonOptions: runIDOptions{runID: "run-1"},
+				ClusterOptions: &options.ClusterOptions{
+					ClusterReadyTimeout: tc.clusterReadyTimeout,
+					DownTimeout:         tc.downTimeout,
+				},
+			}
+			c, err := d.prepareExistingCluster("p1", cluster{name: "kt2-abc"}, "--zone=us-central1-c")
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error but got none")
+				}
+			} else if err != nil {
+				t.Errorf("did not expect an error, but got: %v", err)
+			}
+			if adopted := c != nil; adopted != tc.expectAdopted {
+				t.Errorf("expected adopted %v but got %v", tc.expectAdopted, adopted)
+			}
+			if commands := cmder.Commands(); !reflect.DeepEqual(commands, tc.expectedCommands) {
+				t.Errorf("expected commands %q but got %q", tc.expectedCommands, commands)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/artifacts"
+)
+
+const (
+	exportFormatKRM       = "krm"
+	exportFormatTerraform = "terraform"
+
+	// exportDir is the directory of the exports in the artifacts
+	exportDir = "infra-export"
+)
+
+// exportResourceTypes are the Config Connector kinds of the infrastructure
+// created by up
+var exportResourceTypes = []string{
+	"ContainerCluster",
+	"ContainerNodePool",
+	"ComputeNetwork",
+	"ComputeSubnetwork",
+	"ComputeFirewall",
+}
+
+func (d *Deployer) validateExportFlags() error {
+	switch d.ExportInfra {
+	case "", exportFormatKRM, exportFormatTerraform:
+		return nil
+	default:
+		return fmt.Errorf("--export-infra must be %q or %q, got %q", exportFormatKRM, exportFormatTerraform, d.ExportInfra)
+	}
+}
+
+// exportArgs returns the gcloud args to export the infrastructure of the
+// project in format into dir
+func exportArgs(project, format, dir string) []string {
+	return []string{
+		"beta", "resource-config", "bulk-export",
+		"--project=" + project,
+		"--resource-types=" + strings.Join(exportResourceTypes, ","),
+		"--resource-format=" + format,
+		"--path=" + dir,
+		"--quiet",
+	}
+}
+
+// exportNames returns the names of the clusters and of the network of the
+// project, which the exported files of the infrastructure of the run refer
+// to. The default network is shared with other runs and not exported.
+func (d *Deployer) exportNames(project string) []string {
+	var names []string
+	for _, cluster := range d.projectClustersLayout[project] {
+		names = append(names, cluster.name)
+	}
+	if d.Network != "default" {
+		names = append(names, d.Network)
+	}
+	return names
+}
+
+// filterExport deletes the exported files in dir which don't refer to any of
+// names, bulk-export exports every resource of the types in the project,
+// including the ones of other runs sharing it.
+func filterExport(dir string, names []string) error {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = regexp.QuoteMeta(name)
+	}
+	// the names are only matched as a whole, kt2-abc-1 must not match the
+	// files of kt2-abc-10
+	nameRe := regexp.MustCompile(`(^|[^a-z0-9-])(` + strings.Join(quoted, "|") + `)($|[^a-z0-9-])`)
+	return filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if len(names) > 0 && nameRe.Match(content) {
+			return nil
+		}
+		return os.Remove(path)
+	})
+}
+
+// maybeExportInfrastructure exports the infrastructure of the projects into
+// the artifacts if --export-infra is set. Failing to export doesn't fail up,
+// the clusters are usable either way.
+func (d *Deployer) maybeExportInfrastructure() {
+	if d.ExportInfra == "" {
+		return
+	}
+	if err := d.stepRunner.Run("ExportInfrastructure", d.exportInfrastructure); err != nil {
+		klog.Warningf("Failed to export the infrastructure: %v", err)
+	}
+}
+
+// exportInfrastructure exports the clusters, nodepools, networks, subnets and
+// firewall rules of the run in each project as --export-infra into
+// infra-export/<project> in the artifacts.
+func (d *Deployer) exportInfrastructure() error {
+	var errs []error
+	for _, project := range d.Projects {
+		dir := filepath.Join(artifacts.BaseDir(), exportDir, project)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		klog.V(1).Infof("Exporting the infrastructure of project %s as %s to %s", project, d.ExportInfra, dir)
+		if err := runWithOutput(d.cmder.Command("gcloud", exportArgs(project, d.ExportInfra, dir)...)); err != nil {
+			errs = append(errs, fmt.Errorf("error exporting the infrastructure of project %s: %w", project, err))
+			continue
+		}
+		if err := filterExport(dir, d.exportNames(project)); err != nil {
+			errs = append(errs, fmt.Errorf("error filtering the infrastructure of project %s: %w", project, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gke/deployer/options"
+)
+
+func TestValidateExportFlags(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		opts  options.ClusterOptions
+		valid bool
+	}{
+		{
+			desc:  "no export",
+			valid: true,
+		},
+		{
+			desc:  "config connector export",
+			opts:  options.ClusterOptions{ExportInfra: exportFormatKRM},
+			valid: true,
+		},
+		{
+			desc:  "terraform export",
+			opts:  options.ClusterOptions{ExportInfra: exportFormatTerraform},
+			valid: true,
+		},
+		{
+			desc:  "unknown format",
+			opts:  options.ClusterOptions{ExportInfra: "yaml"},
+			valid: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			d := &Deployer{ClusterOptions: &tc.opts}
+			err := d.validateExportFlags()
+			if tc.valid && err != nil {
+				t.Errorf("expected no error but got %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Error("expected an error but got none")
+			}
+		})
+	}
+}
+
+func TestExportArgs(t *testing.T) {
+	expected := []string{
+		"beta", "resource-config", "bulk-export",
+		"--project=p",
+		"--resource-types=ContainerCluster,ContainerNodePool,ComputeNetwork,ComputeSubnetwork,ComputeFirewall",
+		"--resource-format=terraform",
+		"--path=/artifacts/infra-export/p",
+		"--quiet",
+	}
+	if actual := exportArgs("p", exportFormatTerraform, "/artifacts/infra-export/p"); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected args %v but got %v", expected, actual)
+	}
+}
+
+func TestFilterExport(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"ContainerCluster/kt2-abc-1.yaml":    "metadata:\n  name: kt2-abc-1\n",
+		"ContainerCluster/kt2-abc-10.yaml":   "metadata:\n  name: kt2-abc-10\n",
+		"ContainerNodePool/pool.yaml":        "spec:\n  clusterRef:\n    name: kt2-abc-1\n",
+		"ComputeFirewall/e2e-ports.yaml":     "spec:\n  networkRef:\n    name: kt2-net\n",
+		"ComputeFirewall/other-network.yaml": "spec:\n  networkRef:\n    name: kt2-net-other\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := filterExport(dir, []string{"kt2-abc-1", "kt2-net"}); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+
+	var actual []string
+	for name := range files {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			actual = append(actual, name)
+		}
+	}
+	sort.Strings(actual)
+	expected := []string{
+		"ComputeFirewall/e2e-ports.yaml",
+		"ContainerCluster/kt2-abc-1.yaml",
+		"ContainerNodePool/pool.yaml",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected the files %v to be kept but got %v", expected, actual)
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/gcp"
 )
 
 func (d *Deployer) EnsureFirewallRules() error {
@@ -47,7 +48,7 @@ func (d *Deployer) ensureFirewallRulesForSingleProject() error {
 		clusterName := cluster.name
 		klog.V(1).Infof("Ensuring firewall rules for cluster %s in %s", clusterName, project)
 		firewall := clusterFirewallName(project, clusterName, d.instanceGroups)
-		if runWithNoOutput(exec.Command("gcloud", "compute", "firewall-rules", "describe", firewall,
+		if runWithNoOutput(d.cmder.Command("gcloud", "compute", "firewall-rules", "describe", firewall,
 			"--project="+project,
 			"--format=value(name)")) == nil {
 			// Assume that if this unique firewall exists, it's good to go.
@@ -61,8 +62,16 @@ func (d *Deployer) ensureFirewallRulesForSingleProject() error {
 			"--network=" + d.Network,
 			"--allow=" + d.FirewallRuleAllow,
 		}
-		if !d.Autopilot {
-			tagOut, err := exec.Output(exec.Command("gcloud", "compute", "instances", "list",
+		if len(d.FirewallSourceServiceAccounts) > 0 {
+			targets, err := d.nodeServiceAccounts(project)
+			if err != nil {
+				return err
+			}
+			firewallRulesCreateCmd = append(firewallRulesCreateCmd, firewallSelectorArgs(nil, d.FirewallSourceServiceAccounts, targets)...)
+		} else if len(d.NodeTags) > 0 {
+			firewallRulesCreateCmd = append(firewallRulesCreateCmd, firewallSelectorArgs(d.NodeTags, nil, nil)...)
+		} else if !d.Autopilot {
+			tagOut, err := exec.Output(d.cmder.Command("gcloud", "compute", "instances", "list",
 				"--project="+project,
 				"--filter=metadata.created-by:"+d.instanceGroups[project][clusterName][0].path,
 				"--limit=1",
@@ -77,13 +86,68 @@ func (d *Deployer) ensureFirewallRulesForSingleProject() error {
 			firewallRulesCreateCmd = append(firewallRulesCreateCmd, "--target-tags="+tag)
 		}
 
-		if err := runWithOutput(exec.Command(firewallRulesCreateCmd[0], firewallRulesCreateCmd[1:]...)); err != nil {
+		if err := runWithOutput(d.cmder.Command(firewallRulesCreateCmd[0], firewallRulesCreateCmd[1:]...)); err != nil {
 			return fmt.Errorf("error creating firewall rule: %v", err)
 		}
 	}
 	return nil
 }
 
+// firewallSelectorArgs returns the gcloud args selecting the sources and
+// targets of an e2e firewall rule. A rule can't mix network tags and
+// service accounts, so source service accounts go with target service
+// accounts and tags with tags.
+func firewallSelectorArgs(targetTags, sourceServiceAccounts, targetServiceAccounts []string) []string {
+	if len(sourceServiceAccounts) > 0 {
+		return []string{
+			"--source-service-accounts=" + strings.Join(sourceServiceAccounts, ","),
+			"--target-service-accounts=" + strings.Join(targetServiceAccounts, ","),
+		}
+	}
+	if len(targetTags) > 0 {
+		return []string{"--target-tags=" + strings.Join(targetTags, ",")}
+	}
+	return nil
+}
+
+// nodeServiceAccounts returns the service accounts the nodes of the
+// clusters in the project run as, including the ones of extra nodepools.
+func (d *Deployer) nodeServiceAccounts(project string) ([]string, error) {
+	sa := d.nodeServiceAccount(project)
+	if sa == "" {
+		number, err := gcp.ProjectNumber(project)
+		if err != nil {
+			return nil, err
+		}
+		sa = defaultComputeServiceAccount(number)
+	}
+	accounts := []string{sa}
+	seen := map[string]bool{sa: true}
+	for _, enp := range d.extraNodePoolSpecs {
+		if enp.ServiceAccount != "" && !seen[enp.ServiceAccount] {
+			seen[enp.ServiceAccount] = true
+			accounts = append(accounts, enp.ServiceAccount)
+		}
+	}
+	return accounts, nil
+}
+
+// defaultComputeServiceAccount returns the Compute Engine default service
+// account of the project with the given number, which nodes run as unless
+// told otherwise.
+func defaultComputeServiceAccount(projectNumber string) string {
+	return projectNumber + "-compute@developer.gserviceaccount.com"
+}
+
+// nodeTagArgs returns the gcloud args to create nodes with the given
+// network tags.
+func nodeTagArgs(tags []string) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+	return []string{"--tags=" + strings.Join(tags, ",")}
+}
+
 func clusterFirewallName(project, cluster string, instanceGroups map[string]map[string][]*ig) string {
 	// We want to ensure that there's an e2e-ports-* firewall rule
 	// that maps to the cluster nodes, but the target tag for the
@@ -97,20 +161,20 @@ func clusterFirewallName(project, cluster string, instanceGroups map[string]map[
 // Please note we are not including the firewall rule for SSH connection as it's not needed for testing.
 func (d *Deployer) ensureFirewallRulesForMultiProjects() error {
 	hostProject := d.Projects[0]
-	hostProjectNumber, err := getProjectNumber(hostProject)
+	hostProjectNumber, err := gcp.ProjectNumber(hostProject)
 	if err != nil {
 		return fmt.Errorf("error looking up project number for id %q: %w", hostProject, err)
 	}
 	for i := 1; i < len(d.Projects); i++ {
 		curtProject := d.Projects[i]
-		curtProjectNumber, err := getProjectNumber(curtProject)
+		curtProjectNumber, err := gcp.ProjectNumber(curtProject)
 		if err != nil {
 			return fmt.Errorf("error looking up project number for id %q: %w", curtProject, err)
 		}
 		firewall := fmt.Sprintf("rule-%s-%s", hostProjectNumber, curtProjectNumber)
 		// sourceRanges need to be separated with ",", while the provided subnetworkRanges are separated with space.
 		sourceRanges := strings.ReplaceAll(d.SubnetworkRanges[i-1], " ", ",")
-		if err := runWithOutput(exec.Command("gcloud", "compute", "firewall-rules", "create", firewall,
+		if err := runWithOutput(d.cmder.Command("gcloud", "compute", "firewall-rules", "create", firewall,
 			"--project="+hostProject,
 			"--network="+d.Network,
 			"--allow="+d.FirewallRuleAllow,
@@ -130,7 +194,7 @@ func (d *Deployer) CleanupNetworkFirewalls(hostProject, network string) (int, er
 	}
 
 	klog.V(1).Infof("Cleaning up network firewall rules for network %s in %s", network, hostProject)
-	fws, err := exec.Output(exec.Command("gcloud", "compute", "firewall-rules", "list",
+	fws, err := exec.Output(d.cmder.Command("gcloud", "compute", "firewall-rules", "list",
 		"--format=value(name)",
 		"--project="+hostProject,
 		"--filter=network:"+network))
@@ -143,7 +207,7 @@ func (d *Deployer) CleanupNetworkFirewalls(hostProject, network string) (int, er
 		commandArgs := []string{"compute", "firewall-rules", "delete", "-q"}
 		commandArgs = append(commandArgs, fwList...)
 		commandArgs = append(commandArgs, "--project="+hostProject)
-		errFirewall := runWithOutput(exec.Command("gcloud", commandArgs...))
+		errFirewall := runWithOutput(d.cmder.Command("gcloud", commandArgs...))
 		if errFirewall != nil {
 			return 0, fmt.Errorf("error deleting firewall: %v", errFirewall)
 		}
@@ -172,7 +236,7 @@ func (d *Deployer) GetInstanceGroups() error {
 		for _, cluster := range d.projectClustersLayout[project] {
 			clusterName := cluster.name
 
-			igs, err := exec.Output(exec.Command("gcloud", containerArgs("clusters", "describe", clusterName,
+			igs, err := exec.Output(d.cmder.Command("gcloud", containerArgs("clusters", "describe", clusterName,
 				"--format=value(instanceGroupUrls)",
 				"--project="+project,
 				location)...))
@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gke/deployer/options"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+func TestFirewallSelectorArgs(t *testing.T) {
+	testCases := []struct {
+		desc                  string
+		targetTags            []string
+		sourceServiceAccounts []string
+		targetServiceAccounts []string
+		expected              []string
+	}{
+		{
+			desc: "no selectors",
+		},
+		{
+			desc:       "target tags",
+			targetTags: []string{"e2e", "gke-node"},
+			expected:   []string{"--target-tags=e2e,gke-node"},
+		},
+		{
+			desc:                  "source service accounts target the node service accounts",
+			sourceServiceAccounts: []string{"prober@p.iam.gserviceaccount.com"},
+			targetServiceAccounts: []string{"123-compute@developer.gserviceaccount.com", "gpu@p.iam.gserviceaccount.com"},
+			expected: []string{
+				"--source-service-accounts=prober@p.iam.gserviceaccount.com",
+				"--target-service-accounts=123-compute@developer.gserviceaccount.com,gpu@p.iam.gserviceaccount.com",
+			},
+		},
+		{
+			desc:                  "source service accounts take precedence over tags",
+			targetTags:            []string{"e2e"},
+			sourceServiceAccounts: []string{"prober@p.iam.gserviceaccount.com"},
+			targetServiceAccounts: []string{"nodes@p.iam.gserviceaccount.com"},
+			expected: []string{
+				"--source-service-accounts=prober@p.iam.gserviceaccount.com",
+				"--target-service-accounts=nodes@p.iam.gserviceaccount.com",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := firewallSelectorArgs(tc.targetTags, tc.sourceServiceAccounts, tc.targetServiceAccounts)
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf("unexpected args (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNodeTagArgs(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		tags     []string
+		expected []string
+	}{
+		{
+			desc: "no tags",
+		},
+		{
+			desc:     "tags are joined",
+			tags:     []string{"e2e", "gke-node"},
+			expected: []string{"--tags=e2e,gke-node"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, nodeTagArgs(tc.tags)); diff != "" {
+				t.Errorf("unexpected args (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEnsureFirewallRulesForSingleProject(t *testing.T) {
+	describe := "gcloud compute firewall-rules describe e2e-ports-abc --project=p --format=value(name)"
+	create := "gcloud compute firewall-rules create e2e-ports-abc --project=p --network=net --allow=tcp"
+	testCases := []struct {
+		desc                          string
+		nodeTags                      []string
+		firewallSourceServiceAccounts []string
+		expected                      string
+	}{
+		{
+			desc:     "node tags",
+			nodeTags: []string{"e2e"},
+			expected: create + " --target-tags=e2e",
+		},
+		{
+			desc:                          "source service accounts",
+			firewallSourceServiceAccounts: []string{"prober@p.iam.gserviceaccount.com"},
+			expected:                      create + " --source-service-accounts=prober@p.iam.gserviceaccount.com --target-service-accounts=nodes@p.iam.gserviceaccount.com",
+		},
+		{
+			desc:                          "node tags with source service accounts",
+			nodeTags:                      []string{"e2e"},
+			firewallSourceServiceAccounts: []string{"prober@p.iam.gserviceaccount.com"},
+			expected:                      create + " --source-service-accounts=prober@p.iam.gserviceaccount.com --target-service-accounts=nodes@p.iam.gserviceaccount.com",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cmder := &exec.FakeCmder{Responses: []exec.FakeResponse{{Prefix: describe, Err: errors.New("not found")}}}
+			d := &Deployer{
+				cmder:                 cmder,
+				projectClustersLayout: map[string][]cluster{"p": {{index: 0, name: "c1"}}},
+				instanceGroups:        map[string]map[string][]*ig{"p": {"c1": {{uniq: "abc"}}}},
+				ProjectOptions:        &options.ProjectOptions{Projects: []string{"p"}},
+				NetworkOptions:        &options.NetworkOptions{Network: "net"},
+				ClusterOptions: &options.ClusterOptions{
+					FirewallRuleAllow:             "tcp",
+					NodeServiceAccount:            "nodes@p.iam.gserviceaccount.com",
+					NodeTags:                      tc.nodeTags,
+					FirewallSourceServiceAccounts: tc.firewallSourceServiceAccounts,
+				},
+			}
+			if err := d.ensureFirewallRulesForSingleProject(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if diff := cmp.Diff([]string{describe, tc.expected}, cmder.Commands()); diff != "" {
+				t.Errorf("unexpected commands (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	kfs "sigs.k8s.io/kubetest2/pkg/fs"
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+// gcloudConfigEnv is the environment variable gcloud reads its configuration
+// directory from
+const gcloudConfigEnv = "CLOUDSDK_CONFIG"
+
+// gcloudConfigSkipDirs are the directories of the gcloud configuration not
+// worth copying
+var gcloudConfigSkipDirs = map[string]bool{
+	"logs": true,
+}
+
+// assert that the isolated gcloud configuration is removed at the end of the run
+var _ types.DeployerWithFinish = &Deployer{}
+
+// isolateGcloudConfig points gcloud, for the deployer and the processes it
+// starts, to a copy of the current gcloud configuration in a temporary
+// directory, so that setting the project of the run doesn't change the
+// user's active project, nor the project of other runs on the same machine.
+// The copy holds the user's credentials, so it is kept out of the run dir,
+// which may be uploaded with the artifacts, and removed by Finish.
+func (d *Deployer) isolateGcloudConfig() error {
+	if !d.IsolateGcloudConfig || d.gcloudConfigDir != "" {
+		return nil
+	}
+	src, err := d.currentGcloudConfigDir()
+	if err != nil {
+		return err
+	}
+	dst, err := os.MkdirTemp("", "kubetest2-gcloud-config-")
+	if err != nil {
+		return err
+	}
+	d.gcloudConfigDir = dst
+	klog.V(1).Infof("Copying the gcloud configuration from %s to %s", src, dst)
+	if err := copyGcloudConfig(src, dst); err != nil {
+		return fmt.Errorf("failed to copy the gcloud configuration: %w", err)
+	}
+	return os.Setenv(gcloudConfigEnv, dst)
+}
+
+// currentGcloudConfigDir returns the configuration directory gcloud uses,
+// as reported by gcloud itself since it differs between platforms.
+func (d *Deployer) currentGcloudConfigDir() (string, error) {
+	if dir := os.Getenv(gcloudConfigEnv); dir != "" {
+		return dir, nil
+	}
+	out, err := exec.Output(d.cmder.Command("gcloud", "info", "--format=value(config.paths.global_config_dir)"))
+	if err != nil {
+		return "", fmt.Errorf("failed to find the gcloud configuration: %w", err)
+	}
+	dir := strings.TrimSpace(string(out))
+	if dir == "" {
+		return "", fmt.Errorf("failed to find the gcloud configuration: gcloud info returned no config dir")
+	}
+	return dir, nil
+}
+
+// Finish removes the gcloud configuration isolated for the run, if any.
+func (d *Deployer) Finish() error {
+	if d.gcloudConfigDir == "" {
+		return nil
+	}
+	if os.Getenv(gcloudConfigEnv) == d.gcloudConfigDir {
+		os.Unsetenv(gcloudConfigEnv)
+	}
+	if err := os.RemoveAll(d.gcloudConfigDir); err != nil {
+		return fmt.Errorf("failed to remove the isolated gcloud configuration: %w", err)
+	}
+	d.gcloudConfigDir = ""
+	return nil
+}
+
+// copyGcloudConfig copies the gcloud configuration in src to dst, which is
+// created even if src doesn't exist, e.g. when the credentials come from a
+// service account activated later.
+func copyGcloudConfig(src, dst string) error {
+	if err := os.MkdirAll(dst, 0700); err != nil {
+		return err
+	}
+	if _, err := os.Stat(src); os.IsNotExist(err) {
+		return nil
+	}
+	return filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if gcloudConfigSkipDirs[rel] {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(filepath.Join(dst, rel), 0700)
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		return kfs.CopyFile(path, filepath.Join(dst, rel))
+	})
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gke/deployer/options"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+func TestCopyGcloudConfig(t *testing.T) {
+	src := t.TempDir()
+	files := map[string]string{
+		"active_config":                  "default",
+		"configurations/config_default":  "[core]\nproject = user-project\n",
+		"credentials.db":                 "creds",
+		"logs/2024.01.01/00.00.00.0.log": "log",
+	}
+	for name, content := range files {
+		path := filepath.Join(src, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dst := filepath.Join(t.TempDir(), "gcloud-config")
+	if err := copyGcloudConfig(src, dst); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for name, content := range files {
+		data, err := os.ReadFile(filepath.Join(dst, name))
+		if filepath.Dir(filepath.Dir(name)) == "logs" {
+			if !os.IsNotExist(err) {
+				t.Errorf("expected %s not to be copied", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("expected %s to be copied: %v", name, err)
+		} else if string(data) != content {
+			t.Errorf("expected %s to contain %q but got %q", name, content, data)
+		}
+	}
+}
+
+func TestCopyGcloudConfigMissingSource(t *testing.T) {
+	dst := filepath.Join(t.TempDir(), "gcloud-config")
+	if err := copyGcloudConfig(filepath.Join(t.TempDir(), "missing"), dst); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info, err := os.Stat(dst); err != nil || !info.IsDir() {
+		t.Errorf("expected %s to be created: %v", dst, err)
+	}
+}
+
+func TestCurrentGcloudConfigDir(t *testing.T) {
+	t.Setenv(gcloudConfigEnv, "")
+	cmder := &exec.FakeCmder{Responses: []exec.FakeResponse{
+		{Prefix: "gcloud info", Stdout: "/Users/me/.config/gcloud\n"},
+	}}
+	d := &Deployer{cmder: cmder}
+	dir, err := d.currentGcloudConfigDir()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dir != "/Users/me/.config/gcloud" {
+		t.Errorf("expected the config dir reported by gcloud but got %q", dir)
+	}
+
+	t.Setenv(gcloudConfigEnv, "/custom/gcloud")
+	dir, err = d.currentGcloudConfigDir()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dir != "/custom/gcloud" {
+		t.Errorf("expected the config dir of %s but got %q", gcloudConfigEnv, dir)
+	}
+	if len(cmder.Commands()) != 1 {
+		t.Errorf("expected gcloud to be asked only without %s, got %v", gcloudConfigEnv, cmder.Commands())
+	}
+}
+
+func TestIsolateGcloudConfig(t *testing.T) {
+	src := t.TempDir()
+	if err := os.WriteFile(filepath.Join(src, "credentials.db"), []byte("creds"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(gcloudConfigEnv, src)
+
+	// the isolation is opt-in
+	d := &Deployer{CommonOptions: &options.CommonOptions{}}
+	if err := d.isolateGcloudConfig(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.gcloudConfigDir != "" || os.Getenv(gcloudConfigEnv) != src {
+		t.Errorf("expected the gcloud configuration not to be isolated by default")
+	}
+
+	d.IsolateGcloudConfig = true
+	if err := d.isolateGcloudConfig(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dst := d.gcloudConfigDir
+	if dst == "" || os.Getenv(gcloudConfigEnv) != dst {
+		t.Fatalf("expected %s to point to the isolated configuration but got %q", gcloudConfigEnv, os.Getenv(gcloudConfigEnv))
+	}
+	if !strings.HasPrefix(dst, os.TempDir()) {
+		t.Errorf("expected the isolated configuration in a temporary dir but got %s", dst)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "credentials.db")); err != nil {
+		t.Errorf("expected the credentials to be copied: %v", err)
+	}
+
+	if err := d.Finish(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(dst); !os.IsNotExist(err) {
+		t.Errorf("expected %s to be removed at the end of the run, got %v", dst, err)
+	}
+	if os.Getenv(gcloudConfigEnv) == dst {
+		t.Errorf("expected %s not to point to the removed configuration", gcloudConfigEnv)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/artifacts"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/metadata"
+)
+
+// defaultMinGcloudVersion is the oldest Cloud SDK supporting all the gcloud
+// flags the deployer uses
+const defaultMinGcloudVersion = "400.0.0"
+
+// checkGcloudVersion records the version of the Cloud SDK in the metadata,
+// and fails if it is older than --min-gcloud-version, instead of gcloud
+// failing on unrecognized arguments in the middle of Up.
+func (d *Deployer) checkGcloudVersion() error {
+	out, err := exec.Output(d.cmder.Command("gcloud", "version", "--format=json"))
+	if err != nil {
+		return fmt.Errorf("failed to get the gcloud version: %w", err)
+	}
+	version, err := parseGcloudVersion(out)
+	if err != nil {
+		return err
+	}
+	klog.V(1).Infof("Using Google Cloud SDK %s", version)
+	if err := metadata.AddToFile(filepath.Join(artifacts.BaseDir(), "metadata.json"),
+		map[string]string{"gcloud-version": version}); err != nil {
+		klog.Warningf("failed to record the gcloud version in the metadata: %v", err)
+	}
+	return verifyGcloudVersion(version, d.MinGcloudVersion)
+}
+
+// parseGcloudVersion returns the Cloud SDK version in the output of
+// `gcloud version --format=json`
+func parseGcloudVersion(out []byte) (string, error) {
+	components := map[string]string{}
+	if err := json.Unmarshal(out, &components); err != nil {
+		return "", fmt.Errorf("failed to parse the gcloud version: %w", err)
+	}
+	version, ok := components["Google Cloud SDK"]
+	if !ok {
+		return "", fmt.Errorf("no Google Cloud SDK version in the gcloud version %s", out)
+	}
+	return version, nil
+}
+
+// verifyGcloudVersion returns an error if version is older than minimum, an
+// empty minimum skips the check
+func verifyGcloudVersion(version, minimum string) error {
+	if minimum == "" {
+		return nil
+	}
+	minVersion, err := semver.ParseTolerant(minimum)
+	if err != nil {
+		return fmt.Errorf("failed to parse --min-gcloud-version: %w", err)
+	}
+	v, err := semver.ParseTolerant(version)
+	if err != nil {
+		return fmt.Errorf("failed to parse the gcloud version %q: %w", version, err)
+	}
+	if v.LT(minVersion) {
+		return fmt.Errorf("gcloud version %s is older than --min-gcloud-version %s, update the Google Cloud SDK with `gcloud components update`", version, minimum)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"testing"
+)
+
+func TestParseGcloudVersion(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		out             string
+		expectedVersion string
+		expectError     bool
+	}{
+		{
+			desc:            "sdk and components",
+			out:             `{"Google Cloud SDK": "470.0.0", "beta": "2024.03.29", "bq": "2.1.3", "core": "2024.03.29"}`,
+			expectedVersion: "470.0.0",
+		},
+		{
+			desc:        "no sdk version",
+			out:         `{"core": "2024.03.29"}`,
+			expectError: true,
+		},
+		{
+			desc:        "not json",
+			out:         "Google Cloud SDK 470.0.0",
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			version, err := parseGcloudVersion([]byte(tc.out))
+			if tc.expectError != (err != nil) {
+				t.Fatalf("expected error %v but got %v", tc.expectError, err)
+			}
+			if version != tc.expectedVersion {
+				t.Errorf("expected version %q but got %q", tc.expectedVersion, version)
+			}
+		})
+	}
+}
+
+func TestVerifyGcloudVersion(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		version     string
+		min         string
+		expectError bool
+	}{
+		{
+			desc:    "newer",
+			version: "470.0.0",
+			min:     "400.0.0",
+		},
+		{
+			desc:    "equal",
+			version: "400.0.0",
+			min:     "400",
+		},
+		{
+			desc:        "older",
+			version:     "399.0.0",
+			min:         "400.0.0",
+			expectError: true,
+		},
+		{
+			desc:    "no minimum",
+			version: "300.0.0",
+		},
+		{
+			desc:        "invalid minimum",
+			version:     "470.0.0",
+			min:         "latest",
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := verifyGcloudVersion(tc.version, tc.min)
+			if tc.expectError != (err != nil) {
+				t.Errorf("expected error %v but got %v", tc.expectError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	nvidiaDriverInstallerCOS    = "https://raw.githubusercontent.com/GoogleCloudPlatform/container-engine-accelerators/master/nvidia-driver-installer/cos/daemonset-preloaded.yaml"
+	nvidiaDriverInstallerUbuntu = "https://raw.githubusercontent.com/GoogleCloudPlatform/container-engine-accelerators/master/nvidia-driver-installer/ubuntu/daemonset-preloaded.yaml"
+)
+
+// acceleratorArgs returns the gcloud node-pools create args for the
+// accelerators of the nodepool
+func (enp *extraNodepool) acceleratorArgs() []string {
+	var args []string
+	if enp.Accelerator != "" {
+		args = append(args, "--accelerator="+enp.Accelerator)
+	}
+	if enp.TPUTopology != "" {
+		args = append(args, "--tpu-topology="+enp.TPUTopology)
+	}
+	return args
+}
+
+// acceleratorProperty returns the value of key in an accelerator spec of
+// the form type=nvidia-tesla-t4,count=1
+func acceleratorProperty(accelerator, key string) (string, bool) {
+	for _, property := range strings.Split(accelerator, ",") {
+		k, v, found := strings.Cut(property, "=")
+		if found && k == key {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// nvidiaDriverInstallers returns the driver installer manifests needed by
+// the GPU nodepools, GKE installs the driver itself when gpu-driver-version
+// is set on the accelerator.
+func (d *Deployer) nvidiaDriverInstallers() []string {
+	installers := map[string]bool{}
+	for _, enp := range d.extraNodePoolSpecs {
+		if enp.Accelerator == "" {
+			continue
+		}
+		if _, ok := acceleratorProperty(enp.Accelerator, "gpu-driver-version"); ok {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(enp.ImageType), "ubuntu") {
+			installers[nvidiaDriverInstallerUbuntu] = true
+		} else {
+			installers[nvidiaDriverInstallerCOS] = true
+		}
+	}
+
+	var manifests []string
+	for _, manifest := range []string{nvidiaDriverInstallerCOS, nvidiaDriverInstallerUbuntu} {
+		if installers[manifest] {
+			manifests = append(manifests, manifest)
+		}
+	}
+	return manifests
+}
+
+// installNvidiaDrivers applies the NVIDIA driver installer daemonsets to all
+// the clusters, GPUs are not allocatable until the driver is installed.
+func (d *Deployer) installNvidiaDrivers() error {
+	manifests := d.nvidiaDriverInstallers()
+	if len(manifests) == 0 {
+		return nil
+	}
+
+	kubeconfigs, err := d.Kubeconfig()
+	if err != nil {
+		return err
+	}
+	for _, kubeconfig := range filepath.SplitList(kubeconfigs) {
+		for _, manifest := range manifests {
+			klog.V(1).Infof("Installing NVIDIA driver from %s with kubeconfig %s", manifest, kubeconfig)
+			if err := runWithOutput(d.cmder.Command("kubectl", "--kubeconfig="+kubeconfig, "apply", "-f", manifest)); err != nil {
+				return fmt.Errorf("failed to apply %s: %w", manifest, err)
+			}
+		}
+	}
+	return nil
+}
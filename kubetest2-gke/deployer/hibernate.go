@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"google.golang.org/api/container/v1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// downActionDelete deletes the clusters at down
+	downActionDelete = "delete"
+	// downActionScaleToZero resizes all the nodepools of the clusters to zero
+	// nodes at down, the next up with --skip-cluster-create resizes them back
+	downActionScaleToZero = "scale-to-zero"
+)
+
+// verifyDownAction validates --down-action
+func (d *Deployer) verifyDownAction() error {
+	switch d.DownAction {
+	case downActionDelete:
+		return nil
+	case downActionScaleToZero:
+	default:
+		return fmt.Errorf("--down-action must be %q or %q, got %q", downActionDelete, downActionScaleToZero, d.DownAction)
+	}
+	if d.Autopilot {
+		return fmt.Errorf("--down-action=%s is not supported with --autopilot", downActionScaleToZero)
+	}
+	if d.totalBoskosProjectsRequested > 0 {
+		return fmt.Errorf("--down-action=%s requires --project, boskos projects are released at down", downActionScaleToZero)
+	}
+	// the clusters keep using these after down, so they can't be deleted
+	if d.CreateNodeServiceAccount {
+		return fmt.Errorf("--create-node-service-account cannot be used with --down-action=%s", downActionScaleToZero)
+	}
+	if d.CaptureNotifications {
+		return fmt.Errorf("--capture-notifications cannot be used with --down-action=%s", downActionScaleToZero)
+	}
+	// the cluster autoscaler scales the nodepools back up to their minimum
+	for _, np := range d.ExtraNodePool {
+		enp := &extraNodepool{}
+		// invalid specs are reported by the validation of --extra-nodepool,
+		// max-nodes is parsed either way
+		_ = buildExtraNodePoolOptions(np, enp)
+		if enp.MaxNodes > 0 {
+			return fmt.Errorf("--down-action=%s is not supported with the autoscaled extra nodepool %q", downActionScaleToZero, np)
+		}
+	}
+	return nil
+}
+
+// ScaleClustersToZero resizes all the nodepools of all the clusters to zero
+// nodes concurrently, and returns the aggregated errors of the clusters that
+// failed to be scaled.
+func (d *Deployer) ScaleClustersToZero(retryCount int) error {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+	for i := range d.Projects {
+		project := d.Projects[i]
+		for j := range d.projectClustersLayout[project] {
+			cluster := d.projectClustersLayout[project][j]
+			loc := locationFlag(d.Regions, d.Zones, retryCount)
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := d.scaleClusterToZero(project, loc, cluster); err != nil {
+					klog.Errorf("Error scaling cluster to zero: %v", err)
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+				}
+			}()
+		}
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (d *Deployer) scaleClusterToZero(project, loc string, cluster cluster) error {
+	c, err := d.describeCluster(project, cluster.name, loc)
+	if err != nil {
+		return err
+	}
+	// e.g. the existing clusters of --skip-cluster-create, whose autoscaled
+	// nodepools would be scaled back up by the cluster autoscaler
+	for _, np := range c.NodePools {
+		if np.Autoscaling != nil && np.Autoscaling.Enabled {
+			return fmt.Errorf("cannot scale cluster %q in project %q to zero, nodepool %q is autoscaled", cluster.name, project, np.Name)
+		}
+	}
+	for _, np := range c.NodePools {
+		klog.V(1).Infof("Scaling nodepool %q of cluster %q in project %q to zero", np.Name, cluster.name, project)
+		if err := runWithOutput(d.cmder.Command("gcloud", resizeArgs(project, loc, cluster.name, np.Name, 0)...)); err != nil {
+			return fmt.Errorf("error scaling nodepool %q of cluster %q in project %q to zero: %w", np.Name, cluster.name, project, err)
+		}
+	}
+	return nil
+}
+
+// resumeCluster resizes the nodepools of a cluster scaled to zero by
+// --down-action=scale-to-zero back to their initial size, and returns the
+// cluster as described after. Clusters with nodes are returned as is.
+func (d *Deployer) resumeCluster(project, loc string, c *container.Cluster) (*container.Cluster, error) {
+	if c.CurrentNodeCount > 0 || (c.Autopilot != nil && c.Autopilot.Enabled) {
+		return c, nil
+	}
+	for _, np := range c.NodePools {
+		if np.InitialNodeCount <= 0 {
+			continue
+		}
+		klog.V(1).Infof("Resizing nodepool %q of cluster %q in project %q back to %d nodes", np.Name, c.Name, project, np.InitialNodeCount)
+		if err := runWithOutput(d.cmder.Command("gcloud", resizeArgs(project, loc, c.Name, np.Name, np.InitialNodeCount)...)); err != nil {
+			return nil, fmt.Errorf("error resizing nodepool %q of cluster %q in project %q: %w", np.Name, c.Name, project, err)
+		}
+	}
+	return d.describeCluster(project, c.Name, loc)
+}
+
+// resizeArgs returns the gcloud args resizing a nodepool, numNodes is per
+// zone for regional clusters like the initial node count
+func resizeArgs(project, loc, clusterName, nodePool string, numNodes int64) []string {
+	return containerArgs("clusters", "resize", clusterName,
+		"--project="+project,
+		loc,
+		"--node-pool="+nodePool,
+		fmt.Sprintf("--num-nodes=%d", numNodes),
+		"--quiet")
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gke/deployer/options"
+)
+
+func TestVerifyDownAction(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		downAction     string
+		autopilot      bool
+		boskosProjects int
+		createNodeSA   bool
+		extraNodePool  []string
+		expectError    bool
+	}{
+		{
+			desc:       "delete",
+			downAction: downActionDelete,
+		},
+		{
+			desc:        "unknown action",
+			downAction:  "stop",
+			expectError: true,
+		},
+		{
+			desc:       "scale to zero",
+			downAction: downActionScaleToZero,
+		},
+		{
+			desc:        "scale to zero with autopilot",
+			downAction:  downActionScaleToZero,
+			autopilot:   true,
+			expectError: true,
+		},
+		{
+			desc:           "scale to zero with boskos projects",
+			downAction:     downActionScaleToZero,
+			boskosProjects: 1,
+			expectError:    true,
+		},
+		{
+			desc:         "scale to zero with a node service account of the run",
+			downAction:   downActionScaleToZero,
+			createNodeSA: true,
+			expectError:  true,
+		},
+		{
+			desc:         "delete with a node service account of the run",
+			downAction:   downActionDelete,
+			createNodeSA: true,
+		},
+		{
+			desc:          "scale to zero with a fixed size extra nodepool",
+			downAction:    downActionScaleToZero,
+			extraNodePool: []string{"name=extra&machine-type=e2-standard-4&image-type=cos_containerd&num-nodes=2"},
+		},
+		{
+			desc:          "scale to zero with an autoscaled extra nodepool",
+			downAction:    downActionScaleToZero,
+			extraNodePool: []string{"name=extra&machine-type=e2-standard-4&image-type=cos_containerd&num-nodes=1&min-nodes=1&max-nodes=3"},
+			expectError:   true,
+		},
+		{
+			desc:          "delete with an autoscaled extra nodepool",
+			downAction:    downActionDelete,
+			extraNodePool: []string{"name=extra&machine-type=e2-standard-4&image-type=cos_containerd&num-nodes=1&min-nodes=1&max-nodes=3"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			d := &Deployer{
+				ClusterOptions: &options.ClusterOptions{
+					DownAction:               tc.downAction,
+					Autopilot:                tc.autopilot,
+					CreateNodeServiceAccount: tc.createNodeSA,
+					ExtraNodePool:            tc.extraNodePool,
+				},
+				totalBoskosProjectsRequested: tc.boskosProjects,
+			}
+			err := d.verifyDownAction()
+			if tc.expectError && err == nil {
+				t.Error("expected an error but got none")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
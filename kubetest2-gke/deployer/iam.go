@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	iamPolicyAttempts      = 5
+	iamPolicyRetryInterval = 5 * time.Second
+)
+
+// concurrentPolicyChangeRe matches the gcloud errors of IAM policy updates
+// that lost the etag race against another update of the same policy, e.g.
+// by a concurrent run sharing the host project, which are safe to retry.
+var concurrentPolicyChangeRe = regexp.MustCompile(`(?i)concurrent policy changes|etag mismatch|status code: 409|ABORTED`)
+
+// addIAMPolicyBinding runs gcloud with the args of an add-iam-policy-binding
+// command, retrying it when the policy was modified concurrently. Adding a
+// binding that already exists succeeds, so the updates are idempotent.
+func (d *Deployer) addIAMPolicyBinding(args ...string) error {
+	for attempt := 1; ; attempt++ {
+		output, err := runWithOutputAndReturn(d.cmder.Command("gcloud", append(args, "--quiet")...))
+		if err == nil {
+			return nil
+		}
+		if attempt == iamPolicyAttempts || !isConcurrentPolicyChange(output) {
+			return fmt.Errorf("%v, output: %q", err, output)
+		}
+		klog.Warningf("IAM policy was modified concurrently, retrying (attempt %d of %d)", attempt, iamPolicyAttempts)
+		time.Sleep(time.Duration(attempt) * iamPolicyRetryInterval)
+	}
+}
+
+// isConcurrentPolicyChange returns whether the output of a failed IAM policy
+// update is an error about a concurrent modification of the policy.
+func isConcurrentPolicyChange(output string) bool {
+	return concurrentPolicyChangeRe.MatchString(output)
+}
+
+// iamGrantError returns err when strict, and otherwise logs it and returns
+// nil, as the roles may have been granted beforehand by an account with
+// more permissions than the one running the deployer.
+func iamGrantError(strict bool, err error) error {
+	if err == nil || strict {
+		return err
+	}
+	klog.Warningf("Ignoring IAM role grant failure as --strict-iam=false: %v", err)
+	return nil
+}
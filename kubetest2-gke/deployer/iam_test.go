@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIsConcurrentPolicyChange(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		output   string
+		expected bool
+	}{
+		{
+			desc:     "concurrent policy changes",
+			output:   "ERROR: (gcloud.projects.add-iam-policy-binding) ABORTED: There were concurrent policy changes. Please retry the whole read-modify-write with exponential backoff.",
+			expected: true,
+		},
+		{
+			desc:     "conflict",
+			output:   "ERROR: (gcloud.compute.networks.subnets.add-iam-policy-binding) HTTPError 409: status code: 409, etag mismatch",
+			expected: true,
+		},
+		{
+			desc:   "permission denied",
+			output: "ERROR: (gcloud.projects.add-iam-policy-binding) PERMISSION_DENIED: Policy update access denied.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if actual := isConcurrentPolicyChange(tc.output); actual != tc.expected {
+				t.Errorf("expected %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIAMGrantError(t *testing.T) {
+	err := errors.New("PERMISSION_DENIED")
+	if iamGrantError(false, err) != nil {
+		t.Errorf("expected the error to be ignored with --strict-iam=false")
+	}
+	if iamGrantError(true, err) != err {
+		t.Errorf("expected the error with --strict-iam")
+	}
+	if iamGrantError(true, nil) != nil {
+		t.Errorf("expected no error")
+	}
+}
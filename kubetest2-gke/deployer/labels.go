@@ -0,0 +1,54 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"hash/crc32"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// cleanupAfterLabel holds the unix time after which janitors may
+	// delete the cluster
+	cleanupAfterLabel = "cleanup-after"
+	// deletionProtectionLabel marks clusters janitors must not delete
+	deletionProtectionLabel = "deletion-protection"
+	// runLabel identifies the run which created the cluster, so that the
+	// clusters left by a previous attempt of the run can be adopted
+	runLabel = "kubetest2-run"
+)
+
+// runLabelValue returns the value of runLabel for the run, label values are
+// too restricted to hold any run id
+func runLabelValue(runID string) string {
+	return fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(runID)))
+}
+
+// clusterLabels returns the value of --labels for cluster creation
+func (d *Deployer) clusterLabels(runID string, now time.Time) string {
+	labels := []string{runLabel + "=" + runLabelValue(runID)}
+	if d.ClusterTTL > 0 {
+		labels = append(labels, cleanupAfterLabel+"="+strconv.FormatInt(now.Add(d.ClusterTTL).Unix(), 10))
+	}
+	if d.DeletionProtection {
+		labels = append(labels, deletionProtectionLabel+"=true")
+	}
+	return strings.Join(labels, ",")
+}
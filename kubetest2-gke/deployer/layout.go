@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/api/container/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/artifacts"
+)
+
+// clusterLayoutFile is the artifact describing the clusters of the run
+const clusterLayoutFile = "clusters.json"
+
+// projectLayout describes the clusters of a project, for external tools and
+// testers consuming the topology of the run without querying gcloud
+type projectLayout struct {
+	Project  string          `json:"project"`
+	Clusters []clusterLayout `json:"clusters"`
+}
+
+type clusterLayout struct {
+	Name     string `json:"name"`
+	Location string `json:"location"`
+	// NodePools is empty for autopilot clusters, whose nodes are managed by GKE
+	NodePools      []nodePoolLayout      `json:"nodePools,omitempty"`
+	InstanceGroups []instanceGroupLayout `json:"instanceGroups,omitempty"`
+}
+
+type nodePoolLayout struct {
+	Name        string `json:"name"`
+	MachineType string `json:"machineType,omitempty"`
+	ImageType   string `json:"imageType,omitempty"`
+	// NumNodes is the number of nodes in each zone of the cluster, the
+	// nodepools of regional clusters have NumNodes times the number of node
+	// zones of the region
+	NumNodes int `json:"numNodes"`
+}
+
+type instanceGroupLayout struct {
+	Name string `json:"name"`
+	Zone string `json:"zone"`
+	Path string `json:"path"`
+}
+
+// nodePoolLayouts returns the nodepools created in every cluster
+func (d *Deployer) nodePoolLayouts() []nodePoolLayout {
+	if d.Autopilot {
+		return nil
+	}
+	nodePools := []nodePoolLayout{{
+		Name:        "default-pool",
+		MachineType: d.MachineType,
+		ImageType:   d.ImageType,
+		NumNodes:    d.NumNodes,
+	}}
+	if d.WindowsEnabled {
+		nodePools = append(nodePools, nodePoolLayout{
+			Name:        "windows-pool",
+			MachineType: d.WindowsMachineType,
+			ImageType:   d.WindowsImageType,
+			NumNodes:    d.WindowsNumNodes,
+		})
+	}
+	for _, enp := range d.extraNodePoolSpecs {
+		nodePools = append(nodePools, nodePoolLayout{
+			Name:        enp.Name,
+			MachineType: enp.MachineType,
+			ImageType:   enp.ImageType,
+			NumNodes:    enp.NumNodes,
+		})
+	}
+	return nodePools
+}
+
+// recordExistingNodePools records the nodepools of the existing cluster c,
+// which may differ from the nodepools the flags would create
+func (d *Deployer) recordExistingNodePools(project string, c *container.Cluster) {
+	if d.existingNodePools == nil {
+		d.existingNodePools = map[string]map[string][]nodePoolLayout{}
+	}
+	if d.existingNodePools[project] == nil {
+		d.existingNodePools[project] = map[string][]nodePoolLayout{}
+	}
+	var nodePools []nodePoolLayout
+	// the nodes of autopilot clusters are managed by GKE, like when they are
+	// created by the run
+	if c.Autopilot == nil || !c.Autopilot.Enabled {
+		for _, np := range c.NodePools {
+			layout := nodePoolLayout{Name: np.Name, NumNodes: int(np.InitialNodeCount)}
+			if np.Config != nil {
+				layout.MachineType = np.Config.MachineType
+				layout.ImageType = np.Config.ImageType
+			}
+			nodePools = append(nodePools, layout)
+		}
+	}
+	d.existingNodePools[project][c.Name] = nodePools
+}
+
+// clusterLayouts describes the clusters of every project in the location
+// they were created in, with the instance groups found by GetInstanceGroups
+func (d *Deployer) clusterLayouts() []projectLayout {
+	location := d.Zones
+	if len(location) == 0 {
+		location = d.Regions
+	}
+	var layouts []projectLayout
+	for _, project := range d.Projects {
+		layout := projectLayout{Project: project}
+		for _, cluster := range d.projectClustersLayout[project] {
+			c := clusterLayout{
+				Name:      cluster.name,
+				Location:  location[d.retryCount],
+				NodePools: d.nodePoolLayouts(),
+			}
+			if d.SkipClusterCreate {
+				c.NodePools = d.existingNodePools[project][cluster.name]
+			}
+			for _, group := range d.instanceGroups[project][cluster.name] {
+				c.InstanceGroups = append(c.InstanceGroups, instanceGroupLayout{
+					Name: group.name,
+					Zone: group.zone,
+					Path: group.path,
+				})
+			}
+			layout.Clusters = append(layout.Clusters, c)
+		}
+		layouts = append(layouts, layout)
+	}
+	return layouts
+}
+
+// recordClusterLayout writes clusters.json at the end of Up, once the
+// instance groups of the clusters are known. Failing to write it does not
+// fail Up.
+func (d *Deployer) recordClusterLayout() {
+	if err := d.writeClusterLayout(); err != nil {
+		klog.Warningf("Failed to write %s: %v", clusterLayoutFile, err)
+	}
+}
+
+// writeClusterLayout writes the layout of the clusters to clusters.json in
+// the artifacts
+func (d *Deployer) writeClusterLayout() error {
+	b, err := json.MarshalIndent(d.clusterLayouts(), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(artifacts.BaseDir(), clusterLayoutFile), b, 0644); err != nil {
+		return fmt.Errorf("error writing the cluster layout: %w", err)
+	}
+	return nil
+}
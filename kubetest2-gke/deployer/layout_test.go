@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gke/deployer/options"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+func TestClusterLayouts(t *testing.T) {
+	layout := map[string][]cluster{"p1": {{0, "kt2-a"}}, "p2": {{0, "kt2-a"}}}
+	instanceGroups := map[string]map[string][]*ig{
+		"p1": {"kt2-a": {{
+			path: "zones/us-central1-c/instanceGroupManagers/gke-kt2-a-default-pool-90fcb815-grp",
+			zone: "us-central1-c",
+			name: "gke-kt2-a-default-pool-90fcb815-grp",
+			uniq: "90fcb815",
+		}}},
+	}
+	testCases := []struct {
+		desc      string
+		autopilot bool
+		expected  []projectLayout
+	}{
+		{
+			desc: "standard clusters",
+			expected: []projectLayout{
+				{
+					Project: "p1",
+					Clusters: []clusterLayout{{
+						Name:     "kt2-a",
+						Location: "us-central1-c",
+						NodePools: []nodePoolLayout{
+							{Name: "default-pool", MachineType: "e2-standard-4", NumNodes: 3},
+							{Name: "gpu-pool", MachineType: "n1-standard-4", ImageType: "cos_containerd", NumNodes: 1},
+						},
+						InstanceGroups: []instanceGroupLayout{{
+							Name: "gke-kt2-a-default-pool-90fcb815-grp",
+							Zone: "us-central1-c",
+							Path: "zones/us-central1-c/instanceGroupManagers/gke-kt2-a-default-pool-90fcb815-grp",
+						}},
+					}},
+				},
+				{
+					Project: "p2",
+					Clusters: []clusterLayout{{
+						Name:     "kt2-a",
+						Location: "us-central1-c",
+						NodePools: []nodePoolLayout{
+							{Name: "default-pool", MachineType: "e2-standard-4", NumNodes: 3},
+							{Name: "gpu-pool", MachineType: "n1-standard-4", ImageType: "cos_containerd", NumNodes: 1},
+						},
+					}},
+				},
+			},
+		},
+		{
+			desc:      "autopilot clusters",
+			autopilot: true,
+			expected: []projectLayout{
+				{
+					Project: "p1",
+					Clusters: []clusterLayout{{
+						Name:     "kt2-a",
+						Location: "us-central1-c",
+						InstanceGroups: []instanceGroupLayout{{
+							Name: "gke-kt2-a-default-pool-90fcb815-grp",
+							Zone: "us-central1-c",
+							Path: "zones/us-central1-c/instanceGroupManagers/gke-kt2-a-default-pool-90fcb815-grp",
+						}},
+					}},
+				},
+				{
+					Project:  "p2",
+					Clusters: []clusterLayout{{Name: "kt2-a", Location: "us-central1-c"}},
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			d := &Deployer{
+				ProjectOptions: &options.ProjectOptions{Projects: []string{"p1", "p2"}},
+				ClusterOptions: &options.ClusterOptions{
+					Zones:       []string{"us-central1-c"},
+					Autopilot:   tc.autopilot,
+					MachineType: "e2-standard-4",
+					NumNodes:    3,
+				},
+				projectClustersLayout: layout,
+				instanceGroups:        instanceGroups,
+				extraNodePoolSpecs: []*extraNodepool{
+					{Name: "gpu-pool", MachineType: "n1-standard-4", ImageType: "cos_containerd", NumNodes: 1},
+				},
+			}
+			if actual := d.clusterLayouts(); !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected layout %+v but got %+v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestClusterLayoutsOfExistingClusters(t *testing.T) {
+	cmder := &exec.FakeCmder{
+		Responses: []exec.FakeResponse{{
+			Prefix: "gcloud container clusters describe kt2-a --project=p1 --zone=us-central1-c",
+			Stdout: `{"name": "kt2-a", "status": "RUNNING", "currentNodeCount": 4, "nodePools": [` +
+				`{"name": "default-pool", "status": "RUNNING", "initialNodeCount": 2, "config": {"machineType": "n2-standard-8", "imageType": "UBUNTU_CONTAINERD"}}, ` +
+				`{"name": "pool-2", "status": "RUNNING", "initialNodeCount": 2, "config": {"machineType": "e2-medium"}}]}`,
+		}},
+	}
+	d := &Deployer{
+		cmder:          cmder,
+		ProjectOptions: &options.ProjectOptions{Projects: []string{"p1"}},
+		ClusterOptions: &options.ClusterOptions{
+			Zones:             []string{"us-central1-c"},
+			SkipClusterCreate: true,
+			MachineType:       "e2-standard-4",
+			NumNodes:          3,
+		},
+		projectClustersLayout: map[string][]cluster{"p1": {{0, "kt2-a"}}},
+	}
+	if err := d.verifyExistingClusters(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the nodepools are those of the existing cluster, not of the flags
+	expected := []projectLayout{{
+		Project: "p1",
+		Clusters: []clusterLayout{{
+			Name:     "kt2-a",
+			Location: "us-central1-c",
+			NodePools: []nodePoolLayout{
+				{Name: "default-pool", MachineType: "n2-standard-8", ImageType: "UBUNTU_CONTAINERD", NumNodes: 2},
+				{Name: "pool-2", MachineType: "e2-medium", NumNodes: 2},
+			},
+		}},
+	}}
+	if actual := d.clusterLayouts(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected layout %+v but got %+v", expected, actual)
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+)
+
+const (
+	gatewayAPIDisabled = "disabled"
+	gatewayAPIStandard = "standard"
+)
+
+// validateLoadBalancingFlags validates the Gateway API and load balancer
+// flags.
+func (d *Deployer) validateLoadBalancingFlags() error {
+	switch d.GatewayAPI {
+	case "", gatewayAPIDisabled, gatewayAPIStandard:
+		return nil
+	default:
+		return fmt.Errorf("--gateway-api must be %q or %q, got %q", gatewayAPIStandard, gatewayAPIDisabled, d.GatewayAPI)
+	}
+}
+
+// loadBalancingClusterArgs returns the gcloud clusters create args for the
+// Gateway API and load balancer flags, gcloud defaults apply to the unset ones.
+func (d *Deployer) loadBalancingClusterArgs() []string {
+	var args []string
+	if d.GatewayAPI != "" {
+		args = append(args, "--gateway-api="+d.GatewayAPI)
+	}
+	if d.EnableL4ILBSubsetting {
+		args = append(args, "--enable-l4-ilb-subsetting")
+	}
+	return args
+}
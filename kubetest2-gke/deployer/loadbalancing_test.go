@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gke/deployer/options"
+)
+
+func TestLoadBalancingClusterArgs(t *testing.T) {
+	testCases := []struct {
+		desc                  string
+		gatewayAPI            string
+		enableL4ILBSubsetting bool
+		expected              []string
+		expectError           bool
+	}{
+		{
+			desc: "defaults",
+		},
+		{
+			desc:                  "gateway api and subsetting",
+			gatewayAPI:            gatewayAPIStandard,
+			enableL4ILBSubsetting: true,
+			expected:              []string{"--gateway-api=standard", "--enable-l4-ilb-subsetting"},
+		},
+		{
+			desc:       "gateway api disabled",
+			gatewayAPI: gatewayAPIDisabled,
+			expected:   []string{"--gateway-api=disabled"},
+		},
+		{
+			desc:        "unknown gateway api channel",
+			gatewayAPI:  "experimental",
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			d := &Deployer{
+				ClusterOptions: &options.ClusterOptions{
+					GatewayAPI:            tc.gatewayAPI,
+					EnableL4ILBSubsetting: tc.enableL4ILBSubsetting,
+				},
+			}
+			err := d.validateLoadBalancingFlags()
+			if tc.expectError {
+				if err == nil {
+					t.Error("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := d.loadBalancingClusterArgs(); !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected args %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"net"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/lease"
+)
+
+// masterIPRangePrefixLen is the size of the master IP range GKE requires for
+// a private cluster
+const masterIPRangePrefixLen = 28
+
+// masterIPRangesLeasePool returns the lease pool of the master IP range
+// blocks of the private clusters in network, the ranges must not overlap
+// within a network
+func masterIPRangesLeasePool(project, network string) string {
+	return fmt.Sprintf("gke-master-ip-ranges/%s/%s", project, network)
+}
+
+// verifyMasterIPRangePool validates --private-cluster-master-ip-range-pool,
+// which replaces --private-cluster-master-ip-range
+func verifyMasterIPRangePool(pool string, masterIPRanges []string) error {
+	if len(masterIPRanges) > 0 {
+		return fmt.Errorf("--private-cluster-master-ip-range-pool cannot be used with --private-cluster-master-ip-range")
+	}
+	ip, ipNet, err := net.ParseCIDR(pool)
+	if err != nil || ip.To4() == nil {
+		return fmt.Errorf("--private-cluster-master-ip-range-pool %q must be an IPv4 CIDR like 172.16.0.0/16", pool)
+	}
+	if ones, _ := ipNet.Mask.Size(); ones > masterIPRangePrefixLen {
+		return fmt.Errorf("--private-cluster-master-ip-range-pool %q must be at least a /%d", pool, masterIPRangePrefixLen)
+	}
+	return nil
+}
+
+// leaseMasterIPRanges leases a block of --private-cluster-master-ip-range-pool
+// large enough for the master IP ranges of every cluster and attempt of the
+// run, so that the runs sharing the network of a project don't create
+// private clusters with overlapping master ranges. The lease is released by
+// kubetest2 after down.
+func (d *Deployer) leaseMasterIPRanges(leaser *lease.Leaser) error {
+	n := len(d.Clusters) * d.totalTryCount
+	blocks, err := masterIPRangeBlocks(d.PrivateClusterMasterIPRangePool, n)
+	if err != nil {
+		return err
+	}
+	block, err := leaser.Acquire(masterIPRangesLeasePool(d.Projects[0], d.Network), blocks)
+	if err != nil {
+		return fmt.Errorf("failed to lease the private cluster master IP ranges: %w", err)
+	}
+	ranges, err := splitCIDR(block, masterIPRangePrefixLen)
+	if err != nil {
+		return err
+	}
+	klog.V(1).Infof("Leased the private cluster master IP ranges %s of %s", block, d.PrivateClusterMasterIPRangePool)
+	d.PrivateClusterMasterIPRanges = ranges[:n]
+	d.internalizeMasterIPRanges()
+	return nil
+}
+
+// masterIPRangeBlocks splits pool into the smallest blocks holding n master
+// IP ranges
+func masterIPRangeBlocks(pool string, n int) ([]string, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("no master IP ranges to lease")
+	}
+	// the block holds the next power of two ranges
+	blockPrefixLen := masterIPRangePrefixLen - bits.Len(uint(n-1))
+	_, poolNet, err := net.ParseCIDR(pool)
+	if err != nil {
+		return nil, err
+	}
+	if ones, _ := poolNet.Mask.Size(); ones > blockPrefixLen {
+		return nil, fmt.Errorf("--private-cluster-master-ip-range-pool %s is too small for the %d master IP ranges of the run, a /%d is needed", pool, n, blockPrefixLen)
+	}
+	return splitCIDR(pool, blockPrefixLen)
+}
+
+// splitCIDR splits the IPv4 cidr into its sub ranges of prefixLen
+func splitCIDR(cidr string, prefixLen int) ([]string, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+	ip := ipNet.IP.To4()
+	if ip == nil {
+		return nil, fmt.Errorf("%s is not an IPv4 CIDR", cidr)
+	}
+	ones, _ := ipNet.Mask.Size()
+	if ones > prefixLen || prefixLen > 32 {
+		return nil, fmt.Errorf("%s cannot be split into /%d ranges", cidr, prefixLen)
+	}
+	start := binary.BigEndian.Uint32(ip)
+	count := uint32(1) << (prefixLen - ones)
+	size := uint32(1) << (32 - prefixLen)
+	ranges := make([]string, 0, count)
+	for i := uint32(0); i < count; i++ {
+		sub := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(sub, start+i*size)
+		ranges = append(ranges, fmt.Sprintf("%s/%d", sub, prefixLen))
+	}
+	return ranges, nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gke/deployer/options"
+	"sigs.k8s.io/kubetest2/pkg/lease"
+)
+
+func TestMasterIPRangeBlocks(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		pool        string
+		n           int
+		expected    []string
+		expectError bool
+	}{
+		{
+			desc:     "a range per block",
+			pool:     "172.16.0.0/27",
+			n:        1,
+			expected: []string{"172.16.0.0/28", "172.16.0.16/28"},
+		},
+		{
+			desc:     "blocks rounded up to a power of two ranges",
+			pool:     "172.16.0.0/24",
+			n:        3,
+			expected: []string{"172.16.0.0/26", "172.16.0.64/26", "172.16.0.128/26", "172.16.0.192/26"},
+		},
+		{
+			desc:        "pool too small",
+			pool:        "172.16.0.0/28",
+			n:           2,
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		blocks, err := masterIPRangeBlocks(tc.pool, tc.n)
+		if tc.expectError {
+			if err == nil {
+				t.Errorf("%s: expected an error, but got blocks %v", tc.desc, blocks)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.desc, err)
+			continue
+		}
+		if !reflect.DeepEqual(blocks, tc.expected) {
+			t.Errorf("%s: expected blocks %v, but got %v", tc.desc, tc.expected, blocks)
+		}
+	}
+}
+
+func TestVerifyMasterIPRangePool(t *testing.T) {
+	if err := verifyMasterIPRangePool("172.16.0.0/16", nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := verifyMasterIPRangePool("172.16.0.0/16", []string{"172.16.0.0/28"}); err == nil {
+		t.Errorf("expected an error with --private-cluster-master-ip-range")
+	}
+	if err := verifyMasterIPRangePool("fd00::/64", nil); err == nil {
+		t.Errorf("expected an error for an IPv6 pool")
+	}
+	if err := verifyMasterIPRangePool("172.16.0.0/29", nil); err == nil {
+		t.Errorf("expected an error for a pool smaller than a master IP range")
+	}
+}
+
+func TestLeaseMasterIPRanges(t *testing.T) {
+	leaseDir := t.TempDir()
+	newDeployer := func() *Deployer {
+		return &Deployer{
+			ProjectOptions: &options.ProjectOptions{Projects: []string{"shared-project"}},
+			ClusterOptions: &options.ClusterOptions{Clusters: []string{"a", "b"}},
+			NetworkOptions: &options.NetworkOptions{
+				Network:                         "default",
+				PrivateClusterAccessLevel:       "no",
+				PrivateClusterMasterIPRangePool: "172.16.0.0/26",
+			},
+			totalTryCount: 2,
+		}
+	}
+	newLeaser := func(runID string) *lease.Leaser {
+		return &lease.Leaser{
+			Dir:    leaseDir,
+			Owner:  runID,
+			RunDir: filepath.Join(t.TempDir(), runID),
+			TTL:    lease.DefaultTTL,
+		}
+	}
+
+	first := newDeployer()
+	firstLeaser := newLeaser("run-1")
+	if err := first.leaseMasterIPRanges(firstLeaser); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := [][]string{
+		{"172.16.0.0/28", "172.16.0.16/28"},
+		{"172.16.0.32/28", "172.16.0.48/28"},
+	}
+	if !reflect.DeepEqual(first.privateClusterMasterIPRangesInternal, expected) {
+		t.Errorf("expected the master IP ranges %v, but got %v", expected, first.privateClusterMasterIPRangesInternal)
+	}
+
+	// the run gets the same ranges again, e.g. when retried
+	again := newDeployer()
+	if err := again.leaseMasterIPRanges(firstLeaser); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(again.PrivateClusterMasterIPRanges, first.PrivateClusterMasterIPRanges) {
+		t.Errorf("expected the run to keep its ranges %v, but got %v", first.PrivateClusterMasterIPRanges, again.PrivateClusterMasterIPRanges)
+	}
+
+	// the pool only holds a single block of 4 ranges
+	if err := newDeployer().leaseMasterIPRanges(newLeaser("run-2")); err == nil {
+		t.Errorf("expected an error leasing the ranges of a concurrent run from a full pool")
+	}
+	if err := firstLeaser.Release(); err != nil {
+		t.Fatalf("failed to release the leases: %v", err)
+	}
+	if err := newDeployer().leaseMasterIPRanges(newLeaser("run-2")); err != nil {
+		t.Errorf("expected the released ranges to be leased again, but got: %v", err)
+	}
+}
@@ -0,0 +1,296 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// privateGoogleAccessIPs are the IPs of private.googleapis.com, the range
+// Private Google Access serves the Google APIs on without internet access
+var privateGoogleAccessIPs = []string{"199.36.153.8", "199.36.153.9", "199.36.153.10", "199.36.153.11"}
+
+// privateGoogleAccessZone is a private DNS zone resolving a domain of Google
+// APIs to privateGoogleAccessIPs.
+type privateGoogleAccessZone struct {
+	// domain is the DNS name of the zone
+	domain string
+	// host is the name resolving to privateGoogleAccessIPs, the other
+	// names of the domain are a CNAME of it
+	host string
+}
+
+// privateGoogleAccessZones are the domains the cluster nodes reach, for the
+// Google APIs and the images of the Google registries.
+var privateGoogleAccessZones = []privateGoogleAccessZone{
+	{domain: "googleapis.com.", host: "private.googleapis.com."},
+	{domain: "gcr.io.", host: "gcr.io."},
+	{domain: "pkg.dev.", host: "pkg.dev."},
+}
+
+// runHash returns a short hash of the run ID, for the names of the resources
+// created for the run in a network that may be shared, e.g. default.
+func runHash(runID string) string {
+	return fmt.Sprintf("%08x", crc32.ChecksumIEEE([]byte(runID)))
+}
+
+// natRouterPrefix prefixes the names of the Cloud Routers created by
+// kubetest2 runs
+const natRouterPrefix = "kt2-nat-"
+
+// natRouterName returns the name of the Cloud Router created for the run in
+// region, which is unique per run and region since the network may be shared
+// and the retries of the run create one in every region they try.
+func natRouterName(runID, region string) string {
+	return fmt.Sprintf("%s%s-%s", natRouterPrefix, region, runHash(runID))
+}
+
+// privateGoogleAccessZoneName returns the name of the DNS zone created for
+// the run for domain.
+func privateGoogleAccessZoneName(runID, domain string) string {
+	return fmt.Sprintf("kt2-pga-%s-%s", strings.ReplaceAll(strings.TrimSuffix(domain, "."), ".", "-"), runHash(runID))
+}
+
+// shouldCreateNAT returns true if a Cloud NAT may be needed for the nodes to
+// reach the internet, private nodes have no external IPs to pull images.
+func (d *Deployer) shouldCreateNAT() bool {
+	return d.PrivateClusterAccessLevel != "" && d.CreateNAT
+}
+
+// SetupPrivateNodesAccess sets up what the nodes of private clusters need to
+// reach the Google APIs and the internet without external IPs, see
+// EnablePrivateGoogleAccess, CreatePrivateGoogleAccessDNS and CreateCloudNAT.
+func (d *Deployer) SetupPrivateNodesAccess() error {
+	if d.PrivateClusterAccessLevel == "" {
+		return nil
+	}
+	if err := d.EnablePrivateGoogleAccess(); err != nil {
+		return err
+	}
+	if err := d.CreatePrivateGoogleAccessDNS(); err != nil {
+		return err
+	}
+	return d.CreateCloudNAT()
+}
+
+// TeardownPrivateNodesAccess deletes what SetupPrivateNodesAccess created
+// for the attempt retryCount, the DNS zones even if the NAT failed to be
+// deleted.
+func (d *Deployer) TeardownPrivateNodesAccess(retryCount int) error {
+	if d.PrivateClusterAccessLevel == "" {
+		return nil
+	}
+	return errors.Join(d.DeleteCloudNAT(retryCount), d.DeletePrivateGoogleAccessDNS())
+}
+
+// EnablePrivateGoogleAccess enables Private Google Access on the existing
+// --subnetwork of the current location, for the private nodes to reach the Google APIs. It is enabled
+// on the subnets created for private clusters by GKE, or by CreateSubnets for
+// the multi-project profile.
+func (d *Deployer) EnablePrivateGoogleAccess() error {
+	subnetwork := d.subnetwork(d.retryCount)
+	if subnetwork == "" {
+		return nil
+	}
+	klog.V(1).Infof("Enabling Private Google Access on subnetwork %q", subnetwork)
+	if err := runWithOutput(d.cmder.Command("gcloud", "compute", "networks", "subnets", "update", subnetwork,
+		"--project="+d.Projects[0],
+		"--region="+regionFromLocation(d.Regions, d.Zones, d.retryCount),
+		"--enable-private-ip-google-access")); err != nil {
+		return fmt.Errorf("error enabling Private Google Access on subnetwork %q: %w", subnetwork, err)
+	}
+	return nil
+}
+
+// CreatePrivateGoogleAccessDNS creates private DNS zones in the network
+// resolving the Google APIs and registries to private.googleapis.com, with
+// --private-google-access-dns, for networks without a route to the internet.
+// The zones are not per region, the zones created by a previous attempt of
+// the cluster creation are kept.
+func (d *Deployer) CreatePrivateGoogleAccessDNS() error {
+	if !d.PrivateGoogleAccessDNS {
+		return nil
+	}
+	for _, zone := range privateGoogleAccessZones {
+		name := privateGoogleAccessZoneName(d.Kubetest2CommonOptions.RunID(), zone.domain)
+		if d.dnsZoneExists(name) {
+			continue
+		}
+		klog.V(1).Infof("Creating DNS zone %q for %s in network %q", name, zone.domain, d.Network)
+		if err := runWithOutput(d.cmder.Command("gcloud", "dns", "managed-zones", "create", name,
+			"--project="+d.Projects[0],
+			"--description=Private Google Access for kubetest2",
+			"--dns-name="+zone.domain,
+			"--visibility=private",
+			"--networks="+d.Network)); err != nil {
+			return fmt.Errorf("error creating DNS zone %q: %w", name, err)
+		}
+		for _, record := range zone.records() {
+			if err := runWithOutput(d.cmder.Command("gcloud", "dns", "record-sets", "create", record.name,
+				"--project="+d.Projects[0],
+				"--zone="+name,
+				"--type="+record.recordType,
+				"--ttl=300",
+				"--rrdatas="+record.data)); err != nil {
+				return fmt.Errorf("error creating the %s record %s in DNS zone %q: %w", record.recordType, record.name, name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// DeletePrivateGoogleAccessDNS deletes the DNS zones created by
+// CreatePrivateGoogleAccessDNS, the records first as zones with records
+// can't be deleted.
+func (d *Deployer) DeletePrivateGoogleAccessDNS() error {
+	if !d.PrivateGoogleAccessDNS {
+		return nil
+	}
+	for _, zone := range privateGoogleAccessZones {
+		name := privateGoogleAccessZoneName(d.Kubetest2CommonOptions.RunID(), zone.domain)
+		if !d.dnsZoneExists(name) {
+			klog.V(1).Infof("DNS zone %q not found, assuming it was not created", name)
+			continue
+		}
+		for _, record := range zone.records() {
+			if err := runWithOutput(d.cmder.Command("gcloud", "dns", "record-sets", "delete", record.name,
+				"--project="+d.Projects[0],
+				"--zone="+name,
+				"--type="+record.recordType)); err != nil {
+				klog.Warningf("Error deleting the %s record %s in DNS zone %q: %v", record.recordType, record.name, name, err)
+			}
+		}
+		if err := runWithOutput(d.cmder.Command("gcloud", "dns", "managed-zones", "delete", name,
+			"--project="+d.Projects[0],
+			"--quiet")); err != nil {
+			return fmt.Errorf("error deleting DNS zone %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// dnsZoneExists returns true if the DNS zone name exists in the host project
+func (d *Deployer) dnsZoneExists(name string) bool {
+	// assume an error implies the zone doesn't exist
+	return runWithNoOutput(d.cmder.Command("gcloud", "dns", "managed-zones", "describe", name,
+		"--project="+d.Projects[0],
+		"--format=value(name)")) == nil
+}
+
+// dnsRecord is a record of a privateGoogleAccessZone
+type dnsRecord struct {
+	name       string
+	recordType string
+	data       string
+}
+
+// records returns the records of the zone, the host resolving to
+// privateGoogleAccessIPs and the other names of the domain to the host
+func (z privateGoogleAccessZone) records() []dnsRecord {
+	return []dnsRecord{
+		{name: z.host, recordType: "A", data: strings.Join(privateGoogleAccessIPs, ",")},
+		{name: "*." + z.domain, recordType: "CNAME", data: z.host},
+	}
+}
+
+// hasCloudNAT returns true if the network already has a Cloud NAT in region,
+// e.g. one set up with the network, the nodes then have egress already, or
+// the router of this run created by a previous attempt. The routers of other
+// kubetest2 runs don't count, they are deleted when those runs are torn down.
+func (d *Deployer) hasCloudNAT(region, router string) (bool, error) {
+	routers, err := exec.OutputLines(d.cmder.Command("gcloud", "compute", "routers", "list",
+		"--project="+d.Projects[0],
+		"--regions="+region,
+		"--filter=network~/networks/"+d.Network+"$ AND nats:*",
+		"--format=value(name)"))
+	if err != nil {
+		return false, fmt.Errorf("error listing the Cloud Routers of network %q: %w", d.Network, err)
+	}
+	for _, name := range routers {
+		name = strings.TrimSpace(name)
+		if name == router || (name != "" && !strings.HasPrefix(name, natRouterPrefix)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CreateCloudNAT creates a Cloud Router with Cloud NAT in the network for the
+// private cluster nodes, in the region of the clusters, unless the network
+// already has a Cloud NAT there.
+func (d *Deployer) CreateCloudNAT() error {
+	if !d.shouldCreateNAT() {
+		return nil
+	}
+	region := regionFromLocation(d.Regions, d.Zones, d.retryCount)
+	router := natRouterName(d.Kubetest2CommonOptions.RunID(), region)
+	hasNAT, err := d.hasCloudNAT(region, router)
+	if err != nil {
+		return err
+	}
+	if hasNAT {
+		klog.V(1).Infof("Network %q has a Cloud NAT in region %q already, not creating one", d.Network, region)
+		return nil
+	}
+	klog.V(1).Infof("Creating Cloud NAT router %q in region %q", router, region)
+	if err := runWithOutput(d.cmder.Command("gcloud", "compute", "routers", "create", router,
+		"--project="+d.Projects[0],
+		"--region="+region,
+		"--network="+d.Network)); err != nil {
+		return fmt.Errorf("error creating Cloud NAT router: %w", err)
+	}
+	if err := runWithOutput(d.cmder.Command("gcloud", "compute", "routers", "nats", "create", router,
+		"--router="+router,
+		"--project="+d.Projects[0],
+		"--region="+region,
+		"--auto-allocate-nat-external-ips",
+		"--nat-all-subnet-ip-ranges")); err != nil {
+		return fmt.Errorf("error creating Cloud NAT: %w", err)
+	}
+	return nil
+}
+
+// DeleteCloudNAT deletes the Cloud Router created by CreateCloudNAT, and
+// the Cloud NAT with it, if it was created.
+func (d *Deployer) DeleteCloudNAT(retryCount int) error {
+	if !d.shouldCreateNAT() {
+		return nil
+	}
+	region := regionFromLocation(d.Regions, d.Zones, retryCount)
+	router := natRouterName(d.Kubetest2CommonOptions.RunID(), region)
+	if runWithNoOutput(d.cmder.Command("gcloud", "compute", "routers", "describe", router,
+		"--project="+d.Projects[0],
+		"--region="+region,
+		"--format=value(name)")) != nil {
+		klog.V(1).Infof("Cloud NAT router %q not found, assuming it was not created", router)
+		return nil
+	}
+	if err := runWithOutput(d.cmder.Command("gcloud", "compute", "routers", "delete", router,
+		"--project="+d.Projects[0],
+		"--region="+region,
+		"--quiet")); err != nil {
+		return fmt.Errorf("error deleting Cloud NAT router: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gke/deployer/options"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+func TestNATRouterName(t *testing.T) {
+	name := natRouterName("run-1", "us-central1")
+	if name != "kt2-nat-us-central1-"+runHash("run-1") {
+		t.Errorf("unexpected router name %q", name)
+	}
+	if other := natRouterName("run-1", "us-east1"); other == name {
+		t.Errorf("expected the router names of different regions to differ, but both are %q", name)
+	}
+	if other := natRouterName("run-2", "us-central1"); other == name {
+		t.Errorf("expected the router names of different runs to differ, but both are %q", name)
+	}
+}
+
+func TestPrivateGoogleAccessZoneRecords(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		zone         privateGoogleAccessZone
+		expectedName string
+		expected     []dnsRecord
+	}{
+		{
+			desc:         "googleapis.com",
+			zone:         privateGoogleAccessZone{domain: "googleapis.com.", host: "private.googleapis.com."},
+			expectedName: "kt2-pga-googleapis-com-" + runHash("run-1"),
+			expected: []dnsRecord{
+				{name: "private.googleapis.com.", recordType: "A", data: "199.36.153.8,199.36.153.9,199.36.153.10,199.36.153.11"},
+				{name: "*.googleapis.com.", recordType: "CNAME", data: "private.googleapis.com."},
+			},
+		},
+		{
+			desc:         "gcr.io",
+			zone:         privateGoogleAccessZone{domain: "gcr.io.", host: "gcr.io."},
+			expectedName: "kt2-pga-gcr-io-" + runHash("run-1"),
+			expected: []dnsRecord{
+				{name: "gcr.io.", recordType: "A", data: "199.36.153.8,199.36.153.9,199.36.153.10,199.36.153.11"},
+				{name: "*.gcr.io.", recordType: "CNAME", data: "gcr.io."},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if name := privateGoogleAccessZoneName("run-1", tc.zone.domain); name != tc.expectedName {
+				t.Errorf("expected zone name %q, but got %q", tc.expectedName, name)
+			}
+			if records := tc.zone.records(); !reflect.DeepEqual(records, tc.expected) {
+				t.Errorf("expected records %+v, but got %+v", tc.expected, records)
+			}
+		})
+	}
+}
+
+func TestHasCloudNAT(t *testing.T) {
+	list := "gcloud compute routers list --project=p --regions=us-central1 --filter=network~/networks/net$ AND nats:* --format=value(name)"
+	testCases := []struct {
+		desc        string
+		response    exec.FakeResponse
+		expected    bool
+		expectError bool
+	}{
+		{
+			desc:     "no router with a NAT",
+			response: exec.FakeResponse{Prefix: list},
+		},
+		{
+			desc:     "router with a NAT",
+			response: exec.FakeResponse{Prefix: list, Stdout: "shared-nat-router\n"},
+			expected: true,
+		},
+		{
+			desc:     "router of this run",
+			response: exec.FakeResponse{Prefix: list, Stdout: natRouterName("run-1", "us-central1") + "\n"},
+			expected: true,
+		},
+		{
+			desc:     "router of another run",
+			response: exec.FakeResponse{Prefix: list, Stdout: natRouterName("run-2", "us-central1") + "\n"},
+		},
+		{
+			desc:        "list failed",
+			response:    exec.FakeResponse{Prefix: list, Err: errors.New("exit status 1")},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cmder := &exec.FakeCmder{Responses: []exec.FakeResponse{tc.response}}
+			d := &Deployer{
+				cmder:          cmder,
+				ProjectOptions: &options.ProjectOptions{Projects: []string{"p"}},
+				NetworkOptions: &options.NetworkOptions{Network: "net"},
+			}
+			actual, err := d.hasCloudNAT("us-central1", natRouterName("run-1", "us-central1"))
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error, but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != tc.expected {
+				t.Errorf("expected %v, but got %v", tc.expected, actual)
+			}
+			if commands := cmder.Commands(); !reflect.DeepEqual(commands, []string{list}) {
+				t.Errorf("expected commands %v, but got %v", []string{list}, commands)
+			}
+		})
+	}
+}
+
+func TestEnablePrivateGoogleAccess(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		subnetworks []string
+		retryCount  int
+		expected    []string
+	}{
+		{
+			desc:     "no existing subnetwork",
+			expected: []string{},
+		},
+		{
+			desc:        "subnetwork of the first region",
+			subnetworks: []string{"subnet1", "subnet2"},
+			expected:    []string{"gcloud compute networks subnets update subnet1 --project=p --region=us-central1 --enable-private-ip-google-access"},
+		},
+		{
+			desc:        "subnetwork of the retried region",
+			subnetworks: []string{"subnet1", "subnet2"},
+			retryCount:  1,
+			expected:    []string{"gcloud compute networks subnets update subnet2 --project=p --region=us-east1 --enable-private-ip-google-access"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cmder := &exec.FakeCmder{}
+			d := &Deployer{
+				cmder:          cmder,
+				retryCount:     tc.retryCount,
+				ProjectOptions: &options.ProjectOptions{Projects: []string{"p"}},
+				ClusterOptions: &options.ClusterOptions{Regions: []string{"us-central1", "us-east1"}},
+				NetworkOptions: &options.NetworkOptions{Network: "net", Subnetworks: tc.subnetworks},
+			}
+			if err := d.EnablePrivateGoogleAccess(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if commands := cmder.Commands(); !reflect.DeepEqual(commands, tc.expected) {
+				t.Errorf("expected commands %v, but got %v", tc.expected, commands)
+			}
+		})
+	}
+}
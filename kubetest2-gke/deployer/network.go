@@ -20,23 +20,14 @@ import (
 	"errors"
 	"fmt"
 	"net"
-	"os"
+	"strconv"
 	"strings"
 
 	"k8s.io/klog/v2"
 
-	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/gcp"
 )
 
-const networkUserPolicyTemplate = `
-bindings:
-- members:
-  - serviceAccount:%s
-  - serviceAccount:%s
-  role: roles/compute.networkUser
-etag: %s
-`
-
 func (d *Deployer) VerifyNetworkFlags() error {
 
 	// Verify private cluster args.
@@ -45,13 +36,22 @@ func (d *Deployer) VerifyNetworkFlags() error {
 			d.PrivateClusterAccessLevel != string(limited) && d.PrivateClusterAccessLevel != string(unrestricted) {
 			return fmt.Errorf("--private-cluster-access-level must be one of %v", []string{"", string(no), string(limited), string(unrestricted)})
 		}
-		if len(d.PrivateClusterMasterIPRanges) != len(d.Clusters)*d.totalTryCount {
-			return fmt.Errorf("the number of master ip ranges provided via --private-cluster-master-ip-range "+
-				"should be the same as the number of clusters times the total try count : %d!=%d", len(d.PrivateClusterMasterIPRanges), len(d.Clusters)*d.totalTryCount)
-		}
-		if err := assertNoOverlaps(d.PrivateClusterMasterIPRanges); err != nil {
-			return fmt.Errorf("error in private cluster master ip ranges: %v", err)
+		if d.PrivateClusterMasterIPRangePool != "" {
+			// the ranges are leased once the projects are known
+			if err := verifyMasterIPRangePool(d.PrivateClusterMasterIPRangePool, d.PrivateClusterMasterIPRanges); err != nil {
+				return err
+			}
+		} else {
+			if len(d.PrivateClusterMasterIPRanges) != len(d.Clusters)*d.totalTryCount {
+				return fmt.Errorf("the number of master ip ranges provided via --private-cluster-master-ip-range "+
+					"should be the same as the number of clusters times the total try count : %d!=%d", len(d.PrivateClusterMasterIPRanges), len(d.Clusters)*d.totalTryCount)
+			}
+			if err := assertNoOverlaps(d.PrivateClusterMasterIPRanges); err != nil {
+				return fmt.Errorf("error in private cluster master ip ranges: %v", err)
+			}
 		}
+	} else if d.PrivateClusterMasterIPRangePool != "" {
+		return fmt.Errorf("--private-cluster-master-ip-range-pool requires --private-cluster-access-level")
 	}
 
 	numProjects := len(d.Projects)
@@ -59,6 +59,17 @@ func (d *Deployer) VerifyNetworkFlags() error {
 		numProjects = d.totalBoskosProjectsRequested
 	}
 
+	if err := verifySubnetworkFlags(numProjects, d.totalTryCount, d.Subnetworks, d.ClusterSecondaryRangeName, d.ServicesSecondaryRangeName); err != nil {
+		return err
+	}
+	masterIPRanges := d.PrivateClusterMasterIPRanges
+	if d.PrivateClusterMasterIPRangePool != "" {
+		masterIPRanges = []string{d.PrivateClusterMasterIPRangePool}
+	}
+	if err := verifyIPv4CIDRFlags(numProjects, len(d.Clusters), d.ClusterSecondaryRangeName, d.ClusterIPv4CIDR, d.ServicesIPv4CIDR, masterIPRanges); err != nil {
+		return err
+	}
+
 	// Verify for multi-project profile.
 	if numProjects > 1 {
 		if d.Network == "default" {
@@ -78,6 +89,87 @@ func (d *Deployer) VerifyNetworkFlags() error {
 	return d.internalizeNetworkFlags(numProjects)
 }
 
+// verifySubnetworkFlags validates the flags of an existing subnetwork
+func verifySubnetworkFlags(numProjects, totalTryCount int, subnetworks []string, clusterRange, servicesRange string) error {
+	if len(subnetworks) == 0 {
+		if clusterRange != "" || servicesRange != "" {
+			return errors.New("--cluster-secondary-range-name and --services-secondary-range-name require --subnetwork")
+		}
+		return nil
+	}
+	if numProjects > 1 {
+		return errors.New("--subnetwork is only supported for single-project profile, multi-project profile creates the subnetworks of the shared VPC")
+	}
+	if len(subnetworks) != totalTryCount {
+		return fmt.Errorf("the number of --subnetwork should be the same as the total try count, one per region or zone: %d!=%d", len(subnetworks), totalTryCount)
+	}
+	if (clusterRange == "") != (servicesRange == "") {
+		return errors.New("--cluster-secondary-range-name and --services-secondary-range-name must be set together")
+	}
+	return nil
+}
+
+// verifyIPv4CIDRFlags validates --cluster-ipv4-cidr and --services-ipv4-cidr,
+// which size the ranges GKE creates for the clusters. Full CIDRs are only
+// accepted for a single cluster, the clusters of a project cannot share
+// them, and must not overlap the private cluster master ranges.
+func verifyIPv4CIDRFlags(numProjects, numClusters int, clusterRange, clusterCIDR, servicesCIDR string, masterIPRanges []string) error {
+	if clusterCIDR == "" && servicesCIDR == "" {
+		return nil
+	}
+	if numProjects > 1 {
+		return errors.New("--cluster-ipv4-cidr and --services-ipv4-cidr are only supported for single-project profile, use --subnetwork-ranges for multi-project profile")
+	}
+	if clusterRange != "" {
+		return errors.New("--cluster-ipv4-cidr and --services-ipv4-cidr cannot be used with the existing secondary ranges of --cluster-secondary-range-name and --services-secondary-range-name")
+	}
+	var fullCIDRs []string
+	for _, f := range []struct{ flag, cidr string }{
+		{"--cluster-ipv4-cidr", clusterCIDR},
+		{"--services-ipv4-cidr", servicesCIDR},
+	} {
+		if f.cidr == "" {
+			continue
+		}
+		if size, isSize := strings.CutPrefix(f.cidr, "/"); isSize {
+			if n, err := strconv.Atoi(size); err != nil || n < 8 || n > 30 {
+				return fmt.Errorf("%s size %q must be between /8 and /30", f.flag, f.cidr)
+			}
+			continue
+		}
+		if _, _, err := net.ParseCIDR(f.cidr); err != nil {
+			return fmt.Errorf("%s %q must be a CIDR like 10.0.0.0/14 or a size like /14: %w", f.flag, f.cidr, err)
+		}
+		if numClusters > 1 {
+			return fmt.Errorf("%s %q must be a size like /14 for multiple clusters, the clusters cannot share the same range", f.flag, f.cidr)
+		}
+		fullCIDRs = append(fullCIDRs, f.cidr)
+	}
+	if len(fullCIDRs) == 0 {
+		return nil
+	}
+	if err := assertNoOverlaps(append(fullCIDRs, masterIPRanges...)); err != nil {
+		return fmt.Errorf("--cluster-ipv4-cidr, --services-ipv4-cidr and --private-cluster-master-ip-range must not overlap: %w", err)
+	}
+	return nil
+}
+
+// ipv4CIDRArgs returns the args sizing the pod and service ranges of the
+// clusters, a services range requires a VPC-native cluster
+func ipv4CIDRArgs(autopilot bool, clusterCIDR, servicesCIDR string) []string {
+	var args []string
+	if clusterCIDR != "" {
+		args = append(args, "--cluster-ipv4-cidr="+clusterCIDR)
+	}
+	if servicesCIDR != "" {
+		args = append(args, "--services-ipv4-cidr="+servicesCIDR)
+		if !autopilot {
+			args = append(args, "--enable-ip-alias")
+		}
+	}
+	return args
+}
+
 func validateSubnetRanges(subnetworkRanges []string) error {
 	// The subnets are passed in a list, each containing groups of 3 CIDR ranges.
 	// We need to verify there are no overlaps within the entire group.
@@ -130,11 +222,18 @@ func (d *Deployer) internalizeNetworkFlags(numProjects int) error {
 		}
 	}
 
-	// Since len(d.PrivateClusterMasterIPRanges) is 0 when private cluster is not requested.
-	if d.PrivateClusterAccessLevel == "" {
+	// Since len(d.PrivateClusterMasterIPRanges) is 0 when private cluster is not requested,
+	// or until the ranges are leased from --private-cluster-master-ip-range-pool.
+	if d.PrivateClusterAccessLevel == "" || d.PrivateClusterMasterIPRangePool != "" {
 		return nil
 	}
+	d.internalizeMasterIPRanges()
+	return nil
+}
 
+// internalizeMasterIPRanges splits the private cluster master IP ranges
+// per attempt
+func (d *Deployer) internalizeMasterIPRanges() {
 	d.privateClusterMasterIPRangesInternal = make([][]string, d.totalTryCount)
 	for tc := 0; tc < d.totalTryCount; tc++ {
 		d.privateClusterMasterIPRangesInternal[tc] = make([]string, len(d.Clusters))
@@ -143,7 +242,6 @@ func (d *Deployer) internalizeNetworkFlags(numProjects int) error {
 			d.privateClusterMasterIPRangesInternal[tc][c] = d.PrivateClusterMasterIPRanges[index]
 		}
 	}
-	return nil
 }
 
 func (d *Deployer) CreateNetwork() error {
@@ -152,17 +250,21 @@ func (d *Deployer) CreateNetwork() error {
 	// For multiple projects profile, the subnet-mode must be custom and should only be created in the host project.
 	//   (Here we consider the first project to be the host project and the rest be service projects)
 	//   Reference: https://cloud.google.com/kubernetes-engine/docs/how-to/cluster-shared-vpc#creating_a_network_and_two_subnets
+	// The network of an existing subnetwork exists already.
+	if len(d.Subnetworks) != 0 {
+		return nil
+	}
 	subnetMode := "auto"
 	if len(d.Projects) > 1 {
 		subnetMode = "custom"
 	}
-	if runWithNoOutput(exec.Command("gcloud", "compute", "networks", "describe", d.Network,
+	if runWithNoOutput(d.cmder.Command("gcloud", "compute", "networks", "describe", d.Network,
 		"--project="+d.Projects[0],
 		"--format=value(name)")) != nil {
 		// Assume error implies non-existent.
 		// TODO(chizhg): find a more reliable way to check if the network exists or not.
 		klog.V(1).Infof("Couldn't describe network %q, assuming it doesn't exist and creating it", d.Network)
-		if err := runWithOutput(exec.Command("gcloud", "compute", "networks", "create", d.Network,
+		if err := runWithOutput(d.cmder.Command("gcloud", "compute", "networks", "create", d.Network,
 			"--project="+d.Projects[0],
 			"--subnet-mode="+subnetMode)); err != nil {
 			return err
@@ -198,7 +300,7 @@ func (d *Deployer) CreateSubnets() error {
 		if d.PrivateClusterAccessLevel != "" {
 			createSubnetCommand = append(createSubnetCommand, "--enable-private-ip-google-access")
 		}
-		if err := runWithOutput(exec.Command(createSubnetCommand[0], createSubnetCommand[1:]...)); err != nil {
+		if err := runWithOutput(d.cmder.Command(createSubnetCommand[0], createSubnetCommand[1:]...)); err != nil {
 			return err
 		}
 	}
@@ -213,7 +315,7 @@ func (d *Deployer) DeleteSubnets(retryCount int) error {
 		for i := 1; i < len(d.Projects); i++ {
 			serviceProject := d.Projects[i]
 			subnetName := d.Network + "-" + serviceProject
-			if err := runWithOutput(exec.Command("gcloud", "compute", "networks", "subnets", "delete",
+			if err := runWithOutput(d.cmder.Command("gcloud", "compute", "networks", "subnets", "delete",
 				subnetName,
 				"--project="+hostProject,
 				"--region="+regionFromLocation(d.Regions, d.Zones, retryCount),
@@ -228,12 +330,12 @@ func (d *Deployer) DeleteSubnets(retryCount int) error {
 }
 
 func (d *Deployer) DeleteNetwork() error {
-	// Do not delete the default network.
-	if d.Network == "default" {
+	// Do not delete the default network, nor the network of an existing subnetwork.
+	if d.Network == "default" || len(d.Subnetworks) != 0 {
 		return nil
 	}
 
-	return runWithOutput(exec.Command("gcloud", "compute", "networks", "delete", "-q", d.Network,
+	return runWithOutput(d.cmder.Command("gcloud", "compute", "networks", "delete", "-q", d.Network,
 		"--project="+d.Projects[0], "--quiet"))
 }
 
@@ -271,16 +373,16 @@ func subNetworkArgs(autopilot bool, projects []string, region, network string, p
 }
 
 func (d *Deployer) SetupNetwork() error {
-	err := enableSharedVPCAndGrantRoles(d.Projects, regionFromLocation(d.Regions, d.Zones, d.retryCount), d.Network)
+	err := d.enableSharedVPCAndGrantRoles(d.Projects, regionFromLocation(d.Regions, d.Zones, d.retryCount), d.Network, d.StrictIAM)
 	if err != nil {
 		return err
 	}
-	return grantHostServiceAgentUserRole(d.Projects)
+	return d.grantHostServiceAgentUserRole(d.Projects, d.StrictIAM)
 }
 
 // This function implements https://cloud.google.com/kubernetes-engine/docs/how-to/cluster-shared-vpc#enabling_and_granting_roles
 // to enable shared VPC and grant required roles for the multi-project multi-cluster profile.
-func enableSharedVPCAndGrantRoles(projects []string, region, network string) error {
+func (d *Deployer) enableSharedVPCAndGrantRoles(projects []string, region, network string, strictIAM bool) error {
 	// Nothing needs to be done for single project.
 	if len(projects) == 1 {
 		return nil
@@ -296,7 +398,7 @@ func enableSharedVPCAndGrantRoles(projects []string, region, network string) err
 	networkHostProject := projects[0]
 	// Shared VPC is still in beta, so we have to use the beta command group here.
 	// TODO(chizhg): remove beta after shared VPC is in prod.
-	if err := runWithOutput(exec.Command("gcloud", "beta", "compute", "shared-vpc", "enable", networkHostProject)); err != nil {
+	if err := runWithOutput(d.cmder.Command("gcloud", "beta", "compute", "shared-vpc", "enable", networkHostProject)); err != nil {
 		// Sometimes we may want to use the projects pre-configured with shared-vpc for testing,
 		// and the service account that runs this command might not have the right permission, so do not
 		// error out if an error happens here.
@@ -305,7 +407,7 @@ func enableSharedVPCAndGrantRoles(projects []string, region, network string) err
 
 	// Associate the rest of the projects.
 	for i := 1; i < len(projects); i++ {
-		if err := runWithOutput(exec.Command("gcloud", "beta", "compute", "shared-vpc",
+		if err := runWithOutput(d.cmder.Command("gcloud", "beta", "compute", "shared-vpc",
 			"associated-projects", "add", projects[i],
 			"--host-project", networkHostProject)); err != nil {
 			klog.Warningf("Error associating project %q to Shared VPC: %v, it might be due to permission issues.", projects[i], err)
@@ -316,14 +418,8 @@ func enableSharedVPCAndGrantRoles(projects []string, region, network string) err
 	for i := 1; i < len(projects); i++ {
 		serviceProject := projects[i]
 		subnetName := network + "-" + serviceProject
-		// Get the subnet etag.
-		subnetETag, err := exec.Output(exec.Command("gcloud", "compute", "networks", "subnets",
-			"get-iam-policy", subnetName, "--project="+networkHostProject, "--region="+region, "--format=value(etag)"))
-		if err != nil {
-			return fmt.Errorf("failed to get the etag for the subnet: %s %s %v", network, region, err)
-		}
 		// Get the service project number.
-		serviceProjectNum, err := getProjectNumber(serviceProject)
+		serviceProjectNum, err := gcp.ProjectNumber(serviceProject)
 		if err != nil {
 			return fmt.Errorf("failed to get the project number for %s: %v", serviceProject, err)
 		}
@@ -331,17 +427,17 @@ func enableSharedVPCAndGrantRoles(projects []string, region, network string) err
 		googleAPIServiceAccount := serviceProjectNum + "@cloudservices.gserviceaccount.com"
 
 		// Grant the required IAM roles to service accounts that belong to the service project.
-		tempFile, err := os.CreateTemp("", "*.yaml")
-		if err != nil {
-			return fmt.Errorf("failed to create a temporary yaml file: %v", err)
-		}
-		policyStr := fmt.Sprintf(networkUserPolicyTemplate, googleAPIServiceAccount, gkeServiceAccount, strings.TrimSpace(string(subnetETag)))
-		if err = os.WriteFile(tempFile.Name(), []byte(policyStr), os.ModePerm); err != nil {
-			return fmt.Errorf("failed to write the content into %s: %v", tempFile.Name(), err)
-		}
-		if err = runWithOutput(exec.Command("gcloud", "compute", "networks", "subnets", "set-iam-policy", subnetName,
-			tempFile.Name(), "--project="+networkHostProject, "--region="+region)); err != nil {
-			return fmt.Errorf("failed to set IAM policy: %v", err)
+		// Adding the bindings one by one leaves the other bindings of the subnet alone, and
+		// is a no-op for the bindings that already exist.
+		for _, serviceAccount := range []string{googleAPIServiceAccount, gkeServiceAccount} {
+			err := d.addIAMPolicyBinding("compute", "networks", "subnets", "add-iam-policy-binding", subnetName,
+				"--project="+networkHostProject,
+				"--region="+region,
+				"--member=serviceAccount:"+serviceAccount,
+				"--role=roles/compute.networkUser")
+			if err := iamGrantError(strictIAM, err); err != nil {
+				return fmt.Errorf("failed to grant the network user role on subnet %s to %s: %w", subnetName, serviceAccount, err)
+			}
 		}
 	}
 
@@ -350,7 +446,7 @@ func enableSharedVPCAndGrantRoles(projects []string, region, network string) err
 
 // This function implements https://cloud.google.com/kubernetes-engine/docs/how-to/cluster-shared-vpc#grant_host_service_agent_role
 // to grant the Host Service Agent User role to each service project's GKE service account.
-func grantHostServiceAgentUserRole(projects []string) error {
+func (d *Deployer) grantHostServiceAgentUserRole(projects []string, strictIAM bool) error {
 	// Nothing needs to be done for single project.
 	if len(projects) == 1 {
 		return nil
@@ -359,30 +455,31 @@ func grantHostServiceAgentUserRole(projects []string) error {
 	hostProject := projects[0]
 	for i := 1; i < len(projects); i++ {
 		serviceProject := projects[i]
-		serviceProjectNum, err := getProjectNumber(serviceProject)
+		serviceProjectNum, err := gcp.ProjectNumber(serviceProject)
 		if err != nil {
 			return err
 		}
 
 		gkeServiceAccount := fmt.Sprintf("service-%s@container-engine-robot.iam.gserviceaccount.com", serviceProjectNum)
-		if err = runWithOutput(exec.Command("gcloud", "projects", "add-iam-policy-binding", hostProject,
+		err = d.addIAMPolicyBinding("projects", "add-iam-policy-binding", hostProject,
 			"--member=serviceAccount:"+gkeServiceAccount,
-			"--role=roles/container.hostServiceAgentUser")); err != nil {
-			return err
+			"--role=roles/container.hostServiceAgentUser")
+		if err := iamGrantError(strictIAM, err); err != nil {
+			return fmt.Errorf("failed to grant the host service agent user role on %s to %s: %w", hostProject, gkeServiceAccount, err)
 		}
 	}
 	return nil
 }
 
 func (d *Deployer) TeardownNetwork() error {
-	err := disableSharedVPCProjects(d.Projects)
+	err := d.disableSharedVPCProjects(d.Projects)
 	if err != nil {
 		return err
 	}
-	return removeHostServiceAgentUserRole(d.Projects)
+	return d.removeHostServiceAgentUserRole(d.Projects)
 }
 
-func disableSharedVPCProjects(projects []string) error {
+func (d *Deployer) disableSharedVPCProjects(projects []string) error {
 	// Nothing needs to be done for single project.
 	if len(projects) == 1 {
 		return nil
@@ -398,7 +495,7 @@ func disableSharedVPCProjects(projects []string) error {
 
 	// Disassociate the rest of the projects
 	for i := 1; i < len(projects); i++ {
-		if err := runWithOutput(exec.Command("gcloud", "beta", "compute", "shared-vpc",
+		if err := runWithOutput(d.cmder.Command("gcloud", "beta", "compute", "shared-vpc",
 			"associated-projects", "remove", projects[i],
 			"--host-project", networkHostProject)); err != nil {
 			klog.Warningf("Error removing the associated project %q from Shared VPC: %v", projects[i], err)
@@ -406,7 +503,7 @@ func disableSharedVPCProjects(projects []string) error {
 	}
 
 	// Disable Shared VPC for multiproject requests on the host project
-	if err := runWithOutput(exec.Command("gcloud", "beta", "compute", "shared-vpc", "disable", networkHostProject)); err != nil {
+	if err := runWithOutput(d.cmder.Command("gcloud", "beta", "compute", "shared-vpc", "disable", networkHostProject)); err != nil {
 		klog.Warningf("Error disabling Shared VPC for the host project: %v", err)
 	}
 
@@ -415,7 +512,7 @@ func disableSharedVPCProjects(projects []string) error {
 
 // This function implements https://cloud.google.com/kubernetes-engine/docs/how-to/cluster-shared-vpc#removing_the_host_service_agent_user_role
 // to remove the Host Service Agent User role granted to each service project's GKE service account.
-func removeHostServiceAgentUserRole(projects []string) error {
+func (d *Deployer) removeHostServiceAgentUserRole(projects []string) error {
 	// Nothing needs to be done for single project.
 	if len(projects) == 1 {
 		return nil
@@ -424,13 +521,13 @@ func removeHostServiceAgentUserRole(projects []string) error {
 	hostProject := projects[0]
 	for i := 1; i < len(projects); i++ {
 		serviceProject := projects[i]
-		serviceProjectNum, err := getProjectNumber(serviceProject)
+		serviceProjectNum, err := gcp.ProjectNumber(serviceProject)
 		if err != nil {
 			return err
 		}
 
 		gkeServiceAccount := fmt.Sprintf("service-%s@container-engine-robot.iam.gserviceaccount.com", serviceProjectNum)
-		if err = runWithOutput(exec.Command("gcloud", "projects", "remove-iam-policy-binding", hostProject,
+		if err = runWithOutput(d.cmder.Command("gcloud", "projects", "remove-iam-policy-binding", hostProject,
 			"--member=serviceAccount:"+gkeServiceAccount,
 			"--role=roles/container.hostServiceAgentUser")); err != nil {
 			return err
@@ -439,9 +536,37 @@ func removeHostServiceAgentUserRole(projects []string) error {
 	return nil
 }
 
+// subnetwork returns the existing --subnetwork of the location of the attempt
+// retryCount, empty if there is none.
+func (d *Deployer) subnetwork(retryCount int) string {
+	if len(d.Subnetworks) == 0 {
+		return ""
+	}
+	return d.Subnetworks[retryCount]
+}
+
+// existingSubnetworkArgs returns the args to create a cluster in an existing
+// subnetwork of a single-project profile, empty if there is none.
+func existingSubnetworkArgs(autopilot bool, subnetwork, clusterRange, servicesRange string) []string {
+	if subnetwork == "" {
+		return []string{}
+	}
+	args := []string{"--subnetwork=" + subnetwork}
+	if clusterRange != "" {
+		args = append(args,
+			"--cluster-secondary-range-name="+clusterRange,
+			"--services-secondary-range-name="+servicesRange)
+		// secondary ranges require a VPC-native cluster, which Autopilot always is
+		if !autopilot {
+			args = append(args, "--enable-ip-alias")
+		}
+	}
+	return args
+}
+
 // This function returns the args required for creating a private cluster.
 // Reference: https://cloud.google.com/kubernetes-engine/docs/how-to/private-clusters#top_of_page
-func getPrivateClusterArgs(projects []string, network, accessLevel string, masterIPRanges []string, clusterInfo cluster, autopilot bool) []string {
+func getPrivateClusterArgs(projects []string, network, subnetwork, accessLevel string, masterIPRanges []string, clusterInfo cluster, autopilot bool) []string {
 	common := []string{
 		"--enable-private-nodes",
 	}
@@ -455,8 +580,8 @@ func getPrivateClusterArgs(projects []string, network, accessLevel string, maste
 	}
 
 	// For multi-project profile, it'll be using the shared vpc, which creates subnets before cluster creation.
-	// So only create subnetworks if it's single-project profile.
-	if len(projects) == 1 {
+	// So only create subnetworks if it's single-project profile without an existing subnetwork.
+	if len(projects) == 1 && subnetwork == "" {
 		subnetName := network + "-" + clusterInfo.name
 		common = append(common, "--create-subnetwork=name="+subnetName)
 	}
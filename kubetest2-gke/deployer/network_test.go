@@ -27,6 +27,7 @@ func TestPrivateClusterArgs(t *testing.T) {
 		desc           string
 		projects       []string
 		network        string
+		subnetwork     string
 		accessLevel    string
 		masterIPRanges []string
 		clusterInfo    cluster
@@ -115,13 +116,30 @@ func TestPrivateClusterArgs(t *testing.T) {
 				"--no-enable-master-authorized-networks",
 			},
 		},
+		{
+			desc:           "no subnetwork is created for private clusters in an existing subnetwork",
+			projects:       []string{"project1"},
+			network:        "test-network6",
+			subnetwork:     "test-subnet6",
+			accessLevel:    string(limited),
+			masterIPRanges: []string{"173.16.0.32/28"},
+			clusterInfo:    cluster{index: 0, name: "cluster1"},
+			expected: []string{
+				"--enable-private-nodes",
+				"--enable-ip-alias",
+				"--no-enable-basic-auth",
+				"--master-ipv4-cidr=173.16.0.32/28",
+				"--no-issue-client-certificate",
+				"--enable-master-authorized-networks",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.desc, func(st *testing.T) {
 			st.Parallel()
-			actual := getPrivateClusterArgs(tc.projects, tc.network, tc.accessLevel, tc.masterIPRanges, tc.clusterInfo, tc.autopilot)
+			actual := getPrivateClusterArgs(tc.projects, tc.network, tc.subnetwork, tc.accessLevel, tc.masterIPRanges, tc.clusterInfo, tc.autopilot)
 			if diff := cmp.Diff(actual, tc.expected); diff != "" {
 				st.Error("Got private cluster args (-want, +got) =", diff)
 			}
@@ -129,6 +147,280 @@ func TestPrivateClusterArgs(t *testing.T) {
 	}
 }
 
+func TestExistingSubnetworkArgs(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		autopilot     bool
+		subnetwork    string
+		clusterRange  string
+		servicesRange string
+		expected      []string
+	}{
+		{
+			desc:     "no existing subnetwork",
+			expected: []string{},
+		},
+		{
+			desc:       "existing subnetwork",
+			subnetwork: "subnet1",
+			expected:   []string{"--subnetwork=subnet1"},
+		},
+		{
+			desc:          "existing subnetwork with secondary ranges",
+			subnetwork:    "subnet1",
+			clusterRange:  "pods",
+			servicesRange: "services",
+			expected: []string{
+				"--subnetwork=subnet1",
+				"--cluster-secondary-range-name=pods",
+				"--services-secondary-range-name=services",
+				"--enable-ip-alias",
+			},
+		},
+		{
+			desc:          "Autopilot clusters are always VPC-native",
+			autopilot:     true,
+			subnetwork:    "subnet1",
+			clusterRange:  "pods",
+			servicesRange: "services",
+			expected: []string{
+				"--subnetwork=subnet1",
+				"--cluster-secondary-range-name=pods",
+				"--services-secondary-range-name=services",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			actual := existingSubnetworkArgs(tc.autopilot, tc.subnetwork, tc.clusterRange, tc.servicesRange)
+			if diff := cmp.Diff(actual, tc.expected); diff != "" {
+				t.Error("Got existing subnetwork args (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
+func TestVerifySubnetworkFlags(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		numProjects   int
+		totalTryCount int
+		subnetworks   []string
+		clusterRange  string
+		servicesRange string
+		expectErr     bool
+	}{
+		{
+			desc:          "no existing subnetwork",
+			numProjects:   1,
+			totalTryCount: 1,
+		},
+		{
+			desc:          "existing subnetwork with secondary ranges",
+			numProjects:   1,
+			totalTryCount: 1,
+			subnetworks:   []string{"subnet1"},
+			clusterRange:  "pods",
+			servicesRange: "services",
+		},
+		{
+			desc:          "one existing subnetwork per location",
+			numProjects:   1,
+			totalTryCount: 2,
+			subnetworks:   []string{"subnet1", "subnet2"},
+		},
+		{
+			desc:          "a single existing subnetwork for several locations",
+			numProjects:   1,
+			totalTryCount: 2,
+			subnetworks:   []string{"subnet1"},
+			expectErr:     true,
+		},
+		{
+			desc:          "multi-project profile",
+			numProjects:   2,
+			totalTryCount: 1,
+			subnetworks:   []string{"subnet1"},
+			expectErr:     true,
+		},
+		{
+			desc:          "only one secondary range",
+			numProjects:   1,
+			totalTryCount: 1,
+			subnetworks:   []string{"subnet1"},
+			clusterRange:  "pods",
+			expectErr:     true,
+		},
+		{
+			desc:          "secondary ranges without subnetwork",
+			numProjects:   1,
+			totalTryCount: 1,
+			clusterRange:  "pods",
+			servicesRange: "services",
+			expectErr:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := verifySubnetworkFlags(tc.numProjects, tc.totalTryCount, tc.subnetworks, tc.clusterRange, tc.servicesRange)
+			if tc.expectErr != (err != nil) {
+				t.Errorf("expected error %v but got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestVerifyIPv4CIDRFlags(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		numProjects    int
+		numClusters    int
+		clusterRange   string
+		clusterCIDR    string
+		servicesCIDR   string
+		masterIPRanges []string
+		expectErr      bool
+	}{
+		{
+			desc:        "no ranges",
+			numProjects: 1,
+			numClusters: 1,
+		},
+		{
+			desc:         "full CIDRs",
+			numProjects:  1,
+			numClusters:  1,
+			clusterCIDR:  "10.0.0.0/12",
+			servicesCIDR: "10.16.0.0/19",
+		},
+		{
+			desc:         "full CIDR for multiple clusters",
+			numProjects:  1,
+			numClusters:  2,
+			clusterCIDR:  "10.0.0.0/12",
+			servicesCIDR: "/19",
+			expectErr:    true,
+		},
+		{
+			desc:         "sizes for multiple clusters",
+			numProjects:  1,
+			numClusters:  2,
+			clusterCIDR:  "/12",
+			servicesCIDR: "/19",
+		},
+		{
+			desc:           "full CIDRs with private cluster master ranges",
+			numProjects:    1,
+			numClusters:    1,
+			clusterCIDR:    "10.0.0.0/12",
+			servicesCIDR:   "10.16.0.0/19",
+			masterIPRanges: []string{"172.16.0.0/28"},
+		},
+		{
+			desc:           "full CIDR overlapping a private cluster master range",
+			numProjects:    1,
+			numClusters:    1,
+			clusterCIDR:    "10.0.0.0/12",
+			masterIPRanges: []string{"10.8.0.0/28"},
+			expectErr:      true,
+		},
+		{
+			desc:         "sizes",
+			numProjects:  1,
+			numClusters:  1,
+			clusterCIDR:  "/12",
+			servicesCIDR: "/19",
+		},
+		{
+			desc:         "overlapping CIDRs",
+			numProjects:  1,
+			numClusters:  1,
+			clusterCIDR:  "10.0.0.0/12",
+			servicesCIDR: "10.4.0.0/19",
+			expectErr:    true,
+		},
+		{
+			desc:        "invalid size",
+			numProjects: 1,
+			numClusters: 1,
+			clusterCIDR: "/40",
+			expectErr:   true,
+		},
+		{
+			desc:         "invalid CIDR",
+			numProjects:  1,
+			numClusters:  1,
+			servicesCIDR: "10.4.0.0",
+			expectErr:    true,
+		},
+		{
+			desc:        "multi-project profile",
+			numProjects: 2,
+			clusterCIDR: "/12",
+			expectErr:   true,
+		},
+		{
+			desc:         "existing secondary ranges",
+			numProjects:  1,
+			numClusters:  1,
+			clusterRange: "pods",
+			clusterCIDR:  "/12",
+			expectErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := verifyIPv4CIDRFlags(tc.numProjects, tc.numClusters, tc.clusterRange, tc.clusterCIDR, tc.servicesCIDR, tc.masterIPRanges)
+			if tc.expectErr != (err != nil) {
+				t.Errorf("expected error %v but got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
+
+func TestIPv4CIDRArgs(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		autopilot    bool
+		clusterCIDR  string
+		servicesCIDR string
+		expected     []string
+	}{
+		{
+			desc: "no ranges",
+		},
+		{
+			desc:        "pods range only",
+			clusterCIDR: "/12",
+			expected:    []string{"--cluster-ipv4-cidr=/12"},
+		},
+		{
+			desc:         "pods and services ranges",
+			clusterCIDR:  "10.0.0.0/12",
+			servicesCIDR: "10.16.0.0/19",
+			expected:     []string{"--cluster-ipv4-cidr=10.0.0.0/12", "--services-ipv4-cidr=10.16.0.0/19", "--enable-ip-alias"},
+		},
+		{
+			desc:         "Autopilot clusters are always VPC-native",
+			autopilot:    true,
+			servicesCIDR: "/19",
+			expected:     []string{"--services-ipv4-cidr=/19"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			actual := ipv4CIDRArgs(tc.autopilot, tc.clusterCIDR, tc.servicesCIDR)
+			if diff := cmp.Diff(actual, tc.expected); diff != "" {
+				t.Error("Got ipv4 CIDR args (-want, +got) =", diff)
+			}
+		})
+	}
+}
+
 func TestAssertNoOverlaps(t *testing.T) {
 	testCases := []struct {
 		ranges     []string
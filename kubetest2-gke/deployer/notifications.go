@@ -0,0 +1,192 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/artifacts"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+const (
+	// notificationPullLimit is the number of notifications pulled at once
+	notificationPullLimit = 100
+	// maxNotificationPulls bounds the pulls of a subscription, in case
+	// notifications keep coming in
+	maxNotificationPulls = 50
+)
+
+// notificationTopicID returns the id of both the Pub/Sub topic the clusters of
+// the run send their notifications to, and of its subscription.
+func notificationTopicID(runID string) string {
+	return fmt.Sprintf("kt2-notifications-%08x", crc32.ChecksumIEEE([]byte(runID)))
+}
+
+// notificationConfigArgs returns the gcloud args to send the notifications of
+// a cluster in the given project to the topic of the run, if
+// --capture-notifications is set.
+func (d *Deployer) notificationConfigArgs(project string) []string {
+	if !d.CaptureNotifications {
+		return nil
+	}
+	topic := fmt.Sprintf("projects/%s/topics/%s", project, notificationTopicID(d.Kubetest2CommonOptions.RunID()))
+	return []string{"--notification-config=pubsub=ENABLED,pubsub-topic=" + topic}
+}
+
+// CreateNotificationTopics creates the topic and subscription of the cluster
+// notifications in each project if --capture-notifications is set.
+func (d *Deployer) CreateNotificationTopics() error {
+	if !d.CaptureNotifications {
+		return nil
+	}
+	id := notificationTopicID(d.Kubetest2CommonOptions.RunID())
+	for _, project := range d.Projects {
+		klog.V(1).Infof("Creating cluster notifications topic %s in project %s", id, project)
+		if err := runWithOutput(d.cmder.Command("gcloud", "pubsub", "topics", "create", id,
+			"--project="+project),
+		); err != nil {
+			return fmt.Errorf("error creating cluster notifications topic in project %s: %w", project, err)
+		}
+		if err := runWithOutput(d.cmder.Command("gcloud", "pubsub", "subscriptions", "create", id,
+			"--project="+project,
+			"--topic="+id),
+		); err != nil {
+			return fmt.Errorf("error subscribing to cluster notifications in project %s: %w", project, err)
+		}
+	}
+	return nil
+}
+
+// DeleteNotificationTopics deletes the subscriptions and topics created by
+// CreateNotificationTopics.
+func (d *Deployer) DeleteNotificationTopics() error {
+	if !d.CaptureNotifications {
+		return nil
+	}
+	id := notificationTopicID(d.Kubetest2CommonOptions.RunID())
+	var errs []error
+	for _, project := range d.Projects {
+		if err := runWithOutput(d.cmder.Command("gcloud", "pubsub", "subscriptions", "delete", id,
+			"--project="+project,
+			"--quiet"),
+		); err != nil {
+			errs = append(errs, fmt.Errorf("error deleting cluster notifications subscription in project %s: %w", project, err))
+		}
+		if err := runWithOutput(d.cmder.Command("gcloud", "pubsub", "topics", "delete", id,
+			"--project="+project,
+			"--quiet"),
+		); err != nil {
+			errs = append(errs, fmt.Errorf("error deleting cluster notifications topic in project %s: %w", project, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// notification is a cluster notification saved to the artifacts
+type notification struct {
+	MessageID   string            `json:"messageId"`
+	PublishTime string            `json:"publishTime"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	Data        string            `json:"data"`
+}
+
+// parseNotifications parses the output of
+// `gcloud pubsub subscriptions pull --format=json`, decoding the payloads.
+func parseNotifications(out []byte) ([]notification, error) {
+	var pulled []struct {
+		Message struct {
+			MessageID   string            `json:"messageId"`
+			PublishTime string            `json:"publishTime"`
+			Attributes  map[string]string `json:"attributes"`
+			Data        string            `json:"data"`
+		} `json:"message"`
+	}
+	if err := json.Unmarshal(out, &pulled); err != nil {
+		return nil, err
+	}
+	notifications := make([]notification, 0, len(pulled))
+	for _, p := range pulled {
+		n := notification{
+			MessageID:   p.Message.MessageID,
+			PublishTime: p.Message.PublishTime,
+			Attributes:  p.Message.Attributes,
+			Data:        p.Message.Data,
+		}
+		if data, err := base64.StdEncoding.DecodeString(p.Message.Data); err == nil {
+			n.Data = string(data)
+		}
+		notifications = append(notifications, n)
+	}
+	return notifications, nil
+}
+
+// SaveNotifications pulls the cluster notifications received during the run
+// and saves them to gke-notifications-<project>.json in the artifacts, if
+// --capture-notifications is set.
+func (d *Deployer) SaveNotifications() error {
+	if !d.CaptureNotifications {
+		return nil
+	}
+	id := notificationTopicID(d.Kubetest2CommonOptions.RunID())
+	var errs []error
+	for _, project := range d.Projects {
+		var notifications []notification
+		for i := 0; i < maxNotificationPulls; i++ {
+			out, err := exec.Output(d.cmder.Command("gcloud", "pubsub", "subscriptions", "pull", id,
+				"--project="+project,
+				"--auto-ack",
+				fmt.Sprintf("--limit=%d", notificationPullLimit),
+				"--format=json"))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("error pulling cluster notifications in project %s: %s", project, execError(err)))
+				break
+			}
+			pulled, err := parseNotifications(out)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("error parsing cluster notifications in project %s: %w", project, err))
+				break
+			}
+			if len(pulled) == 0 {
+				break
+			}
+			notifications = append(notifications, pulled...)
+		}
+		klog.V(1).Infof("Received %d cluster notifications in project %s", len(notifications), project)
+		if len(notifications) == 0 {
+			continue
+		}
+		b, err := json.MarshalIndent(notifications, "", "  ")
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		path := filepath.Join(artifacts.BaseDir(), fmt.Sprintf("gke-notifications-%s.json", project))
+		if err := os.WriteFile(path, b, 0644); err != nil {
+			errs = append(errs, fmt.Errorf("error saving cluster notifications: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
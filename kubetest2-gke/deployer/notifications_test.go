@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseNotifications(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		output        string
+		expected      []notification
+		expectedError bool
+	}{
+		{
+			desc:     "no notifications",
+			output:   `[]`,
+			expected: []notification{},
+		},
+		{
+			desc: "upgrade event",
+			output: `[
+  {
+    "ackId": "UAYWLF1GSFE3GQhoUQ5PXiM_NSAoRRIJB08CKF15MEorQVh0",
+    "message": {
+      "attributes": {
+        "cluster_name": "kt2-abc",
+        "type_url": "type.googleapis.com/google.container.v1beta1.UpgradeEvent"
+      },
+      "data": "TWFzdGVyIGlzIHVwZ3JhZGluZyB0byB2ZXJzaW9uIDEuMzEuMS1na2UuMTAwMC4=",
+      "messageId": "1234",
+      "publishTime": "2026-10-16T10:00:00.000Z"
+    }
+  }
+]`,
+			expected: []notification{
+				{
+					MessageID:   "1234",
+					PublishTime: "2026-10-16T10:00:00.000Z",
+					Attributes: map[string]string{
+						"cluster_name": "kt2-abc",
+						"type_url":     "type.googleapis.com/google.container.v1beta1.UpgradeEvent",
+					},
+					Data: "Master is upgrading to version 1.31.1-gke.1000.",
+				},
+			},
+		},
+		{
+			desc:   "data that is not base64 is kept as is",
+			output: `[{"message": {"data": "not base64!", "messageId": "1"}}]`,
+			expected: []notification{
+				{
+					MessageID: "1",
+					Data:      "not base64!",
+				},
+			},
+		},
+		{
+			desc:          "invalid output",
+			output:        `Listed 0 items.`,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			notifications, err := parseNotifications([]byte(tc.output))
+			if tc.expectedError {
+				if err == nil {
+					t.Errorf("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(notifications, tc.expected) {
+				t.Errorf("expected %#v but got %#v", tc.expected, notifications)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	observabilityNone   = "NONE"
+	observabilitySystem = "SYSTEM"
+)
+
+// validateObservabilityComponents validates the components of the --logging
+// or --monitoring flag: NONE disables the integration and can't be combined,
+// the other components require SYSTEM.
+func validateObservabilityComponents(flag string, components []string) error {
+	if len(components) == 0 {
+		return nil
+	}
+	hasSystem := false
+	for _, c := range components {
+		switch strings.ToUpper(c) {
+		case observabilityNone:
+			if len(components) > 1 {
+				return fmt.Errorf("--%s=%s cannot be combined with other components", flag, observabilityNone)
+			}
+			return nil
+		case observabilitySystem:
+			hasSystem = true
+		}
+	}
+	if !hasSystem {
+		return fmt.Errorf("--%s components %s require %s", flag, strings.Join(components, ","), observabilitySystem)
+	}
+	return nil
+}
+
+// validateObservabilityFlags validates the logging and monitoring flags.
+func (d *Deployer) validateObservabilityFlags() error {
+	if err := validateObservabilityComponents("logging", d.Logging); err != nil {
+		return err
+	}
+	if err := validateObservabilityComponents("monitoring", d.Monitoring); err != nil {
+		return err
+	}
+	if d.EnableManagedPrometheus && len(d.Monitoring) == 1 && strings.ToUpper(d.Monitoring[0]) == observabilityNone {
+		return fmt.Errorf("--enable-managed-prometheus requires system monitoring, cannot be used with --monitoring=%s", observabilityNone)
+	}
+	return nil
+}
+
+// observabilityClusterArgs returns the gcloud clusters create args for the
+// logging and monitoring flags, gcloud defaults apply to the unset ones.
+func (d *Deployer) observabilityClusterArgs() []string {
+	var args []string
+	if len(d.Logging) > 0 {
+		args = append(args, "--logging="+strings.ToUpper(strings.Join(d.Logging, ",")))
+	}
+	if len(d.Monitoring) > 0 {
+		args = append(args, "--monitoring="+strings.ToUpper(strings.Join(d.Monitoring, ",")))
+	}
+	if d.EnableManagedPrometheus {
+		args = append(args, "--enable-managed-prometheus")
+	}
+	return args
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gke/deployer/options"
+)
+
+func TestValidateObservabilityFlags(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		opts  options.ClusterOptions
+		valid bool
+	}{
+		{
+			desc:  "no observability flags is valid",
+			valid: true,
+		},
+		{
+			desc: "system and workload logging is valid",
+			opts: options.ClusterOptions{
+				Logging: []string{"SYSTEM", "WORKLOAD"},
+			},
+			valid: true,
+		},
+		{
+			desc: "no logging and monitoring is valid",
+			opts: options.ClusterOptions{
+				Logging:    []string{"none"},
+				Monitoring: []string{"NONE"},
+			},
+			valid: true,
+		},
+		{
+			desc: "none combined with other components is invalid",
+			opts: options.ClusterOptions{
+				Monitoring: []string{"NONE", "SYSTEM"},
+			},
+			valid: false,
+		},
+		{
+			desc: "workload logging without system is invalid",
+			opts: options.ClusterOptions{
+				Logging: []string{"WORKLOAD"},
+			},
+			valid: false,
+		},
+		{
+			desc: "managed prometheus without monitoring is invalid",
+			opts: options.ClusterOptions{
+				Monitoring:              []string{"NONE"},
+				EnableManagedPrometheus: true,
+			},
+			valid: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			d := &Deployer{ClusterOptions: &tc.opts}
+			err := d.validateObservabilityFlags()
+			if tc.valid && err != nil {
+				t.Errorf("expected no error but got %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Error("expected an error but got none")
+			}
+		})
+	}
+}
+
+func TestObservabilityClusterArgs(t *testing.T) {
+	d := &Deployer{ClusterOptions: &options.ClusterOptions{
+		Logging:                 []string{"system", "workload"},
+		Monitoring:              []string{"SYSTEM", "API_SERVER"},
+		EnableManagedPrometheus: true,
+	}}
+	expected := []string{"--logging=SYSTEM,WORKLOAD", "--monitoring=SYSTEM,API_SERVER", "--enable-managed-prometheus"}
+	if diff := cmp.Diff(expected, d.observabilityClusterArgs()); diff != "" {
+		t.Errorf("unexpected args (-want +got):\n%s", diff)
+	}
+}
@@ -16,7 +16,10 @@ limitations under the License.
 
 package options
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type ExtraNodePoolOptions struct {
 	Name        string
@@ -26,7 +29,8 @@ type ExtraNodePoolOptions struct {
 }
 
 type ClusterOptions struct {
-	Environment string `flag:"~environment" desc:"Container API endpoint to use, one of 'test', 'staging', 'prod', or a custom https:// URL. Defaults to prod if not provided"`
+	Environment        string `flag:"~environment" desc:"Container API endpoint to use, one of 'test', 'autopush', 'staging', 'staging2', 'prod', or a custom https:// URL. Defaults to prod if not provided"`
+	EnvironmentCACerts string `flag:"~environment-ca-certs" desc:"Path to a PEM bundle of the CA certificates gcloud trusts, for --environment endpoints with a custom TLS CA."`
 
 	GcloudCommandGroup string `flag:"~gcloud-command-group" desc:"gcloud command group, can be one of empty, alpha, beta."`
 	Autopilot          bool   `flag:"~autopilot" desc:"Whether to create GKE Autopilot clusters or not."`
@@ -52,10 +56,53 @@ type ClusterOptions struct {
 	WindowsMachineType string `flag:"~windows-machine-type" desc:"For use with gcloud commands to specify the machine type for Windows node in the cluster."`
 	WindowsImageType   string `flag:"~windows-image-type" desc:"The Windows image type to use for the cluster."`
 
+	ClusterCreateBatchSize  int           `flag:"~cluster-create-batch-size" desc:"Maximum number of clusters created concurrently in each project, the clusters of a project are created in batches of this size. Keeps runs creating many clusters under the GKE limit of concurrent operations per project. 0 creates all the clusters at once."`
+	ClusterCreateBatchDelay time.Duration `flag:"~cluster-create-batch-delay" desc:"Time to wait between the batches of --cluster-create-batch-size clusters in a project, e.g. 1m."`
+
 	NodePoolCreateConcurrency int      `flag:"~nodepool-create-concurrency" desc:"Number of nodepools to create concurrently, default is 1"`
-	ExtraNodePool             []string `flag:"~extra-nodepool" desc:"create an extra nodepool. repeat the flag for another nodepool. options as key=value&key=value... supported options are name,machine-type,image-type,num-nodes. "`
+	ExtraNodePool             []string `flag:"~extra-nodepool" desc:"create an extra nodepool. repeat the flag for another nodepool. options as key=value&key=value... supported options are name,machine-type,image-type,num-nodes,accelerator,tpu-topology,service-account,min-nodes,max-nodes,location-policy. service-account defaults to --node-service-account. max-nodes enables the autoscaling of the nodepool between min-nodes and max-nodes per zone, location-policy is ANY or BALANCED and requires GKE 1.24 or later. accelerator takes the gcloud format e.g. accelerator=type=nvidia-tesla-t4,count=1, the NVIDIA driver is installed after up unless it sets gpu-driver-version."`
+
+	NodeServiceAccount       string `flag:"~node-service-account" desc:"Service account email used by the nodes of the clusters and extra nodepools. Defaults to the Compute Engine default service account."`
+	CreateNodeServiceAccount bool   `flag:"~create-node-service-account" desc:"Whether to create a least-privilege service account in each project for the nodes of the run, and delete it at down. Cannot be used with --node-service-account."`
+
+	NodeTags                      []string `flag:"~node-tags" desc:"Comma separated network tags of the nodes of the clusters and extra nodepools. When set, the e2e firewall rule of each cluster targets them instead of the tags of its first instance, unless --firewall-source-service-accounts is set. Not supported with --autopilot."`
+	FirewallSourceServiceAccounts []string `flag:"~firewall-source-service-accounts" desc:"Comma separated service accounts whose instances the e2e firewall rule of each cluster allows traffic from. The rule then targets the node service accounts of the cluster instead of network tags, as a rule can't mix both; the nodes are still tagged with --node-tags."`
+
+	RetryableErrorPatterns []string      `flag:"~retryable-error-patterns" desc:"Comma separated list of regex match patterns for retryable errors during cluster creation."`
+	RetryReservedTestTime  time.Duration `flag:"~retry-reserved-test-time" desc:"Time reserved for the tests when retrying the cluster creation in the next region or zone within the deadline of the run set by --run-timeout. The creation is not retried when the remaining time cannot fit another attempt, as long as the last one, and this time."`
+
+	EnableShieldedNodes         bool   `flag:"~enable-shielded-nodes" desc:"Whether to enable Shielded GKE Nodes for the clusters. Not supported with --autopilot, where nodes are always shielded."`
+	ShieldedSecureBoot          bool   `flag:"~shielded-secure-boot" desc:"Whether the nodes of the clusters and extra nodepools use Secure Boot. Requires --enable-shielded-nodes."`
+	ShieldedIntegrityMonitoring bool   `flag:"~shielded-integrity-monitoring" desc:"Whether the nodes of the clusters and extra nodepools use integrity monitoring. Requires --enable-shielded-nodes."`
+	BinauthzEvaluationMode      string `flag:"~binauthz-evaluation-mode" desc:"Binary Authorization evaluation mode of the clusters, one of DISABLED, PROJECT_SINGLETON_POLICY_ENFORCE, POLICY_BINDINGS or POLICY_BINDINGS_AND_PROJECT_SINGLETON_POLICY_ENFORCE. The POLICY_BINDINGS modes require GKE 1.27 or later."`
+
+	Logging                 []string `flag:"~logging" desc:"Comma separated list of the logging components of the clusters, e.g. SYSTEM,WORKLOAD, or NONE to disable Cloud Logging, e.g. for scale tests. Defaults to the GKE default."`
+	Monitoring              []string `flag:"~monitoring" desc:"Comma separated list of the monitoring components of the clusters, e.g. SYSTEM,API_SERVER, or NONE to disable Cloud Monitoring, e.g. for scale tests. Defaults to the GKE default."`
+	EnableManagedPrometheus bool     `flag:"~enable-managed-prometheus" desc:"Whether to enable Google Cloud Managed Service for Prometheus in the clusters. Requires system monitoring."`
+
+	GatewayAPI            string `flag:"~gateway-api" desc:"Gateway API channel of the clusters, standard or disabled, e.g. for the Gateway API e2e tests. Defaults to the GKE default."`
+	EnableL4ILBSubsetting bool   `flag:"~enable-l4-ilb-subsetting" desc:"Whether to enable GKE subsetting for the L4 internal load balancers of the clusters, e.g. for the LoadBalancer Service e2e tests of large clusters. Cannot be disabled on a cluster once enabled."`
+
+	AutoscalingProfile string `flag:"~autoscaling-profile" desc:"Cluster autoscaler profile of the clusters, optimize-utilization or balanced, e.g. for autoscaler behavior tests. Defaults to the GKE default, balanced. Requires GKE 1.18 or later, not supported with --autopilot."`
+
+	Spot               bool `flag:"~spot" desc:"Whether the default nodepool of the clusters uses Spot VMs, which can be preempted at any time. Not supported with --autopilot."`
+	SimulatePreemption bool `flag:"~simulate-preemption" desc:"Whether to delete the VM of one node of the default nodepool of each cluster at the end of up, before the tests, like a preemption does. The VM is recreated by its instance group."`
+
+	PostUpManifests []string `flag:"~post-up-manifests" desc:"Paths or http(s) URLs of manifests applied to all the clusters at the end of up, e.g. to install addons or the CRDs of the tests. Repeat the flag for several manifests, applied in order. Each apply is retried, the Deployments, DaemonSets and StatefulSets of the manifests are waited for, and the manifests are copied to the artifacts."`
+
+	CaptureNotifications bool `flag:"~capture-notifications" desc:"Whether to send the GKE cluster notifications of the created clusters, e.g. upgrade events and security bulletins, to a Pub/Sub topic during the run, and save them to gke-notifications-<project>.json in the artifacts at down. Cannot be used with --skip-cluster-create."`
+
+	ExportInfra string `flag:"~export-infra" desc:"Format to export the clusters, nodepools, networks, subnets and firewall rules of the run in at the end of up, krm for Config Connector YAML or terraform, into infra-export/<project> in the artifacts, to reproduce the topology of the run as infrastructure as code. Uses gcloud beta resource-config bulk-export, which needs the config-connector gcloud component. Only the files referring to the clusters or the network of the run are kept, the resources of other runs in shared projects are left out. A failed export doesn't fail up."`
+
+	SkipClusterCreate bool `flag:"~skip-cluster-create" desc:"Whether to reuse the existing clusters named by --cluster-name in --project and --zone/--region instead of creating them. Up checks that the clusters are ready and prepares them for the tests, Down leaves the clusters and their network in place."`
+
+	ClusterTTL          time.Duration `flag:"~cluster-ttl" desc:"If set, the clusters are labeled with cleanup-after=<unix time> this long after creation, for janitors of shared projects."`
+	DeletionProtection  bool          `flag:"~deletion-protection" desc:"Whether to label the clusters with deletion-protection=true for their lifetime, janitors of shared projects must not delete protected clusters. The label is removed at down."`
+	ClusterReadyTimeout time.Duration `flag:"~cluster-ready-timeout" desc:"Maximum time to wait after creation for each cluster and all its nodepools to be RUNNING, e.g. 15m. 0 disables the wait, except for clusters adopted from a previous attempt which are waited for up to 15m."`
+	DownTimeout         time.Duration `flag:"~down-timeout" desc:"Maximum time to wait for the deletion of each cluster during down, e.g. 30m. 0 means no timeout, except when waiting for the cluster of a previous attempt to be deleted, which gives up after 30m."`
+	DownAction          string        `flag:"~down-action" desc:"What down does with the clusters, delete them or scale-to-zero, which resizes all their nodepools to zero nodes to stop the node cost and leaves the clusters and their network in place. The next up with --skip-cluster-create resizes the nodepools back to their initial size, much faster than creating the clusters. scale-to-zero requires --project and is not supported with --autopilot or autoscaled nodepools, which the cluster autoscaler scales back up."`
 
-	RetryableErrorPatterns []string `flag:"~retryable-error-patterns" desc:"Comma separated list of regex match patterns for retryable errors during cluster creation."`
+	WorkloadIdentityReadyTimeout time.Duration `flag:"~workload-identity-ready-timeout" desc:"With --enable-workload-identity, maximum time to wait before the tests for a canary pod in each cluster to get its workload identity from the GKE metadata server, e.g. 5m. 0 disables the check."`
 }
 
 func (uo *ClusterOptions) Validate() error {
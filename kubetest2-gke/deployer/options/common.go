@@ -20,4 +20,8 @@ type CommonOptions struct {
 	RepoRoot          string `desc:"Path to root of the kubernetes repo. Used with --build and for dumping cluster logs."`
 	GCPServiceAccount string `flag:"~gcp-service-account" desc:"Service account to activate before using gcloud."`
 	GCPSSHKeyIgnored  bool   `flag:"~ignore-gcp-ssh-key" desc:"Whether the GCP SSH key should be ignored or not for bringing up the cluster."`
+
+	MinGcloudVersion string `flag:"~min-gcloud-version" desc:"The oldest Google Cloud SDK version the deployer runs with, checked at init so that an outdated gcloud fails fast instead of on unrecognized arguments. Empty skips the check."`
+
+	IsolateGcloudConfig bool `flag:"~isolate-gcloud-config" desc:"Whether to run gcloud with a copy of the gcloud configuration in a temporary directory removed at the end of the run, so that setting the project of the run doesn't change the active project of the user or of parallel runs. The copy holds the gcloud credentials and is kept out of the run dir."`
 }
@@ -19,7 +19,17 @@ package options
 type NetworkOptions struct {
 	Network string `flag:"~network" desc:"Cluster network. Defaults to the default network if not provided. For multi-project use cases, this will be the Shared VPC network name."`
 
-	PrivateClusterAccessLevel    string   `flag:"~private-cluster-access-level" desc:"Private cluster access level, if not empty, must be one of 'no', 'limited' or 'unrestricted'. See the details in https://cloud.google.com/kubernetes-engine/docs/how-to/private-clusters."`
-	PrivateClusterMasterIPRanges []string `flag:"~private-cluster-master-ip-range" desc:"Private cluster master IP ranges. It should be IPv4 CIDR(s), and its length must be the same as the number of clusters if private cluster is requested."`
-	SubnetworkRanges             []string `flag:"~subnetwork-ranges" desc:"Subnetwork ranges as required for shared VPC setup as described in https://cloud.google.com/kubernetes-engine/docs/how-to/cluster-shared-vpc#creating_a_network_and_two_subnets. For multi-project profile, it is required and should be in the format of 10.0.4.0/22 10.0.32.0/20 10.4.0.0/14,172.16.4.0/22 172.16.16.0/20 172.16.4.0/22, where the subnetworks configuration for different project are separated by comma, and the ranges of each subnetwork configuration is separated by space."`
+	PrivateClusterAccessLevel       string   `flag:"~private-cluster-access-level" desc:"Private cluster access level, if not empty, must be one of 'no', 'limited' or 'unrestricted'. See the details in https://cloud.google.com/kubernetes-engine/docs/how-to/private-clusters."`
+	CreateNAT                       bool     `flag:"~create-nat" desc:"Whether to create a Cloud Router with Cloud NAT in the network for private clusters, so that the private nodes can pull images from outside Google. Defaults to true, it is only created if the network has no Cloud NAT in the region of the clusters yet. Set --create-nat=false if the network provides egress otherwise."`
+	PrivateGoogleAccessDNS          bool     `flag:"~private-google-access-dns" desc:"Whether to create private DNS zones in the network for private clusters, resolving googleapis.com, gcr.io and pkg.dev to private.googleapis.com, for networks without a route to the internet. Defaults to true, like --create-nat, so that the private nodes reach the Google APIs and registries through Private Google Access. The zones are deleted at down."`
+	PrivateClusterMasterIPRanges    []string `flag:"~private-cluster-master-ip-range" desc:"Private cluster master IP ranges. It should be IPv4 CIDR(s), and its length must be the same as the number of clusters if private cluster is requested."`
+	PrivateClusterMasterIPRangePool string   `flag:"~private-cluster-master-ip-range-pool" desc:"IPv4 CIDR, e.g. 172.16.0.0/16, to lease the private cluster master IP ranges from instead of --private-cluster-master-ip-range, so that the runs sharing the network of a project don't use overlapping ranges. Each run leases a block of /28 ranges for all of its clusters and attempts, released after down. Only the runs sharing $KUBETEST2_LEASE_DIR, on the same machine by default, are coordinated."`
+	SubnetworkRanges                []string `flag:"~subnetwork-ranges" desc:"Subnetwork ranges as required for shared VPC setup as described in https://cloud.google.com/kubernetes-engine/docs/how-to/cluster-shared-vpc#creating_a_network_and_two_subnets. For multi-project profile, it is required and should be in the format of 10.0.4.0/22 10.0.32.0/20 10.4.0.0/14,172.16.4.0/22 172.16.16.0/20 172.16.4.0/22, where the subnetworks configuration for different project are separated by comma, and the ranges of each subnetwork configuration is separated by space."`
+	Subnetworks                     []string `flag:"~subnetwork" desc:"Existing subnetworks of --network to create the clusters in, for single-project profile, instead of auto-creating them. A subnetwork belongs to a single region, so one is required per --region or --zone, in the same order, and the one of the location is used on each retry. The network and subnetworks are left in place at down."`
+	ClusterSecondaryRangeName       string   `flag:"~cluster-secondary-range-name" desc:"Name of the existing secondary range of the --subnetwork subnetworks used for pod IPs. Requires --subnetwork and --services-secondary-range-name."`
+	ServicesSecondaryRangeName      string   `flag:"~services-secondary-range-name" desc:"Name of the existing secondary range of the --subnetwork subnetworks used for service IPs. Requires --subnetwork and --cluster-secondary-range-name."`
+	ClusterIPv4CIDR                 string   `flag:"~cluster-ipv4-cidr" desc:"IP range of the pods of the clusters, a CIDR like 10.0.0.0/14 for a single cluster or a size like /14, for single-project profile. Large scale tests need a bigger range than the default /14 not to run out of pod IPs. Cannot be used with --cluster-secondary-range-name."`
+	ServicesIPv4CIDR                string   `flag:"~services-ipv4-cidr" desc:"IP range of the services of the clusters, a CIDR like 10.4.0.0/19 for a single cluster or a size like /19, for single-project profile. The clusters are VPC-native. Cannot be used with --services-secondary-range-name."`
+
+	StrictIAM bool `flag:"~strict-iam" desc:"Whether failing to grant the shared VPC IAM roles to the service projects of the multi-project profile fails up. Set --strict-iam=false to only log the failures, for projects granted the roles beforehand."`
 }
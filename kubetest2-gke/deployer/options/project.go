@@ -24,4 +24,7 @@ type ProjectOptions struct {
 	BoskosHeartbeatIntervalSeconds int      `flag:"~boskos-heartbeat-interval-seconds" desc:"How often (in seconds) to send a heartbeat to Boskos to hold the acquired resource. 0 means no heartbeat."`
 	BoskosResourceType             []string `flag:"~boskos-resource-type" desc:"If set, manually specifies the resource type(s) of GCP projects to acquire from Boskos."`
 	BoskosProjectsRequested        []int    `flag:"~projects-requested" desc:"Number of projects to request from Boskos. It is only respected if projects is empty, and must be larger than zero."`
+	BoskosVerifyProjects           bool     `flag:"~boskos-verify-projects" desc:"Whether to check the projects acquired from Boskos before use: the compute and container APIs must be enabled, and no cluster or network with the names of the run must exist. Projects failing the check are released and replaced."`
+	BoskosFixProjects              bool     `flag:"~boskos-fix-projects" desc:"Whether to fix the projects failing --boskos-verify-projects, by enabling the APIs and deleting the leftover clusters and network, before replacing them."`
+	BoskosVerifyAttempts           int      `flag:"~boskos-verify-attempts" desc:"How many projects to acquire from Boskos, for each project requested, before giving up on finding one that passes --boskos-verify-projects."`
 }
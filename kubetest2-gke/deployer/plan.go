@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+// assert that deployer implements types.DeployerWithPlan
+var _ types.DeployerWithPlan = &Deployer{}
+
+// Plan implements types.DeployerWithPlan, it lists the clusters and the
+// network created by Up or deleted by Down.
+func (d *Deployer) Plan(action string) (*types.Plan, error) {
+	plan := &types.Plan{Shared: d.totalBoskosProjectsRequested == 0}
+	if action == "Down" && d.totalBoskosProjectsRequested > 0 {
+		plan.Resources = append(plan.Resources,
+			fmt.Sprintf("projects %s are released to boskos", strings.Join(d.Projects, ",")))
+		return plan, nil
+	}
+	// reused or hibernated clusters and their network are left in place by Down
+	if d.SkipClusterCreate || (action == "Down" && d.DownAction == downActionScaleToZero) {
+		return plan, nil
+	}
+
+	location := strings.SplitN(locationFlag(d.Regions, d.Zones, d.retryCount), "=", 2)[1]
+	for _, project := range d.Projects {
+		for _, cluster := range d.projectClustersLayout[project] {
+			plan.Resources = append(plan.Resources,
+				fmt.Sprintf("cluster %s in project %s (%s)", cluster.name, project, location))
+		}
+	}
+	// the network of an existing subnetwork is left in place too
+	if d.Network != "default" && len(d.Subnetworks) == 0 && len(d.Projects) > 0 {
+		plan.Resources = append(plan.Resources,
+			fmt.Sprintf("network %s in project %s", d.Network, d.Projects[0]))
+	}
+	return plan, nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gke/deployer/options"
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+func TestPlan(t *testing.T) {
+	layout := map[string][]cluster{"p1": {{0, "kt2-a"}, {1, "kt2-b"}}}
+	testCases := []struct {
+		desc           string
+		action         string
+		network        string
+		boskosProjects int
+		skipCreate     bool
+		downAction     string
+		expected       *types.Plan
+	}{
+		{
+			desc:    "up in user projects",
+			action:  "Up",
+			network: "kt2-net",
+			expected: &types.Plan{
+				Resources: []string{
+					"cluster kt2-a in project p1 (us-central1-c)",
+					"cluster kt2-b in project p1 (us-central1-c)",
+					"network kt2-net in project p1",
+				},
+				Shared: true,
+			},
+		},
+		{
+			desc:           "down in boskos projects",
+			action:         "Down",
+			network:        "default",
+			boskosProjects: 1,
+			expected: &types.Plan{
+				Resources: []string{"projects p1 are released to boskos"},
+			},
+		},
+		{
+			desc:       "down of reused clusters",
+			action:     "Down",
+			network:    "kt2-net",
+			skipCreate: true,
+			expected:   &types.Plan{Shared: true},
+		},
+		{
+			desc:       "down scaling the clusters to zero",
+			action:     "Down",
+			network:    "kt2-net",
+			downAction: downActionScaleToZero,
+			expected:   &types.Plan{Shared: true},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			d := &Deployer{
+				ProjectOptions: &options.ProjectOptions{Projects: []string{"p1"}},
+				NetworkOptions: &options.NetworkOptions{Network: tc.network},
+				ClusterOptions: &options.ClusterOptions{
+					Zones:             []string{"us-central1-c"},
+					SkipClusterCreate: tc.skipCreate,
+					DownAction:        tc.downAction,
+				},
+				projectClustersLayout:        layout,
+				totalBoskosProjectsRequested: tc.boskosProjects,
+			}
+			actual, err := d.Plan(tc.action)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected plan %+v but got %+v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// gkeSystemDaemonSets are the GKE-specific kube-system daemonsets whose pod
+// logs are dumped, log-dump.sh only collects node level logs.
+var gkeSystemDaemonSets = []string{
+	"gke-metadata-server",
+	"konnectivity-agent",
+	"netd",
+}
+
+// dumpSystemPodLogs best-effort fetches the logs of the pods of
+// gkeSystemDaemonSets in every cluster into <localLogsDir>/system-pods.
+func (d *Deployer) dumpSystemPodLogs() error {
+	kubeconfigs, err := d.Kubeconfig()
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	var errs []error
+	for _, kubeconfig := range filepath.SplitList(kubeconfigs) {
+		clusterLogsDir := filepath.Join(d.localLogsDir, "system-pods", strings.TrimPrefix(filepath.Base(kubeconfig), "kubecfg-"))
+		if err := os.MkdirAll(clusterLogsDir, os.ModePerm); err != nil {
+			return err
+		}
+		for _, daemonSet := range gkeSystemDaemonSets {
+			if err := d.dumpDaemonSetPodLogs(kubeconfig, daemonSet, clusterLogsDir); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// dumpDaemonSetPodLogs writes the logs of all the containers of each pod
+// of the kube-system daemonset to <dir>/<pod>.log.
+func (d *Deployer) dumpDaemonSetPodLogs(kubeconfig, daemonSet, dir string) error {
+	pods, err := exec.OutputLines(d.cmder.Command("kubectl",
+		"--kubeconfig="+kubeconfig,
+		"--namespace=kube-system",
+		"get", "pods",
+		"--selector=k8s-app="+daemonSet,
+		"--output=name",
+	))
+	if err != nil {
+		return fmt.Errorf("failed to list %s pods: %s", daemonSet, execError(err))
+	}
+	klog.V(1).Infof("Dumping logs of %d %s pods", len(pods), daemonSet)
+
+	var errs []error
+	for _, pod := range pods {
+		podName := strings.TrimPrefix(pod, "pod/")
+		if err := d.dumpPodLogs(kubeconfig, podName, filepath.Join(dir, podName+".log")); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d *Deployer) dumpPodLogs(kubeconfig, pod, path string) error {
+	logFile, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+
+	cmd := d.cmder.Command("kubectl",
+		"--kubeconfig="+kubeconfig,
+		"--namespace=kube-system",
+		"logs", pod,
+		"--all-containers",
+		"--timestamps",
+	)
+	cmd.SetStdout(logFile)
+	cmd.SetStderr(logFile)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to get logs of pod %s: %w", pod, err)
+	}
+	return nil
+}
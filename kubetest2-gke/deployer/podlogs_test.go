@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+func TestDumpSystemPodLogs(t *testing.T) {
+	kubeconfigs := []string{"/kubeconfigs/kubecfg-p-c1", "/kubeconfigs/kubecfg-p-c2"}
+	list := func(kubeconfig, daemonSet string) string {
+		return "kubectl --kubeconfig=" + kubeconfig + " --namespace=kube-system get pods --selector=k8s-app=" + daemonSet + " --output=name"
+	}
+	logs := func(kubeconfig, pod string) string {
+		return "kubectl --kubeconfig=" + kubeconfig + " --namespace=kube-system logs " + pod + " --all-containers --timestamps"
+	}
+	cmder := &exec.FakeCmder{
+		Responses: []exec.FakeResponse{
+			{Prefix: list(kubeconfigs[0], "netd"), Stdout: "pod/netd-a\npod/netd-b\n"},
+			{Prefix: logs(kubeconfigs[0], "netd-a"), Stdout: "netd-a logs\n"},
+			{Prefix: logs(kubeconfigs[0], "netd-b"), Err: errors.New("exit status 1")},
+			{Prefix: list(kubeconfigs[1], "konnectivity-agent"), Err: errors.New("exit status 1")},
+			{Prefix: list(kubeconfigs[1], "netd"), Stdout: "pod/netd-c\n"},
+			{Prefix: logs(kubeconfigs[1], "netd-c"), Stdout: "netd-c logs\n"},
+		},
+	}
+	logsDir := t.TempDir()
+	d := &Deployer{
+		cmder:        cmder,
+		kubecfgPath:  strings.Join(kubeconfigs, string(os.PathListSeparator)),
+		localLogsDir: logsDir,
+	}
+
+	// the failures are reported after dumping the logs of the other pods
+	if err := d.dumpSystemPodLogs(); err == nil {
+		t.Error("expected the failures to list and get the logs to be reported")
+	}
+
+	expectedCommands := []string{
+		list(kubeconfigs[0], "gke-metadata-server"),
+		list(kubeconfigs[0], "konnectivity-agent"),
+		list(kubeconfigs[0], "netd"),
+		logs(kubeconfigs[0], "netd-a"),
+		logs(kubeconfigs[0], "netd-b"),
+		list(kubeconfigs[1], "gke-metadata-server"),
+		list(kubeconfigs[1], "konnectivity-agent"),
+		list(kubeconfigs[1], "netd"),
+		logs(kubeconfigs[1], "netd-c"),
+	}
+	if commands := cmder.Commands(); !reflect.DeepEqual(commands, expectedCommands) {
+		t.Errorf("expected commands %v but got %v", expectedCommands, commands)
+	}
+
+	expectedLogs := map[string]string{
+		filepath.Join(logsDir, "system-pods", "p-c1", "netd-a.log"): "netd-a logs\n",
+		filepath.Join(logsDir, "system-pods", "p-c1", "netd-b.log"): "",
+		filepath.Join(logsDir, "system-pods", "p-c2", "netd-c.log"): "netd-c logs\n",
+	}
+	for path, expected := range expectedLogs {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			t.Errorf("expected the pod logs at %s: %v", path, err)
+			continue
+		}
+		if string(contents) != expected {
+			t.Errorf("expected pod logs %q at %s but got %q", expected, path, contents)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/artifacts"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+const (
+	// postUpManifestAttempts is how many times applying a manifest is
+	// attempted, e.g. custom resources fail until their CRDs are established
+	postUpManifestAttempts = 3
+	// postUpManifestRetryInterval is how long to wait before retrying
+	postUpManifestRetryInterval = 30 * time.Second
+	// postUpRolloutTimeout is how long to wait for the rollout of each
+	// workload of the manifests
+	postUpRolloutTimeout = 10 * time.Minute
+)
+
+// manifestClient fetches the --post-up-manifests given as URLs; the timeout
+// keeps an unresponsive server from hanging up until the run is killed
+var manifestClient = &http.Client{Timeout: 2 * time.Minute}
+
+// rolloutKinds are the kinds of the workloads kubectl rollout status waits for
+var rolloutKinds = map[string]string{
+	"Deployment":  "deployment",
+	"DaemonSet":   "daemonset",
+	"StatefulSet": "statefulset",
+}
+
+// applyPostUpManifests applies the --post-up-manifests to all the clusters and
+// waits for their workloads to roll out. The manifests are copied to the
+// artifacts first, so that what was applied is known after the run.
+func (d *Deployer) applyPostUpManifests() error {
+	manifestsDir := filepath.Join(artifacts.BaseDir(), "post-up-manifests")
+	if err := os.MkdirAll(manifestsDir, os.ModePerm); err != nil {
+		return err
+	}
+	var manifests []string
+	for i, source := range d.PostUpManifests {
+		manifest := filepath.Join(manifestsDir, fmt.Sprintf("%02d-%s", i, filepath.Base(source)))
+		if err := copyManifest(source, manifest); err != nil {
+			return fmt.Errorf("failed to copy manifest %s to the artifacts: %w", source, err)
+		}
+		manifests = append(manifests, manifest)
+	}
+
+	kubeconfigs, err := d.Kubeconfig()
+	if err != nil {
+		return err
+	}
+	for _, kubeconfig := range filepath.SplitList(kubeconfigs) {
+		for _, manifest := range manifests {
+			klog.V(1).Infof("Applying %s with kubeconfig %s", manifest, kubeconfig)
+			if err := d.applyManifest(kubeconfig, manifest); err != nil {
+				return err
+			}
+		}
+		for _, manifest := range manifests {
+			if err := d.waitForRollouts(kubeconfig, manifest); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// copyManifest copies the manifest at source, a path or an http(s) URL, to dest
+func copyManifest(source, dest string) error {
+	var contents []byte
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		resp, err := manifestClient.Get(source)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status %s", resp.Status)
+		}
+		if contents, err = io.ReadAll(resp.Body); err != nil {
+			return err
+		}
+	} else {
+		var err error
+		if contents, err = os.ReadFile(source); err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(dest, contents, 0644)
+}
+
+func (d *Deployer) applyManifest(kubeconfig, manifest string) error {
+	var err error
+	for attempt := 1; attempt <= postUpManifestAttempts; attempt++ {
+		if attempt > 1 {
+			klog.Warningf("Failed to apply %s, retrying in %v: %v", manifest, postUpManifestRetryInterval, err)
+			time.Sleep(postUpManifestRetryInterval)
+		}
+		if err = runWithOutput(d.cmder.Command("kubectl", "--kubeconfig="+kubeconfig, "apply", "-f", manifest)); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("failed to apply %s after %d attempts: %w", manifest, postUpManifestAttempts, err)
+}
+
+// waitForRollouts waits for the workloads of the manifest to roll out
+func (d *Deployer) waitForRollouts(kubeconfig, manifest string) error {
+	out, err := exec.Output(d.cmder.Command("kubectl", "--kubeconfig="+kubeconfig,
+		"get", "-f", manifest, "--no-headers",
+		"-o=custom-columns=KIND:.kind,NAMESPACE:.metadata.namespace,NAME:.metadata.name"))
+	if err != nil {
+		return fmt.Errorf("failed to list the resources of %s: %s", manifest, execError(err))
+	}
+	for _, target := range rolloutTargets(string(out)) {
+		klog.V(1).Infof("Waiting for the rollout of %s", strings.Join(target, " "))
+		args := append([]string{"--kubeconfig=" + kubeconfig, "rollout", "status",
+			"--timeout=" + postUpRolloutTimeout.String()}, target...)
+		if err := runWithOutput(d.cmder.Command("kubectl", args...)); err != nil {
+			return fmt.Errorf("failed to wait for the rollout of %s from %s: %w", target[0], manifest, err)
+		}
+	}
+	return nil
+}
+
+// rolloutTargets returns the kubectl rollout status args of the workloads
+// listed by kubectl get with the KIND, NAMESPACE and NAME custom columns
+func rolloutTargets(resources string) [][]string {
+	var targets [][]string
+	for _, line := range strings.Split(resources, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		kind, ok := rolloutKinds[fields[0]]
+		if !ok {
+			continue
+		}
+		target := []string{kind + "/" + fields[2]}
+		// cluster scoped resources have no namespace
+		if fields[1] != "<none>" {
+			target = append(target, "--namespace="+fields[1])
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRolloutTargets(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		resources string
+		expected  [][]string
+	}{
+		{
+			desc:      "no workloads",
+			resources: "CustomResourceDefinition   <none>        widgets.example.com\nServiceAccount   kube-system   metrics-server\n",
+		},
+		{
+			desc: "workloads",
+			resources: `ServiceAccount   kube-system   metrics-server
+Deployment       kube-system   metrics-server
+DaemonSet        monitoring    node-exporter
+StatefulSet      default       web
+`,
+			expected: [][]string{
+				{"deployment/metrics-server", "--namespace=kube-system"},
+				{"daemonset/node-exporter", "--namespace=monitoring"},
+				{"statefulset/web", "--namespace=default"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, rolloutTargets(tc.resources)); diff != "" {
+				t.Errorf("unexpected rollout targets (-want, +got) = %s", diff)
+			}
+		})
+	}
+}
+
+func TestCopyManifest(t *testing.T) {
+	manifest := "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: test\n"
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/manifest.yaml":
+			w.Write([]byte(manifest))
+		case "/hanging.yaml":
+			<-release
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	// unblock the hanging handler before the server is closed
+	defer close(release)
+
+	client := manifestClient
+	manifestClient = &http.Client{Timeout: 100 * time.Millisecond}
+	defer func() { manifestClient = client }()
+
+	dest := filepath.Join(t.TempDir(), "manifest.yaml")
+	if err := copyManifest(server.URL+"/manifest.yaml", dest); err != nil {
+		t.Fatalf("unexpected error copying the manifest: %v", err)
+	}
+	contents, err := os.ReadFile(dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(contents) != manifest {
+		t.Errorf("expected manifest %q but got %q", manifest, contents)
+	}
+
+	if err := copyManifest(server.URL+"/missing.yaml", dest); err == nil {
+		t.Error("expected an error for a missing manifest")
+	}
+
+	done := make(chan error)
+	go func() { done <- copyManifest(server.URL+"/hanging.yaml", dest) }()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("expected an error for a hanging server")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("copying the manifest from a hanging server did not time out")
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// simulatePreemption deletes the VM of one node of the default nodepool of
+// each cluster, like a preemption of a spot VM does. The instance group
+// recreates the VM, so the node comes back with a new boot ID.
+func (d *Deployer) simulatePreemption() error {
+	if err := d.GetInstanceGroups(); err != nil {
+		return err
+	}
+	for _, project := range d.Projects {
+		for _, cluster := range d.projectClustersLayout[project] {
+			group := defaultPoolInstanceGroup(d.instanceGroups[project][cluster.name])
+			if group == nil {
+				return fmt.Errorf("no instance group found for cluster %s in project %s", cluster.name, project)
+			}
+			out, err := exec.Output(d.cmder.Command("gcloud", listInstancesArgs(project, group)...))
+			if err != nil {
+				return fmt.Errorf("failed to list the instances of %s: %s", group.name, execError(err))
+			}
+			instances := strings.Fields(string(out))
+			if len(instances) == 0 {
+				return fmt.Errorf("instance group %s has no instances", group.name)
+			}
+			sort.Strings(instances)
+			klog.V(0).Infof("Simulating the preemption of node %s of cluster %s", instances[0], cluster.name)
+			if err := runWithOutput(d.cmder.Command("gcloud", deleteInstanceArgs(project, group.zone, instances[0])...)); err != nil {
+				return fmt.Errorf("failed to delete instance %s: %w", instances[0], err)
+			}
+		}
+	}
+	return nil
+}
+
+// defaultPoolInstanceGroup returns the first instance group of the default
+// nodepool, or the first instance group if there is no default nodepool
+func defaultPoolInstanceGroup(groups []*ig) *ig {
+	for _, group := range groups {
+		if strings.Contains(group.name, "-default-pool-") {
+			return group
+		}
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+	return groups[0]
+}
+
+func listInstancesArgs(project string, group *ig) []string {
+	return []string{"compute", "instance-groups", "managed", "list-instances", group.name,
+		"--project=" + project, "--zone=" + group.zone, "--format=value(instance.basename())"}
+}
+
+func deleteInstanceArgs(project, zone, instance string) []string {
+	return []string{"compute", "instances", "delete", instance,
+		"--project=" + project, "--zone=" + zone, "--quiet"}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gke/deployer/options"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+func TestDefaultPoolInstanceGroup(t *testing.T) {
+	extra := &ig{zone: "us-central1-c", name: "gke-kt2-a-extra-node-pool-0-90fcb815-grp"}
+	defaultPool := &ig{zone: "us-central1-c", name: "gke-kt2-a-default-pool-1a2b3c4d-grp"}
+	testCases := []struct {
+		desc     string
+		groups   []*ig
+		expected *ig
+	}{
+		{
+			desc: "no instance groups",
+		},
+		{
+			desc:     "default pool is preferred",
+			groups:   []*ig{extra, defaultPool},
+			expected: defaultPool,
+		},
+		{
+			desc:     "first group without a default pool",
+			groups:   []*ig{extra},
+			expected: extra,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if actual := defaultPoolInstanceGroup(tc.groups); actual != tc.expected {
+				t.Errorf("expected %+v but got %+v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPreemptionArgs(t *testing.T) {
+	group := &ig{zone: "us-central1-c", name: "gke-kt2-a-default-pool-1a2b3c4d-grp"}
+	expectedList := []string{"compute", "instance-groups", "managed", "list-instances", "gke-kt2-a-default-pool-1a2b3c4d-grp",
+		"--project=p", "--zone=us-central1-c", "--format=value(instance.basename())"}
+	if actual := listInstancesArgs("p", group); !reflect.DeepEqual(actual, expectedList) {
+		t.Errorf("expected list args %v but got %v", expectedList, actual)
+	}
+	expectedDelete := []string{"compute", "instances", "delete", "gke-kt2-a-default-pool-1a2b3c4d-x1",
+		"--project=p", "--zone=us-central1-c", "--quiet"}
+	if actual := deleteInstanceArgs("p", "us-central1-c", "gke-kt2-a-default-pool-1a2b3c4d-x1"); !reflect.DeepEqual(actual, expectedDelete) {
+		t.Errorf("expected delete args %v but got %v", expectedDelete, actual)
+	}
+}
+
+func TestSimulatePreemption(t *testing.T) {
+	group := &ig{zone: "us-central1-c", name: "gke-c1-default-pool-1a2b3c4d-grp"}
+	list := "gcloud compute instance-groups managed list-instances gke-c1-default-pool-1a2b3c4d-grp --project=p --zone=us-central1-c --format=value(instance.basename())"
+	testCases := []struct {
+		name             string
+		groups           []*ig
+		response         exec.FakeResponse
+		expectedCommands []string
+		expectError      bool
+	}{
+		{
+			name:     "first instance is deleted",
+			groups:   []*ig{group},
+			response: exec.FakeResponse{Prefix: list, Stdout: "gke-c1-default-pool-1a2b3c4d-y2\ngke-c1-default-pool-1a2b3c4d-x1\n"},
+			expectedCommands: []string{
+				list,
+				"gcloud compute instances delete gke-c1-default-pool-1a2b3c4d-x1 --project=p --zone=us-central1-c --quiet",
+			},
+		},
+		{
+			name:             "listing the instances fails",
+			groups:           []*ig{group},
+			response:         exec.FakeResponse{Prefix: list, Err: errors.New("exit status 1")},
+			expectedCommands: []string{list},
+			expectError:      true,
+		},
+		{
+			name:             "no instances",
+			groups:           []*ig{group},
+			response:         exec.FakeResponse{Prefix: list},
+			expectedCommands: []string{list},
+			expectError:      true,
+		},
+		{
+			name:             "no instance group",
+			groups:           []*ig{},
+			expectedCommands: []string{},
+			expectError:      true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := &exec.FakeCmder{Responses: []exec.FakeResponse{tc.response}}
+			d := &Deployer{
+				cmder:                 cmder,
+				projectClustersLayout: map[string][]cluster{"p": {{index: 0, name: "c1"}}},
+				instanceGroups:        map[string]map[string][]*ig{"p": {"c1": tc.groups}},
+				ProjectOptions:        &options.ProjectOptions{Projects: []string{"p"}},
+			}
+			err := d.simulatePreemption()
+			if tc.expectError != (err != nil) {
+				t.Errorf("expected error %v but got %v", tc.expectError, err)
+			}
+			if commands := cmder.Commands(); !reflect.DeepEqual(commands, tc.expectedCommands) {
+				t.Errorf("expected commands %v but got %v", tc.expectedCommands, commands)
+			}
+		})
+	}
+}
+
+func TestMaybeSimulatePreemption(t *testing.T) {
+	for _, simulate := range []bool{false, true} {
+		cmder := &exec.FakeCmder{}
+		d := &Deployer{
+			cmder:                 cmder,
+			projectClustersLayout: map[string][]cluster{"p": {{index: 0, name: "c1"}}},
+			instanceGroups:        map[string]map[string][]*ig{"p": {"c1": {}}},
+			ProjectOptions:        &options.ProjectOptions{Projects: []string{"p"}},
+			ClusterOptions:        &options.ClusterOptions{SimulatePreemption: simulate},
+		}
+		// the cluster has no instance group, so the preemption fails if it
+		// is attempted
+		if err := d.maybeSimulatePreemption(); simulate != (err != nil) {
+			t.Errorf("expected error %v with --simulate-preemption=%v but got %v", simulate, simulate, err)
+		}
+	}
+}
+
+func TestCreateClusterSpot(t *testing.T) {
+	testCases := []struct {
+		name     string
+		spot     bool
+		expected bool
+	}{
+		{
+			name: "on-demand nodes",
+		},
+		{
+			name:     "spot nodes",
+			spot:     true,
+			expected: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := &exec.FakeCmder{Responses: []exec.FakeResponse{
+				{Prefix: "gcloud container clusters list", Stdout: "[]"},
+			}}
+			d := &Deployer{
+				cmder:                  cmder,
+				Kubetest2CommonOptions: runIDOptions{runID: "run-1"},
+				ProjectOptions:         &options.ProjectOptions{Projects: []string{"p"}},
+				NetworkOptions:         &options.NetworkOptions{Network: "default"},
+				ClusterOptions: &options.ClusterOptions{
+					NumNodes:       1,
+					Spot:           tc.spot,
+					ReleaseChannel: "regular",
+					ClusterVersion: "1.30",
+				},
+			}
+			if err := d.CreateCluster("p", cluster{index: 0, name: "c1"}, nil, "--zone=us-central1-c"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var create string
+			for _, command := range cmder.Commands() {
+				if strings.HasPrefix(command, "gcloud container clusters create ") {
+					create = command
+				}
+			}
+			if create == "" {
+				t.Fatalf("expected the cluster to be created, got commands %v", cmder.Commands())
+			}
+			if actual := strings.Contains(create, " --spot "); actual != tc.expected {
+				t.Errorf("expected --spot %v but got command %q", tc.expected, create)
+			}
+		})
+	}
+}
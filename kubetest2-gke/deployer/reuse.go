@@ -0,0 +1,51 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+
+	"k8s.io/klog/v2"
+)
+
+// verifyExistingClusters checks that the clusters reused with
+// --skip-cluster-create exist and are ready for the tests. Clusters scaled to
+// zero by --down-action=scale-to-zero are resized back first.
+func (d *Deployer) verifyExistingClusters() error {
+	locationArg := locationFlag(d.Regions, d.Zones, d.retryCount)
+	for _, project := range d.Projects {
+		for _, cluster := range d.projectClustersLayout[project] {
+			klog.V(1).Infof("Reusing existing cluster %q in project %q", cluster.name, project)
+			c, err := d.describeCluster(project, cluster.name, locationArg)
+			if err != nil {
+				return err
+			}
+			if c, err = d.resumeCluster(project, locationArg, c); err != nil {
+				return err
+			}
+			ready, err := checkClusterReady(c)
+			if err != nil {
+				return fmt.Errorf("cluster %q in project %q is unhealthy: %w", cluster.name, project, err)
+			}
+			if !ready {
+				return fmt.Errorf("cluster %q in project %q is not ready: %s", cluster.name, project, clusterStatusSummary(c))
+			}
+			d.recordExistingNodePools(project, c)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gke/deployer/options"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+func TestVerifyExistingClusters(t *testing.T) {
+	describe := "gcloud container clusters describe c1 --project=p1 --zone=us-central1-a --format=json"
+	resize := "gcloud container clusters resize c1 --project=p1 --zone=us-central1-a --node-pool=default-pool --num-nodes=2 --quiet"
+	testCases := []struct {
+		name             string
+		response         exec.FakeResponse
+		expectedCommands []string
+		expectError      bool
+	}{
+		{
+			name: "running cluster",
+			response: exec.FakeResponse{Prefix: describe, Stdout: `{"name": "c1", "status": "RUNNING", "currentNodeCount": 2,
+				"nodePools": [{"name": "default-pool", "status": "RUNNING", "initialNodeCount": 2}]}`},
+			expectedCommands: []string{describe},
+		},
+		{
+			name: "cluster scaled to zero is resized back",
+			response: exec.FakeResponse{Prefix: describe, Stdout: `{"name": "c1", "status": "RUNNING", "currentNodeCount": 0,
+				"nodePools": [{"name": "default-pool", "status": "RUNNING", "initialNodeCount": 2}]}`},
+			expectedCommands: []string{describe, resize, describe},
+		},
+		{
+			name: "cluster not ready",
+			response: exec.FakeResponse{Prefix: describe, Stdout: `{"name": "c1", "status": "RECONCILING", "currentNodeCount": 2,
+				"nodePools": [{"name": "default-pool", "status": "RUNNING", "initialNodeCount": 2}]}`},
+			expectedCommands: []string{describe},
+			expectError:      true,
+		},
+		{
+			name: "unhealthy cluster",
+			response: exec.FakeResponse{Prefix: describe, Stdout: `{"name": "c1", "status": "RUNNING", "currentNodeCount": 2,
+				"nodePools": [{"name": "default-pool", "status": "ERROR", "initialNodeCount": 2}]}`},
+			expectedCommands: []string{describe},
+			expectError:      true,
+		},
+		{
+			name:             "missing cluster",
+			response:         exec.FakeResponse{Prefix: describe, Err: errors.New("exit status 1")},
+			expectedCommands: []string{describe},
+			expectError:      true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := &exec.FakeCmder{Responses: []exec.FakeResponse{tc.response}}
+			d := &Deployer{
+				cmder:                 cmder,
+				projectClustersLayout: map[string][]cluster{"p1": {{index: 0, name: "c1"}}},
+				ProjectOptions:        &options.ProjectOptions{Projects: []string{"p1"}},
+				ClusterOptions:        &options.ClusterOptions{Zones: []string{"us-central1-a"}, SkipClusterCreate: true},
+			}
+			err := d.verifyExistingClusters()
+			if tc.expectError != (err != nil) {
+				t.Errorf("expected error %v but got %v", tc.expectError, err)
+			}
+			if commands := cmder.Commands(); !reflect.DeepEqual(commands, tc.expectedCommands) {
+				t.Errorf("expected commands %v but got %v", tc.expectedCommands, commands)
+			}
+			if recorded := len(d.existingNodePools["p1"]["c1"]) > 0; recorded == tc.expectError {
+				t.Errorf("expected the nodepools of the cluster to be recorded only when it is reused, got %v", d.existingNodePools)
+			}
+		})
+	}
+}
+
+func TestVerifyUpFlagsSkipClusterCreate(t *testing.T) {
+	testCases := []struct {
+		name           string
+		projects       []string
+		clusters       []string
+		clusterOptions options.ClusterOptions
+	}{
+		{
+			name:     "no projects",
+			clusters: []string{"c1"},
+		},
+		{
+			name:     "no clusters",
+			projects: []string{"p1"},
+		},
+		{
+			name:           "node service account of the run",
+			projects:       []string{"p1"},
+			clusters:       []string{"c1"},
+			clusterOptions: options.ClusterOptions{CreateNodeServiceAccount: true},
+		},
+		{
+			name:           "cluster notifications",
+			projects:       []string{"p1"},
+			clusters:       []string{"c1"},
+			clusterOptions: options.ClusterOptions{CaptureNotifications: true},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clusterOptions := tc.clusterOptions
+			clusterOptions.SkipClusterCreate = true
+			clusterOptions.Clusters = tc.clusters
+			d := &Deployer{
+				ProjectOptions: &options.ProjectOptions{
+					Projects:                tc.projects,
+					BoskosProjectsRequested: []int{1},
+					BoskosResourceType:      []string{"gke-project"},
+				},
+				ClusterOptions: &clusterOptions,
+			}
+			if err := d.VerifyUpFlags(); err == nil || !strings.Contains(err.Error(), "--skip-cluster-create") {
+				t.Errorf("expected an error for the invalid --skip-cluster-create flags, got: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	binauthzDisabled                                       = "DISABLED"
+	binauthzProjectSingletonPolicyEnforce                  = "PROJECT_SINGLETON_POLICY_ENFORCE"
+	binauthzPolicyBindings                                 = "POLICY_BINDINGS"
+	binauthzPolicyBindingsAndProjectSingletonPolicyEnforce = "POLICY_BINDINGS_AND_PROJECT_SINGLETON_POLICY_ENFORCE"
+)
+
+// minorVersion is a GKE major.minor version
+type minorVersion struct {
+	major, minor int
+}
+
+func (v minorVersion) String() string {
+	return fmt.Sprintf("%d.%d", v.major, v.minor)
+}
+
+var (
+	// shieldedNodesMinVersion is the first GKE version with shielded nodes
+	shieldedNodesMinVersion = minorVersion{1, 13}
+	// binauthzEvaluationModeMinVersions are the first GKE versions supporting
+	// each binary authorization evaluation mode
+	binauthzEvaluationModeMinVersions = map[string]minorVersion{
+		binauthzDisabled:                                       {1, 0},
+		binauthzProjectSingletonPolicyEnforce:                  {1, 0},
+		binauthzPolicyBindings:                                 {1, 27},
+		binauthzPolicyBindingsAndProjectSingletonPolicyEnforce: {1, 27},
+	}
+)
+
+// parseMinorVersion returns the major.minor version of a GKE cluster version
+// like 1.27.3-gke.100, false for versions it can't tell, e.g. latest.
+func parseMinorVersion(version string) (minorVersion, bool) {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return minorVersion{}, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return minorVersion{}, false
+	}
+	minor, err := strconv.Atoi(strings.SplitN(parts[1], "-", 2)[0])
+	if err != nil {
+		return minorVersion{}, false
+	}
+	return minorVersion{major, minor}, true
+}
+
+// checkMinVersion returns an error if the cluster version is known to be
+// older than the minimum version required by the feature.
+func checkMinVersion(clusterVersion string, minVersion minorVersion, feature string) error {
+	v, ok := parseMinorVersion(clusterVersion)
+	if !ok {
+		return nil
+	}
+	if v.major < minVersion.major || (v.major == minVersion.major && v.minor < minVersion.minor) {
+		return fmt.Errorf("%s requires GKE %s or later, got cluster version %s", feature, minVersion, clusterVersion)
+	}
+	return nil
+}
+
+// validateSecurityFlags validates the shielded nodes and binary authorization
+// flags against the cluster mode and version.
+func (d *Deployer) validateSecurityFlags() error {
+	shielded := d.EnableShieldedNodes || d.ShieldedSecureBoot || d.ShieldedIntegrityMonitoring
+	if shielded {
+		if d.Autopilot {
+			return fmt.Errorf("shielded nodes flags cannot be used with --autopilot, Autopilot nodes are always shielded")
+		}
+		if err := checkMinVersion(d.ClusterVersion, shieldedNodesMinVersion, "--enable-shielded-nodes"); err != nil {
+			return err
+		}
+	}
+	if (d.ShieldedSecureBoot || d.ShieldedIntegrityMonitoring) && !d.EnableShieldedNodes {
+		return fmt.Errorf("--shielded-secure-boot and --shielded-integrity-monitoring require --enable-shielded-nodes")
+	}
+	if d.BinauthzEvaluationMode != "" {
+		minVersion, ok := binauthzEvaluationModeMinVersions[d.BinauthzEvaluationMode]
+		if !ok {
+			return fmt.Errorf("unknown --binauthz-evaluation-mode %q", d.BinauthzEvaluationMode)
+		}
+		if err := checkMinVersion(d.ClusterVersion, minVersion, "--binauthz-evaluation-mode="+d.BinauthzEvaluationMode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// securityClusterArgs returns the gcloud clusters create args for the
+// shielded nodes and binary authorization flags.
+func (d *Deployer) securityClusterArgs() []string {
+	var args []string
+	if d.EnableShieldedNodes {
+		args = append(args, "--enable-shielded-nodes")
+	}
+	args = append(args, d.shieldedNodePoolArgs()...)
+	if d.BinauthzEvaluationMode != "" {
+		args = append(args, "--binauthz-evaluation-mode="+d.BinauthzEvaluationMode)
+	}
+	return args
+}
+
+// shieldedNodePoolArgs returns the gcloud args for the shielded VM options,
+// which also apply to the node pools created after the cluster.
+func (d *Deployer) shieldedNodePoolArgs() []string {
+	var args []string
+	if d.ShieldedSecureBoot {
+		args = append(args, "--shielded-secure-boot")
+	}
+	if d.ShieldedIntegrityMonitoring {
+		args = append(args, "--shielded-integrity-monitoring")
+	}
+	return args
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gke/deployer/options"
+)
+
+func TestValidateSecurityFlags(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		opts  options.ClusterOptions
+		valid bool
+	}{
+		{
+			desc:  "no security flags is valid",
+			valid: true,
+		},
+		{
+			desc: "shielded nodes with secure boot is valid",
+			opts: options.ClusterOptions{
+				EnableShieldedNodes: true,
+				ShieldedSecureBoot:  true,
+			},
+			valid: true,
+		},
+		{
+			desc: "secure boot without shielded nodes is invalid",
+			opts: options.ClusterOptions{
+				ShieldedIntegrityMonitoring: true,
+			},
+			valid: false,
+		},
+		{
+			desc: "shielded nodes with autopilot is invalid",
+			opts: options.ClusterOptions{
+				Autopilot:           true,
+				EnableShieldedNodes: true,
+			},
+			valid: false,
+		},
+		{
+			desc: "shielded nodes on an old version is invalid",
+			opts: options.ClusterOptions{
+				ClusterVersion:      "1.12.7-gke.10",
+				EnableShieldedNodes: true,
+			},
+			valid: false,
+		},
+		{
+			desc: "shielded nodes on latest is valid",
+			opts: options.ClusterOptions{
+				ClusterVersion:      "latest",
+				EnableShieldedNodes: true,
+			},
+			valid: true,
+		},
+		{
+			desc: "unknown binauthz evaluation mode is invalid",
+			opts: options.ClusterOptions{
+				BinauthzEvaluationMode: "ENFORCE",
+			},
+			valid: false,
+		},
+		{
+			desc: "policy bindings on a supported version is valid",
+			opts: options.ClusterOptions{
+				ClusterVersion:         "1.29",
+				BinauthzEvaluationMode: "POLICY_BINDINGS",
+			},
+			valid: true,
+		},
+		{
+			desc: "policy bindings on an old version is invalid",
+			opts: options.ClusterOptions{
+				ClusterVersion:         "1.26.5-gke.1200",
+				BinauthzEvaluationMode: "POLICY_BINDINGS_AND_PROJECT_SINGLETON_POLICY_ENFORCE",
+			},
+			valid: false,
+		},
+		{
+			desc: "project singleton policy on an old version is valid",
+			opts: options.ClusterOptions{
+				ClusterVersion:         "1.20",
+				BinauthzEvaluationMode: "PROJECT_SINGLETON_POLICY_ENFORCE",
+			},
+			valid: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			d := &Deployer{ClusterOptions: &tc.opts}
+			err := d.validateSecurityFlags()
+			if tc.valid && err != nil {
+				t.Errorf("expected no error but got %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Error("expected an error but got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+
+	"k8s.io/klog/v2"
+)
+
+// nodeServiceAccountRoles are the minimal roles GKE nodes need to run,
+// write logs and metrics, and pull images from Artifact Registry.
+// https://cloud.google.com/kubernetes-engine/docs/how-to/hardening-your-cluster#use_least_privilege_sa
+var nodeServiceAccountRoles = []string{
+	"roles/logging.logWriter",
+	"roles/monitoring.metricWriter",
+	"roles/monitoring.viewer",
+	"roles/stackdriver.resourceMetadata.writer",
+	"roles/autoscaling.metricsWriter",
+	"roles/artifactregistry.reader",
+}
+
+// nodeServiceAccountID returns the id of the least-privilege node service
+// account created for the run, which is 6 to 30 characters long.
+func nodeServiceAccountID(runID string) string {
+	return fmt.Sprintf("kt2-nodes-%08x", crc32.ChecksumIEEE([]byte(runID)))
+}
+
+// nodeServiceAccount returns the service account to use for the nodes of
+// the clusters in the given project, empty means the GKE default.
+func (d *Deployer) nodeServiceAccount(project string) string {
+	if d.CreateNodeServiceAccount {
+		return fmt.Sprintf("%s@%s.iam.gserviceaccount.com", nodeServiceAccountID(d.Kubetest2CommonOptions.RunID()), project)
+	}
+	return d.NodeServiceAccount
+}
+
+// serviceAccountArgs returns the gcloud args to run nodes as the given
+// service account, empty means the GKE default.
+func serviceAccountArgs(serviceAccount string) []string {
+	if serviceAccount == "" {
+		return nil
+	}
+	return []string{"--service-account=" + serviceAccount}
+}
+
+// CreateNodeServiceAccounts creates a least-privilege service account for the
+// nodes in each project if --create-node-service-account is set.
+func (d *Deployer) CreateNodeServiceAccounts() error {
+	if !d.CreateNodeServiceAccount {
+		return nil
+	}
+	id := nodeServiceAccountID(d.Kubetest2CommonOptions.RunID())
+	for _, project := range d.Projects {
+		klog.V(1).Infof("Creating node service account %s in project %s", id, project)
+		if err := runWithOutput(d.cmder.Command("gcloud", "iam", "service-accounts", "create", id,
+			"--project="+project,
+			"--display-name=kubetest2 nodes "+d.Kubetest2CommonOptions.RunID()),
+		); err != nil {
+			return fmt.Errorf("error creating node service account in project %s: %w", project, err)
+		}
+		for _, role := range nodeServiceAccountRoles {
+			if err := runWithOutput(d.cmder.Command("gcloud", "projects", "add-iam-policy-binding", project,
+				"--member=serviceAccount:"+d.nodeServiceAccount(project),
+				"--role="+role,
+				"--condition=None",
+				"--quiet"),
+			); err != nil {
+				return fmt.Errorf("error granting %s to the node service account in project %s: %w", role, project, err)
+			}
+		}
+	}
+	return nil
+}
+
+// DeleteNodeServiceAccounts removes the role bindings of, and deletes, the
+// node service accounts created by CreateNodeServiceAccounts.
+func (d *Deployer) DeleteNodeServiceAccounts() error {
+	if !d.CreateNodeServiceAccount {
+		return nil
+	}
+	var errs []error
+	for _, project := range d.Projects {
+		email := d.nodeServiceAccount(project)
+		for _, role := range nodeServiceAccountRoles {
+			if err := runWithOutput(d.cmder.Command("gcloud", "projects", "remove-iam-policy-binding", project,
+				"--member=serviceAccount:"+email,
+				"--role="+role,
+				"--condition=None",
+				"--quiet"),
+			); err != nil {
+				klog.Warningf("Error removing %s from the node service account in project %s: %v", role, project, err)
+			}
+		}
+		if err := runWithOutput(d.cmder.Command("gcloud", "iam", "service-accounts", "delete", email,
+			"--project="+project,
+			"--quiet"),
+		); err != nil {
+			errs = append(errs, fmt.Errorf("error deleting node service account %s: %w", email, err))
+		}
+	}
+	return errors.Join(errs...)
+}
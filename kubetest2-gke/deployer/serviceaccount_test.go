@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gke/deployer/options"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+func TestNodeServiceAccountID(t *testing.T) {
+	// service account ids are 6 to 30 lowercase letters, digits and dashes,
+	// starting with a letter
+	idRe := regexp.MustCompile(`^[a-z][a-z0-9-]{4,28}[a-z0-9]$`)
+	id := nodeServiceAccountID("1b7e5c3e-2f6a-4e7b-9a53-0c6f9d2e8b41")
+	if !idRe.MatchString(id) {
+		t.Errorf("expected a valid service account id but got %q", id)
+	}
+	if other := nodeServiceAccountID("1b7e5c3e-2f6a-4e7b-9a53-0c6f9d2e8b41"); other != id {
+		t.Errorf("expected the id of a run to be stable but got %q and %q", id, other)
+	}
+	if other := nodeServiceAccountID("run-2"); other == id {
+		t.Errorf("expected the ids of different runs to differ but both are %q", id)
+	}
+}
+
+func TestNodeServiceAccount(t *testing.T) {
+	testCases := []struct {
+		name                     string
+		createNodeServiceAccount bool
+		nodeServiceAccount       string
+		expected                 string
+		expectedArgs             []string
+	}{
+		{
+			name: "GKE default",
+		},
+		{
+			name:               "existing service account",
+			nodeServiceAccount: "nodes@p.iam.gserviceaccount.com",
+			expected:           "nodes@p.iam.gserviceaccount.com",
+			expectedArgs:       []string{"--service-account=nodes@p.iam.gserviceaccount.com"},
+		},
+		{
+			name:                     "service account of the run",
+			createNodeServiceAccount: true,
+			expected:                 nodeServiceAccountID("run-1") + "@p.iam.gserviceaccount.com",
+			expectedArgs:             []string{"--service-account=" + nodeServiceAccountID("run-1") + "@p.iam.gserviceaccount.com"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &Deployer{
+				Kubetest2CommonOptions: runIDOptions{runID: "run-1"},
+				ClusterOptions: &options.ClusterOptions{
+					CreateNodeServiceAccount: tc.createNodeServiceAccount,
+					NodeServiceAccount:       tc.nodeServiceAccount,
+				},
+			}
+			actual := d.nodeServiceAccount("p")
+			if actual != tc.expected {
+				t.Errorf("expected service account %q but got %q", tc.expected, actual)
+			}
+			if args := serviceAccountArgs(actual); !reflect.DeepEqual(args, tc.expectedArgs) {
+				t.Errorf("expected args %v but got %v", tc.expectedArgs, args)
+			}
+		})
+	}
+}
+
+func TestCreateNodeServiceAccounts(t *testing.T) {
+	id := nodeServiceAccountID("run-1")
+	// create returns the commands creating the service account of the project
+	create := func(project string) []string {
+		return append([]string{"gcloud iam service-accounts create " + id + " --project=" + project + " --display-name=kubetest2 nodes run-1"},
+			bindingCommands("add", project, id+"@"+project+".iam.gserviceaccount.com")...)
+	}
+	testCases := []struct {
+		name                     string
+		createNodeServiceAccount bool
+		responses                []exec.FakeResponse
+		expectedCommands         []string
+		expectError              bool
+	}{
+		{
+			name:             "not requested",
+			expectedCommands: []string{},
+		},
+		{
+			name:                     "service account per project",
+			createNodeServiceAccount: true,
+			expectedCommands:         append(create("p1"), create("p2")...),
+		},
+		{
+			name:                     "create failed",
+			createNodeServiceAccount: true,
+			responses:                []exec.FakeResponse{{Prefix: "gcloud iam service-accounts create", Err: errors.New("exit status 1")}},
+			expectedCommands:         create("p1")[:1],
+			expectError:              true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := &exec.FakeCmder{Responses: tc.responses}
+			d := &Deployer{
+				cmder:                  cmder,
+				Kubetest2CommonOptions: runIDOptions{runID: "run-1"},
+				ProjectOptions:         &options.ProjectOptions{Projects: []string{"p1", "p2"}},
+				ClusterOptions:         &options.ClusterOptions{CreateNodeServiceAccount: tc.createNodeServiceAccount},
+			}
+			err := d.CreateNodeServiceAccounts()
+			if tc.expectError != (err != nil) {
+				t.Errorf("expected error %v but got %v", tc.expectError, err)
+			}
+			if commands := cmder.Commands(); !reflect.DeepEqual(commands, tc.expectedCommands) {
+				t.Errorf("expected commands %v but got %v", tc.expectedCommands, commands)
+			}
+		})
+	}
+}
+
+func TestDeleteNodeServiceAccounts(t *testing.T) {
+	email := nodeServiceAccountID("run-1") + "@p1.iam.gserviceaccount.com"
+	deleteCommand := "gcloud iam service-accounts delete " + email + " --project=p1 --quiet"
+	testCases := []struct {
+		name        string
+		responses   []exec.FakeResponse
+		expectError bool
+	}{
+		{
+			name: "deleted",
+		},
+		{
+			name:      "deleted after removing a binding failed",
+			responses: []exec.FakeResponse{{Prefix: "gcloud projects remove-iam-policy-binding", Err: errors.New("exit status 1")}},
+		},
+		{
+			name:        "delete failed",
+			responses:   []exec.FakeResponse{{Prefix: deleteCommand, Err: errors.New("exit status 1")}},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := &exec.FakeCmder{Responses: tc.responses}
+			d := &Deployer{
+				cmder:                  cmder,
+				Kubetest2CommonOptions: runIDOptions{runID: "run-1"},
+				ProjectOptions:         &options.ProjectOptions{Projects: []string{"p1"}},
+				ClusterOptions:         &options.ClusterOptions{CreateNodeServiceAccount: true},
+			}
+			err := d.DeleteNodeServiceAccounts()
+			if tc.expectError != (err != nil) {
+				t.Errorf("expected error %v but got %v", tc.expectError, err)
+			}
+			expected := append(bindingCommands("remove", "p1", email), deleteCommand)
+			if commands := cmder.Commands(); !reflect.DeepEqual(commands, expected) {
+				t.Errorf("expected commands %v but got %v", expected, commands)
+			}
+		})
+	}
+}
+
+// bindingCommands returns the commands adding or removing the roles of the
+// node service account in the project
+func bindingCommands(verb, project, email string) []string {
+	var commands []string
+	for _, role := range nodeServiceAccountRoles {
+		commands = append(commands, strings.Join([]string{
+			"gcloud", "projects", verb + "-iam-policy-binding", project,
+			"--member=serviceAccount:" + email,
+			"--role=" + role,
+			"--condition=None",
+			"--quiet",
+		}, " "))
+	}
+	return commands
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package deployer
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"math"
@@ -24,6 +25,8 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 	"k8s.io/klog/v2"
@@ -34,14 +37,50 @@ import (
 
 // Deployer implementation methods below
 func (d *Deployer) Up() error {
+	return d.UpWithContext(context.Background())
+}
+
+// UpWithContext runs Up within the deadline of ctx, if any, which bounds the
+// retries of the cluster creation in other regions or zones
+func (d *Deployer) UpWithContext(ctx context.Context) error {
+	d.upDeadline, _ = ctx.Deadline()
 	if err := d.Init(); err != nil {
 		return err
 	}
 
-	if err := d.CreateNetwork(); err != nil {
+	if d.SkipClusterCreate {
+		// the private nodes of hibernated clusters need the NAT to pull
+		// images when verifyExistingClusters scales them back up
+		if err := d.SetupPrivateNodesAccess(); err != nil {
+			return err
+		}
+		if err := d.stepRunner.Run("VerifyExistingClusters", d.verifyExistingClusters); err != nil {
+			return err
+		}
+		if err := d.stepRunner.Run("TestSetup", d.TestSetup); err != nil {
+			return fmt.Errorf("error running setup for the tests: %w", err)
+		}
+		if err := d.maybeApplyPostUpManifests(); err != nil {
+			return err
+		}
+		d.maybeExportInfrastructure()
+		if err := d.maybeSimulatePreemption(); err != nil {
+			return err
+		}
+		d.recordClusterLayout()
+		return nil
+	}
+
+	if err := d.stepRunner.Run("CreateNetwork", d.CreateNetwork); err != nil {
+		return err
+	}
+	if err := d.CreateNodeServiceAccounts(); err != nil {
+		return err
+	}
+	if err := d.CreateNotificationTopics(); err != nil {
 		return err
 	}
-	if err := d.CreateClusters(); err != nil {
+	if err := d.stepRunner.Run("CreateClusters", d.CreateClusters); err != nil {
 		if d.RepoRoot == "" {
 			klog.Warningf("repo-root not supplied, skip dumping cluster logs")
 		}
@@ -51,7 +90,14 @@ func (d *Deployer) Up() error {
 		return fmt.Errorf("error creating the clusters: %w", err)
 	}
 
-	if err := d.TestSetup(); err != nil {
+	if err := d.installNvidiaDrivers(); err != nil {
+		if err := d.DumpClusterLogs(); err != nil {
+			klog.Warningf("Dumping cluster logs at the end of Up() failed: %v", err)
+		}
+		return fmt.Errorf("error installing the NVIDIA drivers: %w", err)
+	}
+
+	if err := d.stepRunner.Run("TestSetup", d.TestSetup); err != nil {
 		if d.RepoRoot == "" {
 			klog.Warningf("repo-root not supplied, skip dumping cluster logs")
 		}
@@ -61,6 +107,39 @@ func (d *Deployer) Up() error {
 		return fmt.Errorf("error running setup for the tests: %w", err)
 	}
 
+	if err := d.maybeApplyPostUpManifests(); err != nil {
+		return err
+	}
+	d.maybeExportInfrastructure()
+
+	if err := d.maybeSimulatePreemption(); err != nil {
+		return err
+	}
+	d.recordClusterLayout()
+	return nil
+}
+
+// maybeApplyPostUpManifests applies the manifests of --post-up-manifests to
+// the clusters, if any
+func (d *Deployer) maybeApplyPostUpManifests() error {
+	if len(d.PostUpManifests) == 0 {
+		return nil
+	}
+	if err := d.stepRunner.Run("ApplyPostUpManifests", d.applyPostUpManifests); err != nil {
+		return fmt.Errorf("error applying the post up manifests: %w", err)
+	}
+	return nil
+}
+
+// maybeSimulatePreemption preempts a node of each cluster before the tests
+// if --simulate-preemption is set
+func (d *Deployer) maybeSimulatePreemption() error {
+	if !d.SimulatePreemption {
+		return nil
+	}
+	if err := d.stepRunner.Run("SimulatePreemption", d.simulatePreemption); err != nil {
+		return fmt.Errorf("error simulating a preemption: %w", err)
+	}
 	return nil
 }
 
@@ -81,49 +160,101 @@ func (d *Deployer) CreateClusters() error {
 
 func (d *Deployer) tryCreateClusters(retryCount int) (shouldRetry bool, err error) {
 	shouldRetry = false
+	attemptStarted := time.Now()
 	if err = d.CreateSubnets(); err != nil {
 		return
 	}
 	if err = d.SetupNetwork(); err != nil {
 		return
 	}
+	if err = d.SetupPrivateNodesAccess(); err != nil {
+		return
+	}
 
 	eg := new(errgroup.Group)
 	locationArg := locationFlag(d.Regions, d.Zones, retryCount)
+	totalClusters := 0
+	for _, project := range d.Projects {
+		totalClusters += len(d.projectClustersLayout[project])
+	}
+	var createdClusters atomic.Int32
 	for i := range d.Projects {
 		project := d.Projects[i]
-		clusters := d.projectClustersLayout[project]
+		batches := batchClusters(d.projectClustersLayout[project], d.ClusterCreateBatchSize)
 		subNetworkArgs := subNetworkArgs(d.Autopilot, d.Projects, regionFromLocation(d.Regions, d.Zones, retryCount), d.Network, i)
-		for j := range clusters {
-			cluster := clusters[j]
-			eg.Go(
-				func() error {
-					return d.CreateCluster(project, cluster, subNetworkArgs, locationArg)
-				},
-			)
+		if subnetwork := d.subnetwork(retryCount); subnetwork != "" {
+			subNetworkArgs = existingSubnetworkArgs(d.Autopilot, subnetwork, d.ClusterSecondaryRangeName, d.ServicesSecondaryRangeName)
 		}
+		// the projects are created in parallel, the batches of a project
+		// one after the other
+		eg.Go(func() error {
+			for b, batch := range batches {
+				if b > 0 && d.ClusterCreateBatchDelay > 0 {
+					klog.V(1).Infof("Waiting %v before creating the next batch of clusters in project %q", d.ClusterCreateBatchDelay, project)
+					time.Sleep(d.ClusterCreateBatchDelay)
+				}
+				batchGroup := new(errgroup.Group)
+				for j := range batch {
+					cluster := batch[j]
+					batchGroup.Go(func() error {
+						if err := d.CreateCluster(project, cluster, subNetworkArgs, locationArg); err != nil {
+							return err
+						}
+						klog.V(0).Infof("Created cluster %q in project %q (%d/%d)", cluster.name, project, createdClusters.Add(1), totalClusters)
+						return nil
+					})
+				}
+				if err := batchGroup.Wait(); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
 	}
 
 	if err = eg.Wait(); err != nil {
 		// If the error is retryable and it is not the last region/zone that
 		// can be retried, perform cleanups in the background and retry
 		// cluster creation in the next available region/zone.
-		if d.isRetryableError(err) && retryCount != d.totalTryCount-1 {
+		if d.isRetryableError(err) && retryCount != d.totalTryCount-1 && d.canRetryBeforeDeadline(time.Since(attemptStarted)) {
 			shouldRetry = true
 			go func() {
-				d.DeleteClusters(retryCount)
+				if err := d.DeleteClusters(retryCount); err != nil {
+					log.Printf("Warning: error encountered deleting clusters: %v", err)
+				}
+				if err := d.DeleteCloudNAT(retryCount); err != nil {
+					log.Printf("Warning: error encountered deleting Cloud NAT: %v", err)
+				}
 				if err := d.DeleteSubnets(retryCount); err != nil {
 					log.Printf("Warning: error encountered deleting subnets: %v", err)
 				}
 			}()
 		} else {
-			err = fmt.Errorf("error creating clusters: %v", err)
+			err = classifyCreationError(fmt.Errorf("error creating clusters: %v", err))
 		}
 	}
 
 	return
 }
 
+// canRetryBeforeDeadline returns false if another attempt of the cluster
+// creation, as long as the last one, and the tests cannot fit in the time
+// left before the deadline of the run
+func (d *Deployer) canRetryBeforeDeadline(lastAttempt time.Duration) bool {
+	if fitsBeforeDeadline(d.upDeadline, time.Now(), lastAttempt+d.RetryReservedTestTime) {
+		return true
+	}
+	klog.Warningf("Not retrying the cluster creation, another attempt of %v and %v of tests do not fit before the deadline of the run %v",
+		lastAttempt.Round(time.Second), d.RetryReservedTestTime, d.upDeadline.Format(time.RFC3339))
+	return false
+}
+
+// fitsBeforeDeadline returns true if d fits between now and the deadline,
+// always when there is no deadline
+func fitsBeforeDeadline(deadline, now time.Time, d time.Duration) bool {
+	return deadline.IsZero() || !now.Add(d).After(deadline)
+}
+
 // isRetryableError checks if the error happens during cluster creation can be potentially solved by retrying or not.
 func (d *Deployer) isRetryableError(err error) bool {
 	for _, regx := range d.retryableErrorPatternsCompiled {
@@ -137,7 +268,7 @@ func (d *Deployer) isRetryableError(err error) bool {
 func (d *Deployer) CreateCluster(project string, cluster cluster, subNetworkArgs []string, locationArg string) error {
 	privateClusterArgs := []string{}
 	if d.PrivateClusterAccessLevel != "" {
-		privateClusterArgs = getPrivateClusterArgs(d.Projects, d.Network, d.PrivateClusterAccessLevel, d.privateClusterMasterIPRangesInternal[d.retryCount], cluster, d.Autopilot)
+		privateClusterArgs = getPrivateClusterArgs(d.Projects, d.Network, d.subnetwork(d.retryCount), d.PrivateClusterAccessLevel, d.privateClusterMasterIPRangesInternal[d.retryCount], cluster, d.Autopilot)
 	}
 	// Create the cluster
 	args := d.createCommand()
@@ -153,19 +284,23 @@ func (d *Deployer) CreateCluster(project string, cluster cluster, subNetworkArgs
 			args = append(args, "--machine-type="+d.MachineType)
 		}
 		args = append(args, "--num-nodes="+strconv.Itoa(d.NumNodes))
+		if d.Spot {
+			args = append(args, "--spot")
+		}
 		if d.ImageType != "" {
 			args = append(args, "--image-type="+d.ImageType)
 		}
 		if d.WorkloadIdentityEnabled {
-			args = append(args, fmt.Sprintf("--workload-pool=%s.svc.id.goog", project))
+			args = append(args, "--workload-pool="+workloadPool(project))
 		}
+		args = append(args, nodeTagArgs(d.NodeTags)...)
 	}
 
 	if d.ReleaseChannel != "" {
 		args = append(args, "--release-channel="+d.ReleaseChannel)
 		if d.ClusterVersion == "latest" {
 			// If latest is specified, get the latest version from server config for this channel.
-			actualVersion, err := resolveLatestVersionInChannel(locationArg, d.ReleaseChannel)
+			actualVersion, err := d.resolveLatestVersionInChannel(locationArg, d.ReleaseChannel)
 			if err != nil {
 				return err
 			}
@@ -176,25 +311,40 @@ func (d *Deployer) CreateCluster(project string, cluster cluster, subNetworkArgs
 		}
 	} else {
 		args = append(args, "--cluster-version="+d.ClusterVersion)
-		releaseChannel, err := resolveReleaseChannelForClusterVersion(d.ClusterVersion, locationArg)
+		releaseChannel, err := d.resolveReleaseChannelForClusterVersion(d.ClusterVersion, locationArg)
 		if err != nil {
 			klog.Warningf("error resolving the release channel for %q: %v, will proceed with no channel", d.ClusterVersion, err)
 		} else {
 			args = append(args, "--release-channel="+releaseChannel)
 		}
 	}
+	args = append(args, serviceAccountArgs(d.nodeServiceAccount(project))...)
+	args = append(args, d.securityClusterArgs()...)
+	args = append(args, d.observabilityClusterArgs()...)
+	args = append(args, d.loadBalancingClusterArgs()...)
+	args = append(args, d.autoscalingClusterArgs()...)
+	args = append(args, d.notificationConfigArgs(project)...)
+	args = append(args, "--labels="+d.clusterLabels(d.Kubetest2CommonOptions.RunID(), time.Now()))
 	args = append(args, subNetworkArgs...)
+	args = append(args, ipv4CIDRArgs(d.Autopilot, d.ClusterIPv4CIDR, d.ServicesIPv4CIDR)...)
 	args = append(args, privateClusterArgs...)
 	args = append(args, cluster.name)
-	output, err := runWithOutputAndReturn(exec.Command("gcloud", args...))
+	// a retried attempt may find the cluster left by a previous one
+	adopted, err := d.prepareExistingCluster(project, cluster, locationArg)
 	if err != nil {
-		//parse output for match with regex error
-		return fmt.Errorf("error creating cluster: %v, output: %q", err, output)
+		return err
+	}
+	if adopted == nil {
+		output, err := runWithOutputAndReturn(d.cmder.Command("gcloud", args...))
+		if err != nil {
+			//parse output for match with regex error
+			return fmt.Errorf("error creating cluster: %v, output: %q", err, output)
+		}
 	}
 
-	if d.WindowsEnabled {
-		args := d.createNodePoolCommand(project, cluster, locationArg, "windows-pool", d.WindowsImageType, d.WindowsMachineType, d.WindowsNumNodes)
-		output, err := runWithOutputAndReturn(exec.Command("gcloud", args...))
+	if d.WindowsEnabled && !hasNodePool(adopted, "windows-pool") {
+		args := d.createNodePoolCommand(project, cluster, locationArg, "windows-pool", d.WindowsImageType, d.WindowsMachineType, d.WindowsNumNodes, append(serviceAccountArgs(d.nodeServiceAccount(project)), nodeTagArgs(d.NodeTags)...)...)
+		output, err := runWithOutputAndReturn(d.cmder.Command("gcloud", args...))
 		if err != nil {
 			return fmt.Errorf("error creating windows node-pool: %v, output: %q", err, output)
 		}
@@ -209,9 +359,21 @@ func (d *Deployer) CreateCluster(project string, cluster cluster, subNetworkArgs
 
 	for _, enp := range d.extraNodePoolSpecs {
 		enp := enp
+		if hasNodePool(adopted, enp.Name) {
+			continue
+		}
 		eg.Go(func() error {
-			args := d.createNodePoolCommand(project, cluster, locationArg, enp.Name, enp.ImageType, enp.MachineType, enp.NumNodes)
-			output, err := runWithOutputAndReturn(exec.Command("gcloud", args...))
+			extraArgs := enp.acceleratorArgs()
+			extraArgs = append(extraArgs, enp.autoscalingArgs()...)
+			extraArgs = append(extraArgs, d.shieldedNodePoolArgs()...)
+			extraArgs = append(extraArgs, nodeTagArgs(d.NodeTags)...)
+			if enp.ServiceAccount != "" {
+				extraArgs = append(extraArgs, serviceAccountArgs(enp.ServiceAccount)...)
+			} else {
+				extraArgs = append(extraArgs, serviceAccountArgs(d.nodeServiceAccount(project))...)
+			}
+			args := d.createNodePoolCommand(project, cluster, locationArg, enp.Name, enp.ImageType, enp.MachineType, enp.NumNodes, extraArgs...)
+			output, err := runWithOutputAndReturn(d.cmder.Command("gcloud", args...))
 			if err != nil {
 				return fmt.Errorf("error creating nodepool %q: %v, output: %q", enp.Name, err, output)
 			}
@@ -219,7 +381,11 @@ func (d *Deployer) CreateCluster(project string, cluster cluster, subNetworkArgs
 		})
 	}
 
-	return eg.Wait()
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	return d.waitForClusterReady(project, cluster, locationArg)
 }
 
 func (d *Deployer) createCommand() []string {
@@ -244,7 +410,7 @@ func (d *Deployer) createCommand() []string {
 	return fs
 }
 
-func (d *Deployer) createNodePoolCommand(project string, cluster cluster, locationArg, nodePoolName, imageType string, machineType string, numNodes int) []string {
+func (d *Deployer) createNodePoolCommand(project string, cluster cluster, locationArg, nodePoolName, imageType string, machineType string, numNodes int, extraArgs ...string) []string {
 	fs := make([]string, 0)
 	fs = append(fs, "container", "node-pools", "create", nodePoolName)
 	fs = append(fs, "--quiet")
@@ -258,30 +424,32 @@ func (d *Deployer) createNodePoolCommand(project string, cluster cluster, locati
 		fs = append(fs, "--machine-type="+machineType)
 	}
 	fs = append(fs, "--num-nodes="+strconv.Itoa(numNodes))
+	fs = append(fs, extraArgs...)
 
 	return fs
 }
 
+// IsUp checks that every cluster is RUNNING and that its api server reports
+// nodes. It is read-only: neither $KUBECONFIG nor the gcloud config are
+// changed, so it can be used to check the status of existing clusters.
 func (d *Deployer) IsUp() (up bool, err error) {
-	if err := d.PrepareGcpIfNeeded(d.Projects[0]); err != nil {
+	if _, err := d.setGcloudEnv(); err != nil {
 		return false, err
 	}
 
+	locationArg := locationFlag(d.Regions, d.Zones, d.retryCount)
 	for _, project := range d.Projects {
 		for _, cluster := range d.projectClustersLayout[project] {
-			if err := getClusterCredentials(project, locationFlag(d.Regions, d.Zones, d.retryCount), cluster.name); err != nil {
+			c, err := d.describeCluster(project, cluster.name, locationArg)
+			if err != nil {
 				return false, err
 			}
-
-			// naively assume that if the api server reports nodes, the cluster is up
-			lines, err := exec.CombinedOutputLines(
-				exec.RawCommand("kubectl get nodes -o=name"),
-			)
-			if err != nil {
-				return false, metadata.NewJUnitError(err, strings.Join(lines, "\n"))
+			if c.Status != "RUNNING" {
+				klog.V(1).Infof("Cluster %q in project %q is not up: %s", cluster.name, project, clusterStatusSummary(c))
+				return false, nil
 			}
-			if len(lines) == 0 {
-				return false, fmt.Errorf("project had no nodes active: %s", project)
+			if err := d.checkClusterNodes(project, cluster.name, locationArg); err != nil {
+				return false, err
 			}
 		}
 	}
@@ -289,6 +457,36 @@ func (d *Deployer) IsUp() (up bool, err error) {
 	return true, nil
 }
 
+// checkClusterNodes checks that the api server of the cluster reports nodes,
+// with credentials written to a temporary kubeconfig rather than $KUBECONFIG
+func (d *Deployer) checkClusterNodes(project, clusterName, locationArg string) error {
+	kubeconfig, err := os.CreateTemp("", "kubetest2-gke-isup-kubeconfig")
+	if err != nil {
+		return err
+	}
+	kubeconfig.Close()
+	defer os.Remove(kubeconfig.Name())
+
+	cmd := d.cmder.Command("gcloud",
+		containerArgs("clusters", "get-credentials", clusterName, "--project="+project, locationArg)...)
+	cmd.SetEnv(append(os.Environ(), "KUBECONFIG="+kubeconfig.Name())...)
+	if err := runWithNoOutput(cmd); err != nil {
+		return fmt.Errorf("error executing get-credentials: %s", execError(err))
+	}
+
+	// naively assume that if the api server reports nodes, the cluster is up
+	lines, err := exec.CombinedOutputLines(
+		d.cmder.Command("kubectl", "--kubeconfig="+kubeconfig.Name(), "get", "nodes", "-o=name"),
+	)
+	if err != nil {
+		return metadata.NewJUnitError(err, strings.Join(lines, "\n"))
+	}
+	if len(lines) == 0 {
+		return fmt.Errorf("cluster %q in project %q had no nodes active", clusterName, project)
+	}
+	return nil
+}
+
 func (d *Deployer) TestSetup() error {
 	if d.testPrepared {
 		// Ensure setup is a singleton.
@@ -304,6 +502,9 @@ func (d *Deployer) TestSetup() error {
 	if err := d.EnsureFirewallRules(); err != nil {
 		return err
 	}
+	if err := d.waitForWorkloadIdentity(); err != nil {
+		return err
+	}
 	d.testPrepared = true
 	return nil
 }
@@ -328,7 +529,7 @@ func (d *Deployer) Kubeconfig() (string, error) {
 			if err := os.Setenv("KUBECONFIG", filename); err != nil {
 				return "", err
 			}
-			if err := getClusterCredentials(project, locationFlag(d.Regions, d.Zones, d.retryCount), cluster.name); err != nil {
+			if err := d.getClusterCredentials(project, locationFlag(d.Regions, d.Zones, d.retryCount), cluster.name); err != nil {
 				return "", err
 			}
 			kubecfgFiles = append(kubecfgFiles, filename)
@@ -353,6 +554,18 @@ func (d *Deployer) VerifyUpFlags() error {
 		}
 	}
 
+	if d.SkipClusterCreate {
+		if len(d.Projects) == 0 || len(d.Clusters) == 0 {
+			return fmt.Errorf("--skip-cluster-create requires the existing clusters to be set with --project and --cluster-name")
+		}
+		if d.CreateNodeServiceAccount {
+			return fmt.Errorf("--create-node-service-account cannot be used with --skip-cluster-create")
+		}
+		if d.CaptureNotifications {
+			return fmt.Errorf("--capture-notifications cannot be used with --skip-cluster-create")
+		}
+	}
+
 	if len(d.Clusters) == 0 {
 		if len(d.Projects) > 1 || d.totalBoskosProjectsRequested > 1 {
 			return fmt.Errorf("explicit --cluster-name must be set for multi-project profile")
@@ -379,6 +592,39 @@ func (d *Deployer) VerifyUpFlags() error {
 	if err := validateReleaseChannel(d.ReleaseChannel); err != nil {
 		return err
 	}
+	if d.BoskosFixProjects && !d.BoskosVerifyProjects {
+		return fmt.Errorf("--boskos-fix-projects requires --boskos-verify-projects")
+	}
+	if err := d.validateSecurityFlags(); err != nil {
+		return err
+	}
+	if err := d.validateObservabilityFlags(); err != nil {
+		return err
+	}
+	if err := d.validateLoadBalancingFlags(); err != nil {
+		return err
+	}
+	if err := d.validateAutoscalingFlags(); err != nil {
+		return err
+	}
+	if err := d.validateExportFlags(); err != nil {
+		return err
+	}
+	if d.ClusterCreateBatchSize < 0 || d.ClusterCreateBatchDelay < 0 {
+		return fmt.Errorf("--cluster-create-batch-size and --cluster-create-batch-delay must not be negative")
+	}
+	if d.Spot && d.Autopilot {
+		return fmt.Errorf("--spot is not supported with --autopilot")
+	}
+	if len(d.NodeTags) > 0 && d.Autopilot {
+		return fmt.Errorf("--node-tags is not supported with --autopilot")
+	}
+	if err := d.verifyDownAction(); err != nil {
+		return err
+	}
+	if d.CreateNodeServiceAccount && d.NodeServiceAccount != "" {
+		return fmt.Errorf("--create-node-service-account and --node-service-account are mutually exclusive")
+	}
 
 	for _, np := range d.ExtraNodePool {
 		// defaults
@@ -387,11 +633,33 @@ func (d *Deployer) VerifyUpFlags() error {
 		if err := buildExtraNodePoolOptions(np, enp); err != nil {
 			return fmt.Errorf("invalid extra nodepool spec %q: %v", np, err)
 		}
+		if enp.LocationPolicy != "" {
+			if err := checkMinVersion(d.ClusterVersion, locationPolicyMinVersion, "location-policy of extra nodepool "+enp.Name); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
+// batchClusters splits the clusters of a project into the batches created
+// one after the other, a size of 0 puts all the clusters in one batch.
+func batchClusters(clusters []cluster, size int) [][]cluster {
+	if size <= 0 {
+		size = len(clusters)
+	}
+	var batches [][]cluster
+	for start := 0; start < len(clusters); start += size {
+		end := start + size
+		if end > len(clusters) {
+			end = len(clusters)
+		}
+		batches = append(batches, clusters[start:end])
+	}
+	return batches
+}
+
 func generateClusterNames(numClusters int, uid string) []string {
 	clusters := make([]string, numClusters)
 	for i := 1; i <= numClusters; i++ {
@@ -20,8 +20,11 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+
+	"sigs.k8s.io/kubetest2/kubetest2-gke/deployer/options"
 )
 
 func TestClusterVersion(t *testing.T) {
@@ -120,6 +123,45 @@ func TestGenerateClusterNames(t *testing.T) {
 	}
 }
 
+func TestBatchClusters(t *testing.T) {
+	clusters := []cluster{{0, "kt2-1"}, {1, "kt2-2"}, {2, "kt2-3"}}
+	testCases := []struct {
+		desc     string
+		clusters []cluster
+		size     int
+		expected [][]cluster
+	}{
+		{
+			desc:     "no batching",
+			clusters: clusters,
+			expected: [][]cluster{clusters},
+		},
+		{
+			desc:     "batches of 2",
+			clusters: clusters,
+			size:     2,
+			expected: [][]cluster{clusters[:2], clusters[2:]},
+		},
+		{
+			desc:     "batch larger than the clusters",
+			clusters: clusters,
+			size:     5,
+			expected: [][]cluster{clusters},
+		},
+		{
+			desc: "no clusters",
+			size: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if actual := batchClusters(tc.clusters, tc.size); !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected batches %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
 func TestBuildExtraNodePoolOptions(t *testing.T) {
 	for _, c := range []struct {
 		name             string
@@ -138,6 +180,66 @@ func TestBuildExtraNodePoolOptions(t *testing.T) {
 			},
 			expectedError: "%!s(<nil>)",
 		},
+		{
+			name: "gpu nodepool",
+			np:   "name=gpu-pool&machine-type=n1-standard-4&image-type=cos_containerd&num-nodes=1&accelerator=type=nvidia-tesla-t4,count=1",
+			expectedNodepool: extraNodepool{
+				Name:        "gpu-pool",
+				MachineType: "n1-standard-4",
+				ImageType:   "cos_containerd",
+				NumNodes:    1,
+				Accelerator: "type=nvidia-tesla-t4,count=1",
+			},
+			expectedError: "%!s(<nil>)",
+		},
+		{
+			name: "tpu nodepool",
+			np:   "name=tpu-pool&machine-type=ct5lp-hightpu-4t&image-type=cos_containerd&num-nodes=1&tpu-topology=2x2",
+			expectedNodepool: extraNodepool{
+				Name:        "tpu-pool",
+				MachineType: "ct5lp-hightpu-4t",
+				ImageType:   "cos_containerd",
+				NumNodes:    1,
+				TPUTopology: "2x2",
+			},
+			expectedError: "%!s(<nil>)",
+		},
+		{
+			name: "nodepool with service account",
+			np:   "name=sa-pool&machine-type=n1-standard-4&image-type=cos_containerd&num-nodes=1&service-account=nodes@test-project.iam.gserviceaccount.com",
+			expectedNodepool: extraNodepool{
+				Name:           "sa-pool",
+				MachineType:    "n1-standard-4",
+				ImageType:      "cos_containerd",
+				NumNodes:       1,
+				ServiceAccount: "nodes@test-project.iam.gserviceaccount.com",
+			},
+			expectedError: "%!s(<nil>)",
+		},
+		{
+			name: "autoscaling nodepool",
+			np:   "name=autoscaling-pool&machine-type=n1-standard-4&image-type=cos_containerd&num-nodes=1&min-nodes=1&max-nodes=5&location-policy=ANY",
+			expectedNodepool: extraNodepool{
+				Name:           "autoscaling-pool",
+				MachineType:    "n1-standard-4",
+				ImageType:      "cos_containerd",
+				NumNodes:       1,
+				MinNodes:       1,
+				MaxNodes:       5,
+				LocationPolicy: "ANY",
+			},
+			expectedError: "%!s(<nil>)",
+		},
+		{
+			name:          "location policy without max-nodes",
+			np:            "name=autoscaling-pool&machine-type=n1-standard-4&image-type=cos_containerd&num-nodes=1&location-policy=ANY",
+			expectedError: "min-nodes and location-policy require max-nodes",
+		},
+		{
+			name:          "accelerator without count",
+			np:            "name=gpu-pool&machine-type=n1-standard-4&image-type=cos_containerd&num-nodes=1&accelerator=type=nvidia-tesla-t4",
+			expectedError: "accelerator must specify count",
+		},
 		{
 			name:          "num-nodes not set",
 			np:            "name=extra-nodepool&machine-type=test-machine-type&image-type=test-image-type",
@@ -182,3 +284,87 @@ func TestBuildExtraNodePoolOptions(t *testing.T) {
 
 	}
 }
+
+func TestClusterLabels(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	testCases := []struct {
+		name               string
+		clusterTTL         time.Duration
+		deletionProtection bool
+		expectedLabels     string
+	}{
+		{
+			name:           "run only",
+			expectedLabels: "kubetest2-run=2a6ca8e3",
+		},
+		{
+			name:           "cluster ttl",
+			clusterTTL:     2 * time.Hour,
+			expectedLabels: "kubetest2-run=2a6ca8e3,cleanup-after=1700007200",
+		},
+		{
+			name:               "cluster ttl and deletion protection",
+			clusterTTL:         time.Hour,
+			deletionProtection: true,
+			expectedLabels:     "kubetest2-run=2a6ca8e3,cleanup-after=1700003600,deletion-protection=true",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &Deployer{
+				ClusterOptions: &options.ClusterOptions{
+					ClusterTTL:         tc.clusterTTL,
+					DeletionProtection: tc.deletionProtection,
+				},
+			}
+			if actual := d.clusterLabels("run-1", now); actual != tc.expectedLabels {
+				t.Errorf("expected labels %q but got %q", tc.expectedLabels, actual)
+			}
+		})
+	}
+}
+
+func TestFitsBeforeDeadline(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		desc     string
+		deadline time.Time
+		d        time.Duration
+		expected bool
+	}{
+		{
+			desc:     "no deadline",
+			d:        10 * time.Hour,
+			expected: true,
+		},
+		{
+			desc:     "fits",
+			deadline: now.Add(time.Hour),
+			d:        40 * time.Minute,
+			expected: true,
+		},
+		{
+			desc:     "exactly fits",
+			deadline: now.Add(time.Hour),
+			d:        time.Hour,
+			expected: true,
+		},
+		{
+			desc:     "does not fit",
+			deadline: now.Add(time.Hour),
+			d:        70 * time.Minute,
+		},
+		{
+			desc:     "deadline passed",
+			deadline: now.Add(-time.Minute),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if actual := fitsBeforeDeadline(tc.deadline, now, tc.d); actual != tc.expected {
+				t.Errorf("expected %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
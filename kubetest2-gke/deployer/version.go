@@ -64,9 +64,9 @@ func validateReleaseChannel(releaseChannel string) error {
 }
 
 // Resolve the current latest version in the given release channel.
-func resolveLatestVersionInChannel(loc, channelName string) (string, error) {
+func (d *Deployer) resolveLatestVersionInChannel(loc, channelName string) (string, error) {
 	// Get the server config for the current location.
-	cfg, err := getServerConfig(loc)
+	cfg, err := d.getServerConfig(loc)
 	if err != nil {
 		return "", fmt.Errorf("error getting server config: %w", err)
 	}
@@ -83,14 +83,14 @@ func resolveLatestVersionInChannel(loc, channelName string) (string, error) {
 }
 
 // Resolve the valid release channel for the given cluster version.
-func resolveReleaseChannelForClusterVersion(clusterVersion, loc string) (string, error) {
+func (d *Deployer) resolveReleaseChannelForClusterVersion(clusterVersion, loc string) (string, error) {
 	if clusterVersion == "" || clusterVersion == "latest" {
 		// For latest or non cluster version, always use none release channel.
 		return noneReleaseChannel, nil
 	}
 
 	// Get the server config.
-	cfg, err := getServerConfig(loc)
+	cfg, err := d.getServerConfig(loc)
 	if err != nil {
 		return "", err
 	}
@@ -156,9 +156,9 @@ func isClusterVersionMatch(match, version string) bool {
 	return true
 }
 
-func getServerConfig(loc string) (*container.ServerConfig, error) {
+func (d *Deployer) getServerConfig(loc string) (*container.ServerConfig, error) {
 	// List the available versions for each release channel.
-	out, err := exec.Output(exec.RawCommand((fmt.Sprintf("gcloud container get-server-config --format=json %s", loc))))
+	out, err := exec.Output(d.cmder.Command("gcloud", "container", "get-server-config", "--format=json", loc))
 	if err != nil {
 		return nil, err
 	}
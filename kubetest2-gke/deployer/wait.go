@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/api/container/v1"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// clusterReadyPollInterval is how often the cluster status is checked while
+// waiting for it to become ready, it is a var for tests
+var clusterReadyPollInterval = 15 * time.Second
+
+// waitForClusterReady polls the cluster until it is RUNNING and all of its
+// nodepools are RUNNING, or until --cluster-ready-timeout expires. Clusters
+// or nodepools in an error state fail immediately.
+func (d *Deployer) waitForClusterReady(project string, cluster cluster, locationArg string) error {
+	if d.ClusterReadyTimeout <= 0 {
+		return nil
+	}
+	return d.pollClusterReady(project, cluster, locationArg, d.ClusterReadyTimeout)
+}
+
+// pollClusterReady polls the cluster until it is ready, or until timeout
+// expires
+func (d *Deployer) pollClusterReady(project string, cluster cluster, locationArg string, timeout time.Duration) error {
+	klog.V(1).Infof("Waiting up to %v for cluster %q in project %q to be ready", timeout, cluster.name, project)
+
+	deadline := time.Now().Add(timeout)
+	for {
+		c, err := d.describeCluster(project, cluster.name, locationArg)
+		if err != nil {
+			return err
+		}
+		ready, err := checkClusterReady(c)
+		if err != nil {
+			return fmt.Errorf("cluster %q in project %q is unhealthy: %w", cluster.name, project, err)
+		}
+		if ready {
+			klog.V(1).Infof("Cluster %q in project %q is ready", cluster.name, project)
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %v waiting for cluster %q in project %q to be ready, last status: %s",
+				timeout, cluster.name, project, clusterStatusSummary(c))
+		}
+		klog.V(2).Infof("Cluster %q is not ready yet: %s", cluster.name, clusterStatusSummary(c))
+		time.Sleep(clusterReadyPollInterval)
+	}
+}
+
+func (d *Deployer) describeCluster(project, clusterName, locationArg string) (*container.Cluster, error) {
+	out, err := exec.Output(d.cmder.Command("gcloud",
+		containerArgs("clusters", "describe", clusterName,
+			"--project="+project,
+			locationArg,
+			"--format=json")...))
+	if err != nil {
+		return nil, fmt.Errorf("error describing cluster %q: %s", clusterName, execError(err))
+	}
+
+	c := &container.Cluster{}
+	if err := json.Unmarshal(out, c); err != nil {
+		return nil, fmt.Errorf("error parsing cluster %q: %w", clusterName, err)
+	}
+	return c, nil
+}
+
+// checkClusterReady returns true if the cluster and all of its nodepools are
+// RUNNING, and an error if any of them is in a state it won't recover from
+// by waiting.
+func checkClusterReady(c *container.Cluster) (bool, error) {
+	switch c.Status {
+	case "ERROR", "DEGRADED":
+		return false, fmt.Errorf("cluster status is %s: %s", c.Status, c.StatusMessage)
+	}
+	ready := c.Status == "RUNNING"
+	for _, np := range c.NodePools {
+		switch np.Status {
+		case "ERROR", "RUNNING_WITH_ERROR":
+			return false, fmt.Errorf("nodepool %q status is %s: %s", np.Name, np.Status, np.StatusMessage)
+		case "RUNNING":
+		default:
+			ready = false
+		}
+	}
+	return ready, nil
+}
+
+// clusterStatusSummary formats the cluster and nodepool statuses for logs
+func clusterStatusSummary(c *container.Cluster) string {
+	statuses := []string{"cluster=" + c.Status}
+	for _, np := range c.NodePools {
+		statuses = append(statuses, fmt.Sprintf("%s=%s", np.Name, np.Status))
+	}
+	return strings.Join(statuses, ", ")
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"testing"
+
+	"google.golang.org/api/container/v1"
+)
+
+func TestCheckClusterReady(t *testing.T) {
+	testCases := []struct {
+		name          string
+		cluster       *container.Cluster
+		expectedReady bool
+		expectError   bool
+	}{
+		{
+			name: "cluster and nodepools running",
+			cluster: &container.Cluster{
+				Status: "RUNNING",
+				NodePools: []*container.NodePool{
+					{Name: "default-pool", Status: "RUNNING"},
+					{Name: "extra-pool", Status: "RUNNING"},
+				},
+			},
+			expectedReady: true,
+		},
+		{
+			name: "cluster reconciling",
+			cluster: &container.Cluster{
+				Status: "RECONCILING",
+				NodePools: []*container.NodePool{
+					{Name: "default-pool", Status: "RUNNING"},
+				},
+			},
+			expectedReady: false,
+		},
+		{
+			name: "nodepool provisioning",
+			cluster: &container.Cluster{
+				Status: "RUNNING",
+				NodePools: []*container.NodePool{
+					{Name: "default-pool", Status: "RUNNING"},
+					{Name: "extra-pool", Status: "PROVISIONING"},
+				},
+			},
+			expectedReady: false,
+		},
+		{
+			name: "cluster in error",
+			cluster: &container.Cluster{
+				Status:        "ERROR",
+				StatusMessage: "quota exceeded",
+			},
+			expectError: true,
+		},
+		{
+			name: "nodepool running with error",
+			cluster: &container.Cluster{
+				Status: "RUNNING",
+				NodePools: []*container.NodePool{
+					{Name: "default-pool", Status: "RUNNING_WITH_ERROR"},
+				},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ready, err := checkClusterReady(tc.cluster)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect an error, but got: %v", err)
+			}
+			if ready != tc.expectedReady {
+				t.Errorf("expected ready to be %v but got %v", tc.expectedReady, ready)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+const (
+	// workloadIdentityCanaryImage runs the canary pod, it only needs curl
+	workloadIdentityCanaryImage = "curlimages/curl:8.10.1"
+	// workloadIdentityPollInterval is how often the canary pod is run while
+	// waiting for workload identity to work
+	workloadIdentityPollInterval = 15 * time.Second
+	// workloadIdentityCanaryScript prints the service account the GKE metadata
+	// server maps the pod to, and fails if it can't issue a token for it
+	workloadIdentityCanaryScript = `set -e
+metadata=http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default
+curl -sSf -H "Metadata-Flavor: Google" "$metadata/email"
+curl -sSf -o /dev/null -H "Metadata-Flavor: Google" "$metadata/token"`
+)
+
+// waitForWorkloadIdentity runs a canary pod in every cluster until the GKE
+// metadata server serves the workload identity of the pod, or until
+// --workload-identity-ready-timeout expires, so that the tests don't start
+// while workload identity is still propagating.
+func (d *Deployer) waitForWorkloadIdentity() error {
+	if !d.WorkloadIdentityEnabled || d.WorkloadIdentityReadyTimeout <= 0 {
+		return nil
+	}
+	kubeconfigs, err := d.Kubeconfig()
+	if err != nil {
+		return err
+	}
+	for _, project := range d.Projects {
+		for _, cluster := range d.projectClustersLayout[project] {
+			kubeconfig := clusterKubeconfig(kubeconfigs, project, cluster.name)
+			if kubeconfig == "" {
+				return fmt.Errorf("no kubeconfig found for cluster %q in project %q", cluster.name, project)
+			}
+			if err := d.waitForClusterWorkloadIdentity(kubeconfig, project, cluster.name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *Deployer) waitForClusterWorkloadIdentity(kubeconfig, project, clusterName string) error {
+	klog.V(1).Infof("Waiting up to %v for workload identity to work in cluster %q in project %q", d.WorkloadIdentityReadyTimeout, clusterName, project)
+	expected := workloadPool(project)
+	deadline := time.Now().Add(d.WorkloadIdentityReadyTimeout)
+	for attempt := 1; ; attempt++ {
+		identity, err := d.runWorkloadIdentityCanary(kubeconfig, fmt.Sprintf("kt2-wi-canary-%d", attempt))
+		if err == nil && identity == expected {
+			klog.V(1).Infof("Workload identity works in cluster %q in project %q", clusterName, project)
+			return nil
+		}
+		if err == nil {
+			err = fmt.Errorf("the metadata server returned identity %q instead of %q", identity, expected)
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %v waiting for workload identity in cluster %q in project %q: %w",
+				d.WorkloadIdentityReadyTimeout, clusterName, project, err)
+		}
+		klog.V(2).Infof("Workload identity doesn't work yet in cluster %q: %v", clusterName, err)
+		time.Sleep(workloadIdentityPollInterval)
+	}
+}
+
+// runWorkloadIdentityCanary runs the canary pod to completion and returns the
+// identity the metadata server reported to it.
+func (d *Deployer) runWorkloadIdentityCanary(kubeconfig, podName string) (string, error) {
+	out, err := exec.Output(d.cmder.Command("kubectl",
+		"--kubeconfig="+kubeconfig,
+		"--namespace=default",
+		"run", podName,
+		"--image="+workloadIdentityCanaryImage,
+		"--restart=Never",
+		"--rm",
+		"--stdin",
+		"--quiet",
+		"--pod-running-timeout=2m",
+		"--command", "--", "sh", "-c", workloadIdentityCanaryScript,
+	))
+	if err != nil {
+		return "", fmt.Errorf("canary pod %s failed: %s", podName, execError(err))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// workloadPool returns the workload identity pool of the clusters of the
+// project, which is also the identity of pods without an IAM service account.
+func workloadPool(project string) string {
+	return project + ".svc.id.goog"
+}
+
+// clusterKubeconfig returns the kubeconfig of the cluster among the
+// kubeconfigs returned by Kubeconfig(), the empty string if there is none.
+func clusterKubeconfig(kubeconfigs, project, clusterName string) string {
+	for _, kubeconfig := range filepath.SplitList(kubeconfigs) {
+		if filepath.Base(kubeconfig) == fmt.Sprintf("kubecfg-%s-%s", project, clusterName) {
+			return kubeconfig
+		}
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestClusterKubeconfig(t *testing.T) {
+	kubeconfigs := strings.Join([]string{
+		"/tmp/kubetest2-gke1/kubecfg-p1-cluster",
+		"/tmp/kubetest2-gke1/kubecfg-p1-cluster-2",
+		"/tmp/kubetest2-gke1/kubecfg-p2-cluster",
+	}, ":")
+	testCases := []struct {
+		project  string
+		cluster  string
+		expected string
+	}{
+		{project: "p1", cluster: "cluster", expected: "/tmp/kubetest2-gke1/kubecfg-p1-cluster"},
+		{project: "p1", cluster: "cluster-2", expected: "/tmp/kubetest2-gke1/kubecfg-p1-cluster-2"},
+		{project: "p2", cluster: "cluster", expected: "/tmp/kubetest2-gke1/kubecfg-p2-cluster"},
+		{project: "p3", cluster: "cluster", expected: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.project+"/"+tc.cluster, func(t *testing.T) {
+			if actual := clusterKubeconfig(kubeconfigs, tc.project, tc.cluster); actual != tc.expected {
+				t.Errorf("expected kubeconfig %q but got %q", tc.expected, actual)
+			}
+		})
+	}
+}
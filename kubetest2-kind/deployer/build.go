@@ -18,7 +18,6 @@ package deployer
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"runtime"
 
@@ -27,7 +26,6 @@ import (
 	"sigs.k8s.io/kubetest2/pkg/build"
 	"sigs.k8s.io/kubetest2/pkg/exec"
 	"sigs.k8s.io/kubetest2/pkg/fs"
-	"sigs.k8s.io/kubetest2/pkg/process"
 )
 
 const (
@@ -56,8 +54,8 @@ func (d *deployer) Build() error {
 	}
 
 	klog.V(0).Infof("Build(): building kind node image...\n")
-	// we want to see the output so use process.ExecJUnit
-	if err := process.ExecJUnit("kind", args, os.Environ()); err != nil {
+	// we want to see the output so use runKind
+	if err := d.runKind("build-node-image", args...); err != nil {
 		return err
 	}
 	klog.V(0).Infof("Build(): build e2e requirements...\n")
@@ -19,8 +19,10 @@ package deployer
 
 import (
 	"flag"
+	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/octago/sflags/gen/gpflag"
 	"github.com/spf13/pflag"
@@ -39,8 +41,11 @@ var GitTag string
 func New(opts types.Options) (types.Deployer, *pflag.FlagSet) {
 	// create a deployer object and set fields that are not flag controlled
 	d := &deployer{
-		commonOptions: opts,
-		logsDir:       filepath.Join(artifacts.BaseDir(), "logs"),
+		commonOptions:    opts,
+		logsDir:          filepath.Join(artifacts.BaseDir(), "logs"),
+		kindLogsDir:      filepath.Join(artifacts.BaseDir(), "kind"),
+		ImagePullPolicy:  imagePullPolicyIfNotPresent,
+		ImagePullRetries: 3,
 	}
 	// register flags and return
 	return d, bindFlags(d)
@@ -58,21 +63,56 @@ type deployer struct {
 	ClusterName    string `flag:"cluster-name" desc:"the kind cluster --name"`
 	BuildType      string `desc:"--type for kind build node-image"`
 	ConfigPath     string `flag:"config" desc:"--config for kind create cluster"`
-	KubeconfigPath string `flag:"kubeconfig" desc:"--kubeconfig flag for kind create cluster"`
+	KubeconfigPath string `flag:"kubeconfig" desc:"--kubeconfig flag for kind create cluster and kind delete cluster. Defaults to kubeconfig in the run dir, so that runs don't modify the user's kubeconfig."`
+	UserKubeconfig bool   `desc:"Write the kubeconfig to ~/.kube/config, the default before it was written to the run dir. Ignored if --kubeconfig is set."`
 	KubeRoot       string `desc:"the Kubernetes source for kind build node-image"`
 
+	Wait             time.Duration `desc:"--wait for kind create cluster, how long to wait for the control plane to be ready (e.g. 5m). Disabled if zero."`
+	Retain           bool          `desc:"--retain for kind create cluster, keep the nodes if cluster creation fails for debugging."`
+	Verbosity        int           `desc:"--verbosity for kind commands, the kind output is also written under $ARTIFACTS/kind."`
+	ImagePullPolicy  string        `desc:"Pull policy for the node image before creating the cluster, one of IfNotPresent, Always or Never."`
+	ImagePullRetries int           `desc:"Number of times to retry pulling the node image before giving up."`
+
 	logsDir string
+	// kindLogsDir is where the output of kind commands is written
+	kindLogsDir string
 }
 
 func (d *deployer) Kubeconfig() (string, error) {
+	return d.kubeconfigPath()
+}
+
+// kubeconfigPath returns the kubeconfig kind writes the cluster to, the
+// kubeconfig in the run dir unless --kubeconfig or --user-kubeconfig is set,
+// so that concurrent runs don't share (and corrupt) the user's kubeconfig
+func (d *deployer) kubeconfigPath() (string, error) {
 	if d.KubeconfigPath != "" {
 		return d.KubeconfigPath, nil
 	}
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
+	if d.UserKubeconfig {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, ".kube", "config"), nil
 	}
-	return filepath.Join(home, ".kube", "config"), nil
+	return filepath.Join(d.commonOptions.RunDir(), "kubeconfig"), nil
+}
+
+func (d *deployer) verifyUpFlags() error {
+	switch d.ImagePullPolicy {
+	case imagePullPolicyIfNotPresent, imagePullPolicyAlways, imagePullPolicyNever:
+	default:
+		return fmt.Errorf("--image-pull-policy must be one of %v, got %q",
+			[]string{imagePullPolicyIfNotPresent, imagePullPolicyAlways, imagePullPolicyNever}, d.ImagePullPolicy)
+	}
+	if d.ImagePullRetries < 0 {
+		return fmt.Errorf("--image-pull-retries must not be negative, got %d", d.ImagePullRetries)
+	}
+	if d.Wait < 0 {
+		return fmt.Errorf("--wait must not be negative, got %v", d.Wait)
+	}
+	return nil
 }
 
 func (d *deployer) Version() string {
@@ -100,3 +140,10 @@ var _ types.DeployerWithKubeconfig = &deployer{}
 
 // well-known kind related constants
 const kindDefaultBuiltImageName = "kindest/node:latest"
+
+// supported values for --image-pull-policy
+const (
+	imagePullPolicyIfNotPresent = "IfNotPresent"
+	imagePullPolicyAlways       = "Always"
+	imagePullPolicyNever        = "Never"
+)
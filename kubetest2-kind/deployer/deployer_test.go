@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+// runDirOptions are the common options of a run with the given run dir
+type runDirOptions struct {
+	types.Options
+	runDir string
+}
+
+func (o runDirOptions) RunDir() string { return o.runDir }
+
+func TestKubeconfigPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	testCases := []struct {
+		name           string
+		kubeconfigPath string
+		userKubeconfig bool
+		expected       string
+	}{
+		{
+			name:     "run dir by default",
+			expected: filepath.Join("/run", "kubeconfig"),
+		},
+		{
+			name:           "user kubeconfig",
+			userKubeconfig: true,
+			expected:       filepath.Join(home, ".kube", "config"),
+		},
+		{
+			name:           "--kubeconfig takes precedence",
+			kubeconfigPath: "/tmp/kubeconfig",
+			userKubeconfig: true,
+			expected:       "/tmp/kubeconfig",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := &deployer{
+				commonOptions:  runDirOptions{runDir: "/run"},
+				KubeconfigPath: tc.kubeconfigPath,
+				UserKubeconfig: tc.userKubeconfig,
+			}
+			actual, err := d.Kubeconfig()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != tc.expected {
+				t.Errorf("expected kubeconfig %s but got %s", tc.expected, actual)
+			}
+		})
+	}
+}
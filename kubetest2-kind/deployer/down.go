@@ -17,23 +17,24 @@ limitations under the License.
 package deployer
 
 import (
-	"os"
-
 	"k8s.io/klog/v2"
-
-	"sigs.k8s.io/kubetest2/pkg/process"
 )
 
 func (d *deployer) Down() error {
 	if err := d.DumpClusterLogs(); err != nil {
 		klog.Warningf("Dumping cluster logs at the start of Down() failed: %v", err)
 	}
+	kubeconfig, err := d.kubeconfigPath()
+	if err != nil {
+		return err
+	}
 	args := []string{
 		"delete", "cluster",
 		"--name", d.ClusterName,
+		"--kubeconfig", kubeconfig,
 	}
 
 	klog.V(0).Infof("Down(): deleting kind cluster...%s\n", d.ClusterName)
-	// we want to see the output so use process.ExecJUnit
-	return process.ExecJUnit("kind", args, os.Environ())
+	// we want to see the output so use runKind
+	return d.runKind("delete-cluster", args...)
 }
@@ -17,11 +17,7 @@ limitations under the License.
 package deployer
 
 import (
-	"os"
-
 	"k8s.io/klog/v2"
-
-	"sigs.k8s.io/kubetest2/pkg/process"
 )
 
 func (d *deployer) DumpClusterLogs() error {
@@ -32,6 +28,6 @@ func (d *deployer) DumpClusterLogs() error {
 	}
 
 	klog.V(0).Infof("DumpClusterLogs(): exporting kind cluster logs...\n")
-	// we want to see the output so use process.ExecJUnit
-	return process.ExecJUnit("kind", args, os.Environ())
+	// we want to see the output so use runKind
+	return d.runKind("export-logs", args...)
 }
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/metadata"
+)
+
+// runKind executes kind with the given args, streaming the output to the
+// console as well as to $ARTIFACTS/kind/<logName>.log
+// if the command fails a metadata.JUnitError with the output is returned
+func (d *deployer) runKind(logName string, args ...string) error {
+	if d.Verbosity > 0 {
+		args = append(args, "--verbosity", strconv.Itoa(d.Verbosity))
+	}
+
+	if err := os.MkdirAll(d.kindLogsDir, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create kind logs directory: %w", err)
+	}
+	logFile, err := os.Create(filepath.Join(d.kindLogsDir, logName+".log"))
+	if err != nil {
+		return fmt.Errorf("failed to create kind log file: %w", err)
+	}
+	defer logFile.Close()
+
+	var systemout bytes.Buffer
+	// stdout and stderr are copied concurrently, synchronize the shared writers
+	captured := &lockedWriter{writer: io.MultiWriter(logFile, &systemout)}
+	cmd := exec.Command("kind", args...)
+	cmd.SetEnv(os.Environ()...)
+	exec.SetOutput(cmd,
+		io.MultiWriter(os.Stdout, captured),
+		io.MultiWriter(os.Stderr, captured),
+	)
+	if err := cmd.Run(); err != nil {
+		return metadata.NewJUnitError(err, systemout.String())
+	}
+	return nil
+}
+
+// lockedWriter is a simple synchronized wrapper around an io.Writer
+type lockedWriter struct {
+	writer io.Writer
+	mu     sync.Mutex
+}
+
+func (l *lockedWriter) Write(b []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.writer.Write(b)
+}
@@ -17,20 +17,26 @@ limitations under the License.
 package deployer
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/kubetest2/pkg/exec"
 	"sigs.k8s.io/kubetest2/pkg/metadata"
-	"sigs.k8s.io/kubetest2/pkg/process"
 )
 
 func (d *deployer) IsUp() (up bool, err error) {
+	kubeconfig, err := d.kubeconfigPath()
+	if err != nil {
+		return false, err
+	}
 	// naively assume that if the api server reports nodes, the cluster is up
 	lines, err := exec.CombinedOutputLines(
-		exec.Command("kubectl", "get", "nodes", "-o=name"),
+		exec.Command("kubectl", "--kubeconfig", kubeconfig, "get", "nodes", "-o=name"),
 	)
 	if err != nil {
 		return false, metadata.NewJUnitError(err, strings.Join(lines, "\n"))
@@ -39,13 +45,28 @@ func (d *deployer) IsUp() (up bool, err error) {
 }
 
 func (d *deployer) Up() error {
+	if err := d.verifyUpFlags(); err != nil {
+		return err
+	}
+
+	kubeconfig, err := d.kubeconfigPath()
+	if err != nil {
+		return err
+	}
 	args := []string{
 		"create", "cluster",
 		"--name", d.ClusterName,
+		"--kubeconfig", kubeconfig,
 	}
 
 	// set the explicitly specified image name if set
 	if d.NodeImage != "" {
+		// an image built in this run is only available locally
+		if !d.commonOptions.ShouldBuild() {
+			if err := d.ensureNodeImage(d.NodeImage); err != nil {
+				return err
+			}
+		}
 		args = append(args, "--image", d.NodeImage)
 	} else if d.commonOptions.ShouldBuild() {
 		// otherwise if we just built an image, use that
@@ -56,11 +77,54 @@ func (d *deployer) Up() error {
 	if d.ConfigPath != "" {
 		args = append(args, "--config", d.ConfigPath)
 	}
-	if d.KubeconfigPath != "" {
-		args = append(args, "--kubeconfig", d.KubeconfigPath)
+	if d.Wait > 0 {
+		args = append(args, "--wait", d.Wait.String())
+	}
+	if d.Retain {
+		args = append(args, "--retain")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(kubeconfig), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create the kubeconfig directory: %w", err)
 	}
 
 	klog.V(0).Infof("Up(): creating kind cluster...\n")
-	// we want to see the output so use process.ExecJUnit
-	return process.ExecJUnit("kind", args, os.Environ())
+	// we want to see the output so use runKind
+	return d.runKind("create-cluster", args...)
+}
+
+// ensureNodeImage makes sure the node image is available locally
+// according to --image-pull-policy, retrying pulls on failure
+func (d *deployer) ensureNodeImage(image string) error {
+	if d.ImagePullPolicy != imagePullPolicyAlways {
+		inspect := exec.Command("docker", "image", "inspect", image)
+		exec.NoOutput(inspect)
+		if err := inspect.Run(); err == nil {
+			klog.V(1).Infof("node image %s is present locally", image)
+			return nil
+		}
+		if d.ImagePullPolicy == imagePullPolicyNever {
+			return fmt.Errorf("node image %s is not present locally and --image-pull-policy=%s", image, imagePullPolicyNever)
+		}
+	}
+
+	var lines []string
+	var err error
+	for attempt := 0; attempt <= d.ImagePullRetries; attempt++ {
+		if attempt > 0 {
+			// back off a little more after each failed attempt
+			backoff := time.Duration(attempt) * 5 * time.Second
+			klog.Warningf("failed to pull node image %s: %v, retrying in %v", image, err, backoff)
+			time.Sleep(backoff)
+		}
+		klog.V(0).Infof("Up(): pulling node image %s ...\n", image)
+		lines, err = exec.CombinedOutputLines(exec.Command("docker", "pull", image))
+		if err == nil {
+			return nil
+		}
+	}
+	return metadata.NewJUnitError(
+		fmt.Errorf("failed to pull node image %s after %d attempts: %w", image, d.ImagePullRetries+1, err),
+		strings.Join(lines, "\n"),
+	)
 }
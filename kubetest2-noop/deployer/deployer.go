@@ -75,6 +75,12 @@ func (d *deployer) Build() error {
 	return nil
 }
 
+// Unsupported implements types.DeployerWithCapabilities, the noop deployer
+// uses an existing cluster and builds nothing
+func (d *deployer) Unsupported() []types.Capability {
+	return []types.Capability{types.CapabilityBuild, types.CapabilityIsUp}
+}
+
 func (d *deployer) Kubeconfig() (string, error) {
 	// noop deployer is specifically used with an existing cluster and KUBECONFIG
 	if d.KubeconfigPath != "" {
@@ -112,3 +118,6 @@ func bindFlags(d *deployer) *pflag.FlagSet {
 
 // assert that deployer implements types.DeployerWithKubeconfig
 var _ types.DeployerWithKubeconfig = &deployer{}
+
+// assert that deployer implements types.DeployerWithCapabilities
+var _ types.DeployerWithCapabilities = &deployer{}
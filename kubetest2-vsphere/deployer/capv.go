@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// capvEnv returns the environment of clusterctl generate cluster, the
+// variables of the vsphere cluster templates
+func (d *deployer) capvEnv() []string {
+	env := os.Environ()
+	for name, value := range map[string]string{
+		"VSPHERE_SERVER":             vsphereServer(os.Getenv("VSPHERE_SERVER"), os.Getenv("GOVC_URL")),
+		"VSPHERE_DATACENTER":         d.Datacenter,
+		"VSPHERE_DATASTORE":          d.Datastore,
+		"VSPHERE_NETWORK":            d.Network,
+		"VSPHERE_RESOURCE_POOL":      d.ResourcePool,
+		"VSPHERE_FOLDER":             d.Folder,
+		"VSPHERE_TEMPLATE":           d.Template,
+		"VSPHERE_TLS_THUMBPRINT":     d.TLSThumbprint,
+		"VSPHERE_SSH_AUTHORIZED_KEY": d.SSHAuthorizedKey,
+		"CONTROL_PLANE_ENDPOINT_IP":  d.ControlPlaneEndpoint,
+	} {
+		env = append(env, name+"="+value)
+	}
+	// the templates reference it, empty uses the default storage policy
+	return append(env, "VSPHERE_STORAGE_POLICY=")
+}
+
+// vsphereServer returns the vCenter address, VSPHERE_SERVER if set or else
+// the host of GOVC_URL
+func vsphereServer(server, govcURL string) string {
+	if server != "" || govcURL == "" {
+		return server
+	}
+	u, err := url.Parse(govcURL)
+	if err != nil || u.Host == "" {
+		// GOVC_URL may be a bare host name
+		return govcURL
+	}
+	return u.Hostname()
+}
+
+// upCAPV applies the cluster generated by clusterctl to the management
+// cluster and retrieves its kubeconfig once it is ready
+func (d *deployer) upCAPV() error {
+	klog.V(0).Infof("Up(): generating the Cluster API cluster %s...\n", d.ClusterName)
+	generate := exec.Command("clusterctl", "generate", "cluster", d.ClusterName,
+		"--kubeconfig="+d.ManagementKubeconfig,
+		"--infrastructure=vsphere",
+		"--kubernetes-version="+d.KubernetesVersion,
+		"--control-plane-machine-count="+strconv.Itoa(d.ControlPlaneCount),
+		"--worker-machine-count="+strconv.Itoa(d.WorkerCount),
+	)
+	generate.SetEnv(d.capvEnv()...)
+	generate.SetStderr(os.Stderr)
+	manifest, err := exec.Output(generate)
+	if err != nil {
+		return fmt.Errorf("failed to generate the cluster: %w", err)
+	}
+	// kept in the run dir to inspect the cluster
+	manifestPath := filepath.Join(d.commonOptions.RunDir(), d.ClusterName+".yaml")
+	if err := os.WriteFile(manifestPath, manifest, 0644); err != nil {
+		return err
+	}
+
+	klog.V(0).Infof("Up(): creating the Cluster API cluster %s...\n", d.ClusterName)
+	if err := d.runManagementKubectl("apply", "-f", manifestPath); err != nil {
+		return fmt.Errorf("failed to create the cluster: %w", err)
+	}
+	if err := d.runManagementKubectl("wait", "--for=condition=Ready", "cluster/"+d.ClusterName, "--timeout="+d.Wait.String()); err != nil {
+		return fmt.Errorf("failed waiting for the cluster to be ready: %w", err)
+	}
+
+	klog.V(0).Infof("Up(): exporting kubeconfig to %s...\n", d.KubeconfigPath)
+	kubeconfig, err := exec.Output(exec.Command("clusterctl", "get", "kubeconfig", d.ClusterName,
+		"--kubeconfig="+d.ManagementKubeconfig))
+	if err != nil {
+		return fmt.Errorf("failed to export kubeconfig: %w", err)
+	}
+	return os.WriteFile(d.KubeconfigPath, kubeconfig, 0600)
+}
+
+// downCAPV deletes the cluster from the management cluster, which deletes
+// its VMs
+func (d *deployer) downCAPV() error {
+	return d.runManagementKubectl("delete", "cluster", d.ClusterName, "--ignore-not-found", "--wait", "--timeout="+d.Wait.String())
+}
+
+// runManagementKubectl executes kubectl against the management cluster,
+// streaming the output to the console
+func (d *deployer) runManagementKubectl(args ...string) error {
+	cmd := exec.Command("kubectl", append([]string{"--kubeconfig=" + d.ManagementKubeconfig}, args...)...)
+	exec.InheritOutput(cmd)
+	return cmd.Run()
+}
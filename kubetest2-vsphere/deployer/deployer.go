@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package deployer implements the kubetest2 vsphere deployer, which creates
+// clusters of vSphere VMs either by cloning VM templates with govc and
+// bootstrapping them with kubeadm, or with Cluster API Provider vSphere
+package deployer
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/octago/sflags/gen/gpflag"
+	"github.com/spf13/pflag"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/artifacts"
+	"sigs.k8s.io/kubetest2/pkg/types"
+	"sigs.k8s.io/kubetest2/pkg/util"
+)
+
+// Name is the name of the deployer
+const Name = "vsphere"
+
+var GitTag string
+
+const (
+	// modeGovc clones --template VMs with govc and runs kubeadm in them
+	modeGovc = "govc"
+	// modeCAPV creates a Cluster API cluster on a management cluster
+	modeCAPV = "capv"
+)
+
+// New implements deployer.New for vsphere
+func New(opts types.Options) (types.Deployer, *pflag.FlagSet) {
+	// create a deployer object and set fields that are not flag controlled
+	d := &deployer{
+		commonOptions: opts,
+		logsDir:       filepath.Join(artifacts.BaseDir(), "logs"),
+		Mode:          modeGovc,
+		// names need to start with an alphabet
+		ClusterName:       "kt2-" + util.PseudoUniqueSubstring(opts.RunID()),
+		KubeconfigPath:    filepath.Join(opts.RunDir(), "kubetest2-kubeconfig"),
+		ControlPlaneCount: 1,
+		WorkerCount:       2,
+		CNIManifest:       defaultCNIManifest,
+		Wait:              20 * time.Minute,
+	}
+	// register flags and return
+	return d, bindFlags(d)
+}
+
+// assert that New implements types.NewDeployer
+var _ types.NewDeployer = New
+
+type deployer struct {
+	// generic parts
+	commonOptions types.Options
+
+	Mode        string `desc:"how the cluster is created, \"govc\" clones --template VMs with govc and bootstraps them with kubeadm, \"capv\" creates a Cluster API cluster on the --management-kubeconfig cluster with Cluster API Provider vSphere"`
+	ClusterName string `flag:"cluster-name" desc:"the cluster name, also the prefix of the VM names, defaults to a name derived from the run id"`
+	// vSphere placement, the vCenter and its credentials are read by govc
+	// from GOVC_URL, GOVC_USERNAME and GOVC_PASSWORD
+	Datacenter    string `desc:"the vSphere datacenter of the VMs"`
+	Datastore     string `desc:"the datastore of the VM disks"`
+	Network       string `desc:"the network (port group) the VMs are attached to"`
+	ResourcePool  string `desc:"the resource pool of the VMs"`
+	Folder        string `desc:"the VM folder the VMs are created in"`
+	Template      string `desc:"the VM template cloned for the nodes, it must have kubeadm and a container runtime installed and run cloud-init with the VMware guestinfo datasource"`
+	TLSThumbprint string `flag:"tls-thumbprint" desc:"the SHA-1 thumbprint of the vCenter certificate, required by --mode=capv"`
+	// cluster shape
+	ControlPlaneCount int    `desc:"the number of control plane nodes, --mode=govc only supports 1"`
+	WorkerCount       int    `desc:"the number of worker nodes"`
+	KubernetesVersion string `desc:"the Kubernetes version of the cluster, e.g. v1.30.2. Required by --mode=capv, --mode=govc defaults to the kubeadm version of --template"`
+	CNIManifest       string `flag:"cni-manifest" desc:"the manifest of the pod network applied to the cluster once its kubeconfig is retrieved, it must use the 192.168.0.0/16 pod CIDR in --mode=govc. Empty skips it, e.g. when the template or the cluster class installs one"`
+	// Cluster API details
+	ManagementKubeconfig string `desc:"the kubeconfig of the Cluster API management cluster with the vSphere provider installed, required by --mode=capv"`
+	ControlPlaneEndpoint string `desc:"the IP of the control plane endpoint of the cluster, required by --mode=capv"`
+	SSHAuthorizedKey     string `flag:"ssh-authorized-key" desc:"a public key authorized for SSH access to the nodes"`
+
+	KubeconfigPath string        `flag:"kubeconfig" desc:"path the cluster kubeconfig is exported to, defaults to a file in the run dir"`
+	Wait           time.Duration `desc:"how long to wait for the cluster to be ready (e.g. 20m)"`
+
+	logsDir string
+}
+
+func (d *deployer) Kubeconfig() (string, error) {
+	return d.KubeconfigPath, nil
+}
+
+func (d *deployer) verifyUpFlags() error {
+	if d.ClusterName == "" {
+		return fmt.Errorf("--cluster-name must not be empty")
+	}
+	if d.Template == "" {
+		return fmt.Errorf("--template must be set")
+	}
+	if d.WorkerCount < 0 {
+		return fmt.Errorf("--worker-count must not be negative, got %d", d.WorkerCount)
+	}
+	if d.Wait <= 0 {
+		return fmt.Errorf("--wait must be positive, got %v", d.Wait)
+	}
+	switch d.Mode {
+	case modeGovc:
+		if d.ControlPlaneCount != 1 {
+			return fmt.Errorf("--mode=%s only supports a single control plane node, got --control-plane-count=%d", modeGovc, d.ControlPlaneCount)
+		}
+	case modeCAPV:
+		if d.ControlPlaneCount < 1 {
+			return fmt.Errorf("--control-plane-count must be at least 1, got %d", d.ControlPlaneCount)
+		}
+		if d.ManagementKubeconfig == "" || d.ControlPlaneEndpoint == "" || d.KubernetesVersion == "" || d.TLSThumbprint == "" {
+			return fmt.Errorf("--mode=%s requires --management-kubeconfig, --control-plane-endpoint, --kubernetes-version and --tls-thumbprint", modeCAPV)
+		}
+	default:
+		return fmt.Errorf("--mode must be one of %q or %q, got %q", modeGovc, modeCAPV, d.Mode)
+	}
+	return nil
+}
+
+func (d *deployer) Version() string {
+	return GitTag
+}
+
+// helper used to create & bind a flagset to the deployer
+func bindFlags(d *deployer) *pflag.FlagSet {
+	flags, err := gpflag.Parse(d)
+	if err != nil {
+		klog.Fatalf("unable to generate flags from deployer")
+		return nil
+	}
+
+	// initing the klog flags adds them to goflag.CommandLine
+	// they can then be added to the built pflag set
+	klog.InitFlags(nil)
+	flags.AddGoFlagSet(flag.CommandLine)
+
+	return flags
+}
+
+// assert that deployer implements types.DeployerWithKubeconfig
+var _ types.DeployerWithKubeconfig = &deployer{}
+
+const defaultCNIManifest = "https://raw.githubusercontent.com/projectcalico/calico/v3.28.0/manifests/calico.yaml"
@@ -0,0 +1,48 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+
+	"k8s.io/klog/v2"
+)
+
+func (d *deployer) Down() error {
+	if err := d.DumpClusterLogs(); err != nil {
+		klog.Warningf("Dumping cluster logs at the start of Down() failed: %v", err)
+	}
+
+	if d.Mode == modeCAPV {
+		if d.ManagementKubeconfig == "" {
+			return fmt.Errorf("--mode=%s requires --management-kubeconfig", modeCAPV)
+		}
+		klog.V(0).Infof("Down(): deleting the Cluster API cluster %s...\n", d.ClusterName)
+		return d.downCAPV()
+	}
+
+	vms, err := d.clusterVMs()
+	if err != nil {
+		return fmt.Errorf("failed to list the VMs of the cluster: %w", err)
+	}
+	if len(vms) == 0 {
+		klog.V(0).Infof("Down(): no VMs found for cluster %s\n", d.ClusterName)
+		return nil
+	}
+	klog.V(0).Infof("Down(): deleting VMs %v...\n", vms)
+	return d.runGovc(append([]string{"vm.destroy"}, vms...)...)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"k8s.io/klog/v2"
+)
+
+// consoleLogFile is the file in the VM directory the serial console of the
+// VMs created by --mode=govc is written to
+const consoleLogFile = "console.log"
+
+// DumpClusterLogs downloads the vmware.log and the serial console log of
+// every VM of the cluster from their datastore directory to the logs dir.
+func (d *deployer) DumpClusterLogs() error {
+	klog.V(0).Infof("DumpClusterLogs(): downloading VM logs...\n")
+	vms, err := d.clusterVMs()
+	if err != nil {
+		return fmt.Errorf("failed to list the VMs of the cluster: %w", err)
+	}
+	if err := os.MkdirAll(d.logsDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, vm := range vms {
+		if err := d.downloadVMLog(vm, "vmware.log"); err != nil {
+			errs = append(errs, err)
+		}
+		// CAPV templates usually have no serial port
+		if err := d.downloadVMLog(vm, consoleLogFile); err != nil {
+			klog.Warningf("failed to download the console log of %s: %v", vm, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (d *deployer) downloadVMLog(vm, file string) error {
+	path := filepath.Join(d.logsDir, vm+"-"+file)
+	if err := d.runGovc("datastore.download", vm+"/"+file, path); err != nil {
+		return fmt.Errorf("failed to download %s of %s: %w", file, vm, err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"os"
+	"path"
+	"strings"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// govcEnv returns the environment of govc, the placement flags override the
+// GOVC_* variables of the user
+func (d *deployer) govcEnv() []string {
+	env := os.Environ()
+	for name, value := range map[string]string{
+		"GOVC_DATACENTER":    d.Datacenter,
+		"GOVC_DATASTORE":     d.Datastore,
+		"GOVC_NETWORK":       d.Network,
+		"GOVC_RESOURCE_POOL": d.ResourcePool,
+		"GOVC_FOLDER":        d.Folder,
+	} {
+		if value != "" {
+			env = append(env, name+"="+value)
+		}
+	}
+	return env
+}
+
+// runGovc executes govc with the given args, streaming the output to the console
+func (d *deployer) runGovc(args ...string) error {
+	cmd := exec.Command("govc", args...)
+	cmd.SetEnv(d.govcEnv()...)
+	exec.InheritOutput(cmd)
+	return cmd.Run()
+}
+
+// govcOutput executes govc with the given args and returns its output lines
+func (d *deployer) govcOutput(args ...string) ([]string, error) {
+	cmd := exec.Command("govc", args...)
+	cmd.SetEnv(d.govcEnv()...)
+	cmd.SetStderr(os.Stderr)
+	return exec.OutputLines(cmd)
+}
+
+// clusterVMs returns the names of the VMs of the cluster, in both modes the
+// VM names are prefixed with the cluster name
+func (d *deployer) clusterVMs() ([]string, error) {
+	root := "/"
+	if d.Folder != "" {
+		root = d.Folder
+	}
+	paths, err := d.govcOutput("find", root, "-type", "m", "-name", d.ClusterName+"-*")
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, p := range paths {
+		if p = strings.TrimSpace(p); p != "" {
+			names = append(names, path.Base(p))
+		}
+	}
+	return names, nil
+}
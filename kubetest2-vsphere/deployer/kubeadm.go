@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// podNetworkCIDR is the pod network of the kubeadm clusters, the default of
+// the common CNI manifests
+const podNetworkCIDR = "192.168.0.0/16"
+
+// newBootstrapToken returns a random kubeadm bootstrap token, of the form
+// [a-z0-9]{6}.[a-z0-9]{16}
+func newBootstrapToken() (string, error) {
+	const chars = "abcdefghijklmnopqrstuvwxyz0123456789"
+	token := make([]byte, 0, 23)
+	for i := 0; i < 22; i++ {
+		if i == 6 {
+			token = append(token, '.')
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+		if err != nil {
+			return "", err
+		}
+		token = append(token, chars[n.Int64()])
+	}
+	return string(token), nil
+}
+
+// controlPlaneUserData is the cloud-init config running kubeadm init
+func controlPlaneUserData(token, kubernetesVersion, sshAuthorizedKey string) string {
+	args := []string{"kubeadm", "init",
+		"--token=" + token,
+		"--token-ttl=24h",
+		"--pod-network-cidr=" + podNetworkCIDR,
+	}
+	if kubernetesVersion != "" {
+		args = append(args, "--kubernetes-version="+kubernetesVersion)
+	}
+	return userData(strings.Join(args, " "), sshAuthorizedKey)
+}
+
+// workerUserData is the cloud-init config running kubeadm join, the CA of
+// the control plane is not known in advance so it is not pinned
+func workerUserData(token, controlPlaneIP, sshAuthorizedKey string) string {
+	args := []string{"kubeadm", "join", controlPlaneIP + ":6443",
+		"--token=" + token,
+		"--discovery-token-unsafe-skip-ca-verification",
+	}
+	return userData(strings.Join(args, " "), sshAuthorizedKey)
+}
+
+func userData(command, sshAuthorizedKey string) string {
+	var b strings.Builder
+	b.WriteString("#cloud-config\n")
+	if sshAuthorizedKey != "" {
+		fmt.Fprintf(&b, "ssh_authorized_keys:\n- %s\n", sshAuthorizedKey)
+	}
+	fmt.Fprintf(&b, "runcmd:\n- %s\n", command)
+	return b.String()
+}
+
+// guestInfoArgs are the govc vm.change args passing userData to cloud-init
+// through the VMware guestinfo datasource
+func guestInfoArgs(vm, userData string) []string {
+	return []string{"vm.change", "-vm", vm,
+		"-e", "guestinfo.userdata=" + base64.StdEncoding.EncodeToString([]byte(userData)),
+		"-e", "guestinfo.userdata.encoding=base64",
+	}
+}
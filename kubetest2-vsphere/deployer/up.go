@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/kubectl"
+	"sigs.k8s.io/kubetest2/pkg/metadata"
+)
+
+func (d *deployer) IsUp() (up bool, err error) {
+	// naively assume that if the api server reports nodes, the cluster is up
+	lines, err := exec.CombinedOutputLines(
+		exec.Command("kubectl", "--kubeconfig="+d.KubeconfigPath, "get", "nodes", "-o=name"),
+	)
+	if err != nil {
+		return false, metadata.NewJUnitError(err, strings.Join(lines, "\n"))
+	}
+	return len(lines) > 0, nil
+}
+
+func (d *deployer) Up() error {
+	if err := d.verifyUpFlags(); err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(d.Wait)
+	var err error
+	if d.Mode == modeCAPV {
+		err = d.upCAPV()
+	} else {
+		err = d.upGovc(deadline)
+	}
+	if err != nil {
+		return err
+	}
+
+	if d.CNIManifest != "" {
+		klog.V(0).Infof("Up(): applying the pod network %s...\n", d.CNIManifest)
+		if err := kubectl.Run(d.KubeconfigPath, "apply", "-f", d.CNIManifest); err != nil {
+			return fmt.Errorf("failed to apply the pod network: %w", err)
+		}
+	}
+	return kubectl.WaitForReadyNodes(d.KubeconfigPath, d.ControlPlaneCount+d.WorkerCount, deadline)
+}
+
+// upGovc clones the control plane VM, then the workers joining it once it
+// has an IP, and downloads the admin kubeconfig written by kubeadm init
+func (d *deployer) upGovc(deadline time.Time) error {
+	token, err := newBootstrapToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate a bootstrap token: %w", err)
+	}
+
+	controlPlane := d.ClusterName + "-control-plane"
+	if err := d.createVM(controlPlane, controlPlaneUserData(token, d.KubernetesVersion, d.SSHAuthorizedKey)); err != nil {
+		return err
+	}
+	ips, err := d.govcOutput("vm.ip", "-wait="+time.Until(deadline).Round(time.Second).String(), controlPlane)
+	if err != nil || len(ips) == 0 {
+		return fmt.Errorf("failed to get the IP of %s: %v", controlPlane, err)
+	}
+	controlPlaneIP := strings.TrimSpace(ips[0])
+	klog.V(0).Infof("Up(): control plane %s has IP %s\n", controlPlane, controlPlaneIP)
+
+	for i := 0; i < d.WorkerCount; i++ {
+		worker := fmt.Sprintf("%s-worker-%d", d.ClusterName, i)
+		if err := d.createVM(worker, workerUserData(token, controlPlaneIP, d.SSHAuthorizedKey)); err != nil {
+			return err
+		}
+	}
+
+	// guest operations authenticate with GOVC_GUEST_LOGIN, user:password
+	klog.V(0).Infof("Up(): downloading the kubeconfig to %s...\n", d.KubeconfigPath)
+	for {
+		err := d.runGovc("guest.download", "-f", "-vm", controlPlane, "/etc/kubernetes/admin.conf", d.KubeconfigPath)
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for kubeadm init on %s: %w", controlPlane, err)
+		}
+		time.Sleep(15 * time.Second)
+	}
+}
+
+// createVM clones --template into vm, passes it userData and powers it on.
+// The serial console of the VM is written to console.log in its directory
+// for DumpClusterLogs.
+func (d *deployer) createVM(vm, userData string) error {
+	klog.V(0).Infof("Up(): creating VM %s from %s...\n", vm, d.Template)
+	if err := d.runGovc("vm.clone", "-vm", d.Template, "-on=false", vm); err != nil {
+		return fmt.Errorf("failed to clone %s: %w", d.Template, err)
+	}
+	if err := d.runGovc(guestInfoArgs(vm, userData)...); err != nil {
+		return fmt.Errorf("failed to set the user data of %s: %w", vm, err)
+	}
+	if d.Datastore != "" {
+		if err := d.runGovc("device.serial.add", "-vm", vm); err != nil {
+			return fmt.Errorf("failed to add a serial port to %s: %w", vm, err)
+		}
+		if err := d.runGovc("device.serial.connect", "-vm", vm, fmt.Sprintf("[%s] %s/%s", d.Datastore, vm, consoleLogFile)); err != nil {
+			return fmt.Errorf("failed to connect the serial port of %s: %w", vm, err)
+		}
+	} else {
+		klog.Warningf("--datastore is not set, the console of %s is not logged", vm)
+	}
+	return d.runGovc("vm.power", "-on", vm)
+}
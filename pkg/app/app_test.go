@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/pkg/metadata"
+)
+
+func TestWriteFailureTypeToMetadataJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "unclassified error",
+			err:      errors.New("oh noes"),
+			expected: `{"kubetest-version":"v1"}`,
+		},
+		{
+			name:     "wrapped classified error",
+			err:      fmt.Errorf("error creating clusters: %w", metadata.NewClassifiedError(errors.New("QUOTA_EXCEEDED"), "QuotaExceeded")),
+			expected: `{"kubetest-version":"v1","up-failure-type":"QuotaExceeded"}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "metadata.json")
+			if err := os.WriteFile(path, []byte(`{"kubetest-version":"v1"}`), 0644); err != nil {
+				t.Fatalf("failed to write metadata: %v", err)
+			}
+			writeFailureTypeToMetadataJSON(dir, "Up", tc.err)
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read metadata: %v", err)
+			}
+			if string(data) != tc.expected {
+				t.Errorf("expected metadata %s but got %s", tc.expected, data)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// argsWarningsKey is the metadata.json key the args warnings are recorded as
+const argsWarningsKey = "args-warnings"
+
+// checkArgs returns warnings for the most common mistakes splitting the
+// arguments at `--`: positional arguments before `--`, which kubetest2
+// ignores, and kubetest2 or deployer flags after `--`, which are passed to
+// the tester instead. flags must have parsed the args before `--`, flags the
+// tester also registers (according to testerUsage) are not reported.
+func checkArgs(flags *pflag.FlagSet, testerArgs []string, testerUsage string) []string {
+	var warnings []string
+	if ignored := flags.Args(); len(ignored) > 0 {
+		warnings = append(warnings, fmt.Sprintf(
+			"arguments %q before `--` are ignored, tester arguments must follow `--`", ignored,
+		))
+	}
+	for _, arg := range testerArgs {
+		name, ok := flagName(arg)
+		if !ok || usageHasFlag(testerUsage, name) {
+			continue
+		}
+		if flags.Lookup(name) != nil {
+			warnings = append(warnings, fmt.Sprintf(
+				"flag %q after `--` is passed to the tester, kubetest2 and deployer flags must precede `--`", arg,
+			))
+		}
+	}
+	return warnings
+}
+
+// unknownFlagHint returns a hint for the pflag parse error err, when the
+// unknown flag is one of the tester flags listed in testerUsage
+func unknownFlagHint(err error, testerUsage string) string {
+	name, found := strings.CutPrefix(err.Error(), "unknown flag: --")
+	if !found || !usageHasFlag(testerUsage, name) {
+		return ""
+	}
+	return fmt.Sprintf("--%s is a tester flag, tester arguments must follow `--`", name)
+}
+
+// flagName returns the long flag name of arg, if arg is a long flag
+func flagName(arg string) (string, bool) {
+	name, found := strings.CutPrefix(arg, "--")
+	if !found || name == "" {
+		return "", false
+	}
+	name, _, _ = strings.Cut(name, "=")
+	return name, true
+}
+
+// usageHasFlag returns true if the --name flag is listed in usage
+func usageHasFlag(usage, name string) bool {
+	for _, field := range strings.Fields(usage) {
+		if n, ok := flagName(strings.TrimSuffix(field, ",")); ok && n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+const fakeTesterUsage = `Usage of ginkgo:
+      --focus-regex string   Regular expression of jobs to focus on.
+      --parallel int         Run this many tests in parallel at once. (default 1)
+      --timeout duration     How long to run the tests. (default 24h0m0s)
+`
+
+func TestCheckArgs(t *testing.T) {
+	testCases := []struct {
+		name             string
+		deployerArgs     []string
+		testerArgs       []string
+		expectedWarnings []string
+	}{
+		{
+			name:         "correctly split",
+			deployerArgs: []string{"--up", "--region=us-east1"},
+			testerArgs:   []string{"--focus-regex=foo", "--parallel", "30"},
+		},
+		{
+			name:             "positional args before --",
+			deployerArgs:     []string{"--up", "ginkgo"},
+			testerArgs:       []string{"--focus-regex=foo"},
+			expectedWarnings: []string{"arguments [\"ginkgo\"] before `--` are ignored, tester arguments must follow `--`"},
+		},
+		{
+			name:         "deployer and kubetest2 flags after --",
+			deployerArgs: []string{"--up"},
+			testerArgs:   []string{"--focus-regex=foo", "--region=us-east1", "--down"},
+			expectedWarnings: []string{
+				"flag \"--region=us-east1\" after `--` is passed to the tester, kubetest2 and deployer flags must precede `--`",
+				"flag \"--down\" after `--` is passed to the tester, kubetest2 and deployer flags must precede `--`",
+			},
+		},
+		{
+			name:         "flags shared with the tester after --",
+			deployerArgs: []string{"--up"},
+			testerArgs:   []string{"--timeout=1h"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			flags.Bool("up", false, "")
+			flags.Bool("down", false, "")
+			flags.String("region", "", "")
+			flags.Duration("timeout", 0, "")
+			if err := flags.Parse(tc.deployerArgs); err != nil {
+				t.Fatalf("failed to parse args: %v", err)
+			}
+			warnings := checkArgs(flags, tc.testerArgs, fakeTesterUsage)
+			if !reflect.DeepEqual(warnings, tc.expectedWarnings) {
+				t.Errorf("expected warnings %q but got %q", tc.expectedWarnings, warnings)
+			}
+		})
+	}
+}
+
+func TestUnknownFlagHint(t *testing.T) {
+	testCases := []struct {
+		name         string
+		err          error
+		expectedHint string
+	}{
+		{
+			name:         "unknown tester flag",
+			err:          errors.New("unknown flag: --focus-regex"),
+			expectedHint: "--focus-regex is a tester flag, tester arguments must follow `--`",
+		},
+		{
+			name: "unknown flag",
+			err:  errors.New("unknown flag: --foo"),
+		},
+		{
+			name: "other parse error",
+			err:  errors.New(`invalid argument "x" for "--parallel" flag`),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if hint := unknownFlagHint(tc.err, fakeTesterUsage); hint != tc.expectedHint {
+				t.Errorf("expected hint %q but got %q", tc.expectedHint, hint)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// expandArgFiles replaces every @path argument with the arguments listed in
+// the file at path, one per line. Blank lines and lines starting with # are
+// ignored, and the lines are not further expanded. A leading @@ escapes a
+// literal @.
+func expandArgFiles(args []string) ([]string, error) {
+	expanded := make([]string, 0, len(args))
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "@@") {
+			expanded = append(expanded, arg[1:])
+			continue
+		}
+		if !strings.HasPrefix(arg, "@") || len(arg) == 1 {
+			expanded = append(expanded, arg)
+			continue
+		}
+		fileArgs, err := readArgsFile(arg[1:])
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, fileArgs...)
+	}
+	return expanded, nil
+}
+
+func readArgsFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read args file: %w", err)
+	}
+	var args []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		args = append(args, line)
+	}
+	return args, nil
+}
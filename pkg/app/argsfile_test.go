@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExpandArgFiles(t *testing.T) {
+	argsFile := filepath.Join(t.TempDir(), "args.txt")
+	contents := `# skip slow tests
+--skip-regex=\[Slow\]|\[Serial\]
+
+  --parallel=30
+`
+	if err := os.WriteFile(argsFile, []byte(contents), 0644); err != nil {
+		t.Fatalf("failed to write args file: %v", err)
+	}
+
+	testCases := []struct {
+		name         string
+		args         []string
+		expectedArgs []string
+		expectError  bool
+	}{
+		{
+			name:         "no args files",
+			args:         []string{"--up", "--", "--focus-regex=foo"},
+			expectedArgs: []string{"--up", "--", "--focus-regex=foo"},
+		},
+		{
+			name:         "args file after --",
+			args:         []string{"--up", "--", "@" + argsFile, "--focus-regex=foo"},
+			expectedArgs: []string{"--up", "--", `--skip-regex=\[Slow\]|\[Serial\]`, "--parallel=30", "--focus-regex=foo"},
+		},
+		{
+			name:         "escaped and bare @",
+			args:         []string{"@@literal", "@"},
+			expectedArgs: []string{"@literal", "@"},
+		},
+		{
+			name:        "missing args file",
+			args:        []string{"@" + filepath.Join(t.TempDir(), "missing.txt")},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actualArgs, err := expandArgFiles(tc.args)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect an error, but got: %v", err)
+			}
+			if !reflect.DeepEqual(tc.expectedArgs, actualArgs) {
+				t.Errorf("mismatched args: expected: %v, but got: %v", tc.expectedArgs, actualArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+// buildManifestFile is written to the artifacts dir by build only runs
+const buildManifestFile = "build-manifest.json"
+
+// writeBuildManifest writes the manifest of the artifacts built by d to
+// build-manifest.json in dir, if d reports one.
+func writeBuildManifest(d types.Deployer, dir string) error {
+	dWithManifest, ok := d.(types.DeployerWithBuildManifest)
+	if !ok {
+		klog.V(1).Infof("The deployer does not report its build artifacts, not writing %s", buildManifestFile)
+		return nil
+	}
+	manifest, err := dWithManifest.BuildManifest()
+	if err != nil {
+		return err
+	}
+	if manifest == nil {
+		return errors.New("the deployer returned no build manifest")
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(dir, buildManifestFile)
+	klog.Infof("Writing the build manifest to %s", path)
+	return os.WriteFile(path, data, 0644)
+}
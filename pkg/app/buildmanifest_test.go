@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+type fakeDeployer struct{}
+
+func (f *fakeDeployer) Up() error              { return nil }
+func (f *fakeDeployer) Down() error            { return nil }
+func (f *fakeDeployer) IsUp() (bool, error)    { return true, nil }
+func (f *fakeDeployer) DumpClusterLogs() error { return nil }
+func (f *fakeDeployer) Build() error           { return nil }
+
+type fakeDeployerWithManifest struct {
+	fakeDeployer
+	manifest *types.BuildManifest
+}
+
+func (f *fakeDeployerWithManifest) BuildManifest() (*types.BuildManifest, error) {
+	return f.manifest, nil
+}
+
+func TestWriteBuildManifest(t *testing.T) {
+	manifest := &types.BuildManifest{
+		Version:   "v1.31.0-alpha.1+abc",
+		Tars:      []string{"/go/src/k8s.io/kubernetes/_output/release-tars/kubernetes.tar.gz"},
+		Images:    []string{"gcr.io/p/kube-apiserver-amd64:v1.31.0-alpha.1_abc"},
+		StagedURL: "gs://bucket/ci/v1.31.0-alpha.1+abc",
+	}
+	testCases := []struct {
+		name     string
+		deployer types.Deployer
+		expected *types.BuildManifest
+	}{
+		{
+			name:     "deployer without manifest",
+			deployer: &fakeDeployer{},
+		},
+		{
+			name:     "deployer with manifest",
+			deployer: &fakeDeployerWithManifest{manifest: manifest},
+			expected: manifest,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := writeBuildManifest(tc.deployer, dir); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			data, err := os.ReadFile(filepath.Join(dir, buildManifestFile))
+			if tc.expected == nil {
+				if !os.IsNotExist(err) {
+					t.Errorf("expected no build manifest but got %q, %v", data, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to read the build manifest: %v", err)
+			}
+			actual := &types.BuildManifest{}
+			if err := json.Unmarshal(data, actual); err != nil {
+				t.Fatalf("failed to parse the build manifest: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected build manifest %+v but got %+v", tc.expected, actual)
+			}
+		})
+	}
+}
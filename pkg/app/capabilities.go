@@ -0,0 +1,44 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+// checkCapabilities returns an error if opts request a lifecycle action
+// that d declares unsupported
+func checkCapabilities(opts types.Options, d types.Deployer) error {
+	dWithCapabilities, ok := d.(types.DeployerWithCapabilities)
+	if !ok {
+		return nil
+	}
+	requested := map[types.Capability]bool{
+		types.CapabilityBuild: opts.ShouldBuild(),
+		types.CapabilityUp:    opts.ShouldUp(),
+		types.CapabilityDown:  opts.ShouldDown(),
+	}
+	for _, c := range dWithCapabilities.Unsupported() {
+		if requested[c] {
+			return fmt.Errorf("the deployer does not support --%s", strings.ToLower(string(c)))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"testing"
+
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+type fakeDeployerWithCapabilities struct {
+	fakeDeployer
+	unsupported []types.Capability
+}
+
+func (f *fakeDeployerWithCapabilities) Unsupported() []types.Capability {
+	return f.unsupported
+}
+
+func TestCheckCapabilities(t *testing.T) {
+	testCases := []struct {
+		name        string
+		opts        *options
+		deployer    types.Deployer
+		expectError bool
+	}{
+		{
+			name:     "deployer without capabilities",
+			opts:     &options{build: true, up: true},
+			deployer: &fakeDeployer{},
+		},
+		{
+			name:        "unsupported build requested",
+			opts:        &options{build: true, up: true},
+			deployer:    &fakeDeployerWithCapabilities{unsupported: []types.Capability{types.CapabilityBuild, types.CapabilityIsUp}},
+			expectError: true,
+		},
+		{
+			name:     "unsupported build not requested",
+			opts:     &options{up: true, down: true},
+			deployer: &fakeDeployerWithCapabilities{unsupported: []types.Capability{types.CapabilityBuild, types.CapabilityIsUp}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkCapabilities(tc.opts, tc.deployer)
+			if tc.expectError && err == nil {
+				t.Errorf("expected an error but got none")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
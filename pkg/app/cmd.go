@@ -22,15 +22,20 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/kubetest2/pkg/app/shim"
 	"sigs.k8s.io/kubetest2/pkg/artifacts"
 	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/flagdump"
+	"sigs.k8s.io/kubetest2/pkg/testers"
 	"sigs.k8s.io/kubetest2/pkg/types"
+	"sigs.k8s.io/kubetest2/pkg/version"
 )
 
 // Run instantiates and executes the kubetest2 cobra command, returning the result
@@ -62,6 +67,7 @@ func runE(
 ) error {
 	// setup the options struct & flags, etc.
 	opts := &options{}
+
 	kubetest2Flags := pflag.NewFlagSet(deployerName, pflag.ContinueOnError)
 	opts.bindFlags(kubetest2Flags)
 	artifacts.MustBindFlags(kubetest2Flags)
@@ -69,6 +75,12 @@ func runE(
 	// NOTE: unknown flags are forwarded to the deployer as arguments
 	kubetest2Flags.ParseErrorsWhitelist.UnknownFlags = true
 
+	// expand @file arguments, so long argument lists can live in files
+	args, err := expandArgFiles(args)
+	if err != nil {
+		return err
+	}
+
 	// parse arguments, splitting out test args (after the `--`)
 	deployerArgs, testerArgs := splitArgs(args)
 
@@ -83,6 +95,12 @@ func runE(
 	// We will later show this + usage if there is one
 	parseError := kubetest2Flags.Parse(deployerArgs)
 
+	// report the build information when run by `kubetest2 version`, the
+	// deployer is created with the parsed options like for a run
+	if version.Requested() {
+		return printDeployerVersion(cmd, opts, newDeployer)
+	}
+
 	// now that we've parsed flags we can look up the tester
 	tester := types.Tester{}
 	if opts.test != "" {
@@ -130,6 +148,12 @@ func runE(
 	allFlags := pflag.NewFlagSet(deployerName, pflag.ContinueOnError)
 	allFlags.AddFlagSet(kubetest2Flags)
 	allFlags.AddFlagSet(deployerFlags)
+
+	// report the flags for shell completion when run by `kubetest2 __complete-words`
+	if flagdump.Requested() {
+		return flagdump.Print(cmd.OutOrStdout(), flagdump.Get(allFlags))
+	}
+
 	if err := allFlags.Parse(deployerArgs); err != nil && parseError == nil {
 		// NOTE: we only retain the first parse error currently, and handle below
 		parseError = err
@@ -152,6 +176,9 @@ func runE(
 			cmd.Print(v.HelpText())
 		} else {
 			incorrectUsageString := fmt.Sprintf("Error: %s", parseError)
+			if hint := unknownFlagHint(parseError, usage.testerUsage); hint != "" {
+				incorrectUsageString += "\n" + hint
+			}
 			parseError = types.NewIncorrectUsage(incorrectUsageString)
 			cmd.Print(incorrectUsageString)
 		}
@@ -161,8 +188,32 @@ func runE(
 		return parseError
 	}
 
+	if err := opts.validate(); err != nil {
+		return err
+	}
+
+	// warn about likely mistakes splitting the args at `--`, these otherwise
+	// silently change which flags the deployer and the tester see
+	runnerOpts := append(opts.runnerOptions(), WithRunRegistry(deployerName, args))
+	if warnings := checkArgs(allFlags, testerArgs, usage.testerUsage); len(warnings) > 0 {
+		for _, w := range warnings {
+			klog.Warning(w)
+		}
+		runnerOpts = append(runnerOpts, WithMetadata(map[string]string{argsWarningsKey: strings.Join(warnings, "\n")}))
+	}
+
 	// run RealMain, which contains all of the logic beyond the CLI boilerplate
-	return RealMain(opts, deployer, tester)
+	return RealMain(opts, deployer, tester, runnerOpts...)
+}
+
+// printDeployerVersion prints the version.Info of the deployer binary
+func printDeployerVersion(cmd *cobra.Command, opts types.Options, newDeployer types.NewDeployer) error {
+	var gitTag string
+	d, _ := newDeployer(opts)
+	if dWithVersion, ok := d.(types.DeployerWithVersion); ok {
+		gitTag = dWithVersion.Version()
+	}
+	return version.Print(cmd.OutOrStdout(), version.Get(gitTag))
 }
 
 // splitArgs splits args into deployerArgs and testerArgs at the first bare `--`
@@ -190,7 +241,15 @@ type options struct {
 	test                string
 	skipTestJUnitReport bool
 	runid               string
+	runIDPrefix         string
 	rundirInArtifacts   bool
+	kubeconfigMode      string
+	finalizeErrorPolicy string
+	leakPolicy          string
+	resultsSink         string
+	interactive         bool
+	runTimeout          time.Duration
+	progressEvents      string
 }
 
 // bindFlags registers all first class kubetest2 flags
@@ -209,8 +268,42 @@ func (o *options) bindFlags(flags *pflag.FlagSet) {
 	} else {
 		defaultRunID = uuid.New().String()
 	}
-	flags.StringVar(&o.runid, "run-id", defaultRunID, "unique identifier for a kubetest2 run")
+	flags.StringVar(&o.runid, "run-id", defaultRunID, "unique identifier for a kubetest2 run, of lowercase letters, digits and dashes. The deployers name cloud resources after its start")
+	flags.StringVar(&o.runIDPrefix, "run-id-prefix", "", "if set, prefixed to the run id with a dash, e.g. to tell the runs of a user apart. At most 5 characters, the deployers name cloud resources after the first 13 characters of the run id")
 	flags.BoolVar(&o.rundirInArtifacts, "rundir-in-artifacts", false, `if true, the test binaries and run specific metadata will be in the ARTIFACTS`)
+	flags.StringVar(&o.kubeconfigMode, "kubeconfig-mode", kubeconfigModeReplace, `how the deployer kubeconfig is passed to the tester when KUBECONFIG is already set, "replace" it or "prepend" to it`)
+	flags.StringVar(&o.finalizeErrorPolicy, "finalize-error-policy", finalizeErrorPolicyWarn, `how errors writing the junit and metadata at the end of the run are handled, "warn" logs them and records them to `+finalizeErrorsFile+` in the artifacts, "fail" fails the run`)
+	flags.StringVar(&o.leakPolicy, "leak-policy", leakPolicyFail, `how resources left over by --down are handled when the deployer can list them, "fail" fails the run, "warn" only fails the VerifyDown junit step`)
+	flags.StringVar(&o.resultsSink, "results-sink", "", `if set, a summary of the run and of its junit results is uploaded there at the end of the run, "`+resultsSinkBigQuery+`<project>.<dataset>.<table>" inserts it into a BigQuery table with the bq tool, an http(s) URL receives it as a JSON POST`)
+	flags.BoolVar(&o.interactive, "interactive", false, "ask for confirmation before --down, and before --up in projects not acquired for the run, printing the resources to be created or deleted")
+	flags.DurationVar(&o.runTimeout, "run-timeout", 0, "the time budget of the whole run, e.g. the timeout of the CI job. If set, deployers implementing DeployerWithContext get the deadline of the run for Up, and the tester gets the deadline of the run as "+testers.RunDeadlineEnv+" in RFC 3339 format to fit its own timeouts in the remaining time")
+	flags.StringVar(&o.progressEvents, "progress-events", "", `if set, the progress of the run is written to this file, or to stderr for "-", as newline delimited JSON events, e.g. {"type":"step-finished","step":"Up","stepIndex":2,"steps":4,"percent":50,...}, for programs rendering the progress`)
+}
+
+// validate checks the flag values that cannot be checked while parsing
+func (o *options) validate() error {
+	if err := validateRunIDPrefix(o.runIDPrefix); err != nil {
+		return err
+	}
+	if err := validateRunID(o.RunID()); err != nil {
+		return err
+	}
+	switch o.kubeconfigMode {
+	case kubeconfigModeReplace, kubeconfigModePrepend:
+	default:
+		return fmt.Errorf("--kubeconfig-mode must be one of %q or %q, got %q", kubeconfigModeReplace, kubeconfigModePrepend, o.kubeconfigMode)
+	}
+	switch o.finalizeErrorPolicy {
+	case finalizeErrorPolicyWarn, finalizeErrorPolicyFail:
+	default:
+		return fmt.Errorf("--finalize-error-policy must be one of %q or %q, got %q", finalizeErrorPolicyWarn, finalizeErrorPolicyFail, o.finalizeErrorPolicy)
+	}
+	switch o.leakPolicy {
+	case leakPolicyWarn, leakPolicyFail:
+	default:
+		return fmt.Errorf("--leak-policy must be one of %q or %q, got %q", leakPolicyWarn, leakPolicyFail, o.leakPolicy)
+	}
+	return validateResultsSink(o.resultsSink)
 }
 
 // assert that options implements deployer options
@@ -241,7 +334,7 @@ func (o *options) SkipTestJUnitReport() bool {
 }
 
 func (o *options) RunID() string {
-	return o.runid
+	return runIDWithPrefix(o.runIDPrefix, o.runid)
 }
 
 func (o *options) RunDir() string {
@@ -267,6 +360,19 @@ func (o *options) RundirInArtifacts() bool {
 	return o.rundirInArtifacts
 }
 
+// runnerOptions returns the Runner options set by the kubetest2 flags
+func (o *options) runnerOptions() []RunnerOption {
+	return []RunnerOption{
+		WithKubeconfigMode(o.kubeconfigMode),
+		WithFinalizeErrorPolicy(o.finalizeErrorPolicy),
+		WithLeakPolicy(o.leakPolicy),
+		WithResultsSink(o.resultsSink),
+		WithInteractive(o.interactive),
+		WithRunTimeout(o.runTimeout),
+		WithProgressEvents(o.progressEvents),
+	}
+}
+
 // metadata used for CLI usage string
 type usage struct {
 	kubetest2Flags *pflag.FlagSet
@@ -0,0 +1,50 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubetest2/pkg/types"
+	"sigs.k8s.io/kubetest2/pkg/version"
+)
+
+func TestPrintDeployerVersion(t *testing.T) {
+	t.Setenv(version.RequestEnv, "1")
+	var runID string
+	newDeployer := func(opts types.Options) (types.Deployer, *pflag.FlagSet) {
+		runID = opts.RunID()
+		return &fakeDeployer{}, pflag.NewFlagSet("fake", pflag.ContinueOnError)
+	}
+
+	cmd := NewCommand("fake", newDeployer)
+	cmd.SetArgs([]string{"--run-id=run-1", "--up"})
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if runID != "run-1" {
+		t.Errorf("expected the deployer to be created with the parsed --run-id, got %q", runID)
+	}
+	if out.Len() == 0 {
+		t.Error("expected the version to be printed")
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"time"
+
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+// runContext returns the context of the run, with the deadline of the run
+// when the run has a time budget
+func runContext(started time.Time, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithDeadline(context.Background(), started.Add(timeout))
+}
+
+// upStep returns the Up step of the deployer, run with ctx if the deployer
+// supports it
+func upStep(ctx context.Context, deployer types.Deployer) func() error {
+	if dWithContext, ok := deployer.(types.DeployerWithContext); ok {
+		return func() error {
+			return dWithContext.UpWithContext(ctx)
+		}
+	}
+	return deployer.Up
+}
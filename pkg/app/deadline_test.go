@@ -0,0 +1,65 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type fakeDeployerWithContext struct {
+	fakeDeployer
+	deadline    time.Time
+	hasDeadline bool
+}
+
+func (f *fakeDeployerWithContext) UpWithContext(ctx context.Context) error {
+	f.deadline, f.hasDeadline = ctx.Deadline()
+	return nil
+}
+
+func TestUpStep(t *testing.T) {
+	started := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name             string
+		timeout          time.Duration
+		expectedDeadline time.Time
+	}{
+		{
+			name:             "run timeout",
+			timeout:          2 * time.Hour,
+			expectedDeadline: started.Add(2 * time.Hour),
+		},
+		{
+			name: "no run timeout",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := runContext(started, tc.timeout)
+			defer cancel()
+			d := &fakeDeployerWithContext{}
+			if err := upStep(ctx, d)(); err != nil {
+				t.Fatalf("did not expect an error, but got: %v", err)
+			}
+			if d.hasDeadline != !tc.expectedDeadline.IsZero() || !d.deadline.Equal(tc.expectedDeadline) {
+				t.Errorf("expected deadline %v but got %v", tc.expectedDeadline, d.deadline)
+			}
+		})
+	}
+}
@@ -15,6 +15,9 @@ limitations under the License.
 */
 
 // Package app implements the kubetest2 high level application logic
+//
+// Programs embedding kubetest2 can construct a deployer and use Runner
+// to run the same flow as the kubetest2 binaries.
 package app
 
 // TODO(bentheelder): actually implement!
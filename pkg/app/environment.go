@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// toolVersionTimeout bounds how long a single version probe may take,
+// a wedged docker daemon should not hold up the run
+const toolVersionTimeout = 10 * time.Second
+
+// toolVersionCommands are the host tools whose versions are recorded,
+// keyed by the name used in environment.json
+var toolVersionCommands = map[string][]string{
+	"gcloud":  {"gcloud", "version"},
+	"kubectl": {"kubectl", "version", "--client"},
+	"docker":  {"docker", "version", "--format", "{{.Client.Version}}"},
+	"kind":    {"kind", "version"},
+	"go":      {"go", "version"},
+}
+
+// envAllowlist lists the environment variables recorded verbatim
+var envAllowlist = []string{
+	"ARTIFACTS",
+	"BUILD_ID",
+	"CLOUDSDK_CORE_PROJECT",
+	"GOPATH",
+	"KUBECONFIG",
+	"PATH",
+}
+
+// envAllowlistPrefixes lists prefixes of environment variables recorded
+// verbatim, credentials must never match any of these
+var envAllowlistPrefixes = []string{
+	"KUBETEST2_",
+	"PROW_",
+	"JOB_",
+	"PULL_",
+	"REPO_",
+}
+
+// environment is the structure written to environment.json
+type environment struct {
+	OS           string            `json:"os"`
+	Arch         string            `json:"arch"`
+	ToolVersions map[string]string `json:"toolVersions"`
+	Env          map[string]string `json:"env"`
+}
+
+// writeEnvironmentJSON captures the host toolchain, probed with cmder, and a
+// filtered view of the environment into environment.json in artifactsDir
+func writeEnvironmentJSON(cmder exec.Cmder, artifactsDir string) error {
+	env := environment{
+		OS:           runtime.GOOS,
+		Arch:         runtime.GOARCH,
+		ToolVersions: map[string]string{},
+		Env:          filterEnv(os.Environ()),
+	}
+	for name, command := range toolVersionCommands {
+		env.ToolVersions[name] = toolVersion(cmder, command[0], command[1:]...)
+	}
+
+	data, err := json.MarshalIndent(env, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(artifactsDir, "environment.json"), data, 0644)
+}
+
+// toolVersion returns the trimmed output of the version command,
+// or an empty string if the tool is missing or fails
+func toolVersion(cmder exec.Cmder, name string, args ...string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), toolVersionTimeout)
+	defer cancel()
+	lines, err := exec.OutputLines(cmder.CommandContext(ctx, name, args...))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// filterEnv returns the allowlisted subset of environ, which is in the
+// "key=value" form returned by os.Environ
+func filterEnv(environ []string) map[string]string {
+	filtered := map[string]string{}
+	for _, kv := range environ {
+		key, value, found := strings.Cut(kv, "=")
+		if !found || !envAllowed(key) {
+			continue
+		}
+		filtered[key] = value
+	}
+	return filtered
+}
+
+func envAllowed(key string) bool {
+	for _, allowed := range envAllowlist {
+		if key == allowed {
+			return true
+		}
+	}
+	for _, prefix := range envAllowlistPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilterEnv(t *testing.T) {
+	environ := []string{
+		"PATH=/usr/bin:/bin",
+		"KUBETEST2_RUN_ID=abc",
+		"PROW_JOB_ID=123",
+		"GOOGLE_APPLICATION_CREDENTIALS=/secret/key.json",
+		"HOME=/root",
+		"ARTIFACTS=/logs/artifacts",
+		"JOB_NAME=ci-kubernetes-e2e=gce",
+		"malformed",
+	}
+	expected := map[string]string{
+		"PATH":             "/usr/bin:/bin",
+		"KUBETEST2_RUN_ID": "abc",
+		"PROW_JOB_ID":      "123",
+		"ARTIFACTS":        "/logs/artifacts",
+		"JOB_NAME":         "ci-kubernetes-e2e=gce",
+	}
+	if actual := filterEnv(environ); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected filtered env %v but got %v", expected, actual)
+	}
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+const (
+	// resultsSinkBigQuery prefixes the <project>.<dataset>.<table> of a
+	// BigQuery results sink
+	resultsSinkBigQuery = "bigquery:"
+	// resultsExportTimeout bounds the upload of the results, an unreachable
+	// sink should not hold up the end of the run
+	resultsExportTimeout = 2 * time.Minute
+)
+
+// runResult is the record uploaded to the results sink
+type runResult struct {
+	RunID    string          `json:"run_id"`
+	Started  string          `json:"started"`
+	Finished string          `json:"finished"`
+	Passed   bool            `json:"passed"`
+	Metadata []metadataEntry `json:"metadata"`
+	Suites   []junitSummary  `json:"junit_suites"`
+}
+
+// metadataEntry is a key of metadata.json, stored as a key value pair since
+// the keys, e.g. kubetest-version, are not valid BigQuery column names
+type metadataEntry struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// junitSummary is the summary of a junit test suite
+type junitSummary struct {
+	File     string  `json:"file"`
+	Name     string  `json:"name"`
+	Tests    int     `json:"tests"`
+	Failures int     `json:"failures"`
+	Errors   int     `json:"errors"`
+	Skipped  int     `json:"skipped"`
+	Time     float64 `json:"time"`
+}
+
+// junitSuite is the part of a junit test suite summarized
+type junitSuite struct {
+	Name     string  `xml:"name,attr"`
+	Tests    int     `xml:"tests,attr"`
+	Failures int     `xml:"failures,attr"`
+	Errors   int     `xml:"errors,attr"`
+	Skipped  int     `xml:"skipped,attr"`
+	Time     float64 `xml:"time,attr"`
+}
+
+// junitRoot is either a single <testsuite> or <testsuites>
+type junitRoot struct {
+	XMLName xml.Name
+	junitSuite
+	Suites []junitSuite `xml:"testsuite"`
+}
+
+// validateResultsSink checks the --results-sink flag value
+func validateResultsSink(sink string) error {
+	switch {
+	case sink == "":
+	case strings.HasPrefix(sink, resultsSinkBigQuery):
+		if parts := strings.Split(strings.TrimPrefix(sink, resultsSinkBigQuery), "."); len(parts) != 3 {
+			return fmt.Errorf("--results-sink %q must be %s<project>.<dataset>.<table>", sink, resultsSinkBigQuery)
+		}
+	case strings.HasPrefix(sink, "http://"), strings.HasPrefix(sink, "https://"):
+	default:
+		return fmt.Errorf("--results-sink must be %s<project>.<dataset>.<table> or an http(s) URL, got %q", resultsSinkBigQuery, sink)
+	}
+	return nil
+}
+
+// newRunResult summarizes the run from metadata.json and the junit files at
+// the top of the artifacts dir
+func newRunResult(artifactsDir, runID string, started, finished time.Time, passed bool) (*runResult, error) {
+	result := &runResult{
+		RunID:    runID,
+		Started:  started.UTC().Format(time.RFC3339),
+		Finished: finished.UTC().Format(time.RFC3339),
+		Passed:   passed,
+		Metadata: []metadataEntry{},
+		Suites:   []junitSummary{},
+	}
+
+	if data, err := os.ReadFile(filepath.Join(artifactsDir, "metadata.json")); err == nil {
+		meta := map[string]string{}
+		if err := json.Unmarshal(data, &meta); err != nil {
+			return nil, fmt.Errorf("failed to parse metadata.json: %w", err)
+		}
+		for key, value := range meta {
+			result.Metadata = append(result.Metadata, metadataEntry{Key: key, Value: value})
+		}
+		sort.Slice(result.Metadata, func(i, j int) bool { return result.Metadata[i].Key < result.Metadata[j].Key })
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	files, err := filepath.Glob(filepath.Join(artifactsDir, "junit*.xml"))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		root := junitRoot{}
+		if err := xml.Unmarshal(data, &root); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		suites := root.Suites
+		if root.XMLName.Local == "testsuite" {
+			suites = []junitSuite{root.junitSuite}
+		}
+		for _, s := range suites {
+			result.Suites = append(result.Suites, junitSummary{
+				File:     filepath.Base(file),
+				Name:     s.Name,
+				Tests:    s.Tests,
+				Failures: s.Failures,
+				Errors:   s.Errors,
+				Skipped:  s.Skipped,
+				Time:     s.Time,
+			})
+		}
+	}
+	return result, nil
+}
+
+// exportResults uploads the summary of the run in artifactsDir to the results
+// sink, failing to do so doesn't fail the run
+func exportResults(sink, artifactsDir, runID string, started time.Time, passed bool) {
+	klog.Infof("Exporting the run results to %s", sink)
+	result, err := newRunResult(artifactsDir, runID, started, time.Now(), passed)
+	if err == nil {
+		err = exportRunResult(sink, result)
+	}
+	if err != nil {
+		klog.Warningf("Failed to export the run results to %s: %v", sink, err)
+	}
+}
+
+// exportRunResult uploads the result to the sink
+func exportRunResult(sink string, result *runResult) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), resultsExportTimeout)
+	defer cancel()
+
+	if table, ok := strings.CutPrefix(sink, resultsSinkBigQuery); ok {
+		// bq wants <project>:<dataset>.<table>
+		project, datasetTable, _ := strings.Cut(table, ".")
+		cmd := exec.CommandContext(ctx, "bq", "insert", project+":"+datasetTable)
+		cmd.SetStdin(bytes.NewReader(append(data, '\n')))
+		exec.InheritOutput(cmd)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to insert the run result into %s: %w", table, err)
+		}
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sink, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post the run result to %s: %w", sink, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to post the run result to %s: %s", sink, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestValidateResultsSink(t *testing.T) {
+	testCases := []struct {
+		sink      string
+		expectErr bool
+	}{
+		{sink: ""},
+		{sink: "bigquery:my-project.k8s_e2e.runs"},
+		{sink: "https://results.example.com/runs"},
+		{sink: "bigquery:my-project.runs", expectErr: true},
+		{sink: "gs://bucket/results", expectErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.sink, func(t *testing.T) {
+			err := validateResultsSink(tc.sink)
+			if tc.expectErr && err == nil {
+				t.Errorf("expected an error but got none")
+			}
+			if !tc.expectErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestNewRunResult(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"metadata.json": `{"kubetest-version":"v0.1.0","deployer-version":"v0.2.0"}`,
+		"junit_runner.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite name="kubetest2" failures="1" tests="3" time="120.5"></testsuite>`,
+		"junit_01.xml":  `<testsuites><testsuite name="Kubernetes e2e suite" tests="10" failures="0" errors="1" skipped="4" time="60"></testsuite></testsuites>`,
+		"not_junit.xml": `<testsuite name="ignored" tests="1"></testsuite>`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	started := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	actual, err := newRunResult(dir, "run-1", started, started.Add(time.Hour), false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &runResult{
+		RunID:    "run-1",
+		Started:  "2026-01-02T03:04:05Z",
+		Finished: "2026-01-02T04:04:05Z",
+		Passed:   false,
+		Metadata: []metadataEntry{
+			{Key: "deployer-version", Value: "v0.2.0"},
+			{Key: "kubetest-version", Value: "v0.1.0"},
+		},
+		Suites: []junitSummary{
+			{File: "junit_01.xml", Name: "Kubernetes e2e suite", Tests: 10, Errors: 1, Skipped: 4, Time: 60},
+			{File: "junit_runner.xml", Name: "kubetest2", Tests: 3, Failures: 1, Time: 120.5},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected run result %+v but got %+v", expected, actual)
+	}
+}
+
+func TestExportRunResultHTTP(t *testing.T) {
+	received := make(chan *runResult, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		result := &runResult{}
+		if err := json.Unmarshal(data, result); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- result
+	}))
+	defer server.Close()
+
+	result := &runResult{RunID: "run-1", Passed: true, Metadata: []metadataEntry{}, Suites: []junitSummary{}}
+	if err := exportRunResult(server.URL, result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual := <-received; !reflect.DeepEqual(actual, result) {
+		t.Errorf("expected the server to receive %+v but got %+v", result, actual)
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// finalizeErrorPolicyWarn logs errors finalizing the run artifacts
+	// without failing the run
+	finalizeErrorPolicyWarn = "warn"
+	// finalizeErrorPolicyFail fails the run on errors finalizing the run
+	// artifacts, even if all the steps passed
+	finalizeErrorPolicyFail = "fail"
+)
+
+// finalizeErrorsFile records the finalization errors ignored by the warn policy
+const finalizeErrorsFile = "finalize_errors.txt"
+
+// finalizeError returns the error to fail the run with for the errors
+// encountered while finalizing the run artifacts in artifactsDir, according
+// to policy.
+func finalizeError(policy, artifactsDir string, errs ...error) error {
+	err := errors.Join(errs...)
+	if err == nil || policy == finalizeErrorPolicyFail {
+		return err
+	}
+	klog.Errorf("Ignoring errors finalizing the run artifacts: %v", err)
+	if err := recordFinalizeError(artifactsDir, err); err != nil {
+		klog.Errorf("Failed to record the finalization errors: %v", err)
+	}
+	return nil
+}
+
+// recordFinalizeError writes err to finalizeErrorsFile in artifactsDir, so
+// the errors are visible with the other results of the run.
+func recordFinalizeError(artifactsDir string, err error) error {
+	path := filepath.Join(artifactsDir, finalizeErrorsFile)
+	return os.WriteFile(path, []byte(fmt.Sprintln(err)), 0644)
+}
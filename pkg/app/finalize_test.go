@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFinalizeError(t *testing.T) {
+	writeErr := errors.New("write junit_runner.xml: no space left on device")
+	testCases := []struct {
+		name         string
+		policy       string
+		errs         []error
+		expectErr    bool
+		expectRecord bool
+	}{
+		{
+			name:   "no errors",
+			policy: finalizeErrorPolicyFail,
+		},
+		{
+			name:      "fail policy",
+			policy:    finalizeErrorPolicyFail,
+			errs:      []error{writeErr},
+			expectErr: true,
+		},
+		{
+			name:         "warn policy",
+			policy:       finalizeErrorPolicyWarn,
+			errs:         []error{writeErr},
+			expectRecord: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			err := finalizeError(tc.policy, dir, tc.errs...)
+			if tc.expectErr != (err != nil) {
+				t.Fatalf("expected error: %v, got: %v", tc.expectErr, err)
+			}
+			record, err := os.ReadFile(filepath.Join(dir, finalizeErrorsFile))
+			if !tc.expectRecord {
+				if !os.IsNotExist(err) {
+					t.Errorf("expected no %s, got error: %v", finalizeErrorsFile, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", finalizeErrorsFile, err)
+			}
+			if !strings.Contains(string(record), writeErr.Error()) {
+				t.Errorf("expected %s to contain %q, got %q", finalizeErrorsFile, writeErr, record)
+			}
+		})
+	}
+}
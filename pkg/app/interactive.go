@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+// deployerPlan returns the plan of action, nil if d does not report one
+func deployerPlan(d types.Deployer, action string) *types.Plan {
+	dWithPlan, ok := d.(types.DeployerWithPlan)
+	if !ok {
+		return nil
+	}
+	plan, err := dWithPlan.Plan(action)
+	if err != nil {
+		klog.Warningf("Failed to get the plan of %s: %v", action, err)
+		return nil
+	}
+	return plan
+}
+
+// confirm prints the plan of action to out and asks for confirmation on in,
+// anything but "y" or "yes" declines
+func confirm(in io.Reader, out io.Writer, action string, plan *types.Plan) (bool, error) {
+	if plan != nil {
+		verb := "created"
+		if action == "Down" {
+			verb = "deleted"
+		}
+		fmt.Fprintf(out, "%s will run, the following resources will be %s:\n", action, verb)
+		for _, r := range plan.Resources {
+			fmt.Fprintf(out, "  - %s\n", r)
+		}
+	}
+	fmt.Fprintf(out, "Continue with %s? [y/N]: ", action)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	}
+	return false, nil
+}
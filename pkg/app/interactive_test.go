@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+func TestConfirm(t *testing.T) {
+	plan := &types.Plan{Resources: []string{"cluster kt2-abc in project p"}}
+	testCases := []struct {
+		name           string
+		action         string
+		plan           *types.Plan
+		input          string
+		expected       bool
+		expectedOutput string
+	}{
+		{
+			name:           "yes",
+			action:         "Down",
+			plan:           plan,
+			input:          "yes\n",
+			expected:       true,
+			expectedOutput: "Down will run, the following resources will be deleted:\n  - cluster kt2-abc in project p\nContinue with Down? [y/N]: ",
+		},
+		{
+			name:           "y without newline",
+			action:         "Up",
+			plan:           plan,
+			input:          " Y",
+			expected:       true,
+			expectedOutput: "Up will run, the following resources will be created:\n  - cluster kt2-abc in project p\nContinue with Up? [y/N]: ",
+		},
+		{
+			name:           "no",
+			action:         "Down",
+			input:          "n\n",
+			expectedOutput: "Continue with Down? [y/N]: ",
+		},
+		{
+			name:           "empty input",
+			action:         "Down",
+			expectedOutput: "Continue with Down? [y/N]: ",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			actual, err := confirm(strings.NewReader(tc.input), &out, tc.action, tc.plan)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != tc.expected {
+				t.Errorf("expected %v but got %v", tc.expected, actual)
+			}
+			if out.String() != tc.expectedOutput {
+				t.Errorf("expected output %q but got %q", tc.expectedOutput, out.String())
+			}
+		})
+	}
+}
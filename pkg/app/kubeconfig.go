@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// kubeconfigModeReplace passes only the deployer kubeconfig to the tester
+	kubeconfigModeReplace = "replace"
+	// kubeconfigModePrepend puts the deployer kubeconfig in front of an
+	// existing KUBECONFIG, so its current-context wins while the contexts
+	// of the existing files stay available to the tester
+	kubeconfigModePrepend = "prepend"
+)
+
+// mergeKubeconfig returns the KUBECONFIG value for the tester given the
+// kubeconfig provided by the deployer and the existing KUBECONFIG, and
+// validates that all of the files it refers to are regular files.
+// Missing files are left out as there is nothing to merge from them, e.g. the
+// deployer kubeconfig when only testing a cluster the deployer did not bring
+// up, the result is empty if none of the files exist.
+func mergeKubeconfig(mode, deployerKubeconfig, existing string) (string, error) {
+	kubeconfigs := []string{deployerKubeconfig}
+	if mode == kubeconfigModePrepend {
+		for _, path := range filepath.SplitList(existing) {
+			if path != "" && path != deployerKubeconfig {
+				kubeconfigs = append(kubeconfigs, path)
+			}
+		}
+	}
+	var found []string
+	for _, path := range kubeconfigs {
+		info, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			klog.Warningf("Kubeconfig %s does not exist, not passing it to the tester", path)
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("invalid kubeconfig for tester: %w", err)
+		}
+		if !info.Mode().IsRegular() {
+			return "", fmt.Errorf("invalid kubeconfig for tester: %s is not a file", path)
+		}
+		found = append(found, path)
+	}
+	return strings.Join(found, string(filepath.ListSeparator)), nil
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMergeKubeconfig(t *testing.T) {
+	dir := t.TempDir()
+	deployerKubeconfig := filepath.Join(dir, "deployer")
+	monitoringKubeconfig := filepath.Join(dir, "monitoring")
+	missingKubeconfig := filepath.Join(dir, "missing")
+	dirKubeconfig := filepath.Join(dir, "dir")
+	if err := os.Mkdir(dirKubeconfig, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{deployerKubeconfig, monitoringKubeconfig} {
+		if err := os.WriteFile(path, nil, 0600); err != nil {
+			t.Fatalf("failed to create kubeconfig: %v", err)
+		}
+	}
+	join := func(paths ...string) string {
+		return strings.Join(paths, string(filepath.ListSeparator))
+	}
+
+	testCases := []struct {
+		name               string
+		mode               string
+		deployerKubeconfig string
+		existing           string
+		expected           string
+		expectError        bool
+	}{
+		{
+			name:     "replace ignores existing",
+			mode:     kubeconfigModeReplace,
+			existing: monitoringKubeconfig,
+			expected: deployerKubeconfig,
+		},
+		{
+			name:     "replace does not validate existing",
+			mode:     kubeconfigModeReplace,
+			existing: missingKubeconfig,
+			expected: deployerKubeconfig,
+		},
+		{
+			name:     "prepend to existing",
+			mode:     kubeconfigModePrepend,
+			existing: monitoringKubeconfig,
+			expected: join(deployerKubeconfig, monitoringKubeconfig),
+		},
+		{
+			name:     "prepend with no existing",
+			mode:     kubeconfigModePrepend,
+			expected: deployerKubeconfig,
+		},
+		{
+			name:     "prepend deduplicates",
+			mode:     kubeconfigModePrepend,
+			existing: join(deployerKubeconfig, monitoringKubeconfig),
+			expected: join(deployerKubeconfig, monitoringKubeconfig),
+		},
+		{
+			name:     "prepend skips missing existing",
+			mode:     kubeconfigModePrepend,
+			existing: join(missingKubeconfig, monitoringKubeconfig),
+			expected: join(deployerKubeconfig, monitoringKubeconfig),
+		},
+		{
+			name:               "replace with missing deployer kubeconfig",
+			mode:               kubeconfigModeReplace,
+			deployerKubeconfig: missingKubeconfig,
+			existing:           monitoringKubeconfig,
+			expected:           "",
+		},
+		{
+			name:               "prepend with missing deployer kubeconfig",
+			mode:               kubeconfigModePrepend,
+			deployerKubeconfig: missingKubeconfig,
+			existing:           monitoringKubeconfig,
+			expected:           monitoringKubeconfig,
+		},
+		{
+			name:        "prepend to a directory",
+			mode:        kubeconfigModePrepend,
+			existing:    dirKubeconfig,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kubeconfig := tc.deployerKubeconfig
+			if kubeconfig == "" {
+				kubeconfig = deployerKubeconfig
+			}
+			actual, err := mergeKubeconfig(tc.mode, kubeconfig, tc.existing)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect an error, but got: %v", err)
+			}
+			if actual != tc.expected {
+				t.Errorf("mismatched kubeconfig: expected %q, but got %q", tc.expected, actual)
+			}
+		})
+	}
+}
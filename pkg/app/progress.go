@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+// progress event types
+const (
+	progressRunStarted   = "run-started"
+	progressStepStarted  = "step-started"
+	progressStepFinished = "step-finished"
+	progressRunFinished  = "run-finished"
+)
+
+// statuses of the run in the run-finished event
+const (
+	progressStatusPassed      = "passed"
+	progressStatusFailed      = "failed"
+	progressStatusInterrupted = "interrupted"
+)
+
+// progressEventsStderr is the --progress-events value writing the events to
+// stderr, along with the logs
+const progressEventsStderr = "-"
+
+// progressEvent is written as a line of JSON to the --progress-events file,
+// for programs rendering the progress of the run without parsing the logs
+type progressEvent struct {
+	Time time.Time `json:"time"`
+	Type string    `json:"type"`
+	// Step is the name of the step, a lifecycle step like Up or a step
+	// reported by the deployer
+	Step string `json:"step,omitempty"`
+	// StepIndex is the 1-based index of the lifecycle step, 0 for the steps
+	// reported by the deployer
+	StepIndex int `json:"stepIndex,omitempty"`
+	// Steps is the number of lifecycle steps of the run, Build, Up, Test and
+	// Down as selected by the flags
+	Steps int `json:"steps"`
+	// Percent is the share of the lifecycle steps finished
+	Percent int    `json:"percent"`
+	Message string `json:"message,omitempty"`
+	// Status is how the run ended, only set for run-finished
+	Status   string  `json:"status,omitempty"`
+	Error    string  `json:"error,omitempty"`
+	Duration float64 `json:"durationSeconds,omitempty"`
+}
+
+// progress writes the progress events of a run, it does nothing if out is nil
+type progress struct {
+	mu       sync.Mutex
+	out      io.Writer
+	steps    int
+	started  int
+	finished int
+	// for faking out time when testing
+	timeNow func() time.Time
+}
+
+// openProgressEvents returns the writer of the --progress-events file at
+// path, stderr for "-", and the func closing it
+func openProgressEvents(path string) (io.Writer, func() error, error) {
+	if path == progressEventsStderr {
+		return os.Stderr, func() error { return nil }, nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, f.Close, nil
+}
+
+func newProgress(out io.Writer, opts types.Options) *progress {
+	steps := 0
+	for _, selected := range []bool{opts.ShouldBuild(), opts.ShouldUp(), opts.ShouldTest(), opts.ShouldDown()} {
+		if selected {
+			steps++
+		}
+	}
+	return &progress{out: out, steps: steps, timeNow: time.Now}
+}
+
+// runStarted reports the start of the run
+func (p *progress) runStarted(runID string) {
+	p.emit(progressEvent{Type: progressRunStarted, Message: "ID for this run: " + runID})
+}
+
+// runFinished reports the end of the run and its error, if any
+func (p *progress) runFinished(started time.Time, err error) {
+	event := progressEvent{Type: progressRunFinished, Status: progressStatusPassed, Duration: p.timeNow().Sub(started).Seconds()}
+	if err != nil {
+		event.Status = progressStatusFailed
+		event.Error = err.Error()
+	}
+	p.emit(event)
+}
+
+// runInterrupted reports the end of a run interrupted by a signal, which
+// exits before runFinished is reached
+func (p *progress) runInterrupted(started time.Time, signal os.Signal) {
+	p.emit(progressEvent{
+		Type:     progressRunFinished,
+		Status:   progressStatusInterrupted,
+		Error:    "interrupted by " + signal.String(),
+		Duration: p.timeNow().Sub(started).Seconds(),
+	})
+}
+
+// wrap returns a StepRunner reporting the start and end of the steps run by
+// wrapStep, which count towards the progress if they are lifecycle steps
+func (p *progress) wrap(wrapStep types.StepRunner, lifecycle bool) types.StepRunner {
+	return func(name string, doStep func() error) error {
+		index := 0
+		if lifecycle {
+			p.mu.Lock()
+			p.started++
+			index = p.started
+			p.mu.Unlock()
+		}
+		started := p.timeNow()
+		p.emit(progressEvent{Type: progressStepStarted, Step: name, StepIndex: index})
+		err := wrapStep(name, doStep)
+		if lifecycle {
+			p.mu.Lock()
+			p.finished++
+			p.mu.Unlock()
+		}
+		event := progressEvent{Type: progressStepFinished, Step: name, StepIndex: index, Duration: p.timeNow().Sub(started).Seconds()}
+		if err != nil {
+			event.Error = err.Error()
+		}
+		p.emit(event)
+		return err
+	}
+}
+
+// runStep runs the step without recording it, for the Test step with
+// --skip-test-junit-report
+func runStep(_ string, doStep func() error) error {
+	return doStep()
+}
+
+// emit writes the event as a single line, so that programs tailing the
+// file read whole events
+func (p *progress) emit(event progressEvent) {
+	if p.out == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	event.Time = p.timeNow()
+	event.Steps = p.steps
+	switch {
+	case event.Type == progressRunFinished:
+		// the steps left are skipped when the run fails
+		event.Percent = 100
+	case p.steps > 0:
+		event.Percent = min(p.finished, p.steps) * 100 / p.steps
+	}
+	b, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	_, _ = p.out.Write(append(b, '\n'))
+}
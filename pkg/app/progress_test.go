@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestProgress(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	var out bytes.Buffer
+	p := newProgress(&out, &options{up: true, test: "ginkgo", down: true})
+	p.timeNow = func() time.Time { return now }
+
+	recorded := func(_ string, doStep func() error) error { return doStep() }
+	lifecycleStep := p.wrap(recorded, true)
+	subStep := p.wrap(recorded, false)
+	p.runStarted("run-1")
+	_ = lifecycleStep("Up", func() error {
+		return subStep("CreateCluster", func() error { return nil })
+	})
+	_ = lifecycleStep("Test", func() error { return errors.New("tests failed") })
+	p.runFinished(now, errors.New("tests failed"))
+
+	var actual []progressEvent
+	decoder := json.NewDecoder(&out)
+	for decoder.More() {
+		var event progressEvent
+		if err := decoder.Decode(&event); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		event.Time = time.Time{}
+		actual = append(actual, event)
+	}
+	expected := []progressEvent{
+		{Type: progressRunStarted, Steps: 3, Message: "ID for this run: run-1"},
+		{Type: progressStepStarted, Step: "Up", StepIndex: 1, Steps: 3},
+		{Type: progressStepStarted, Step: "CreateCluster", Steps: 3},
+		{Type: progressStepFinished, Step: "CreateCluster", Steps: 3},
+		{Type: progressStepFinished, Step: "Up", StepIndex: 1, Steps: 3, Percent: 33},
+		{Type: progressStepStarted, Step: "Test", StepIndex: 2, Steps: 3, Percent: 33},
+		{Type: progressStepFinished, Step: "Test", StepIndex: 2, Steps: 3, Percent: 66, Error: "tests failed"},
+		{Type: progressRunFinished, Steps: 3, Percent: 100, Status: progressStatusFailed, Error: "tests failed"},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected events %+v but got %+v", expected, actual)
+	}
+}
+
+func TestProgressDisabled(t *testing.T) {
+	p := newProgress(nil, &options{up: true})
+	called := false
+	err := p.wrap(runStep, true)("Up", func() error {
+		called = true
+		return nil
+	})
+	if err != nil || !called {
+		t.Errorf("expected the step to run without error, got called=%v err=%v", called, err)
+	}
+}
+
+func TestProgressInterrupted(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	var out bytes.Buffer
+	p := newProgress(&out, &options{up: true, down: true})
+	p.timeNow = func() time.Time { return now }
+	p.runInterrupted(now.Add(-time.Minute), syscall.SIGINT)
+
+	var event progressEvent
+	if err := json.Unmarshal(out.Bytes(), &event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	event.Time = time.Time{}
+	expected := progressEvent{Type: progressRunFinished, Steps: 2, Percent: 100, Status: progressStatusInterrupted, Error: "interrupted by interrupt", Duration: 60}
+	if event != expected {
+		t.Errorf("expected event %+v but got %+v", expected, event)
+	}
+}
+
+func TestOpenProgressEvents(t *testing.T) {
+	out, closeProgress, err := openProgressEvents(progressEventsStderr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != os.Stderr {
+		t.Errorf("expected the events to be written to stderr for %q", progressEventsStderr)
+	}
+	if err := closeProgress(); err != nil {
+		t.Errorf("expected closing stderr to be a no-op, got: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "progress.json")
+	out, closeProgress, err = openProgressEvents(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := out.Write([]byte("{}\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := closeProgress(); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "{}\n" {
+		t.Errorf("expected the events to be written to %s, got %q, %v", path, data, err)
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+// providerConfigEnv points the tester to the provider config of the deployer
+const providerConfigEnv = "KUBETEST2_PROVIDER_CONFIG"
+
+// providerConfigFile is the provider config of the deployer in the run dir
+const providerConfigFile = "provider-config.yaml"
+
+// providerConfigForTester returns the path of the provider config written at
+// Up, writing it first if the run did not bring up the cluster, e.g. a run
+// of --test only reusing the run dir of an earlier --up.
+func providerConfigForTester(d types.Deployer, runDir string) (string, error) {
+	if _, ok := d.(types.DeployerWithProviderConfig); !ok {
+		return "", nil
+	}
+	path := filepath.Join(runDir, providerConfigFile)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+	return writeProviderConfig(d, runDir)
+}
+
+// writeProviderConfig writes the provider config of the deployer to
+// provider-config.yaml in the run dir, once the cluster is up, and returns its path, or an empty path
+// if the deployer has none. The provider of DeployerWithProvider is included
+// unless the config sets one.
+func writeProviderConfig(d types.Deployer, runDir string) (string, error) {
+	dWithConfig, ok := d.(types.DeployerWithProviderConfig)
+	if !ok {
+		return "", nil
+	}
+	deployerConfig, err := dWithConfig.ProviderConfig()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the provider config of the deployer: %w", err)
+	}
+	config := map[string]string{}
+	if dWithProvider, ok := d.(types.DeployerWithProvider); ok {
+		config["provider"] = dWithProvider.Provider()
+	}
+	for name, value := range deployerConfig {
+		config[name] = value
+	}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the provider config: %w", err)
+	}
+	path := filepath.Join(runDir, providerConfigFile)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write the provider config: %w", err)
+	}
+	return path, nil
+}
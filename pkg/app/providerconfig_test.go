@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"os"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+type fakeDeployerWithProviderConfig struct {
+	fakeDeployer
+	provider string
+	config   map[string]string
+}
+
+func (f *fakeDeployerWithProviderConfig) Provider() string {
+	return f.provider
+}
+
+func (f *fakeDeployerWithProviderConfig) ProviderConfig() (map[string]string, error) {
+	return f.config, nil
+}
+
+func TestWriteProviderConfig(t *testing.T) {
+	testCases := []struct {
+		name     string
+		deployer types.Deployer
+		expected string
+	}{
+		{
+			name:     "deployer without provider config",
+			deployer: &fakeDeployer{},
+		},
+		{
+			name: "provider of the deployer",
+			deployer: &fakeDeployerWithProviderConfig{
+				provider: "gce",
+				config:   map[string]string{"gce-zone": "us-central1-b", "num-nodes": "3"},
+			},
+			expected: "gce-zone: us-central1-b\nnum-nodes: \"3\"\nprovider: gce\n",
+		},
+		{
+			name: "provider of the config",
+			deployer: &fakeDeployerWithProviderConfig{
+				provider: "gce",
+				config:   map[string]string{"provider": "skeleton"},
+			},
+			expected: "provider: skeleton\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path, err := writeProviderConfig(tc.deployer, t.TempDir())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.expected == "" {
+				if path != "" {
+					t.Errorf("expected no provider config but got %s", path)
+				}
+				return
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read the provider config: %v", err)
+			}
+			if string(data) != tc.expected {
+				t.Errorf("expected provider config %q but got %q", tc.expected, data)
+			}
+		})
+	}
+}
+
+func TestProviderConfigForTester(t *testing.T) {
+	runDir := t.TempDir()
+	d := &fakeDeployerWithProviderConfig{provider: "gce", config: map[string]string{"gce-zone": "us-central1-b"}}
+
+	// written if the run did not bring up the cluster
+	path, err := providerConfigForTester(d, runDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "gce-zone: us-central1-b\nprovider: gce\n" {
+		t.Fatalf("expected the provider config to be written but got %q, %v", data, err)
+	}
+
+	// the provider config written at up is kept
+	d.config = map[string]string{"gce-zone": "us-east1-b"}
+	if path, err = providerConfigForTester(d, runDir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != "gce-zone: us-central1-b\nprovider: gce\n" {
+		t.Errorf("expected the provider config to be kept but got %q, %v", data, err)
+	}
+
+	if path, err := providerConfigForTester(&fakeDeployer{}, runDir); err != nil || path != "" {
+		t.Errorf("expected no provider config for a deployer without one but got %q, %v", path, err)
+	}
+}
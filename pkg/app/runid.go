@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// maxRunIDLength bounds the run id, the deployers use it in the names of
+// cloud resources, which are limited to 63 characters on GCE
+const maxRunIDLength = 63
+
+// maxRunIDPrefixLength bounds the run id prefix, the deployers name cloud
+// resources after the first 13 characters of the run id (see
+// util.PseudoUniqueSubstring), a longer prefix would leave too little of the
+// unique part of the run id in them for the names of concurrent runs to differ
+const maxRunIDPrefixLength = 5
+
+// runIDRe matches the run ids usable in the names of cloud resources and in
+// paths: lowercase letters, digits and dashes, not starting or ending with
+// a dash
+var runIDRe = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// runIDWithPrefix returns runID prefixed with prefix and a dash, unless it
+// already has the prefix, e.g. when an earlier run id is passed again
+func runIDWithPrefix(prefix, runID string) string {
+	if prefix == "" || strings.HasPrefix(runID, prefix+"-") {
+		return runID
+	}
+	return prefix + "-" + runID
+}
+
+// validateRunIDPrefix checks that the prefix leaves enough of the run id in
+// the names of the resources created by the deployers
+func validateRunIDPrefix(prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+	if len(prefix) > maxRunIDPrefixLength {
+		return fmt.Errorf("--run-id-prefix %q is longer than %d characters", prefix, maxRunIDPrefixLength)
+	}
+	if !runIDRe.MatchString(prefix) {
+		return fmt.Errorf("--run-id-prefix %q must consist of lowercase letters, digits and dashes, and start and end with a letter or digit", prefix)
+	}
+	return nil
+}
+
+// validateRunID checks that the run id can be used by the deployers, instead
+// of failing to create the resources named after it deep inside Up
+func validateRunID(runID string) error {
+	if len(runID) > maxRunIDLength {
+		return fmt.Errorf("--run-id %q is longer than %d characters", runID, maxRunIDLength)
+	}
+	if !runIDRe.MatchString(runID) {
+		return fmt.Errorf("--run-id %q must consist of lowercase letters, digits and dashes, and start and end with a letter or digit", runID)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"testing"
+)
+
+func TestRunID(t *testing.T) {
+	testCases := []struct {
+		name        string
+		prefix      string
+		runID       string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "uuid",
+			runID:    "09a2565a-7ac6-11eb-a603-2218f636630c",
+			expected: "09a2565a-7ac6-11eb-a603-2218f636630c",
+		},
+		{
+			name:     "prefix",
+			prefix:   "alice",
+			runID:    "09a2565a-7ac6-11eb-a603-2218f636630c",
+			expected: "alice-09a2565a-7ac6-11eb-a603-2218f636630c",
+		},
+		{
+			name:     "already prefixed",
+			prefix:   "alice",
+			runID:    "alice-09a2565a",
+			expected: "alice-09a2565a",
+		},
+		{
+			name:        "uppercase",
+			runID:       "Run1",
+			expectError: true,
+		},
+		{
+			name:        "underscore",
+			runID:       "run_1",
+			expectError: true,
+		},
+		{
+			name:        "trailing dash",
+			runID:       "run-",
+			expectError: true,
+		},
+		{
+			name:        "empty",
+			expectError: true,
+		},
+		{
+			name:        "too long with the prefix",
+			prefix:      "ci",
+			runID:       "09a2565a-7ac6-11eb-a603-2218f636630c-123456789012345678901234",
+			expectError: true,
+		},
+		{
+			name:        "prefix too long",
+			prefix:      "alice-e2e-gc",
+			runID:       "09a2565a",
+			expectError: true,
+		},
+		{
+			name:        "invalid prefix",
+			prefix:      "Alice",
+			runID:       "09a2565a",
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := runIDWithPrefix(tc.prefix, tc.runID)
+			err := validateRunIDPrefix(tc.prefix)
+			if err == nil {
+				err = validateRunID(actual)
+			}
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error for run id %q but got none", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != tc.expected {
+				t.Errorf("expected run id %q but got %q", tc.expected, actual)
+			}
+		})
+	}
+}
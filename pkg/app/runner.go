@@ -0,0 +1,629 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/artifacts"
+	"sigs.k8s.io/kubetest2/pkg/boskos"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/lease"
+	"sigs.k8s.io/kubetest2/pkg/metadata"
+	"sigs.k8s.io/kubetest2/pkg/runs"
+	"sigs.k8s.io/kubetest2/pkg/testers"
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+// failureOutputSize is how much of the command output is included in the
+// junit failure of a step
+const failureOutputSize = 8 << 10
+
+// Runner runs the kubetest2 build / up / test / down flow for a deployer,
+// recording the steps to junit_runner.xml and metadata.json in the artifacts
+// dir. It allows Go programs to embed kubetest2 without exec'ing binaries.
+type Runner struct {
+	opts          types.Options
+	deployer      types.Deployer
+	tester        types.Tester
+	testerEnv     []string
+	handleSignals bool
+	metadata      map[string]string
+	// kubeconfigMode is how the deployer kubeconfig is combined with an
+	// existing KUBECONFIG for the tester
+	kubeconfigMode string
+	// finalizeErrorPolicy is how errors writing the run artifacts at the end
+	// of the run are handled
+	finalizeErrorPolicy string
+	// leakPolicy is how resources left over by Down are handled
+	leakPolicy string
+	// resultsSink is where a summary of the run is uploaded, empty if it is
+	// not uploaded
+	resultsSink string
+	// interactive asks for confirmation before Down, and before Up in
+	// shared projects
+	interactive bool
+	// runTimeout is the time budget of the whole run, 0 if unbounded
+	runTimeout time.Duration
+	// progressEvents is the file the progress of the run is written to as
+	// JSON events, empty if it is not written
+	progressEvents string
+	// registry is the entry of the run in the local run registry, nil if
+	// the run is not registered
+	registry *runs.Run
+	// artifactsDir is where the results of the run are written
+	artifactsDir string
+	// cmder runs the tester and the commands probing the environment, nil
+	// for a LocalCmder recording the command output of the steps
+	cmder exec.Cmder
+	// leaser holds the sub-leases the deployer acquires for the run
+	leaser *lease.Leaser
+	// confirmIn and confirmOut are where confirmations are read from and
+	// asked on in interactive mode
+	confirmIn  io.Reader
+	confirmOut io.Writer
+	// for faking out time when testing
+	timeNow func() time.Time
+}
+
+// RunnerOption configures a Runner
+type RunnerOption func(*Runner)
+
+// WithTester sets the tester binary and args run when opts.ShouldTest()
+func WithTester(tester types.Tester) RunnerOption {
+	return func(r *Runner) {
+		r.tester = tester
+	}
+}
+
+// WithTesterEnv adds "key=value" entries to the tester environment, these
+// take precedence over the variables set by kubetest2
+func WithTesterEnv(env ...string) RunnerOption {
+	return func(r *Runner) {
+		r.testerEnv = append(r.testerEnv, env...)
+	}
+}
+
+// WithMetadata adds entries to the metadata.json written to the artifacts dir
+func WithMetadata(entries map[string]string) RunnerOption {
+	return func(r *Runner) {
+		if r.metadata == nil {
+			r.metadata = map[string]string{}
+		}
+		for k, v := range entries {
+			r.metadata[k] = v
+		}
+	}
+}
+
+// WithRunRegistry records the run, with the deployer name and its args, in
+// the local run registry listed by `kubetest2 runs`
+func WithRunRegistry(deployerName string, args []string) RunnerOption {
+	return func(r *Runner) {
+		r.registry = &runs.Run{Deployer: deployerName, Args: args}
+	}
+}
+
+// WithSignalHandling controls whether the Runner catches interrupt signals
+// to tear down the cluster and exit the process. Disabled by default, since
+// embedding programs usually handle signals themselves.
+func WithSignalHandling(enabled bool) RunnerOption {
+	return func(r *Runner) {
+		r.handleSignals = enabled
+	}
+}
+
+// WithKubeconfigMode sets how the deployer kubeconfig is combined with an
+// existing KUBECONFIG for the tester, "replace" (the default) or "prepend"
+func WithKubeconfigMode(mode string) RunnerOption {
+	return func(r *Runner) {
+		r.kubeconfigMode = mode
+	}
+}
+
+// WithFinalizeErrorPolicy sets how errors writing the run artifacts at the
+// end of the run are handled, "warn" (the default) or "fail"
+func WithFinalizeErrorPolicy(policy string) RunnerOption {
+	return func(r *Runner) {
+		r.finalizeErrorPolicy = policy
+	}
+}
+
+// WithLeakPolicy sets how resources left over by Down, as reported by
+// types.DeployerWithVerifyDown, are handled, "warn" or "fail" (the default)
+func WithLeakPolicy(policy string) RunnerOption {
+	return func(r *Runner) {
+		r.leakPolicy = policy
+	}
+}
+
+// WithResultsSink uploads a summary of the run to sink at the end of the run,
+// see --results-sink
+func WithResultsSink(sink string) RunnerOption {
+	return func(r *Runner) {
+		r.resultsSink = sink
+	}
+}
+
+// WithInteractive controls whether the Runner asks for confirmation on the
+// terminal before Down, and before Up in projects not acquired for the run
+func WithInteractive(enabled bool) RunnerOption {
+	return func(r *Runner) {
+		r.interactive = enabled
+	}
+}
+
+// WithRunTimeout sets the time budget of the whole run, 0 if unbounded
+func WithRunTimeout(timeout time.Duration) RunnerOption {
+	return func(r *Runner) {
+		r.runTimeout = timeout
+	}
+}
+
+// WithProgressEvents sets the file the Runner writes the progress of the
+// run to as newline delimited JSON events, empty if it is not written
+func WithProgressEvents(path string) RunnerOption {
+	return func(r *Runner) {
+		r.progressEvents = path
+	}
+}
+
+// WithArtifactsDir sets the directory the results of the run are written
+// to, the artifacts dir of kubetest2 by default
+func WithArtifactsDir(dir string) RunnerOption {
+	return func(r *Runner) {
+		r.artifactsDir = dir
+	}
+}
+
+// WithCmder sets the Cmder running the tester. By default the tester and
+// the commands of the deployer run through exec.DefaultCmder, with their
+// output recorded for the junit failures of the steps.
+func WithCmder(cmder exec.Cmder) RunnerOption {
+	return func(r *Runner) {
+		r.cmder = cmder
+	}
+}
+
+// WithLeaser sets the Leaser holding the sub-leases of the run, which are
+// renewed while the run is up and released after Down
+func WithLeaser(leaser *lease.Leaser) RunnerOption {
+	return func(r *Runner) {
+		r.leaser = leaser
+	}
+}
+
+// WithConfirmIO sets where confirmations are read from and asked on with
+// WithInteractive, stdin and stderr by default
+func WithConfirmIO(in io.Reader, out io.Writer) RunnerOption {
+	return func(r *Runner) {
+		r.confirmIn = in
+		r.confirmOut = out
+	}
+}
+
+// NewRunner returns a Runner for the deployer, the steps to run are
+// selected by opts
+func NewRunner(opts types.Options, d types.Deployer, runnerOpts ...RunnerOption) *Runner {
+	r := &Runner{
+		opts:                opts,
+		deployer:            d,
+		kubeconfigMode:      kubeconfigModeReplace,
+		finalizeErrorPolicy: finalizeErrorPolicyWarn,
+		leakPolicy:          leakPolicyFail,
+		confirmIn:           os.Stdin,
+		confirmOut:          os.Stderr,
+		timeNow:             time.Now,
+	}
+	for _, o := range runnerOpts {
+		o(r)
+	}
+	if r.artifactsDir == "" {
+		r.artifactsDir = artifacts.BaseDir()
+	}
+	if r.leaser == nil {
+		r.leaser = lease.NewLeaser(opts)
+	}
+	return r
+}
+
+// Run runs the selected steps, tearing down the cluster last if requested
+func (r *Runner) Run() (result error) {
+	/*
+		Now for the core kubetest2 logic:
+		 - build
+		 - cluster up
+		 - test
+		 - cluster down
+		Throughout this, collecting metadata and writing it out on exit
+	*/
+	// TODO(bentheelder): signal handling & timeout
+	started := r.timeNow()
+	if err := checkCapabilities(r.opts, r.deployer); err != nil {
+		return err
+	}
+	if !r.opts.RundirInArtifacts() {
+		klog.Infof("The files in RunDir shall not be part of Artifacts")
+		klog.Infof("pass rundir-in-artifacts flag True for RunDir to be part of Artifacts")
+	}
+	klog.Infof("RunDir for this run: %q", r.opts.RunDir())
+
+	// ensure the run dir
+	if err := os.MkdirAll(r.opts.RunDir(), os.ModePerm); err != nil {
+		return err
+	}
+
+	// ensure the artifacts dir
+	if err := os.MkdirAll(r.artifactsDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	if err := writeVersionToMetadataJSON(r.artifactsDir, r.deployer); err != nil {
+		return err
+	}
+	if len(r.metadata) > 0 {
+		if err := metadata.AddToFile(filepath.Join(r.artifactsDir, "metadata.json"), r.metadata); err != nil {
+			return err
+		}
+	}
+
+	// include the tail of the command output in the failures of steps, so the
+	// actual error is visible without the full build log
+	output := exec.NewOutputRecorder(failureOutputSize)
+	cmder := r.cmder
+	if cmder == nil {
+		cmder = &exec.LocalCmder{Recorder: output}
+		// the commands of the deployer run through exec.DefaultCmder
+		if _, ok := exec.DefaultCmder.(*exec.LocalCmder); ok {
+			defer func(cmder exec.Cmder) { exec.DefaultCmder = cmder }(exec.DefaultCmder)
+			exec.DefaultCmder = cmder
+		}
+	}
+
+	if err := writeEnvironmentJSON(cmder, r.artifactsDir); err != nil {
+		return fmt.Errorf("could not write environment manifest: %w", err)
+	}
+
+	// register the run, and record how it ended last
+	r.register(started)
+	defer func() {
+		if r.registry == nil {
+			return
+		}
+		r.registry.Status = runs.StatusPassed
+		if result != nil {
+			r.registry.Status = runs.StatusFailed
+		}
+		r.registry.Finished = r.timeNow()
+		r.saveRegistry()
+	}()
+
+	// setup junit writer
+	junitRunner, err := os.Create(
+		filepath.Join(r.artifactsDir, "junit_runner.xml"),
+	)
+	if err != nil {
+		return fmt.Errorf("could not create runner output: %w", err)
+	}
+	writer := metadata.NewWriter("kubetest2", junitRunner)
+	writer.RecordOutput(output)
+
+	// report the lifecycle steps, and the steps of the deployer within them,
+	// as progress events if requested
+	var progressOut io.Writer
+	if r.progressEvents != "" {
+		out, closeProgress, err := openProgressEvents(r.progressEvents)
+		if err != nil {
+			return fmt.Errorf("could not create progress events file: %w", err)
+		}
+		// closed after the run-finished event is written by the deferred
+		// finalization below
+		defer closeProgress()
+		progressOut = out
+	}
+	progress := newProgress(progressOut, r.opts)
+	wrapStep := progress.wrap(writer.WrapStep, true)
+	wrapSubStep := progress.wrap(writer.WrapStep, false)
+
+	if r.handleSignals {
+		done := make(chan bool)
+		defer func() { done <- true }()
+		go func() {
+			c := make(chan os.Signal, 1)
+			signal.Notify(c, os.Interrupt, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+
+			// catch interrupt signals and gracefully attempt to clean up
+			for {
+				select {
+				case sig := <-c:
+					if r.opts.ShouldUp() || r.opts.ShouldTest() {
+						if r.opts.ShouldDown() {
+							klog.Info("Captured ^C, gracefully attempting to cleanup resources..")
+							if err := wrapStep("Down", r.deployer.Down); err != nil {
+								result = err
+							}
+						}
+						if err := boskos.ReleasePools(); err != nil {
+							klog.Errorf("failed to release the boskos resources of the run: %v", err)
+						}
+						// the deferred run-finished event is never reached
+						progress.runInterrupted(started, sig)
+						os.Exit(0)
+					}
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+
+	// defer writing out the metadata on exit
+	// NOTE: defer is LIFO, so this should actually be the finish time
+	defer func() {
+		var finalizeErrs []error
+		if err := writer.Finish(); err != nil {
+			finalizeErrs = append(finalizeErrs, err)
+		}
+		if err := junitRunner.Sync(); err != nil {
+			finalizeErrs = append(finalizeErrs, err)
+		}
+		if err := junitRunner.Close(); err != nil {
+			finalizeErrs = append(finalizeErrs, err)
+		}
+		if err := finalizeError(r.finalizeErrorPolicy, r.artifactsDir, finalizeErrs...); err != nil && result == nil {
+			result = err
+		}
+		// If the deployer has an Finish func, run it
+		if dWithFinish, ok := r.deployer.(types.DeployerWithFinish); ok {
+			if err := dWithFinish.Finish(); err != nil {
+				result = err
+			}
+		}
+		// release the boskos resources still held, e.g. when Up failed
+		// without --down, instead of leaving them to expire
+		if err := boskos.ReleasePools(); err != nil {
+			klog.Errorf("failed to release the boskos resources of the run: %v", err)
+		}
+		if err := writeBoskosMetrics(r.artifactsDir, boskos.Metrics()); err != nil {
+			klog.Warningf("Failed to record the boskos heartbeat metrics: %v", err)
+		}
+		if sink := r.resultsSink; sink != "" {
+			exportResults(sink, r.artifactsDir, r.opts.RunID(), started, result == nil)
+		}
+		progress.runFinished(started, result)
+	}()
+
+	klog.Infof("ID for this run: %q", r.opts.RunID())
+	progress.runStarted(r.opts.RunID())
+
+	// keep the sub-leases the deployer acquires for the run from expiring
+	// while it runs
+	stopLeaseRenewal := r.leaser.StartRenewal(lease.RenewInterval)
+	defer stopLeaseRenewal()
+
+	// If the deployer reports its own steps, record them with the lifecycle steps
+	if dWithSteps, ok := r.deployer.(types.DeployerWithSteps); ok {
+		dWithSteps.SetStepRunner(wrapSubStep)
+	}
+
+	// If the deployer has an initialization routine, run it
+	if dWithInit, ok := r.deployer.(types.DeployerWithInit); ok {
+		if err := dWithInit.Init(); err != nil {
+			// we do not continue to up / test etc. if initialization fails
+			return err
+		}
+	}
+
+	// build if specified
+	if r.opts.ShouldBuild() {
+		if err := wrapStep("Build", r.deployer.Build); err != nil {
+			// we do not continue to up / test etc. if build fails
+			return err
+		}
+		// a build only run is consumed by later runs, tell them where the build is
+		if !r.opts.ShouldUp() && !r.opts.ShouldTest() && !r.opts.ShouldDown() {
+			if err := writeBuildManifest(r.deployer, r.artifactsDir); err != nil {
+				return fmt.Errorf("could not write build manifest: %w", err)
+			}
+		}
+	}
+
+	// ensure tearing down the cluster happens last.
+	// down should be called both when Up and Test fails to ensure resources are being cleaned up.
+	defer func() {
+		if r.opts.ShouldDown() {
+			if r.interactive {
+				confirmed, err := confirm(r.confirmIn, r.confirmOut, "Down", deployerPlan(r.deployer, "Down"))
+				if err != nil || !confirmed {
+					klog.Warningf("Down was not confirmed, the cluster is left up")
+					return
+				}
+			}
+			// TODO(bentheelder): instead of keeping the first error, consider
+			// a multi-error type
+			if err := wrapStep("Down", r.deployer.Down); err != nil {
+				if result == nil {
+					result = err
+				}
+				// the leases are kept while the resources using them may exist
+				return
+			}
+			if _, ok := r.deployer.(types.DeployerWithVerifyDown); ok {
+				if err := wrapSubStep("VerifyDown", func() error { return verifyDown(r.deployer) }); err != nil {
+					if r.leakPolicy == leakPolicyFail && result == nil {
+						result = err
+					}
+					klog.Warningf("Verifying down failed, the leases of the run are kept: %v", err)
+					return
+				}
+			}
+			if r.registry != nil {
+				r.registry.ClusterUp = false
+				r.saveRegistry()
+			}
+			// release the sub-leases the deployer acquired for the run
+			if err := r.leaser.Release(); err != nil {
+				klog.Warningf("Failed to release the leases of the run: %v", err)
+			}
+		}
+	}()
+
+	// up a cluster
+	if r.opts.ShouldUp() {
+		if r.interactive {
+			// only shared projects are worth a confirmation, the resources
+			// of a project acquired for the run are its own
+			if plan := deployerPlan(r.deployer, "Up"); plan != nil && plan.Shared {
+				confirmed, err := confirm(r.confirmIn, r.confirmOut, "Up", plan)
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					return fmt.Errorf("up was not confirmed")
+				}
+			}
+		}
+		if r.registry != nil {
+			r.registry.ClusterUp = true
+			r.saveRegistry()
+		}
+		// TODO(bentheelder): this should write out to JUnit
+		ctx, cancel := runContext(started, r.runTimeout)
+		err := wrapStep("Up", upStep(ctx, r.deployer))
+		cancel()
+		if r.registry != nil {
+			if plan := deployerPlan(r.deployer, "Down"); plan != nil {
+				r.registry.Resources = plan.Resources
+				r.saveRegistry()
+			}
+		}
+		if err != nil {
+			writeFailureTypeToMetadataJSON(r.artifactsDir, "Up", err)
+			// we do not continue to test if build fails
+			return err
+		}
+		if _, err := writeProviderConfig(r.deployer, r.opts.RunDir()); err != nil {
+			return err
+		}
+	}
+
+	// and finally test, if a test was specified
+	if r.opts.ShouldTest() {
+		test := cmder.Command(r.tester.TesterPath, r.tester.TesterArgs...)
+		exec.InheritOutput(test)
+
+		envsForTester := os.Environ()
+		// We expose both ARIFACTS and KUBETEST2_RUN_DIR so we can more granular about caching vs output in future.
+		// also add run_dir to $PATH for locally built binaries
+		updatedPath := r.opts.RunDir() + string(filepath.ListSeparator) + os.Getenv("PATH")
+		envsForTester = append(envsForTester, fmt.Sprintf("%s=%s", "PATH", updatedPath))
+		envsForTester = append(envsForTester, fmt.Sprintf("%s=%s", "ARTIFACTS", r.artifactsDir))
+		envsForTester = append(envsForTester, fmt.Sprintf("%s=%s", "KUBETEST2_RUN_DIR", r.opts.RunDir()))
+		envsForTester = append(envsForTester, fmt.Sprintf("%s=%s", "KUBETEST2_RUN_ID", r.opts.RunID()))
+		if timeout := r.runTimeout; timeout > 0 {
+			envsForTester = append(envsForTester, fmt.Sprintf("%s=%s", testers.RunDeadlineEnv, started.Add(timeout).Format(time.RFC3339)))
+		}
+		// If the deployer provides a kubeconfig pass it to the tester
+		// else assumes that it is handled offline by default methods like
+		// ~/.kube/config
+		if dWithKubeconfig, ok := r.deployer.(types.DeployerWithKubeconfig); ok {
+			if kconfig, err := dWithKubeconfig.Kubeconfig(); err == nil {
+				kubeconfigForTester, err := mergeKubeconfig(r.kubeconfigMode, kconfig, os.Getenv("KUBECONFIG"))
+				if err != nil {
+					return err
+				}
+				if kubeconfigForTester != "" {
+					envsForTester = append(envsForTester, fmt.Sprintf("%s=%s", "KUBECONFIG", kubeconfigForTester))
+				}
+			}
+
+		}
+		providerConfig, err := providerConfigForTester(r.deployer, r.opts.RunDir())
+		if err != nil {
+			return err
+		}
+		if providerConfig != "" {
+			envsForTester = append(envsForTester, fmt.Sprintf("%s=%s", providerConfigEnv, providerConfig))
+		}
+		envsForTester = append(envsForTester, r.testerEnv...)
+		test.SetEnv(envsForTester...)
+
+		effectiveTesterEnv := effectiveEnv(envsForTester)
+		for _, key := range testerEnvOverrides(os.Environ(), effectiveTesterEnv) {
+			klog.Warningf("$%s=%q is overridden for the tester with %q", key, os.Getenv(key), effectiveTesterEnv[key])
+		}
+		if err := writeTesterEnvJSON(r.artifactsDir, effectiveTesterEnv); err != nil {
+			klog.Warningf("Failed to record the tester env: %v", err)
+		}
+
+		var testErr error
+		if !r.opts.SkipTestJUnitReport() {
+			testErr = wrapStep("Test", test.Run)
+		} else {
+			testErr = progress.wrap(runStep, true)("Test", test.Run)
+		}
+
+		if dWithPostTester, ok := r.deployer.(types.DeployerWithPostTester); ok {
+			if err := dWithPostTester.PostTest(testErr); err != nil {
+				return err
+			}
+		}
+		if testErr != nil {
+			return testErr
+		}
+	}
+
+	return nil
+}
+
+// register adds the run to the local run registry, if enabled
+func (r *Runner) register(started time.Time) {
+	if r.registry == nil {
+		return
+	}
+	r.registry.ID = r.opts.RunID()
+	r.registry.Status = runs.StatusRunning
+	r.registry.Artifacts = r.artifactsDir
+	r.registry.RunDir = r.opts.RunDir()
+	r.registry.Started = started
+	r.saveRegistry()
+}
+
+// saveRegistry writes the registry entry of the run, the registry is only a
+// convenience so failures are not fatal
+func (r *Runner) saveRegistry() {
+	if err := runs.Save(runs.Dir(r.artifactsDir), r.registry); err != nil {
+		klog.Warningf("Failed to record the run in the run registry: %v", err)
+	}
+}
+
+// writeBoskosMetrics adds the boskos heartbeat metrics of the run to the
+// metadata.json in artifactsDir, if any heartbeat was sent
+func writeBoskosMetrics(artifactsDir string, m boskos.HeartbeatMetrics) error {
+	if m.Sent == 0 {
+		return nil
+	}
+	return metadata.AddToFile(filepath.Join(artifactsDir, "metadata.json"), m.Metadata())
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/pkg/boskos"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+// fakeRunOptions selects the steps of a run
+type fakeRunOptions struct {
+	build, up, test, down bool
+	runDir                string
+}
+
+func (o *fakeRunOptions) HelpRequested() bool       { return false }
+func (o *fakeRunOptions) ShouldBuild() bool         { return o.build }
+func (o *fakeRunOptions) ShouldUp() bool            { return o.up }
+func (o *fakeRunOptions) ShouldDown() bool          { return o.down }
+func (o *fakeRunOptions) ShouldTest() bool          { return o.test }
+func (o *fakeRunOptions) SkipTestJUnitReport() bool { return false }
+func (o *fakeRunOptions) RunID() string             { return "run-1" }
+func (o *fakeRunOptions) RunDir() string            { return o.runDir }
+func (o *fakeRunOptions) RundirInArtifacts() bool   { return false }
+
+// fakeStepsDeployer records the steps run, failing those in errs
+type fakeStepsDeployer struct {
+	steps []string
+	errs  map[string]error
+}
+
+func (d *fakeStepsDeployer) step(name string) error {
+	d.steps = append(d.steps, name)
+	return d.errs[name]
+}
+
+func (d *fakeStepsDeployer) Init() error            { return d.step("Init") }
+func (d *fakeStepsDeployer) Build() error           { return d.step("Build") }
+func (d *fakeStepsDeployer) Up() error              { return d.step("Up") }
+func (d *fakeStepsDeployer) Down() error            { return d.step("Down") }
+func (d *fakeStepsDeployer) PostTest(error) error   { return d.step("PostTest") }
+func (d *fakeStepsDeployer) Finish() error          { return d.step("Finish") }
+func (d *fakeStepsDeployer) IsUp() (bool, error)    { return true, nil }
+func (d *fakeStepsDeployer) DumpClusterLogs() error { return nil }
+
+var (
+	_ types.DeployerWithInit       = &fakeStepsDeployer{}
+	_ types.DeployerWithPostTester = &fakeStepsDeployer{}
+	_ types.DeployerWithFinish     = &fakeStepsDeployer{}
+)
+
+// testerCmder records running the tester as the Test step of the deployer
+type testerCmder struct {
+	exec.FakeCmder
+	deployer *fakeStepsDeployer
+}
+
+func (c *testerCmder) Command(name string, arg ...string) exec.Cmd {
+	if name == "tester" {
+		c.deployer.steps = append(c.deployer.steps, "Test")
+	}
+	return c.FakeCmder.Command(name, arg...)
+}
+
+func TestRunnerSteps(t *testing.T) {
+	stepErr := errors.New("step failed")
+	testCases := []struct {
+		name          string
+		opts          fakeRunOptions
+		errs          map[string]error
+		testerErr     error
+		expectedSteps []string
+		expectedErr   error
+	}{
+		{
+			name:          "all steps",
+			opts:          fakeRunOptions{build: true, up: true, test: true, down: true},
+			expectedSteps: []string{"Init", "Build", "Up", "Test", "PostTest", "Down", "Finish"},
+		},
+		{
+			name:          "without down the cluster is left up",
+			opts:          fakeRunOptions{up: true, test: true},
+			expectedSteps: []string{"Init", "Up", "Test", "PostTest", "Finish"},
+		},
+		{
+			name:          "init fails",
+			opts:          fakeRunOptions{build: true, up: true, test: true, down: true},
+			errs:          map[string]error{"Init": stepErr},
+			expectedSteps: []string{"Init", "Finish"},
+			expectedErr:   stepErr,
+		},
+		{
+			name:          "build fails before anything is created",
+			opts:          fakeRunOptions{build: true, up: true, test: true, down: true},
+			errs:          map[string]error{"Build": stepErr},
+			expectedSteps: []string{"Init", "Build", "Finish"},
+			expectedErr:   stepErr,
+		},
+		{
+			name:          "up fails and the cluster is torn down",
+			opts:          fakeRunOptions{up: true, test: true, down: true},
+			errs:          map[string]error{"Up": stepErr},
+			expectedSteps: []string{"Init", "Up", "Down", "Finish"},
+			expectedErr:   stepErr,
+		},
+		{
+			name:          "test fails and the cluster is torn down",
+			opts:          fakeRunOptions{up: true, test: true, down: true},
+			testerErr:     stepErr,
+			expectedSteps: []string{"Init", "Up", "Test", "PostTest", "Down", "Finish"},
+			expectedErr:   stepErr,
+		},
+		{
+			name:          "down fails",
+			opts:          fakeRunOptions{up: true, test: true, down: true},
+			errs:          map[string]error{"Down": stepErr},
+			expectedSteps: []string{"Init", "Up", "Test", "PostTest", "Down", "Finish"},
+			expectedErr:   stepErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			opts := tc.opts
+			opts.runDir = filepath.Join(dir, "rundir")
+			artifactsDir := filepath.Join(dir, "artifacts")
+			d := &fakeStepsDeployer{errs: tc.errs}
+			cmder := &testerCmder{
+				FakeCmder: exec.FakeCmder{Responses: []exec.FakeResponse{{Prefix: "tester", Err: tc.testerErr}}},
+				deployer:  d,
+			}
+
+			err := NewRunner(&opts, d,
+				WithTester(types.Tester{TesterPath: "tester", TesterArgs: []string{"--focus=x"}}),
+				WithArtifactsDir(artifactsDir),
+				WithCmder(cmder),
+			).Run()
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("expected error %v but got %v", tc.expectedErr, err)
+			}
+			if !reflect.DeepEqual(d.steps, tc.expectedSteps) {
+				t.Errorf("expected steps %q but got %q", tc.expectedSteps, d.steps)
+			}
+			if _, err := os.Stat(filepath.Join(artifactsDir, "junit_runner.xml")); err != nil {
+				t.Errorf("expected the junit_runner.xml in the artifacts dir: %v", err)
+			}
+		})
+	}
+}
+
+func TestWriteBoskosMetrics(t *testing.T) {
+	testCases := []struct {
+		name     string
+		metrics  boskos.HeartbeatMetrics
+		expected map[string]string
+	}{
+		{
+			name:     "no heartbeats",
+			expected: map[string]string{"kubetest-version": ""},
+		},
+		{
+			name:    "heartbeats",
+			metrics: boskos.HeartbeatMetrics{Sent: 12, Failed: 1, MaxConsecutiveFailures: 1},
+			expected: map[string]string{
+				"kubetest-version":                           "",
+				"boskos-heartbeats-sent":                     "12",
+				"boskos-heartbeats-failed":                   "1",
+				"boskos-heartbeats-max-consecutive-failures": "1",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "metadata.json")
+			if err := os.WriteFile(path, []byte(`{"kubetest-version": ""}`), 0644); err != nil {
+				t.Fatalf("failed to write metadata.json: %v", err)
+			}
+			if err := writeBoskosMetrics(dir, tc.metrics); err != nil {
+				t.Fatalf("did not expect an error, but got: %v", err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read metadata.json: %v", err)
+			}
+			actual := map[string]string{}
+			if err := json.Unmarshal(data, &actual); err != nil {
+				t.Fatalf("failed to parse metadata.json: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected metadata %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
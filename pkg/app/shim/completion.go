@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shim
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/flagdump"
+)
+
+const completionUsage = `Usage:
+  kubetest2 completion bash|zsh   print the shell completion script
+
+To load the completion in the current shell:
+  source <(kubetest2 completion bash)
+
+The deployer and tester flags are completed by running them with --help,
+they are found in PATH like when running kubetest2.`
+
+// the scripts call back into `kubetest2 __complete-words` with the command line
+// up to the cursor, which prints the candidates one per line
+const bashCompletion = `# bash completion for kubetest2
+_kubetest2() {
+	local line="${COMP_LINE:0:COMP_POINT}"
+	local word="${line##*[[:space:]]}"
+	local IFS=$'\n'
+	COMPREPLY=($(compgen -W "$(kubetest2 __complete-words "$line" 2>/dev/null)" -- "$word"))
+	# bash splits words at =, only the value after it is being completed
+	if [[ "$word" == *=* ]]; then
+		COMPREPLY=("${COMPREPLY[@]#*=}")
+	fi
+}
+complete -o default -F _kubetest2 kubetest2
+`
+
+const zshCompletion = `#compdef kubetest2
+_kubetest2() {
+	local -a candidates
+	candidates=(${(f)"$(kubetest2 __complete-words "${(j: :)words[1,CURRENT]}" 2>/dev/null)"})
+	if (( ${#candidates} )); then
+		compadd -- $candidates
+	else
+		_files
+	fi
+}
+compdef _kubetest2 kubetest2
+`
+
+// commands are the shim commands completed along with the deployers
+var commands = []string{"completion", "runs", "version"}
+
+// runCompletion implements `kubetest2 completion`, printing the completion
+// script for the shell
+func runCompletion(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		cmd.Println(completionUsage)
+		return fmt.Errorf("kubetest2 completion takes one shell")
+	}
+	switch args[0] {
+	case "bash":
+		fmt.Fprint(cmd.OutOrStdout(), bashCompletion)
+	case "zsh":
+		fmt.Fprint(cmd.OutOrStdout(), zshCompletion)
+	case "-h", "--help":
+		cmd.Println(completionUsage)
+	default:
+		cmd.Println(completionUsage)
+		return fmt.Errorf("unsupported shell %q, must be one of bash or zsh", args[0])
+	}
+	return nil
+}
+
+// runComplete implements the hidden `kubetest2 __complete-words` command called by
+// the completion scripts with the command line up to the cursor
+func runComplete(cmd *cobra.Command, args []string) error {
+	for _, candidate := range complete(strings.Join(args, " ")) {
+		fmt.Fprintln(cmd.OutOrStdout(), candidate)
+	}
+	return nil
+}
+
+// complete returns the candidates for the last word of line, the shells
+// filter them by the word being completed
+func complete(line string) []string {
+	words := strings.Fields(line)
+	if len(words) == 0 {
+		return nil
+	}
+	// drop the kubetest2 binary, and complete a new word after a space
+	words = words[1:]
+	if unicode.IsSpace(rune(line[len(line)-1])) {
+		words = append(words, "")
+	}
+	if len(words) == 0 {
+		return nil
+	}
+	current := words[len(words)-1]
+
+	// the deployer or a shim command
+	if len(words) == 1 {
+		return append(sortedNames(FindDeployers()), commands...)
+	}
+	deployerArgs, inTesterArgs := splitCompletionArgs(words[1 : len(words)-1])
+
+	if !inTesterArgs {
+		if strings.HasPrefix(current, "--test=") {
+			var candidates []string
+			for _, name := range sortedNames(FindTesters()) {
+				candidates = append(candidates, "--test="+name)
+			}
+			return candidates
+		}
+		if n := len(deployerArgs); n > 0 && deployerArgs[n-1] == "--test" {
+			return sortedNames(FindTesters())
+		}
+	}
+	if !strings.HasPrefix(current, "-") {
+		return nil
+	}
+
+	path, err := FindDeployer(words[0])
+	if inTesterArgs {
+		path, err = FindTester(testerName(deployerArgs))
+	}
+	if err != nil {
+		return nil
+	}
+	var candidates []string
+	for _, f := range binaryFlags(path) {
+		candidates = append(candidates, "--"+f.Name)
+	}
+	return candidates
+}
+
+// splitCompletionArgs returns the deployer args in the words before the
+// cursor, which end at the first bare `--` like for the deployers, and whether
+// the cursor is after it in the tester args
+func splitCompletionArgs(words []string) (deployerArgs []string, inTesterArgs bool) {
+	for i, word := range words {
+		if word == "--" {
+			return words[:i], true
+		}
+	}
+	return words, false
+}
+
+// testerName returns the value of the --test flag in the deployer args
+func testerName(deployerArgs []string) string {
+	name := ""
+	for i, arg := range deployerArgs {
+		if strings.HasPrefix(arg, "--test=") {
+			name = strings.TrimPrefix(arg, "--test=")
+		} else if arg == "--test" && i+1 < len(deployerArgs) {
+			name = deployerArgs[i+1]
+		}
+	}
+	return name
+}
+
+// binaryFlags runs the binary at path with flagdump.RequestEnv set to get its
+// flags, the --help argument makes binaries that don't support it print their
+// usage instead of running, which is parsed instead
+func binaryFlags(path string) []flagdump.Flag {
+	cmd := exec.Command(path, "--help")
+	cmd.SetEnv(append(os.Environ(), flagdump.RequestEnv+"=1")...)
+	out, err := exec.Output(cmd)
+	if err != nil {
+		return nil
+	}
+	return flagdump.Parse(out)
+}
+
+// sortedNames returns the names of the binaries found by FindDeployers or
+// FindTesters, sorted
+func sortedNames(nameToPath map[string]string) []string {
+	names := make([]string, 0, len(nameToPath))
+	for name := range nameToPath {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shim
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSplitCompletionArgs(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		words                []string
+		expectedDeployerArgs []string
+		expectedInTesterArgs bool
+	}{
+		{
+			name: "no args",
+		},
+		{
+			name:                 "deployer args",
+			words:                []string{"--up", "--test=ginkgo"},
+			expectedDeployerArgs: []string{"--up", "--test=ginkgo"},
+		},
+		{
+			name:                 "tester args",
+			words:                []string{"--up", "--test=ginkgo", "--", "--focus-regex=foo"},
+			expectedDeployerArgs: []string{"--up", "--test=ginkgo"},
+			expectedInTesterArgs: true,
+		},
+		{
+			name:                 "only the first -- separates the tester args",
+			words:                []string{"--test", "exec", "--", "foo", "--", "bar"},
+			expectedDeployerArgs: []string{"--test", "exec"},
+			expectedInTesterArgs: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			deployerArgs, inTesterArgs := splitCompletionArgs(tc.words)
+			if !reflect.DeepEqual(deployerArgs, tc.expectedDeployerArgs) {
+				t.Errorf("expected deployer args %v but got %v", tc.expectedDeployerArgs, deployerArgs)
+			}
+			if inTesterArgs != tc.expectedInTesterArgs {
+				t.Errorf("expected in tester args %v but got %v", tc.expectedInTesterArgs, inTesterArgs)
+			}
+		})
+	}
+}
+
+func TestTesterName(t *testing.T) {
+	testCases := []struct {
+		name         string
+		deployerArgs []string
+		expected     string
+	}{
+		{
+			name: "no tester",
+		},
+		{
+			name:         "--test=",
+			deployerArgs: []string{"--up", "--test=ginkgo"},
+			expected:     "ginkgo",
+		},
+		{
+			name:         "--test with a separate value",
+			deployerArgs: []string{"--test", "exec", "--down"},
+			expected:     "exec",
+		},
+		{
+			name:         "--test without a value",
+			deployerArgs: []string{"--up", "--test"},
+		},
+		{
+			name:         "the last --test wins",
+			deployerArgs: []string{"--test=ginkgo", "--test", "exec"},
+			expected:     "exec",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if name := testerName(tc.deployerArgs); name != tc.expected {
+				t.Errorf("expected tester %q but got %q", tc.expected, name)
+			}
+		})
+	}
+}
+
+func TestComplete(t *testing.T) {
+	// fake deployer and testers in PATH, printing their flags like
+	// flagdump.PrintIfRequested
+	dir := t.TempDir()
+	binaries := map[string]string{
+		"kubetest2-noop":          `[{"name":"down"},{"name":"up"}]`,
+		"kubetest2-tester-exec":   `[{"name":"help","shorthand":"h"}]`,
+		"kubetest2-tester-ginkgo": `[{"name":"focus-regex"},{"name":"parallel"}]`,
+	}
+	for name, flags := range binaries {
+		script := "#!/bin/sh\necho '" + flags + "'\n"
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", dir)
+
+	testCases := []struct {
+		name     string
+		line     string
+		expected []string
+	}{
+		{
+			name: "empty line",
+		},
+		{
+			name:     "deployers and commands",
+			line:     "kubetest2 ",
+			expected: []string{"noop", "completion", "runs", "version"},
+		},
+		{
+			name:     "deployer flags",
+			line:     "kubetest2 noop --u",
+			expected: []string{"--down", "--up"},
+		},
+		{
+			name:     "--test= values",
+			line:     "kubetest2 noop --up --test=",
+			expected: []string{"--test=exec", "--test=ginkgo"},
+		},
+		{
+			name:     "--test values",
+			line:     "kubetest2 noop --test ",
+			expected: []string{"exec", "ginkgo"},
+		},
+		{
+			name:     "tester flags",
+			line:     "kubetest2 noop --test=ginkgo -- --",
+			expected: []string{"--focus-regex", "--parallel"},
+		},
+		{
+			name:     "tester flags with a separate --test value",
+			line:     "kubetest2 noop --test exec -- -",
+			expected: []string{"--help"},
+		},
+		{
+			name: "args are not completed",
+			line: "kubetest2 noop --test=exec -- ech",
+		},
+		{
+			name: "unknown deployer",
+			line: "kubetest2 missing --",
+		},
+		{
+			name: "unknown tester",
+			line: "kubetest2 noop --test=missing -- --",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if candidates := complete(tc.line); !reflect.DeepEqual(candidates, tc.expected) {
+				t.Errorf("expected candidates %v but got %v", tc.expected, candidates)
+			}
+		})
+	}
+}
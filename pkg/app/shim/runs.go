@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shim
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubetest2/pkg/artifacts"
+	"sigs.k8s.io/kubetest2/pkg/runs"
+)
+
+const runsUsage = `Usage:
+  kubetest2 runs [list] [--output=text|json]   list the registered runs
+  kubetest2 runs show <run-id>                 show a run and how to tear down its cluster
+  kubetest2 runs clean [--all] [<run-id>...]   remove runs from the registry, by default
+                                               the finished runs without a cluster left up
+
+The registry is in the runs directory of the artifacts ($ARTIFACTS or ./_artifacts).`
+
+// runRuns implements `kubetest2 runs`, listing, inspecting and cleaning the
+// local registry of runs
+func runRuns(cmd *cobra.Command, args []string) error {
+	flags := pflag.NewFlagSet("runs", pflag.ContinueOnError)
+	output := flags.StringP("output", "o", "text", "output format, one of text or json")
+	all := flags.Bool("all", false, "clean all the runs, including the running or interrupted ones and those which may have left a cluster up")
+	help := flags.BoolP("help", "h", false, "")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *help {
+		cmd.Println(runsUsage)
+		return nil
+	}
+	dir := runs.Dir(artifacts.BaseDir())
+	subcommand, ids := "list", flags.Args()
+	if len(ids) > 0 {
+		subcommand, ids = ids[0], ids[1:]
+	}
+	switch subcommand {
+	case "list":
+		return listRuns(cmd, dir, *output)
+	case "show":
+		if len(ids) != 1 {
+			return fmt.Errorf("kubetest2 runs show takes one run id")
+		}
+		return showRun(cmd, dir, ids[0])
+	case "clean":
+		return cleanRuns(cmd, dir, ids, *all)
+	default:
+		cmd.Println(runsUsage)
+		return fmt.Errorf("unknown runs subcommand %q", subcommand)
+	}
+}
+
+func listRuns(cmd *cobra.Command, dir, output string) error {
+	list, err := runs.List(dir)
+	if err != nil {
+		return err
+	}
+	switch output {
+	case "json":
+		b, err := json.MarshalIndent(list, "", "  ")
+		if err != nil {
+			return err
+		}
+		cmd.Println(string(b))
+		return nil
+	case "text":
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "RUN ID\tDEPLOYER\tSTATUS\tCLUSTER UP\tSTARTED\tARTIFACTS")
+		for _, r := range list {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%t\t%s\t%s\n",
+				r.ID, r.Deployer, r.Status, r.ClusterUp, r.Started.Local().Format(time.DateTime), r.Artifacts)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("--output must be one of text or json, got %q", output)
+	}
+}
+
+func showRun(cmd *cobra.Command, dir, id string) error {
+	r, err := runs.Get(dir, id)
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	cmd.Println(string(b))
+	if r.ClusterUp {
+		cmd.Println()
+		cmd.Println("The cluster of the run may still be up, to tear it down run:")
+		cmd.Printf("  %s %s %s\n", BinaryName, r.Deployer, strings.Join(runs.DownArgs(r), " "))
+	}
+	return nil
+}
+
+// cleanRuns removes the runs ids from the registry, or without ids the
+// finished runs which didn't leave a cluster up, all the runs with all
+func cleanRuns(cmd *cobra.Command, dir string, ids []string, all bool) error {
+	if len(ids) > 0 {
+		for _, id := range ids {
+			if err := runs.Remove(dir, id); err != nil {
+				return err
+			}
+			cmd.Printf("Removed run %s\n", id)
+		}
+		return nil
+	}
+	list, err := runs.List(dir)
+	if err != nil {
+		return err
+	}
+	for _, r := range list {
+		if (r.Status == runs.StatusRunning || r.ClusterUp) && !all {
+			continue
+		}
+		if err := runs.Remove(dir, r.ID); err != nil {
+			return err
+		}
+		cmd.Printf("Removed run %s\n", r.ID)
+	}
+	return nil
+}
@@ -75,6 +75,19 @@ func runE(cmd *cobra.Command, args []string) error {
 		return cmd.Help()
 	}
 
+	if args[0] == "version" {
+		return runVersion(cmd, args[1:])
+	}
+	if args[0] == "runs" {
+		return runRuns(cmd, args[1:])
+	}
+	if args[0] == "completion" {
+		return runCompletion(cmd, args[1:])
+	}
+	if args[0] == "__complete-words" {
+		return runComplete(cmd, args[1:])
+	}
+
 	// gracefully handle help or version command if it is the only argument
 	if len(args) == 1 {
 		// check for -h, --help
@@ -136,4 +149,7 @@ func usage(cmd *cobra.Command) {
 	}
 	cmd.Println()
 	cmd.Println("For more help, run kubetest2 [deployer] --help")
+	cmd.Println("To report the versions of kubetest2 and of the detected deployers and testers, run kubetest2 version [--output=json]")
+	cmd.Println("To list past runs and find the clusters left up by interrupted runs, run kubetest2 runs [--help]")
+	cmd.Println("To complete the deployers, testers and their flags in bash or zsh, run kubetest2 completion [bash|zsh]")
 }
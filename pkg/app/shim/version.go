@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shim
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/version"
+)
+
+// runVersion implements `kubetest2 version`, reporting the build information
+// of the shim and of every deployer and tester found in PATH
+func runVersion(cmd *cobra.Command, args []string) error {
+	flags := pflag.NewFlagSet("version", pflag.ContinueOnError)
+	output := flags.StringP("output", "o", "text", "output format, one of text or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	infos := []version.Info{version.Get(GitTag)}
+	infos[0].Binary = BinaryName
+	infos = append(infos, binaryVersions(FindDeployers())...)
+	infos = append(infos, binaryVersions(FindTesters())...)
+
+	switch *output {
+	case "json":
+		b, err := json.MarshalIndent(infos, "", "  ")
+		if err != nil {
+			return err
+		}
+		cmd.Println(string(b))
+	case "text":
+		w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "BINARY\tGIT TAG\tGO VERSION\tBUILD DATE")
+		for _, info := range infos {
+			if info.Error != "" {
+				// errors are last, not to widen the other columns
+				fmt.Fprintf(w, "%s\t\t\terror: %s\n", info.Binary, info.Error)
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", info.Binary, info.GitTag, info.GoVersion, info.BuildDate)
+		}
+		return w.Flush()
+	default:
+		return fmt.Errorf("--output must be one of text or json, got %q", *output)
+	}
+	return nil
+}
+
+// binaryVersions runs each of the named binaries to get their build
+// information, sorted by binary name
+func binaryVersions(nameToPath map[string]string) []version.Info {
+	var infos []version.Info
+	for _, path := range nameToPath {
+		infos = append(infos, binaryVersion(path))
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Binary < infos[j].Binary })
+	return infos
+}
+
+// binaryVersion runs the binary at path with version.RequestEnv set, the
+// --help argument makes binaries that don't support it print their usage
+// instead of running
+func binaryVersion(path string) version.Info {
+	name := filepath.Base(path)
+	cmd := exec.Command(path, "--help")
+	cmd.SetEnv(append(os.Environ(), version.RequestEnv+"=1")...)
+	out, err := exec.Output(cmd)
+	if err != nil {
+		return version.Info{Binary: name, Error: err.Error()}
+	}
+	info, err := version.Parse(out)
+	if err != nil {
+		return version.Info{Binary: name, Error: err.Error()}
+	}
+	// report the binary found in PATH, regardless of what it calls itself
+	info.Binary = name
+	return info
+}
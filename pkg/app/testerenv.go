@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// testerEnvOverrideKeys are the variables set for the tester by kubetest2
+// whose values from the environment of kubetest2 are commonly expected to
+// reach the tester
+var testerEnvOverrideKeys = []string{
+	"ARTIFACTS",
+	"KUBECONFIG",
+}
+
+// effectiveEnv resolves "key=value" entries the way exec does when passing
+// them to a process, later entries win over earlier ones
+func effectiveEnv(environ []string) map[string]string {
+	env := map[string]string{}
+	for _, kv := range environ {
+		key, value, found := strings.Cut(kv, "=")
+		if !found {
+			continue
+		}
+		env[key] = value
+	}
+	return env
+}
+
+// testerEnvOverrides returns the keys of testerEnvOverrideKeys whose value in
+// environ is replaced in the effective tester env. Values are path lists, so
+// a KUBECONFIG still containing the existing paths is not an override.
+func testerEnvOverrides(environ []string, testerEnv map[string]string) []string {
+	existing := effectiveEnv(environ)
+	var overrides []string
+	for _, key := range testerEnvOverrideKeys {
+		value, set := existing[key]
+		if !set || value == "" {
+			continue
+		}
+		kept := map[string]bool{}
+		for _, path := range filepath.SplitList(testerEnv[key]) {
+			kept[path] = true
+		}
+		for _, path := range filepath.SplitList(value) {
+			if path != "" && !kept[path] {
+				overrides = append(overrides, key)
+				break
+			}
+		}
+	}
+	sort.Strings(overrides)
+	return overrides
+}
+
+// writeTesterEnvJSON records the allowlisted subset of the effective tester
+// env into tester-env.json in artifactsDir, for debugging which kubeconfig
+// or artifacts dir the tester actually used
+func writeTesterEnvJSON(artifactsDir string, testerEnv map[string]string) error {
+	filtered := map[string]string{}
+	for key, value := range testerEnv {
+		if envAllowed(key) {
+			filtered[key] = value
+		}
+	}
+	data, err := json.MarshalIndent(filtered, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(artifactsDir, "tester-env.json"), data, 0644)
+}
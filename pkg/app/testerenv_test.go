@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTesterEnvOverrides(t *testing.T) {
+	cases := []struct {
+		name      string
+		environ   []string
+		testerEnv []string
+		expected  []string
+	}{
+		{
+			name:      "unset",
+			environ:   []string{"PATH=/bin"},
+			testerEnv: []string{"ARTIFACTS=/a", "KUBECONFIG=/k"},
+		},
+		{
+			name:      "same values",
+			environ:   []string{"ARTIFACTS=/a", "KUBECONFIG=/k"},
+			testerEnv: []string{"ARTIFACTS=/a", "KUBECONFIG=/k"},
+		},
+		{
+			name:      "replaced",
+			environ:   []string{"ARTIFACTS=/logs", "KUBECONFIG=/home/.kube/config"},
+			testerEnv: []string{"ARTIFACTS=/a", "KUBECONFIG=/k"},
+			expected:  []string{"ARTIFACTS", "KUBECONFIG"},
+		},
+		{
+			name:      "prepended kubeconfig",
+			environ:   []string{"KUBECONFIG=/home/.kube/config"},
+			testerEnv: []string{"KUBECONFIG=/k:/home/.kube/config"},
+		},
+		{
+			name:      "later entries win",
+			environ:   []string{"KUBECONFIG=/home/.kube/config"},
+			testerEnv: []string{"KUBECONFIG=/home/.kube/config", "KUBECONFIG=/k"},
+			expected:  []string{"KUBECONFIG"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := testerEnvOverrides(tc.environ, effectiveEnv(tc.testerEnv))
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected overrides %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
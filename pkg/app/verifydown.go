@@ -0,0 +1,53 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/kubetest2/pkg/metadata"
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+const (
+	// leakPolicyWarn records the resources left over by Down as a failed
+	// VerifyDown junit step without failing the run
+	leakPolicyWarn = "warn"
+	// leakPolicyFail fails the run when Down leaves resources over
+	leakPolicyFail = "fail"
+)
+
+// verifyDown runs VerifyDown if the deployer implements it, returning an
+// error listing the resources left over by Down.
+func verifyDown(d types.Deployer) error {
+	dWithVerify, ok := d.(types.DeployerWithVerifyDown)
+	if !ok {
+		return nil
+	}
+	leaks, err := dWithVerify.VerifyDown()
+	if err != nil {
+		return fmt.Errorf("failed to verify down: %w", err)
+	}
+	if len(leaks) == 0 {
+		return nil
+	}
+	return metadata.NewJUnitError(
+		fmt.Errorf("down left over %d resources: %s", len(leaks), strings.Join(leaks, ", ")),
+		strings.Join(leaks, "\n"),
+	)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/pkg/metadata"
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+type fakeDeployerWithVerifyDown struct {
+	fakeDeployer
+	leaks []string
+	err   error
+}
+
+func (f *fakeDeployerWithVerifyDown) VerifyDown() ([]string, error) {
+	return f.leaks, f.err
+}
+
+func TestVerifyDown(t *testing.T) {
+	testCases := []struct {
+		name              string
+		deployer          types.Deployer
+		expectError       bool
+		expectedSystemOut string
+	}{
+		{
+			name:     "deployer without verify down",
+			deployer: &fakeDeployer{},
+		},
+		{
+			name:     "no leaks",
+			deployer: &fakeDeployerWithVerifyDown{},
+		},
+		{
+			name:              "leaks",
+			deployer:          &fakeDeployerWithVerifyDown{leaks: []string{"instance kt2-abc-minion-1", "network kt2-abc"}},
+			expectError:       true,
+			expectedSystemOut: "instance kt2-abc-minion-1\nnetwork kt2-abc",
+		},
+		{
+			name:        "verify error",
+			deployer:    &fakeDeployerWithVerifyDown{err: errors.New("permission denied")},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifyDown(tc.deployer)
+			if tc.expectError && err == nil {
+				t.Fatalf("expected an error but got none")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.expectedSystemOut == "" {
+				return
+			}
+			junitErr, ok := err.(metadata.JUnitError)
+			if !ok {
+				t.Fatalf("expected a junit error but got %T", err)
+			}
+			if junitErr.SystemOut() != tc.expectedSystemOut {
+				t.Errorf("expected system out %q but got %q", tc.expectedSystemOut, junitErr.SystemOut())
+			}
+		})
+	}
+}
@@ -19,7 +19,10 @@ package boskos
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"os"
+	"strconv"
+	"sync"
 	"time"
 
 	"k8s.io/klog/v2"
@@ -30,6 +33,64 @@ import (
 // const (for the run) owner string for consistency between up and down
 var boskosOwner = os.Getenv("JOB_NAME") + "-kubetest2"
 
+const (
+	// heartbeatJitterFactor is the maximum fraction of the interval added to
+	// each heartbeat, so that concurrent runs don't all hit boskos at once
+	heartbeatJitterFactor = 0.1
+	// heartbeatFailureThreshold is the number of consecutive failed heartbeats
+	// after which the resource is considered at risk of being reaped
+	heartbeatFailureThreshold = 3
+)
+
+// HeartbeatMetrics counts the heartbeats sent to boskos by the process
+type HeartbeatMetrics struct {
+	// Sent is the number of heartbeats sent, including the failed ones
+	Sent int
+	// Failed is the number of heartbeats which failed
+	Failed int
+	// MaxConsecutiveFailures is the longest run of failed heartbeats for a
+	// single resource
+	MaxConsecutiveFailures int
+}
+
+// Metadata returns the metrics as entries of the metadata.json of the run
+func (m HeartbeatMetrics) Metadata() map[string]string {
+	return map[string]string{
+		"boskos-heartbeats-sent":                     strconv.Itoa(m.Sent),
+		"boskos-heartbeats-failed":                   strconv.Itoa(m.Failed),
+		"boskos-heartbeats-max-consecutive-failures": strconv.Itoa(m.MaxConsecutiveFailures),
+	}
+}
+
+// metrics are the heartbeat metrics of the process, of both the pools and
+// the resources acquired with Acquire
+var metrics struct {
+	sync.Mutex
+	HeartbeatMetrics
+}
+
+// Metrics returns the heartbeat metrics of the process so far
+func Metrics() HeartbeatMetrics {
+	metrics.Lock()
+	defer metrics.Unlock()
+	return metrics.HeartbeatMetrics
+}
+
+// recordHeartbeat adds a heartbeat to the metrics, consecutiveFailures is
+// the number of consecutive failures of the resource including this one
+func recordHeartbeat(err error, consecutiveFailures int) {
+	metrics.Lock()
+	defer metrics.Unlock()
+	metrics.Sent++
+	if err == nil {
+		return
+	}
+	metrics.Failed++
+	if consecutiveFailures > metrics.MaxConsecutiveFailures {
+		metrics.MaxConsecutiveFailures = consecutiveFailures
+	}
+}
+
 // NewClient creates a boskos client for kubetest2 deployers.
 func NewClient(boskosLocation string) (*client.Client, error) {
 	boskos, err := client.NewClient(
@@ -47,6 +108,13 @@ func NewClient(boskosLocation string) (*client.Client, error) {
 
 // Acquire acquires a resource for the given type and starts a heartbeat goroutine to keep the resource reserved.
 func Acquire(boskosClient *client.Client, resourceType string, timeout, heartbeatInterval time.Duration, heartbeatClose chan struct{}) (*common.Resource, error) {
+	if heartbeatInterval < 0 {
+		return nil, fmt.Errorf("boskos heartbeat interval must not be negative, got %v", heartbeatInterval)
+	}
+	if heartbeatInterval != 0 && heartbeatClose == nil {
+		return nil, fmt.Errorf("a heartbeat close channel is required to send boskos heartbeats")
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
@@ -70,28 +138,99 @@ func Acquire(boskosClient *client.Client, resourceType string, timeout, heartbea
 	return boskosResource, nil
 }
 
+// AcquireVerified acquires a resource like Acquire and runs verify on it before
+// returning it. A resource failing verification is released as dirty, for the
+// janitor to clean it up, and another one is acquired, up to attempts resources.
+func AcquireVerified(boskosClient *client.Client, resourceType string, timeout, heartbeatInterval time.Duration, heartbeatClose chan struct{}, attempts int, verify func(name string) error) (*common.Resource, error) {
+	if attempts < 1 {
+		return nil, fmt.Errorf("boskos verify attempts must be at least 1, got %d", attempts)
+	}
+	if heartbeatInterval != 0 && heartbeatClose == nil {
+		return nil, fmt.Errorf("a heartbeat close channel is required to send boskos heartbeats")
+	}
+
+	var verifyErr error
+	for i := 1; i <= attempts; i++ {
+		// each resource gets its own heartbeat, so that it can be stopped
+		// when the resource is released after a failed verification
+		var attemptClose chan struct{}
+		if heartbeatInterval != 0 {
+			attemptClose = make(chan struct{})
+		}
+		resource, err := Acquire(boskosClient, resourceType, timeout, heartbeatInterval, attemptClose)
+		if err != nil {
+			return nil, err
+		}
+		verifyErr = verify(resource.Name)
+		if verifyErr == nil {
+			if attemptClose != nil {
+				go func() {
+					<-heartbeatClose
+					close(attemptClose)
+				}()
+			}
+			return resource, nil
+		}
+		klog.Warningf("[Boskos] %s failed verification (attempt %d/%d): %v", resource.Name, i, attempts, verifyErr)
+		if attemptClose != nil {
+			close(attemptClose)
+		}
+		if err := boskosClient.Release(resource.Name, "dirty"); err != nil {
+			return nil, fmt.Errorf("failed to release %s: %s", resource.Name, err)
+		}
+	}
+	return nil, fmt.Errorf("no %s passed verification after %d attempts, last error: %w", resourceType, attempts, verifyErr)
+}
+
 // startBoskosHeartbeat starts a goroutine that sends periodic updates to boskos
 // about the provided resource until the channel is closed. This prevents
 // reaper from taking the resource from the deployer while it is still in use.
 func startBoskosHeartbeat(boskosClient *client.Client, resource *common.Resource, interval time.Duration, heartbeatClose chan struct{}) {
 	go func(c *client.Client, resource *common.Resource) {
-		klog.V(2).Info("boskos hearbeat starting")
-
-		for {
-			select {
-			case <-heartbeatClose:
-				klog.V(2).Info("Boskos heartbeat func received signal to close")
-				return
-			case <-time.NewTicker(interval).C:
-				klog.V(2).Info("Sending heartbeat to Boskos")
-				if err := c.UpdateOne(resource.Name, "busy", nil); err != nil {
-					klog.Warningf("[Boskos] Update of %s failed with %v", resource.Name, err)
+		klog.V(2).Infof("Boskos heartbeat starting for %s with interval %v", resource.Name, interval)
+
+		var sent, failed, consecutiveFailures int
+		heartbeatLoop(interval, heartbeatClose, func() {
+			klog.V(2).Info("Sending heartbeat to Boskos")
+			sent++
+			err := c.UpdateOne(resource.Name, "busy", nil)
+			if err != nil {
+				failed++
+				consecutiveFailures++
+				klog.Warningf("[Boskos] Update of %s failed with %v", resource.Name, err)
+				if consecutiveFailures >= heartbeatFailureThreshold {
+					klog.Errorf("[Boskos] %d consecutive heartbeats for %s failed, the resource may be reaped", consecutiveFailures, resource.Name)
 				}
+			} else {
+				consecutiveFailures = 0
 			}
-		}
+			recordHeartbeat(err, consecutiveFailures)
+		})
+		klog.V(2).Infof("Boskos heartbeat for %s received signal to close, sent %d heartbeats, %d failed", resource.Name, sent, failed)
 	}(boskosClient, resource)
 }
 
+// heartbeatLoop calls beat about every interval, with jitter, until
+// heartbeatClose is closed
+func heartbeatLoop(interval time.Duration, heartbeatClose chan struct{}, beat func()) {
+	timer := time.NewTimer(jitter(interval, heartbeatJitterFactor))
+	defer timer.Stop()
+	for {
+		select {
+		case <-heartbeatClose:
+			return
+		case <-timer.C:
+			beat()
+			timer.Reset(jitter(interval, heartbeatJitterFactor))
+		}
+	}
+}
+
+// jitter returns a duration between d and d + maxFactor*d.
+func jitter(d time.Duration, maxFactor float64) time.Duration {
+	return d + time.Duration(rand.Float64()*maxFactor*float64(d))
+}
+
 // Release releases a resource.
 func Release(client *client.Client, resourceNames []string, heartbeatClose chan struct{}) error {
 	for _, name := range resourceNames {
@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boskos
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAcquireVerified(t *testing.T) {
+	testCases := []struct {
+		name             string
+		attempts         int
+		verify           func(name string) error
+		expected         string
+		expectedReleased []string
+		expectError      bool
+	}{
+		{
+			name:     "verified",
+			attempts: 3,
+			verify:   func(string) error { return nil },
+			expected: "project-1",
+		},
+		{
+			name:     "first fails verification",
+			attempts: 3,
+			verify: func(name string) error {
+				if name == "project-1" {
+					return errors.New("compute API disabled")
+				}
+				return nil
+			},
+			expected:         "project-2",
+			expectedReleased: []string{"project-1"},
+		},
+		{
+			name:     "all fail verification",
+			attempts: 2,
+			verify: func(string) error {
+				return errors.New("compute API disabled")
+			},
+			expectedReleased: []string{"project-1", "project-2"},
+			expectError:      true,
+		},
+		{
+			name:        "no attempts",
+			verify:      func(string) error { return nil },
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := &fakeBoskos{free: []string{"project-1", "project-2", "project-3"}, updates: map[string]int{}}
+			server := httptest.NewServer(fake)
+			defer server.Close()
+			boskosClient, err := NewClient(server.URL)
+			if err != nil {
+				t.Fatalf("failed to create the boskos client: %v", err)
+			}
+
+			resource, err := AcquireVerified(boskosClient, "gce-project", time.Minute, 0, nil, tc.attempts, tc.verify)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error but got none")
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if resource.Name != tc.expected {
+				t.Errorf("expected %s but got %s", tc.expected, resource.Name)
+			}
+			if actual := fake.releasedResources(); !reflect.DeepEqual(actual, tc.expectedReleased) {
+				t.Errorf("expected released resources %v but got %v", tc.expectedReleased, actual)
+			}
+		})
+	}
+}
+
+func TestAcquireVerifiedHeartbeat(t *testing.T) {
+	fake := &fakeBoskos{free: []string{"project-1", "project-2"}, updates: map[string]int{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	boskosClient, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("failed to create the boskos client: %v", err)
+	}
+
+	// a heartbeat needs a channel to stop it
+	if _, err := AcquireVerified(boskosClient, "gce-project", time.Minute, time.Millisecond, nil, 1, func(string) error { return nil }); err == nil {
+		t.Error("expected an error without a heartbeat close channel")
+	}
+
+	heartbeatClose := make(chan struct{})
+	resource, err := AcquireVerified(boskosClient, "gce-project", time.Minute, 10*time.Millisecond, heartbeatClose, 1, func(string) error { return nil })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer close(heartbeatClose)
+	deadline := time.Now().Add(10 * time.Second)
+	for fake.updateCount(resource.Name) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("no heartbeat sent for %s", resource.Name)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
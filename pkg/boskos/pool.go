@@ -0,0 +1,235 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boskos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/boskos/client"
+	"sigs.k8s.io/boskos/common"
+)
+
+// Pool holds the boskos resources of a run, e.g. the projects of a deployer,
+// and keeps them reserved with a single heartbeat loop. Resources can be
+// acquired and released at any time during the run, e.g. a second project
+// for a test phase needing a cross-project setup, and the remaining ones are
+// released together at teardown with ReleaseAll.
+type Pool struct {
+	client            *client.Client
+	heartbeatInterval time.Duration
+
+	mu        sync.Mutex
+	resources []string
+	// consecutiveFailures counts the consecutive failed heartbeats per resource
+	consecutiveFailures map[string]int
+	heartbeatClose      chan struct{}
+	released            bool
+}
+
+// pools are the pools created by the process, released together by
+// ReleasePools at the teardown of the run
+var pools struct {
+	sync.Mutex
+	all []*Pool
+}
+
+// NewPool returns an empty Pool acquiring resources with boskosClient. A
+// heartbeatInterval of 0 disables the heartbeats.
+func NewPool(boskosClient *client.Client, heartbeatInterval time.Duration) (*Pool, error) {
+	if heartbeatInterval < 0 {
+		return nil, fmt.Errorf("boskos heartbeat interval must not be negative, got %v", heartbeatInterval)
+	}
+	p := &Pool{
+		client:              boskosClient,
+		heartbeatInterval:   heartbeatInterval,
+		consecutiveFailures: map[string]int{},
+	}
+	pools.Lock()
+	defer pools.Unlock()
+	pools.all = append(pools.all, p)
+	return p, nil
+}
+
+// ReleasePools releases the resources of all the pools created by the
+// process. The runner calls it at teardown, so that resources are not left
+// to expire when the run ends before Down, e.g. when Up fails without --down.
+func ReleasePools() error {
+	pools.Lock()
+	defer pools.Unlock()
+	var errs []error
+	for _, p := range pools.all {
+		if err := p.ReleaseAll(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Acquire acquires a resource of resourceType, waiting up to timeout for one
+// to be free, and adds it to the heartbeats of the pool.
+func (p *Pool) Acquire(resourceType string, timeout time.Duration) (*common.Resource, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resource, err := p.client.AcquireWait(ctx, resourceType, "free", "busy")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a %q from boskos: %s", resourceType, err)
+	}
+	if resource == nil {
+		return nil, fmt.Errorf("boskos had no %s available", resourceType)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.released {
+		// the pool was released while waiting, don't leak the resource
+		if err := p.client.Release(resource.Name, "dirty"); err != nil {
+			klog.Warningf("[Boskos] failed to release %s: %v", resource.Name, err)
+		}
+		return nil, fmt.Errorf("boskos pool was released while acquiring a %s", resourceType)
+	}
+	p.resources = append(p.resources, resource.Name)
+	p.consecutiveFailures[resource.Name] = 0
+	klog.V(2).Infof("Boskos pool acquired %s, holding %d resources", resource.Name, len(p.resources))
+	if p.heartbeatInterval != 0 && p.heartbeatClose == nil {
+		p.heartbeatClose = make(chan struct{})
+		go p.heartbeat(p.heartbeatClose)
+	}
+	return resource, nil
+}
+
+// AcquireVerified acquires a resource like Acquire and runs verify on it before
+// returning it, unless verify is nil. A resource failing verification is
+// released as dirty, for the janitor to clean it up, and another one is
+// acquired, up to attempts resources.
+func (p *Pool) AcquireVerified(resourceType string, timeout time.Duration, attempts int, verify func(name string) error) (*common.Resource, error) {
+	if attempts < 1 {
+		return nil, fmt.Errorf("boskos verify attempts must be at least 1, got %d", attempts)
+	}
+	var verifyErr error
+	for i := 1; i <= attempts; i++ {
+		resource, err := p.Acquire(resourceType, timeout)
+		if err != nil {
+			return nil, err
+		}
+		if verify == nil {
+			return resource, nil
+		}
+		verifyErr = verify(resource.Name)
+		if verifyErr == nil {
+			return resource, nil
+		}
+		klog.Warningf("[Boskos] %s failed verification (attempt %d/%d): %v", resource.Name, i, attempts, verifyErr)
+		if err := p.Release(resource.Name); err != nil {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("no %s passed verification after %d attempts, last error: %w", resourceType, attempts, verifyErr)
+}
+
+// Resources returns the names of the resources held by the pool
+func (p *Pool) Resources() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string{}, p.resources...)
+}
+
+// Release releases a resource of the pool before the end of the run, the
+// other resources are kept.
+func (p *Pool) Release(name string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, resource := range p.resources {
+		if resource != name {
+			continue
+		}
+		if err := p.client.Release(name, "dirty"); err != nil {
+			return fmt.Errorf("failed to release %s: %s", name, err)
+		}
+		p.resources = append(p.resources[:i], p.resources[i+1:]...)
+		delete(p.consecutiveFailures, name)
+		return nil
+	}
+	return fmt.Errorf("%s is not held by the boskos pool", name)
+}
+
+// ReleaseAll releases all the resources of the pool and stops the heartbeats.
+// It is safe to call more than once, resources failing to be released are
+// kept for the next call.
+func (p *Pool) ReleaseAll() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.released = true
+	var errs []error
+	var kept []string
+	for _, name := range p.resources {
+		if err := p.client.Release(name, "dirty"); err != nil {
+			errs = append(errs, fmt.Errorf("failed to release %s: %s", name, err))
+			kept = append(kept, name)
+			continue
+		}
+		delete(p.consecutiveFailures, name)
+	}
+	p.resources = kept
+	if len(kept) == 0 && p.heartbeatClose != nil {
+		close(p.heartbeatClose)
+		p.heartbeatClose = nil
+	}
+	return errors.Join(errs...)
+}
+
+// heartbeat sends periodic updates to boskos about all the resources of the
+// pool until heartbeatClose is closed, like startBoskosHeartbeat does for a
+// single resource
+func (p *Pool) heartbeat(heartbeatClose chan struct{}) {
+	klog.V(2).Infof("Boskos pool heartbeat starting with interval %v", p.heartbeatInterval)
+	heartbeatLoop(p.heartbeatInterval, heartbeatClose, func() {
+		klog.V(2).Infof("Sending heartbeats to Boskos for %v", p.Resources())
+		for _, name := range p.Resources() {
+			p.sendHeartbeat(name)
+		}
+	})
+	klog.V(2).Info("Boskos pool heartbeat received signal to close")
+}
+
+// sendHeartbeat updates a resource, logging consecutive failures
+func (p *Pool) sendHeartbeat(name string) {
+	err := p.client.UpdateOne(name, "busy", nil)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, held := p.consecutiveFailures[name]; !held {
+		// released since the heartbeat started
+		return
+	}
+	if err == nil {
+		p.consecutiveFailures[name] = 0
+		recordHeartbeat(nil, 0)
+		return
+	}
+	p.consecutiveFailures[name]++
+	recordHeartbeat(err, p.consecutiveFailures[name])
+	klog.Warningf("[Boskos] Update of %s failed with %v", name, err)
+	if failures := p.consecutiveFailures[name]; failures >= heartbeatFailureThreshold {
+		klog.Errorf("[Boskos] %d consecutive heartbeats for %s failed, the resource may be reaped", failures, name)
+	}
+}
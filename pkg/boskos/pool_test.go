@@ -0,0 +1,277 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package boskos
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/boskos/client"
+	"sigs.k8s.io/boskos/common"
+)
+
+// fakeBoskos is a boskos server handing out the free resources in order
+type fakeBoskos struct {
+	mu       sync.Mutex
+	free     []string
+	released []string
+	updates  map[string]int
+	// failUpdates fails the heartbeats
+	failUpdates bool
+}
+
+func newFakeBoskos(t *testing.T, free ...string) (*fakeBoskos, *Pool) {
+	t.Helper()
+	fake := &fakeBoskos{free: free, updates: map[string]int{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	boskosClient, err := NewClient(server.URL)
+	if err != nil {
+		t.Fatalf("failed to create the boskos client: %v", err)
+	}
+	pool, err := NewPool(boskosClient, 0)
+	if err != nil {
+		t.Fatalf("failed to create the pool: %v", err)
+	}
+	return fake, pool
+}
+
+func (f *fakeBoskos) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	query := r.URL.Query()
+	switch r.URL.Path {
+	case "/acquire":
+		if len(f.free) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		resource := common.Resource{Name: f.free[0], Type: query.Get("type"), State: query.Get("dest"), Owner: query.Get("owner")}
+		f.free = f.free[1:]
+		_ = json.NewEncoder(w).Encode(resource)
+	case "/update":
+		f.updates[query.Get("name")]++
+		if f.failUpdates {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	case "/release":
+		f.released = append(f.released, query.Get("name"))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeBoskos) releasedResources() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.released...)
+}
+
+func (f *fakeBoskos) updateCount(name string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.updates[name]
+}
+
+// the tests are not parallel, ReleasePools releases the pools of all tests
+
+func TestPoolAcquireRelease(t *testing.T) {
+	fake, pool := newFakeBoskos(t, "project-1", "project-2", "project-3")
+	for _, expected := range []string{"project-1", "project-2"} {
+		resource, err := pool.Acquire("gce-project", time.Minute)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resource.Name != expected {
+			t.Errorf("expected %s but got %s", expected, resource.Name)
+		}
+	}
+	if err := pool.Release("project-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := pool.Release("project-1"); err == nil {
+		t.Errorf("expected an error releasing a resource not held anymore but got none")
+	}
+	if actual := pool.Resources(); !reflect.DeepEqual(actual, []string{"project-2"}) {
+		t.Errorf("expected the pool to hold [project-2] but got %v", actual)
+	}
+	if err := pool.ReleaseAll(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := pool.ReleaseAll(); err != nil {
+		t.Fatalf("unexpected error releasing twice: %v", err)
+	}
+	if actual := pool.Resources(); len(actual) != 0 {
+		t.Errorf("expected the pool to be empty but got %v", actual)
+	}
+
+	// a resource acquired after ReleaseAll is released right away
+	if _, err := pool.Acquire("gce-project", time.Minute); err == nil {
+		t.Errorf("expected an error acquiring from a released pool but got none")
+	}
+	expected := []string{"project-1", "project-2", "project-3"}
+	if actual := fake.releasedResources(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected released resources %v but got %v", expected, actual)
+	}
+}
+
+func TestPoolAcquireVerified(t *testing.T) {
+	testCases := []struct {
+		name             string
+		attempts         int
+		verify           func(name string) error
+		expected         string
+		expectedReleased []string
+		expectError      bool
+	}{
+		{
+			name:     "no verification",
+			attempts: 3,
+			expected: "project-1",
+		},
+		{
+			name:     "first fails verification",
+			attempts: 3,
+			verify: func(name string) error {
+				if name == "project-1" {
+					return errors.New("compute API disabled")
+				}
+				return nil
+			},
+			expected:         "project-2",
+			expectedReleased: []string{"project-1"},
+		},
+		{
+			name:     "all fail verification",
+			attempts: 2,
+			verify: func(string) error {
+				return errors.New("compute API disabled")
+			},
+			expectedReleased: []string{"project-1", "project-2"},
+			expectError:      true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake, pool := newFakeBoskos(t, "project-1", "project-2", "project-3")
+			defer pool.ReleaseAll()
+			resource, err := pool.AcquireVerified("gce-project", time.Minute, tc.attempts, tc.verify)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error but got none")
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if resource.Name != tc.expected {
+				t.Errorf("expected %s but got %s", tc.expected, resource.Name)
+			}
+			if actual := fake.releasedResources(); !reflect.DeepEqual(actual, tc.expectedReleased) {
+				t.Errorf("expected released resources %v but got %v", tc.expectedReleased, actual)
+			}
+		})
+	}
+}
+
+func TestPoolHeartbeat(t *testing.T) {
+	fake, pool := newFakeBoskos(t, "project-1")
+	pool.heartbeatInterval = 10 * time.Millisecond
+	if _, err := pool.Acquire("gce-project", time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for fake.updateCount("project-1") == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("no heartbeat was sent for project-1")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := pool.ReleaseAll(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the heartbeats stop with the release
+	sent := fake.updateCount("project-1")
+	time.Sleep(50 * time.Millisecond)
+	if actual := fake.updateCount("project-1"); actual > sent+1 {
+		t.Errorf("expected the heartbeats to stop after the release, but %d more were sent", actual-sent)
+	}
+}
+
+func TestHeartbeatMetrics(t *testing.T) {
+	defer func(sleep func(time.Duration)) { client.SleepFunc = sleep }(client.SleepFunc)
+	client.SleepFunc = func(time.Duration) {}
+
+	fake, pool := newFakeBoskos(t, "project-1")
+	fake.mu.Lock()
+	fake.failUpdates = true
+	fake.mu.Unlock()
+	pool.heartbeatInterval = 10 * time.Millisecond
+	before := Metrics()
+	if _, err := pool.Acquire("gce-project", time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for Metrics().Failed < before.Failed+2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("no failed heartbeats were counted for project-1")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := pool.ReleaseAll(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	metrics := Metrics()
+	if metrics.Sent < metrics.Failed || metrics.Sent < before.Sent+2 {
+		t.Errorf("expected the failed heartbeats to be counted as sent, got %+v", metrics)
+	}
+	if metrics.MaxConsecutiveFailures < 2 {
+		t.Errorf("expected at least 2 consecutive failures, got %+v", metrics)
+	}
+	expected := map[string]string{
+		"boskos-heartbeats-sent":                     "5",
+		"boskos-heartbeats-failed":                   "3",
+		"boskos-heartbeats-max-consecutive-failures": "2",
+	}
+	if actual := (HeartbeatMetrics{Sent: 5, Failed: 3, MaxConsecutiveFailures: 2}).Metadata(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected metadata %v but got %v", expected, actual)
+	}
+}
+
+func TestReleasePools(t *testing.T) {
+	fake1, pool1 := newFakeBoskos(t, "project-1")
+	fake2, pool2 := newFakeBoskos(t, "project-2")
+	for _, pool := range []*Pool{pool1, pool2} {
+		if _, err := pool.Acquire("gce-project", time.Minute); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := ReleasePools(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual := fake1.releasedResources(); !reflect.DeepEqual(actual, []string{"project-1"}) {
+		t.Errorf("expected project-1 to be released but got %v", actual)
+	}
+	if actual := fake2.releasedResources(); !reflect.DeepEqual(actual, []string{"project-2"}) {
+		t.Errorf("expected project-2 to be released but got %v", actual)
+	}
+}
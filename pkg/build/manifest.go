@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// releaseTarsDirs are the directories, relative to the kubernetes repo root,
+// where the make and bazel builds put the release tarballs
+var releaseTarsDirs = []string{
+	"_output/release-tars",
+	"bazel-bin/build/release-tars",
+}
+
+// releaseImages are the kubernetes images built with the release tarballs
+var releaseImages = []string{
+	"kube-apiserver",
+	"kube-controller-manager",
+	"kube-scheduler",
+	"kube-proxy",
+}
+
+// ReleaseTars returns the paths of the release tarballs built in repoRoot.
+func ReleaseTars(repoRoot string) ([]string, error) {
+	var tars []string
+	for _, dir := range releaseTarsDirs {
+		matches, err := filepath.Glob(filepath.Join(repoRoot, dir, "*.tar.gz"))
+		if err != nil {
+			return nil, err
+		}
+		tars = append(tars, matches...)
+	}
+	return tars, nil
+}
+
+// tarVersionFile is the file in the release tarballs holding the version of
+// the build
+const tarVersionFile = "kubernetes/version"
+
+// ReleaseVersion returns the version of the build recorded in the release
+// tarballs, e.g. for the builds that do not report it like the
+// kubernetes/cloud-provider-gcp one. kubernetes.tar.gz is read first, as it is
+// the smallest of the tarballs carrying the version.
+func ReleaseVersion(tars []string) (string, error) {
+	tars = append([]string(nil), tars...)
+	sort.SliceStable(tars, func(i, j int) bool {
+		return filepath.Base(tars[i]) == "kubernetes.tar.gz" && filepath.Base(tars[j]) != "kubernetes.tar.gz"
+	})
+	for _, tarball := range tars {
+		version, err := tarVersion(tarball)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the version of %s: %w", tarball, err)
+		}
+		if version != "" {
+			return version, nil
+		}
+	}
+	return "", errors.New("no release tarball records the version of the build")
+}
+
+// tarVersion returns the content of tarVersionFile in the gzip compressed
+// tarball, the empty string if it is not in it
+func tarVersion(tarball string) (string, error) {
+	f, err := os.Open(tarball)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return "", err
+	}
+	defer gz.Close()
+	r := tar.NewReader(gz)
+	for {
+		header, err := r.Next()
+		if err == io.EOF {
+			return "", nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if path.Clean(header.Name) != tarVersionFile {
+			continue
+		}
+		version, err := io.ReadAll(r)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(version)), nil
+	}
+}
+
+// stagedImageTags returns the tags of the release images krel pushes to
+// registry when staging version, for each of the space or comma separated
+// platforms, e.g. linux/amd64, like the kubernetes build tags them.
+func stagedImageTags(registry, version, platforms string) []string {
+	// krel stages v prefixed versions, and + is not allowed in docker tags
+	tag := strings.ReplaceAll("v"+strings.TrimPrefix(version, "v"), "+", "_")
+	var tags []string
+	for _, platform := range strings.FieldsFunc(platforms, func(r rune) bool { return r == ' ' || r == ',' }) {
+		arch := platform[strings.LastIndex(platform, "/")+1:]
+		for _, image := range releaseImages {
+			tags = append(tags, fmt.Sprintf("%s/%s-%s:%s", registry, image, arch, tag))
+		}
+	}
+	return tags
+}
+
+// Images returns the images of the build pushed to a registry: the release
+// images krel pushes to --image-location when staging version with the make
+// strategy, and the images pushed to --push-images by Push. The images only
+// tagged locally by the build are not included.
+func (o *Options) Images(version string) []string {
+	var images []string
+	if o.StageLocation != "" && o.ImageLocation != "" && BuildAndStageStrategy(o.Strategy) == MakeStrategy {
+		platforms := o.TargetBuildArch
+		if platforms == "" {
+			platforms = "linux/amd64"
+		}
+		images = append(images, stagedImageTags(o.ImageLocation, version, platforms)...)
+	}
+	return append(images, o.pushedImages...)
+}
+
+// StagedURL returns where Stage uploads version to under stageLocation, the
+// empty string if stageLocation is not set.
+func StagedURL(stageLocation, version string) string {
+	if stageLocation == "" {
+		return ""
+	}
+	return stageLocation + "/v" + strings.TrimPrefix(version, "v")
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeTarball writes a gzip compressed tarball with files at path
+func writeTarball(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	w := tar.NewWriter(gz)
+	for name, content := range files {
+		if err := w.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReleaseVersion(t *testing.T) {
+	dir := t.TempDir()
+	server := filepath.Join(dir, "kubernetes-server-linux-amd64.tar.gz")
+	writeTarball(t, server, map[string]string{"kubernetes/server/bin/kubelet": "elf"})
+	full := filepath.Join(dir, "kubernetes.tar.gz")
+	writeTarball(t, full, map[string]string{"./kubernetes/version": "v1.31.0-alpha.1.5+abc\n"})
+
+	version, err := ReleaseVersion([]string{server, full})
+	if err != nil {
+		t.Fatalf("did not expect an error, but got: %v", err)
+	}
+	if version != "v1.31.0-alpha.1.5+abc" {
+		t.Errorf("expected the version of kubernetes.tar.gz, but got %q", version)
+	}
+
+	if _, err := ReleaseVersion([]string{server}); err == nil {
+		t.Errorf("expected an error without a tarball recording the version")
+	}
+}
+
+func TestImages(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		opts     Options
+		expected []string
+	}{
+		{
+			desc: "not staged",
+			opts: Options{Strategy: string(MakeStrategy), ImageLocation: "gcr.io/p"},
+		},
+		{
+			desc: "staged with make",
+			opts: Options{Strategy: string(MakeStrategy), StageLocation: "gs://b/ci", ImageLocation: "gcr.io/p", TargetBuildArch: "linux/amd64,linux/arm64"},
+			expected: []string{
+				"gcr.io/p/kube-apiserver-amd64:v1.31.0_run-1",
+				"gcr.io/p/kube-controller-manager-amd64:v1.31.0_run-1",
+				"gcr.io/p/kube-scheduler-amd64:v1.31.0_run-1",
+				"gcr.io/p/kube-proxy-amd64:v1.31.0_run-1",
+				"gcr.io/p/kube-apiserver-arm64:v1.31.0_run-1",
+				"gcr.io/p/kube-controller-manager-arm64:v1.31.0_run-1",
+				"gcr.io/p/kube-scheduler-arm64:v1.31.0_run-1",
+				"gcr.io/p/kube-proxy-arm64:v1.31.0_run-1",
+			},
+		},
+		{
+			desc: "staged with bazel",
+			opts: Options{Strategy: string(bazelStrategy), StageLocation: "gs://b/ci", ImageLocation: "gcr.io/p"},
+		},
+		{
+			desc:     "pushed",
+			opts:     Options{Strategy: string(MakeStrategy), PushImages: "gcr.io/p", pushedImages: []string{"gcr.io/p/kube-proxy-amd64:run-1"}},
+			expected: []string{"gcr.io/p/kube-proxy-amd64:run-1"},
+		},
+	}
+	for _, tc := range testCases {
+		if actual := tc.opts.Images("1.31.0+run-1"); !reflect.DeepEqual(actual, tc.expected) {
+			t.Errorf("%s: expected images %v, but got %v", tc.desc, tc.expected, actual)
+		}
+	}
+}
@@ -39,14 +39,44 @@ type Options struct {
 	VersionSuffix      string `flag:"-"`
 	UpdateLatest       bool   `flag:"~update-latest" desc:"Whether should upload the build number to the GCS"`
 	TargetBuildArch    string `flag:"~target-build-arch" desc:"Target architecture for the test artifacts for dockerized build"`
+	PushImages         string `flag:"~push-images" desc:"Registry to push the kubernetes component images built with the make strategy to, tagged with the run id. The registry and tag are exported to the deployer and the tester as $KUBETEST2_IMAGE_REGISTRY and $KUBETEST2_IMAGE_TAG."`
+	PushAgnhost        bool   `flag:"~push-agnhost" desc:"Whether to also build and push the agnhost e2e test image to --push-images, the e2e tests are pointed to it with $KUBE_TEST_REPO_LIST. The other e2e test images are copied to --push-images from registry.k8s.io/e2e-test-images, as the e2e tests pull them all from the same registry."`
 	Builder
 	Stager
+
+	// pushedImages are the references of the images pushed by Push
+	pushedImages []string
 }
 
 func (o *Options) Validate() error {
+	if o.PushAgnhost && o.PushImages == "" {
+		return fmt.Errorf("--push-agnhost requires --push-images")
+	}
+	if o.PushImages != "" && BuildAndStageStrategy(o.Strategy) != MakeStrategy {
+		return fmt.Errorf("--push-images requires the %s strategy", MakeStrategy)
+	}
 	return o.implementationFromStrategy()
 }
 
+// Push pushes the images of the build to --push-images, if set, tagged
+// with runID
+func (o *Options) Push(runID, runDir string) error {
+	if o.PushImages == "" {
+		return nil
+	}
+	pusher := &ImagePusher{
+		RepoRoot: o.RepoRoot,
+		Registry: o.PushImages,
+		Tag:      runID,
+		Agnhost:  o.PushAgnhost,
+		RunDir:   runDir,
+	}
+	err := pusher.Push()
+	// record the images pushed before a failure too
+	o.pushedImages = pusher.Pushed
+	return err
+}
+
 func (o *Options) implementationFromStrategy() error {
 	switch BuildAndStageStrategy(o.Strategy) {
 	case bazelStrategy:
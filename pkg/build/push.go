@@ -0,0 +1,232 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+const (
+	// ImageRegistryEnv is set to the registry the images of the build are
+	// pushed to, for the deployers and testers to use them
+	ImageRegistryEnv = "KUBETEST2_IMAGE_REGISTRY"
+	// ImageTagEnv is set to the tag of the pushed images, the run id
+	ImageTagEnv = "KUBETEST2_IMAGE_TAG"
+	// testRepoListEnv points the e2e tests to the registry of the pushed
+	// agnhost image
+	testRepoListEnv = "KUBE_TEST_REPO_LIST"
+	// e2eImagesRegistry is the registry the e2e tests pull the images built
+	// from test/images from, promoterE2eRegistry in the e2e test repo list
+	e2eImagesRegistry = "registry.k8s.io/e2e-test-images"
+	// e2eImagesDir is the directory, relative to the kubernetes repo root,
+	// of the sources of the e2e test images, each with a VERSION file
+	e2eImagesDir = "test/images"
+)
+
+// releaseImagesDir is the directory, relative to the kubernetes repo root,
+// where the make build saves the images of each architecture as tarballs
+const releaseImagesDir = "_output/release-images"
+
+// ImagePusher pushes the kubernetes component images built by the make
+// build, and optionally the agnhost e2e test image, to a user registry
+type ImagePusher struct {
+	RepoRoot string
+	// Registry is the registry the images are pushed to, e.g. gcr.io/my-project
+	Registry string
+	// Tag is the tag of the pushed component images
+	Tag string
+	// Agnhost also builds and pushes the agnhost image of the repo, tagged
+	// with the version the e2e tests of the repo expect. The other e2e test
+	// images are copied to Registry, the e2e tests pull all of them from it.
+	Agnhost bool
+	// RunDir is where the e2e test repo list is written when Agnhost is set
+	RunDir string
+	// Pushed are the references of the component and e2e test images pushed
+	// by Push, the agnhost image is tagged by the make rules of test/images
+	Pushed []string
+}
+
+// Push pushes the images and exports the registry and tag to the
+// environment of the deployer and of the tester
+func (p *ImagePusher) Push() error {
+	tars, err := filepath.Glob(filepath.Join(p.RepoRoot, releaseImagesDir, "*", "*.tar"))
+	if err != nil {
+		return err
+	}
+	if len(tars) == 0 {
+		return fmt.Errorf("no images found in %s, pushing images requires the make build", filepath.Join(p.RepoRoot, releaseImagesDir))
+	}
+	for _, tar := range tars {
+		if err := p.pushImage(tar); err != nil {
+			return err
+		}
+	}
+	env := map[string]string{
+		ImageRegistryEnv: p.Registry,
+		ImageTagEnv:      p.Tag,
+	}
+
+	if p.Agnhost {
+		klog.Infof("pushing the agnhost image to %s", p.Registry)
+		cmd := exec.Command("make", "-C", "test/images", "all-push", "WHAT=agnhost", "REGISTRY="+p.Registry)
+		cmd.SetDir(p.RepoRoot)
+		exec.InheritOutput(cmd)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to push the agnhost image: %w", err)
+		}
+		if err := p.copyE2eImages(); err != nil {
+			return err
+		}
+		repoList := filepath.Join(p.RunDir, "image-repo-list.yaml")
+		if err := os.WriteFile(repoList, []byte(testRepoList(p.Registry)), 0644); err != nil {
+			return fmt.Errorf("failed to write the e2e test repo list: %w", err)
+		}
+		env[testRepoListEnv] = repoList
+	}
+
+	for name, value := range env {
+		klog.Infof("exporting %s=%s", name, value)
+		if err := os.Setenv(name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pushImage loads the image saved in tar, tags it for the registry and
+// pushes it
+func (p *ImagePusher) pushImage(tar string) error {
+	lines, err := exec.OutputLines(exec.Command("docker", "load", "--input", tar))
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", tar, err)
+	}
+	loaded, err := loadedImage(lines)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", tar, err)
+	}
+	ref := PushedImageRef(loaded, p.Registry, p.Tag)
+	klog.Infof("pushing %s as %s", loaded, ref)
+	if err := exec.Command("docker", "tag", loaded, ref).Run(); err != nil {
+		return fmt.Errorf("failed to tag %s: %w", loaded, err)
+	}
+	push := exec.Command("docker", "push", ref)
+	exec.InheritOutput(push)
+	if err := push.Run(); err != nil {
+		return fmt.Errorf("failed to push %s: %w", ref, err)
+	}
+	p.Pushed = append(p.Pushed, ref)
+	return nil
+}
+
+// loadedImage returns the image reported by docker load
+func loadedImage(lines []string) (string, error) {
+	for _, line := range lines {
+		if image, found := strings.CutPrefix(line, "Loaded image: "); found {
+			return strings.TrimSpace(image), nil
+		}
+	}
+	return "", fmt.Errorf("no loaded image in the output of docker load: %q", strings.Join(lines, "\n"))
+}
+
+// PushedImageRef returns the reference image is pushed as to registry, the
+// name of the image, e.g. kube-proxy-amd64, with tag
+func PushedImageRef(image, registry, tag string) string {
+	name := image[strings.LastIndex(image, "/")+1:]
+	if i := strings.LastIndex(name, ":"); i != -1 {
+		name = name[:i]
+	}
+	return fmt.Sprintf("%s/%s:%s", registry, name, tag)
+}
+
+// copyE2eImages copies the e2e test images other than agnhost from
+// e2eImagesRegistry to the registry, as the e2e test repo list can only
+// point all of them to the registry of the pushed agnhost image
+func (p *ImagePusher) copyE2eImages() error {
+	images, err := e2eImages(p.RepoRoot)
+	if err != nil {
+		return err
+	}
+	klog.Infof("copying %d e2e test images from %s to %s", len(images), e2eImagesRegistry, p.Registry)
+	for _, image := range images {
+		src := e2eImagesRegistry + "/" + image
+		if err := exec.Command("docker", "pull", src).Run(); err != nil {
+			// not every image of test/images is published, e.g. those of
+			// unreleased versions
+			klog.Warningf("failed to pull %s, the e2e tests using it will fail to pull it from %s: %v", src, p.Registry, err)
+			continue
+		}
+		dst := p.Registry + "/" + image
+		if err := exec.Command("docker", "tag", src, dst).Run(); err != nil {
+			return fmt.Errorf("failed to tag %s: %w", src, err)
+		}
+		push := exec.Command("docker", "push", dst)
+		exec.InheritOutput(push)
+		if err := push.Run(); err != nil {
+			return fmt.Errorf("failed to push %s: %w", dst, err)
+		}
+		p.Pushed = append(p.Pushed, dst)
+	}
+	return nil
+}
+
+// e2eImages returns the e2e test images of the repo at repoRoot other than
+// agnhost, the directories under e2eImagesDir with a VERSION file, as
+// name:version, e.g. volume/nfs:1.4
+func e2eImages(repoRoot string) ([]string, error) {
+	imagesDir := filepath.Join(repoRoot, e2eImagesDir)
+	var images []string
+	err := filepath.WalkDir(imagesDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || entry.Name() != "VERSION" {
+			return nil
+		}
+		name, err := filepath.Rel(imagesDir, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		if name == "agnhost" || name == "." {
+			return nil
+		}
+		version, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		images = append(images, filepath.ToSlash(name)+":"+strings.TrimSpace(string(version)))
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("no e2e test images found in %s: %w", imagesDir, err)
+	}
+	return images, err
+}
+
+// testRepoList returns an e2e test repo list pulling the e2e test images
+// like agnhost from registry
+func testRepoList(registry string) string {
+	return fmt.Sprintf("promoterE2eRegistry: %s\n", registry)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestPushedImageRef(t *testing.T) {
+	testCases := []struct {
+		image    string
+		expected string
+	}{
+		{image: "registry.k8s.io/kube-proxy-amd64:v1.30.0", expected: "gcr.io/my-project/kube-proxy-amd64:run-1"},
+		{image: "kube-apiserver-arm64:v1.30.0-alpha.1", expected: "gcr.io/my-project/kube-apiserver-arm64:run-1"},
+		{image: "localhost:5000/kube-scheduler-amd64", expected: "gcr.io/my-project/kube-scheduler-amd64:run-1"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.image, func(t *testing.T) {
+			if actual := PushedImageRef(tc.image, "gcr.io/my-project", "run-1"); actual != tc.expected {
+				t.Errorf("expected %s, but got %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestLoadedImage(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		lines       []string
+		expected    string
+		expectError bool
+	}{
+		{
+			desc:     "loaded image",
+			lines:    []string{"abc123: Loading layer 10MB/10MB", "Loaded image: registry.k8s.io/kube-proxy-amd64:v1.30.0 "},
+			expected: "registry.k8s.io/kube-proxy-amd64:v1.30.0",
+		},
+		{
+			desc:        "loaded image ID only",
+			lines:       []string{"Loaded image ID: sha256:abc123"},
+			expectError: true,
+		},
+		{
+			desc:        "no output",
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			actual, err := loadedImage(tc.lines)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error, but got image %s", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect an error, but got: %v", err)
+			}
+			if actual != tc.expected {
+				t.Errorf("expected %s, but got %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestTestRepoList(t *testing.T) {
+	expected := "promoterE2eRegistry: gcr.io/my-project\n"
+	if actual := testRepoList("gcr.io/my-project"); actual != expected {
+		t.Errorf("expected %q, but got %q", expected, actual)
+	}
+}
+
+func TestE2eImages(t *testing.T) {
+	repoRoot := t.TempDir()
+	for name, version := range map[string]string{
+		"agnhost":    "2.47\n",
+		"busybox":    "1.36.1-1\n",
+		"volume/nfs": "1.4",
+	} {
+		dir := filepath.Join(repoRoot, e2eImagesDir, name)
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "VERSION"), []byte(version), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(repoRoot, e2eImagesDir, "busybox", "Dockerfile"), []byte("FROM scratch"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	images, err := e2eImages(repoRoot)
+	if err != nil {
+		t.Fatalf("did not expect an error, but got: %v", err)
+	}
+	sort.Strings(images)
+	expected := []string{"busybox:1.36.1-1", "volume/nfs:1.4"}
+	if !reflect.DeepEqual(images, expected) {
+		t.Errorf("expected images %v, but got %v", expected, images)
+	}
+
+	if _, err := e2eImages(t.TempDir()); err == nil {
+		t.Error("expected an error without test/images, but got none")
+	}
+}
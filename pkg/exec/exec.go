@@ -46,10 +46,14 @@ type Cmder interface {
 
 // DefaultCmder is a LocalCmder instance used for convenience, packages
 // originally using os/exec.Command can instead use pkg/kind/exec.Command
-// which forwards to this instance
-// TODO(bentheelder): swap this for testing
+// which forwards to this instance. Code that needs to be tested without
+// running commands should take a Cmder instead, e.g. a FakeCmder in tests.
+//
+// DefaultCmder used to be declared as a *LocalCmder, it is a Cmder so that
+// tests can swap it. Code using it as a *LocalCmder has to assert the type,
+// e.g. DefaultCmder.(*LocalCmder), and handle other Cmders.
 // TODO(bentheelder): consider not using a global for this :^)
-var DefaultCmder = &LocalCmder{}
+var DefaultCmder Cmder = &LocalCmder{}
 
 // Command is a convenience wrapper over DefaultCmder.Command
 func Command(command string, args ...string) Cmd {
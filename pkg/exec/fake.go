@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+)
+
+// FakeCmder is a Cmder for tests, it records the commands instead of
+// running them and answers them with the first matching FakeResponse
+type FakeCmder struct {
+	// Responses are matched in order against the command lines, commands
+	// without a match succeed without output
+	Responses []FakeResponse
+
+	mu    sync.Mutex
+	calls []FakeCall
+}
+
+// FakeCall is a command run through a FakeCmder
+type FakeCall struct {
+	// Line is the command and its args joined by spaces
+	Line string
+	// Env is the environment set with SetEnv, nil if it was not set
+	Env []string
+	// Dir is the working directory set with SetDir
+	Dir string
+}
+
+// FakeResponse is the result of the commands whose command line, the
+// command and its args joined by spaces, starts with Prefix
+type FakeResponse struct {
+	Prefix string
+	Stdout string
+	Err    error
+}
+
+var _ Cmder = &FakeCmder{}
+
+// Command returns a new FakeCmd
+func (c *FakeCmder) Command(name string, arg ...string) Cmd {
+	return &FakeCmd{
+		cmder: c,
+		line:  strings.Join(append([]string{name}, arg...), " "),
+	}
+}
+
+// CommandContext returns a new FakeCmd, the context is ignored
+func (c *FakeCmder) CommandContext(_ context.Context, name string, arg ...string) Cmd {
+	return c.Command(name, arg...)
+}
+
+// Commands returns the command lines run so far, in order
+func (c *FakeCmder) Commands() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	commands := []string{}
+	for _, call := range c.calls {
+		commands = append(commands, call.Line)
+	}
+	return commands
+}
+
+// Calls returns the commands run so far with their environment and working
+// directory, in order
+func (c *FakeCmder) Calls() []FakeCall {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]FakeCall{}, c.calls...)
+}
+
+func (c *FakeCmder) run(call FakeCall) FakeResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, call)
+	for _, r := range c.Responses {
+		if strings.HasPrefix(call.Line, r.Prefix) {
+			return r
+		}
+	}
+	return FakeResponse{}
+}
+
+// FakeCmd is the Cmd of a FakeCmder
+type FakeCmd struct {
+	cmder  *FakeCmder
+	line   string
+	env    []string
+	dir    string
+	stdout io.Writer
+}
+
+var _ Cmd = &FakeCmd{}
+
+// Run records the command and writes the stdout of its response
+func (cmd *FakeCmd) Run() error {
+	r := cmd.cmder.run(FakeCall{Line: cmd.line, Env: cmd.env, Dir: cmd.dir})
+	if cmd.stdout != nil && r.Stdout != "" {
+		if _, err := io.WriteString(cmd.stdout, r.Stdout); err != nil {
+			return err
+		}
+	}
+	return r.Err
+}
+
+// SetEnv sets the environment recorded with the command
+func (cmd *FakeCmd) SetEnv(env ...string) Cmd {
+	cmd.env = env
+	return cmd
+}
+
+// SetStdin is a no-op
+func (cmd *FakeCmd) SetStdin(io.Reader) Cmd {
+	return cmd
+}
+
+// SetStdout sets stdout
+func (cmd *FakeCmd) SetStdout(w io.Writer) Cmd {
+	cmd.stdout = w
+	return cmd
+}
+
+// SetStderr is a no-op
+func (cmd *FakeCmd) SetStderr(io.Writer) Cmd {
+	return cmd
+}
+
+// SetDir sets the working directory recorded with the command
+func (cmd *FakeCmd) SetDir(dir string) Cmd {
+	cmd.dir = dir
+	return cmd
+}
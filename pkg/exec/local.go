@@ -18,22 +18,33 @@ package exec
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"os"
 	osexec "os/exec"
 	"strings"
+	"time"
 
+	"golang.org/x/term"
 	"k8s.io/klog/v2"
 )
 
 // LocalCmd wraps os/exec.Cmd, implementing the exec.Cmd interface
 type LocalCmd struct {
 	*osexec.Cmd
+	// recorder, if set, records the output of the command, see Run
+	recorder *OutputRecorder
 }
 
 var _ Cmd = &LocalCmd{}
 
 // LocalCmder is a factory for LocalCmd, implementing Cmder
-type LocalCmder struct{}
+type LocalCmder struct {
+	// Recorder, if set, records the output of the commands created by the
+	// LocalCmder, see LocalCmd.Run
+	Recorder *OutputRecorder
+}
 
 var _ Cmder = &LocalCmder{}
 
@@ -41,7 +52,8 @@ var _ Cmder = &LocalCmder{}
 func (c *LocalCmder) Command(name string, arg ...string) Cmd {
 	klog.V(2).Infof("⚙️ %s %s", name, strings.Join(arg, " "))
 	return &LocalCmd{
-		Cmd: osexec.Command(name, arg...),
+		Cmd:      osexec.Command(name, arg...),
+		recorder: c.Recorder,
 	}
 }
 
@@ -49,7 +61,8 @@ func (c *LocalCmder) Command(name string, arg ...string) Cmd {
 func (c *LocalCmder) CommandContext(ctx context.Context, name string, arg ...string) Cmd {
 	klog.V(2).Infof("⚙️ %s %s", name, strings.Join(arg, " "))
 	return &LocalCmd{
-		Cmd: osexec.CommandContext(ctx, name, arg...),
+		Cmd:      osexec.CommandContext(ctx, name, arg...),
+		recorder: c.Recorder,
 	}
 }
 
@@ -82,7 +95,51 @@ func (cmd *LocalCmd) SetDir(dir string) Cmd {
 	return cmd
 }
 
-// Run runs
+// Run runs. If the command has a recorder, its stdout and stderr are copied
+// to the recorder when they are inherited from kubetest2, see InheritOutput,
+// and are not a terminal, which the command keeps using directly for colors
+// and progress bars. Output captured by the caller, e.g. with Output, may
+// hold credentials like kubeconfigs and is never recorded.
 func (cmd *LocalCmd) Run() error {
-	return cmd.Cmd.Run()
+	recordStdout, recordStderr := cmd.records(cmd.Stdout), cmd.records(cmd.Stderr)
+	if !recordStdout && !recordStderr {
+		return cmd.Cmd.Run()
+	}
+	if recordStdout {
+		cmd.Stdout = io.MultiWriter(cmd.Stdout, cmd.recorder)
+	}
+	if recordStderr {
+		cmd.Stderr = io.MultiWriter(cmd.Stderr, cmd.recorder)
+	}
+	if cmd.WaitDelay == 0 {
+		// copying the output no longer happens in the child process itself,
+		// don't wait forever on background processes holding it open
+		cmd.WaitDelay = recordWaitDelay
+	}
+	err := cmd.Cmd.Run()
+	if errors.Is(err, osexec.ErrWaitDelay) {
+		// the command itself succeeded, but its output may be incomplete
+		return fmt.Errorf("%s exited but its output was still open after %v, likely held by a background process: %w",
+			cmd.Path, cmd.WaitDelay, err)
+	}
+	return err
+}
+
+// recordWaitDelay is how long Run waits for the output of a command to be
+// closed after it exits while recording output
+const recordWaitDelay = 10 * time.Second
+
+// isTerminal returns true if f is a terminal, it is a var for tests
+var isTerminal = func(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// records returns true if the output of the command written to w is
+// recorded, w must be the stdout or stderr of kubetest2 and not a terminal
+func (cmd *LocalCmd) records(w io.Writer) bool {
+	if cmd.recorder == nil {
+		return false
+	}
+	f, ok := w.(*os.File)
+	return ok && (f == os.Stdout || f == os.Stderr) && !isTerminal(f)
 }
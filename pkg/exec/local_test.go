@@ -0,0 +1,95 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	osexec "os/exec"
+	"testing"
+	"time"
+)
+
+func TestLocalCmdRecordsOutput(t *testing.T) {
+	defer func(f func(*os.File) bool) { isTerminal = f }(isTerminal)
+
+	testCases := []struct {
+		name             string
+		record           bool
+		terminal         bool
+		captured         bool
+		expectedRecorded string
+	}{
+		{
+			name:             "inherited output",
+			record:           true,
+			expectedRecorded: "out\nerr\n",
+		},
+		{
+			name:     "inherited output is a terminal",
+			record:   true,
+			terminal: true,
+		},
+		{
+			name:     "output captured by the caller",
+			record:   true,
+			captured: true,
+		},
+		{
+			name: "no recorder",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			isTerminal = func(*os.File) bool { return tc.terminal }
+			recorder := NewOutputRecorder(1024)
+			cmder := &LocalCmder{}
+			if tc.record {
+				cmder.Recorder = recorder
+			}
+			cmd := cmder.Command("sh", "-c", "echo out; sleep 0.1; echo err >&2")
+			InheritOutput(cmd)
+			var captured bytes.Buffer
+			if tc.captured {
+				SetOutput(cmd, &captured, &captured)
+			}
+			if err := cmd.Run(); err != nil {
+				t.Fatalf("did not expect an error, but got: %v", err)
+			}
+			if recorded := recorder.Since(0); recorded != tc.expectedRecorded {
+				t.Errorf("expected recorded output %q but got %q", tc.expectedRecorded, recorded)
+			}
+		})
+	}
+}
+
+func TestLocalCmdOutputHeldOpen(t *testing.T) {
+	defer func(f func(*os.File) bool) { isTerminal = f }(isTerminal)
+	isTerminal = func(*os.File) bool { return false }
+
+	cmder := &LocalCmder{Recorder: NewOutputRecorder(1024)}
+	// the background sleep keeps the output open after sh exits
+	cmd := cmder.Command("sh", "-c", "sleep 2 &")
+	cmd.(*LocalCmd).WaitDelay = 100 * time.Millisecond
+	InheritOutput(cmd)
+	err := cmd.Run()
+	if !errors.Is(err, osexec.ErrWaitDelay) {
+		t.Errorf("expected %v but got: %v", osexec.ErrWaitDelay, err)
+	}
+}
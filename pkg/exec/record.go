@@ -0,0 +1,70 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exec
+
+import (
+	"sync"
+)
+
+// OutputRecorder keeps the most recent output of the commands created by a
+// LocalCmder with the OutputRecorder as its Recorder, so that failures can be
+// reported with the output that led to them. It is safe for concurrent use.
+type OutputRecorder struct {
+	mu      sync.Mutex
+	size    int
+	written int64
+	buf     []byte
+}
+
+// NewOutputRecorder returns an OutputRecorder keeping the last size bytes
+func NewOutputRecorder(size int) *OutputRecorder {
+	return &OutputRecorder{size: size}
+}
+
+// Write implements io.Writer
+func (r *OutputRecorder) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.written += int64(len(p))
+	r.buf = append(r.buf, p...)
+	if len(r.buf) > r.size {
+		r.buf = append(r.buf[:0], r.buf[len(r.buf)-r.size:]...)
+	}
+	return len(p), nil
+}
+
+// Written returns the number of bytes recorded so far, to be passed to Since
+func (r *OutputRecorder) Written() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.written
+}
+
+// Since returns the output recorded after Written returned offset, limited to
+// the last size bytes. Output of commands running concurrently is interleaved.
+func (r *OutputRecorder) Since(offset int64) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := r.written - offset
+	if n > int64(len(r.buf)) {
+		n = int64(len(r.buf))
+	}
+	if n <= 0 {
+		return ""
+	}
+	return string(r.buf[int64(len(r.buf))-n:])
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package flagdump reports the flags of the kubetest2 binaries in a machine
+// readable form, for shell completion
+package flagdump
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+
+	"github.com/spf13/pflag"
+)
+
+// RequestEnv is set by `kubetest2 __complete-words` when it runs the deployers and
+// testers, which then print their Flags as JSON instead of running
+const RequestEnv = "KUBETEST2_FLAGS_REQUEST"
+
+// Flag is a flag accepted by a kubetest2 binary
+type Flag struct {
+	Name      string `json:"name"`
+	Shorthand string `json:"shorthand,omitempty"`
+}
+
+// Get returns the visible flags in fs, sorted by name
+func Get(fs *pflag.FlagSet) []Flag {
+	var flags []Flag
+	fs.VisitAll(func(f *pflag.Flag) {
+		if f.Hidden {
+			return
+		}
+		flags = append(flags, Flag{Name: f.Name, Shorthand: f.Shorthand})
+	})
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags
+}
+
+// Requested returns true if the binary was run by `kubetest2 __complete-words`
+func Requested() bool {
+	return os.Getenv(RequestEnv) != ""
+}
+
+// Print writes flags to w as JSON, as expected by `kubetest2 __complete-words`
+func Print(w io.Writer, flags []Flag) error {
+	return json.NewEncoder(w).Encode(flags)
+}
+
+// PrintIfRequested prints the flags in fs to stdout and exits if the binary
+// was run by `kubetest2 __complete-words`, for the tester entrypoints
+func PrintIfRequested(fs *pflag.FlagSet) {
+	if !Requested() {
+		return
+	}
+	if err := Print(os.Stdout, Get(fs)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// usageFlagRe matches the flags listed by pflag's PrintDefaults
+var usageFlagRe = regexp.MustCompile(`(?m)^\s+(?:-(\w), )?--([\w.-]+)`)
+
+// Parse parses the output of a binary run by `kubetest2 __complete-words`,
+// falling back to the flags listed in the usage printed by binaries which
+// don't know about RequestEnv
+func Parse(output []byte) []Flag {
+	var flags []Flag
+	if err := json.Unmarshal(output, &flags); err == nil {
+		return flags
+	}
+	for _, m := range usageFlagRe.FindAllSubmatch(output, -1) {
+		flags = append(flags, Flag{Name: string(m[2]), Shorthand: string(m[1])})
+	}
+	return flags
+}
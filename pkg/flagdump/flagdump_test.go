@@ -0,0 +1,57 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flagdump
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+func TestPrintParse(t *testing.T) {
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	fs.String("test", "", "")
+	fs.BoolP("help", "h", false, "")
+	fs.Bool("internal", false, "")
+	_ = fs.MarkHidden("internal")
+
+	var buf bytes.Buffer
+	if err := Print(&buf, Get(fs)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Flag{{Name: "help", Shorthand: "h"}, {Name: "test"}}
+	if flags := Parse(buf.Bytes()); !reflect.DeepEqual(flags, expected) {
+		t.Errorf("expected %#v but got %#v", expected, flags)
+	}
+}
+
+func TestParseUsage(t *testing.T) {
+	// binaries which don't know about RequestEnv print their usage
+	usage := `Usage:
+  kubetest2-tester-foo [flags]
+
+      --focus-regex string   Regular expression of jobs to focus on.
+  -h, --help                 help for foo
+      --parallel int         Number of parallel test runs. (default 1)
+`
+	expected := []Flag{{Name: "focus-regex"}, {Name: "help", Shorthand: "h"}, {Name: "parallel"}}
+	if flags := Parse([]byte(usage)); !reflect.DeepEqual(flags, expected) {
+		t.Errorf("expected %#v but got %#v", expected, flags)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/boskos/client"
+	"sigs.k8s.io/boskos/common"
+
+	"sigs.k8s.io/kubetest2/pkg/boskos"
+)
+
+// AcquireProject acquires a GCP project of the given resource type from the boskos
+// server at boskosLocation, and keeps it reserved until heartbeatClose is closed.
+// It returns the boskos client needed to release the project, and the project name.
+func AcquireProject(boskosLocation, resourceType string, acquireTimeout, heartbeatInterval time.Duration, heartbeatClose chan struct{}) (*client.Client, string, error) {
+	return AcquireVerifiedProject(boskosLocation, resourceType, acquireTimeout, heartbeatInterval, heartbeatClose, nil, 1)
+}
+
+// AcquireVerifiedProject is like AcquireProject, but if verification is not nil
+// the project is verified before use, and projects failing the verification are
+// released and replaced, up to attempts projects.
+func AcquireVerifiedProject(boskosLocation, resourceType string, acquireTimeout, heartbeatInterval time.Duration, heartbeatClose chan struct{}, verification *ProjectVerification, attempts int) (*client.Client, string, error) {
+	boskosClient, err := boskos.NewClient(boskosLocation)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to make boskos client: %s", err)
+	}
+
+	var resource *common.Resource
+	if verification == nil {
+		resource, err = boskos.Acquire(
+			boskosClient,
+			resourceType,
+			acquireTimeout,
+			heartbeatInterval,
+			heartbeatClose,
+		)
+	} else {
+		resource, err = boskos.AcquireVerified(
+			boskosClient,
+			resourceType,
+			acquireTimeout,
+			heartbeatInterval,
+			heartbeatClose,
+			attempts,
+			verification.Verify,
+		)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get project from boskos: %s", err)
+	}
+	return boskosClient, resource.Name, nil
+}
+
+// AcquirePoolProject acquires a GCP project of the given resource type into
+// pool, which keeps it reserved with the other resources of the run until
+// it is released. If verification is not nil the project is verified before
+// use, and projects failing the verification are released and replaced, up
+// to attempts projects.
+func AcquirePoolProject(pool *boskos.Pool, resourceType string, acquireTimeout time.Duration, verification *ProjectVerification, attempts int) (string, error) {
+	var verify func(name string) error
+	if verification != nil {
+		verify = verification.Verify
+	} else {
+		attempts = 1
+	}
+	resource, err := pool.AcquireVerified(resourceType, acquireTimeout, attempts, verify)
+	if err != nil {
+		return "", fmt.Errorf("failed to get project from boskos: %s", err)
+	}
+	return resource.Name, nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gcp contains gcloud helpers shared by the GCP based deployers and testers.
+package gcp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// ProjectNumber returns the project number of the given project ID.
+func ProjectNumber(project string) (string, error) {
+	out, err := exec.Output(exec.Command("gcloud", "projects", "describe", project,
+		"--format=value(projectNumber)"))
+	if err != nil {
+		return "", fmt.Errorf("failed to get the project number of %s: %w", project, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// EnableServices enables the given services, e.g. compute.googleapis.com, in
+// the project. Enabling an already enabled service is a relatively fast no-op.
+func EnableServices(project string, services ...string) error {
+	args := append([]string{"services", "enable"}, services...)
+	cmd := exec.Command("gcloud", append(args, "--project="+project)...)
+	exec.InheritOutput(cmd)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to enable %s in project %s: %w", strings.Join(services, ","), project, err)
+	}
+	return nil
+}
+
+// ActivateServiceAccount activates the service account with the given key
+// file for gcloud, it does nothing if keyFile is empty.
+func ActivateServiceAccount(keyFile string) error {
+	if keyFile == "" {
+		return nil
+	}
+	cmd := exec.Command("gcloud", "auth", "activate-service-account", "--key-file="+keyFile)
+	exec.InheritOutput(cmd)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to activate service account from %s: %w", keyFile, err)
+	}
+	return nil
+}
+
+// ApplicationDefaultCredentialsFile returns the path of the application
+// default credentials, GOOGLE_APPLICATION_CREDENTIALS or else the file written
+// by gcloud auth application-default login in $CLOUDSDK_CONFIG, which
+// defaults to $HOME/.config/gcloud, and an error if it doesn't exist.
+func ApplicationDefaultCredentialsFile() (string, error) {
+	path := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	if path == "" {
+		configDir := os.Getenv("CLOUDSDK_CONFIG")
+		if configDir == "" {
+			// gcloud ignores $XDG_CONFIG_HOME, unlike os.UserConfigDir
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", fmt.Errorf("failed to find the application default credentials: %w", err)
+			}
+			configDir = filepath.Join(home, ".config", "gcloud")
+		}
+		path = filepath.Join(configDir, "application_default_credentials.json")
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("failed to find the application default credentials: %w", err)
+	}
+	return path, nil
+}
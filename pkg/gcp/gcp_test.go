@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// fakeGcloud answers the commands run through exec.DefaultCmder with
+// responses until the end of the test, the tests using it can't be parallel
+func fakeGcloud(t *testing.T, responses ...exec.FakeResponse) *exec.FakeCmder {
+	cmder := &exec.FakeCmder{Responses: responses}
+	defaultCmder := exec.DefaultCmder
+	exec.DefaultCmder = cmder
+	t.Cleanup(func() { exec.DefaultCmder = defaultCmder })
+	return cmder
+}
+
+func TestProjectNumber(t *testing.T) {
+	describe := "gcloud projects describe p --format=value(projectNumber)"
+	testCases := []struct {
+		name        string
+		response    exec.FakeResponse
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "found",
+			response: exec.FakeResponse{Prefix: describe, Stdout: "123456789\n"},
+			expected: "123456789",
+		},
+		{
+			name:        "describe failed",
+			response:    exec.FakeResponse{Prefix: describe, Err: errors.New("exit status 1")},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := fakeGcloud(t, tc.response)
+			actual, err := ProjectNumber("p")
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error but got %q", actual)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if actual != tc.expected {
+				t.Errorf("expected %q but got %q", tc.expected, actual)
+			}
+			if commands := cmder.Commands(); !reflect.DeepEqual(commands, []string{describe}) {
+				t.Errorf("expected commands %v but got %v", []string{describe}, commands)
+			}
+		})
+	}
+}
+
+func TestEnableServices(t *testing.T) {
+	enable := "gcloud services enable compute.googleapis.com container.googleapis.com --project=p"
+	cmder := fakeGcloud(t)
+	if err := EnableServices("p", "compute.googleapis.com", "container.googleapis.com"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if commands := cmder.Commands(); !reflect.DeepEqual(commands, []string{enable}) {
+		t.Errorf("expected commands %v but got %v", []string{enable}, commands)
+	}
+
+	fakeGcloud(t, exec.FakeResponse{Prefix: enable, Err: errors.New("exit status 1")})
+	if err := EnableServices("p", "compute.googleapis.com", "container.googleapis.com"); err == nil {
+		t.Errorf("expected an error but got none")
+	}
+}
+
+func TestActivateServiceAccount(t *testing.T) {
+	testCases := []struct {
+		name     string
+		keyFile  string
+		expected []string
+	}{
+		{
+			name:     "key file",
+			keyFile:  "/etc/key.json",
+			expected: []string{"gcloud auth activate-service-account --key-file=/etc/key.json"},
+		},
+		{
+			name:     "no key file",
+			expected: []string{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := fakeGcloud(t)
+			if err := ActivateServiceAccount(tc.keyFile); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if commands := cmder.Commands(); !reflect.DeepEqual(commands, tc.expected) {
+				t.Errorf("expected commands %v but got %v", tc.expected, commands)
+			}
+		})
+	}
+}
+
+func TestApplicationDefaultCredentialsFile(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key.json")
+	gcloudADC := filepath.Join(dir, "application_default_credentials.json")
+	home := t.TempDir()
+	homeADC := filepath.Join(home, ".config", "gcloud", "application_default_credentials.json")
+	if err := os.MkdirAll(filepath.Dir(homeADC), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{keyFile, gcloudADC, homeADC} {
+		if err := os.WriteFile(path, []byte("{}"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := []struct {
+		name              string
+		googleCredentials string
+		cloudsdkConfig    string
+		home              string
+		expected          string
+		expectError       bool
+	}{
+		{
+			name:              "GOOGLE_APPLICATION_CREDENTIALS",
+			googleCredentials: keyFile,
+			cloudsdkConfig:    dir,
+			expected:          keyFile,
+		},
+		{
+			name:           "gcloud application default credentials",
+			cloudsdkConfig: dir,
+			expected:       gcloudADC,
+		},
+		{
+			name:     "gcloud config dir in the home dir",
+			home:     home,
+			expected: homeADC,
+		},
+		{
+			name:           "CLOUDSDK_CONFIG takes precedence over the home dir",
+			cloudsdkConfig: dir,
+			home:           home,
+			expected:       gcloudADC,
+		},
+		{
+			name:        "no credentials in the home dir",
+			home:        dir,
+			expectError: true,
+		},
+		{
+			name:              "missing file",
+			googleCredentials: filepath.Join(dir, "missing.json"),
+			expectError:       true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// the credentials are read from the environment, so these
+			// cases cannot run in parallel
+			t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", tc.googleCredentials)
+			t.Setenv("CLOUDSDK_CONFIG", tc.cloudsdkConfig)
+			t.Setenv("HOME", tc.home)
+			// gcloud ignores it, the home dir must be used anyway
+			t.Setenv("XDG_CONFIG_HOME", dir)
+			actual, err := ApplicationDefaultCredentialsFile()
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error but got %q", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != tc.expected {
+				t.Errorf("expected %q but got %q", tc.expected, actual)
+			}
+		})
+	}
+}
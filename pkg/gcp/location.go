@@ -0,0 +1,36 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import "strings"
+
+// RegionFromZone returns the region of a zone, e.g. us-central1 for us-central1-c.
+func RegionFromZone(zone string) string {
+	if i := strings.LastIndex(zone, "-"); i > 0 {
+		return zone[:i]
+	}
+	return zone
+}
+
+// LocationFlag returns the gcloud flag selecting the zone if it is set,
+// otherwise the region.
+func LocationFlag(region, zone string) string {
+	if zone != "" {
+		return "--zone=" + zone
+	}
+	return "--region=" + region
+}
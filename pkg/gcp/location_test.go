@@ -0,0 +1,56 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import "testing"
+
+func TestRegionFromZone(t *testing.T) {
+	testCases := []struct {
+		zone     string
+		expected string
+	}{
+		{zone: "us-central1-c", expected: "us-central1"},
+		{zone: "europe-west4-a", expected: "europe-west4"},
+		{zone: "local", expected: "local"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.zone, func(t *testing.T) {
+			if actual := RegionFromZone(tc.zone); actual != tc.expected {
+				t.Errorf("expected region %q but got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestLocationFlag(t *testing.T) {
+	testCases := []struct {
+		name     string
+		region   string
+		zone     string
+		expected string
+	}{
+		{name: "zone", region: "us-central1", zone: "us-central1-c", expected: "--zone=us-central1-c"},
+		{name: "region", region: "us-central1", expected: "--region=us-central1"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := LocationFlag(tc.region, tc.zone); actual != tc.expected {
+				t.Errorf("expected %q but got %q", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import "testing"
+
+func TestIsARMMachineType(t *testing.T) {
+	testCases := []struct {
+		machineType string
+		expected    bool
+	}{
+		{machineType: "t2a-standard-4", expected: true},
+		{machineType: "c4a-highmem-8", expected: true},
+		{machineType: "e2-standard-4", expected: false},
+		{machineType: "t2d-standard-4", expected: false},
+		{machineType: "", expected: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.machineType, func(t *testing.T) {
+			if actual := IsARMMachineType(tc.machineType); actual != tc.expected {
+				t.Errorf("expected IsARMMachineType(%q) to be %v but it was %v", tc.machineType, tc.expected, actual)
+			}
+		})
+	}
+}
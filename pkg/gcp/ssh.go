@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/fs"
+)
+
+const (
+	// CIPrivateKeyEnv is the "well-known" environment variable pointing to
+	// the ssh private key in CI
+	CIPrivateKeyEnv = "GCE_SSH_PRIVATE_KEY_FILE"
+	// CIPublicKeyEnv is the "well-known" environment variable pointing to
+	// the ssh public key in CI
+	CIPublicKeyEnv = "GCE_SSH_PUBLIC_KEY_FILE"
+)
+
+// SSHKeyPath returns the path of the private key gcloud uses for ssh.
+func SSHKeyPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user's home directory: %w", err)
+	}
+	return filepath.Join(home, ".ssh", "google_compute_engine"), nil
+}
+
+// VerifySSHKeys returns an error if the gcloud ssh key pair does not exist.
+func VerifySSHKeys() error {
+	privateKey, err := SSHKeyPath()
+	if err != nil {
+		return err
+	}
+	for _, key := range []string{privateKey, privateKey + ".pub"} {
+		if _, err := os.Stat(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MaybeSetupSSHKeys will best-effort try to setup ssh keys for gcloud to reuse
+// from existing files pointed to by "well-known" environment variables used in CI.
+// It returns the path of the gcloud private key, or empty if it is unknown.
+func MaybeSetupSSHKeys() string {
+	privateKey, err := SSHKeyPath()
+	if err != nil {
+		klog.Warning(err)
+		return ""
+	}
+	// check if there are existing ssh keys, if either exist don't do anything
+	klog.V(2).Info("checking for existing gcloud ssh keys...")
+	if _, err := os.Stat(privateKey); err == nil {
+		klog.V(2).Infof("found existing private key at %s", privateKey)
+		return privateKey
+	}
+	publicKey := privateKey + ".pub"
+	if _, err := os.Stat(publicKey); err == nil {
+		klog.V(2).Infof("found existing public key at %s", publicKey)
+		return privateKey
+	}
+
+	// no existing keys check for CI variables, create gcloud key files if both exist
+	// note only checks if relevant envs are non-empty, no actual key verification checks
+	maybePrivateKey, privateKeyEnvSet := os.LookupEnv(CIPrivateKeyEnv)
+	if !privateKeyEnvSet {
+		klog.V(2).Infof("%s is not set", CIPrivateKeyEnv)
+		return privateKey
+	}
+	maybePublicKey, publicKeyEnvSet := os.LookupEnv(CIPublicKeyEnv)
+	if !publicKeyEnvSet {
+		klog.V(2).Infof("%s is not set", CIPublicKeyEnv)
+		return privateKey
+	}
+
+	if err := os.MkdirAll(filepath.Dir(privateKey), 0700); err != nil {
+		klog.Warningf("failed to create %s: %v", filepath.Dir(privateKey), err)
+		return privateKey
+	}
+	if err := fs.CopyFile(maybePrivateKey, privateKey); err != nil {
+		klog.Warningf("failed to copy %s to %s: %v", maybePrivateKey, privateKey, err)
+		return privateKey
+	}
+
+	if err := fs.CopyFile(maybePublicKey, publicKey); err != nil {
+		klog.Warningf("failed to copy %s to %s: %v", maybePublicKey, publicKey, err)
+	}
+	return privateKey
+}
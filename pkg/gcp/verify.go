@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// ProjectVerification checks that a project, usually freshly acquired from
+// boskos, has no leftovers of earlier runs that would make the run flaky.
+type ProjectVerification struct {
+	// Services must be enabled in the project, e.g. compute.googleapis.com
+	Services []string
+	// Clusters are GKE cluster names that must not exist in the project
+	Clusters []string
+	// Networks are VPC network names that must not exist in the project
+	Networks []string
+	// Fix enables the missing services and deletes the conflicting clusters
+	// and networks instead of failing the verification
+	Fix bool
+}
+
+// Verify checks the project, fixing it if v.Fix is set, and returns an error
+// describing the problems left.
+func (v *ProjectVerification) Verify(project string) error {
+	klog.V(1).Infof("Verifying project %s", project)
+	if len(v.Services) > 0 {
+		enabled, err := listNames("services", "list", "--enabled", "--project="+project, "--format=value(config.name)")
+		if err != nil {
+			return err
+		}
+		if missing := difference(v.Services, enabled); len(missing) > 0 {
+			if !v.Fix {
+				return fmt.Errorf("services %s are not enabled in project %s", strings.Join(missing, ","), project)
+			}
+			if err := EnableServices(project, missing...); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(v.Clusters) > 0 {
+		// the location is needed to delete the cluster
+		existing, err := listNames("container", "clusters", "list", "--project="+project, "--format=value(name,location)")
+		if err != nil {
+			return err
+		}
+		for _, c := range existing {
+			fields := strings.Fields(c)
+			if len(fields) != 2 || len(intersection([]string{fields[0]}, v.Clusters)) == 0 {
+				continue
+			}
+			if !v.Fix {
+				return fmt.Errorf("cluster %s already exists in %s of project %s", fields[0], fields[1], project)
+			}
+			klog.V(1).Infof("Deleting leftover cluster %s in %s of project %s", fields[0], fields[1], project)
+			if err := runGcloud("container", "clusters", "delete", fields[0], "--location="+fields[1], "--project="+project, "--quiet"); err != nil {
+				return fmt.Errorf("failed to delete leftover cluster %s: %w", fields[0], err)
+			}
+		}
+	}
+
+	if len(v.Networks) > 0 {
+		existing, err := listNames("compute", "networks", "list", "--project="+project, "--format=value(name)")
+		if err != nil {
+			return err
+		}
+		for _, network := range intersection(existing, v.Networks) {
+			if !v.Fix {
+				return fmt.Errorf("network %s already exists in project %s", network, project)
+			}
+			if err := deleteNetwork(project, network); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteNetwork deletes the network and its firewall rules. Networks still
+// used by other resources, e.g. instances or custom subnets, fail to delete.
+func deleteNetwork(project, network string) error {
+	klog.V(1).Infof("Deleting leftover network %s of project %s", network, project)
+	rules, err := listNames("compute", "firewall-rules", "list", "--project="+project,
+		"--filter=network~/networks/"+network+"$", "--format=value(name)")
+	if err != nil {
+		return err
+	}
+	if len(rules) > 0 {
+		args := append([]string{"compute", "firewall-rules", "delete"}, rules...)
+		if err := runGcloud(append(args, "--project="+project, "--quiet")...); err != nil {
+			return fmt.Errorf("failed to delete the firewall rules of leftover network %s: %w", network, err)
+		}
+	}
+	if err := runGcloud("compute", "networks", "delete", network, "--project="+project, "--quiet"); err != nil {
+		return fmt.Errorf("failed to delete leftover network %s: %w", network, err)
+	}
+	return nil
+}
+
+// listNames runs gcloud with the given args and returns the non empty lines
+// of its output.
+func listNames(args ...string) ([]string, error) {
+	out, err := exec.Output(exec.Command("gcloud", args...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to run gcloud %s: %w", strings.Join(args, " "), err)
+	}
+	var names []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			names = append(names, line)
+		}
+	}
+	return names, nil
+}
+
+func runGcloud(args ...string) error {
+	cmd := exec.Command("gcloud", args...)
+	exec.InheritOutput(cmd)
+	return cmd.Run()
+}
+
+// difference returns the elements of a that are not in b.
+func difference(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, s := range b {
+		in[s] = true
+	}
+	var out []string
+	for _, s := range a {
+		if !in[s] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// intersection returns the elements of a that are also in b.
+func intersection(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, s := range b {
+		in[s] = true
+	}
+	var out []string
+	for _, s := range a {
+		if in[s] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+func TestDifferenceAndIntersection(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		a                    []string
+		b                    []string
+		expectedDifference   []string
+		expectedIntersection []string
+	}{
+		{
+			name:                 "disjoint",
+			a:                    []string{"compute.googleapis.com"},
+			b:                    []string{"container.googleapis.com"},
+			expectedDifference:   []string{"compute.googleapis.com"},
+			expectedIntersection: nil,
+		},
+		{
+			name:                 "overlapping",
+			a:                    []string{"kt2-a", "kt2-b", "kt2-c"},
+			b:                    []string{"default", "kt2-b"},
+			expectedDifference:   []string{"kt2-a", "kt2-c"},
+			expectedIntersection: []string{"kt2-b"},
+		},
+		{
+			name:                 "empty",
+			a:                    nil,
+			b:                    []string{"default"},
+			expectedDifference:   nil,
+			expectedIntersection: nil,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := difference(tc.a, tc.b); !reflect.DeepEqual(actual, tc.expectedDifference) {
+				t.Errorf("expected difference %v but got %v", tc.expectedDifference, actual)
+			}
+			if actual := intersection(tc.a, tc.b); !reflect.DeepEqual(actual, tc.expectedIntersection) {
+				t.Errorf("expected intersection %v but got %v", tc.expectedIntersection, actual)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	services := "gcloud services list --enabled --project=p --format=value(config.name)"
+	clusters := "gcloud container clusters list --project=p --format=value(name,location)"
+	networks := "gcloud compute networks list --project=p --format=value(name)"
+	firewalls := "gcloud compute firewall-rules list --project=p --filter=network~/networks/kt2-net$ --format=value(name)"
+	responses := []exec.FakeResponse{
+		{Prefix: services, Stdout: "compute.googleapis.com\n"},
+		{Prefix: clusters, Stdout: "kt2-c1   us-central1-c\nother    us-east1\n"},
+		{Prefix: networks, Stdout: "default\nkt2-net\n"},
+		{Prefix: firewalls, Stdout: "kt2-net-allow-ssh\n"},
+	}
+	testCases := []struct {
+		name             string
+		verification     ProjectVerification
+		expectedCommands []string
+		expectedError    string
+	}{
+		{
+			name: "clean project",
+			verification: ProjectVerification{
+				Services: []string{"compute.googleapis.com"},
+				Clusters: []string{"kt2-c2"},
+				Networks: []string{"kt2-other"},
+			},
+			expectedCommands: []string{services, clusters, networks},
+		},
+		{
+			name:             "missing service",
+			verification:     ProjectVerification{Services: []string{"compute.googleapis.com", "container.googleapis.com"}},
+			expectedCommands: []string{services},
+			expectedError:    "services container.googleapis.com are not enabled",
+		},
+		{
+			name:             "missing service is enabled",
+			verification:     ProjectVerification{Services: []string{"compute.googleapis.com", "container.googleapis.com"}, Fix: true},
+			expectedCommands: []string{services, "gcloud services enable container.googleapis.com --project=p"},
+		},
+		{
+			name:             "leftover cluster",
+			verification:     ProjectVerification{Clusters: []string{"kt2-c1"}},
+			expectedCommands: []string{clusters},
+			expectedError:    "cluster kt2-c1 already exists in us-central1-c",
+		},
+		{
+			name:             "leftover cluster is deleted",
+			verification:     ProjectVerification{Clusters: []string{"kt2-c1"}, Fix: true},
+			expectedCommands: []string{clusters, "gcloud container clusters delete kt2-c1 --location=us-central1-c --project=p --quiet"},
+		},
+		{
+			name:             "leftover network",
+			verification:     ProjectVerification{Networks: []string{"kt2-net"}},
+			expectedCommands: []string{networks},
+			expectedError:    "network kt2-net already exists",
+		},
+		{
+			name:         "leftover network is deleted with its firewall rules",
+			verification: ProjectVerification{Networks: []string{"kt2-net"}, Fix: true},
+			expectedCommands: []string{
+				networks,
+				firewalls,
+				"gcloud compute firewall-rules delete kt2-net-allow-ssh --project=p --quiet",
+				"gcloud compute networks delete kt2-net --project=p --quiet",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := fakeGcloud(t, responses...)
+			err := tc.verification.Verify("p")
+			if tc.expectedError == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tc.expectedError != "" && (err == nil || !strings.Contains(err.Error(), tc.expectedError)) {
+				t.Errorf("expected error %q but got %v", tc.expectedError, err)
+			}
+			if commands := cmder.Commands(); !reflect.DeepEqual(commands, tc.expectedCommands) {
+				t.Errorf("expected commands %v but got %v", tc.expectedCommands, commands)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kubectl contains kubectl helpers shared by the deployers bringing
+// up their clusters from VMs.
+package kubectl
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// pollInterval is the interval between the checks of WaitForReadyNodes
+const pollInterval = 15 * time.Second
+
+// Run executes kubectl against the cluster of kubeconfig, streaming the
+// output to the console
+func Run(kubeconfig string, args ...string) error {
+	cmd := exec.Command("kubectl", append([]string{"--kubeconfig=" + kubeconfig}, args...)...)
+	exec.InheritOutput(cmd)
+	return cmd.Run()
+}
+
+// WaitForReadyNodes waits for expected nodes of the cluster of kubeconfig to
+// be registered and ready, until deadline
+func WaitForReadyNodes(kubeconfig string, expected int, deadline time.Time) error {
+	klog.V(0).Infof("Up(): waiting for %d nodes to be ready...\n", expected)
+	for {
+		cmd := exec.Command("kubectl", "--kubeconfig="+kubeconfig, "get", "nodes", "--no-headers")
+		cmd.SetStderr(os.Stderr)
+		lines, err := exec.OutputLines(cmd)
+		ready := CountReadyNodes(lines)
+		if err == nil && ready >= expected {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for the nodes to be ready, %d of %d are ready: %v", ready, expected, err)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// CountReadyNodes counts the ready nodes in kubectl get nodes --no-headers
+// output
+func CountReadyNodes(lines []string) int {
+	ready := 0
+	for _, line := range lines {
+		if fields := strings.Fields(line); len(fields) > 1 && fields[1] == "Ready" {
+			ready++
+		}
+	}
+	return ready
+}
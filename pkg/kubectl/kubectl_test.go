@@ -0,0 +1,32 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubectl
+
+import (
+	"testing"
+)
+
+func TestCountReadyNodes(t *testing.T) {
+	lines := []string{
+		"kt2-abc-control-plane   Ready      control-plane   5m    v1.30.2",
+		"kt2-abc-worker-0        NotReady   <none>          1m    v1.30.2",
+		"kt2-abc-worker-1        Ready      <none>          1m    v1.30.2",
+	}
+	if actual := CountReadyNodes(lines); actual != 2 {
+		t.Errorf("expected 2 ready nodes but got %d", actual)
+	}
+}
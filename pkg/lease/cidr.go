@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lease
+
+import (
+	"fmt"
+	"net"
+)
+
+// AcquireCIDR claims the first free block of prefixLen bits in the parent
+// IPv4 range, e.g. a /20 of 10.128.0.0/12, for the subnetworks of the run.
+// The pool of the blocks is the parent range within pool.
+func (l *Leaser) AcquireCIDR(pool, parent string, prefixLen int) (*net.IPNet, error) {
+	blocks, err := splitCIDR(parent, prefixLen)
+	if err != nil {
+		return nil, err
+	}
+	name, err := l.Acquire(pool+"/"+parent, blocks)
+	if err != nil {
+		return nil, err
+	}
+	_, block, err := net.ParseCIDR(name)
+	return block, err
+}
+
+// maxBlocks caps the number of blocks a range is split into
+const maxBlocks = 1 << 16
+
+// splitCIDR returns the blocks of prefixLen bits in the parent range
+func splitCIDR(parent string, prefixLen int) ([]string, error) {
+	_, parentNet, err := net.ParseCIDR(parent)
+	if err != nil {
+		return nil, err
+	}
+	ip := parentNet.IP.To4()
+	if ip == nil {
+		return nil, fmt.Errorf("only IPv4 ranges can be leased, got %s", parent)
+	}
+	parentLen, _ := parentNet.Mask.Size()
+	if prefixLen < parentLen || prefixLen > 32 {
+		return nil, fmt.Errorf("the /%d blocks must be within the range %s", prefixLen, parent)
+	}
+	if 1<<(prefixLen-parentLen) > maxBlocks {
+		return nil, fmt.Errorf("splitting %s into /%d blocks exceeds %d blocks", parent, prefixLen, maxBlocks)
+	}
+	start := uint32(ip[0])<<24 | uint32(ip[1])<<16 | uint32(ip[2])<<8 | uint32(ip[3])
+	size := uint64(1) << (32 - prefixLen)
+	count := 1 << (prefixLen - parentLen)
+	blocks := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		n := uint32(uint64(start) + uint64(i)*size)
+		blocks = append(blocks, fmt.Sprintf("%d.%d.%d.%d/%d", byte(n>>24), byte(n>>16), byte(n>>8), byte(n), prefixLen))
+	}
+	return blocks, nil
+}
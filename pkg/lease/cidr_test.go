@@ -0,0 +1,86 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lease
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitCIDR(t *testing.T) {
+	testCases := []struct {
+		name           string
+		parent         string
+		prefixLen      int
+		expectedBlocks []string
+		expectError    bool
+	}{
+		{
+			name:           "blocks",
+			parent:         "10.128.0.0/12",
+			prefixLen:      14,
+			expectedBlocks: []string{"10.128.0.0/14", "10.132.0.0/14", "10.136.0.0/14", "10.140.0.0/14"},
+		},
+		{
+			name:           "unaligned parent",
+			parent:         "172.16.5.0/23",
+			prefixLen:      24,
+			expectedBlocks: []string{"172.16.4.0/24", "172.16.5.0/24"},
+		},
+		{
+			name:           "whole range",
+			parent:         "192.168.0.0/16",
+			prefixLen:      16,
+			expectedBlocks: []string{"192.168.0.0/16"},
+		},
+		{
+			name:        "blocks larger than the range",
+			parent:      "10.0.0.0/16",
+			prefixLen:   8,
+			expectError: true,
+		},
+		{
+			name:        "too many blocks",
+			parent:      "10.0.0.0/8",
+			prefixLen:   28,
+			expectError: true,
+		},
+		{
+			name:        "ipv6",
+			parent:      "fd00::/64",
+			prefixLen:   80,
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			blocks, err := splitCIDR(tc.parent, tc.prefixLen)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error but got %v", blocks)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(blocks, tc.expectedBlocks) {
+				t.Errorf("expected blocks %v but got %v", tc.expectedBlocks, blocks)
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lease implements named sub-leases of a shared resource, e.g. a
+// network slice or a CIDR block of a big boskos project, so that several
+// kubetest2 runs can share the resource without colliding.
+//
+// A lease is a file in a pool directory shared by the runs, created
+// exclusively by the run claiming it. The leases of a run are also recorded
+// in its run dir, so that a later invocation with the same run id, e.g. the
+// one running --down, finds and releases them. The pool directory is local
+// by default, so only the runs on the same machine are coordinated, unless
+// $KUBETEST2_LEASE_DIR points them all to a shared volume.
+package lease
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/types"
+)
+
+// DirEnv overrides the directory the leases are shared in, e.g. with a
+// volume mounted by all the runs sharing a resource
+const DirEnv = "KUBETEST2_LEASE_DIR"
+
+// DefaultTTL is how long the lease of a run which never released it, e.g.
+// because it crashed, is held before other runs reclaim it
+const DefaultTTL = 24 * time.Hour
+
+// RenewInterval is how often a run renews its leases, see StartRenewal
+const RenewInterval = DefaultTTL / 4
+
+// lockFile is the file in a pool directory locked while claiming a lease
+const lockFile = ".lock"
+
+// recordFile is the file in the run dir recording the leases of the run
+const recordFile = "leases.json"
+
+// Lease is a name claimed in a pool
+type Lease struct {
+	Pool string `json:"pool"`
+	Name string `json:"name"`
+}
+
+// Leaser claims leases for a run
+type Leaser struct {
+	// Dir is the directory shared by the runs, with a sub directory per pool
+	Dir string
+	// Owner identifies the run holding the leases
+	Owner string
+	// RunDir is where the leases of the run are recorded
+	RunDir string
+	// TTL is how long a lease is held before other runs can reclaim it
+	TTL time.Duration
+}
+
+// NewLeaser returns a Leaser for the run of opts. The leases are shared in
+// $KUBETEST2_LEASE_DIR if set, otherwise next to the run dirs, which only
+// coordinates the runs on the same machine.
+func NewLeaser(opts types.Options) *Leaser {
+	dir := os.Getenv(DirEnv)
+	if dir == "" {
+		dir = filepath.Join(filepath.Dir(opts.RunDir()), "leases")
+	}
+	return &Leaser{
+		Dir:    dir,
+		Owner:  opts.RunID(),
+		RunDir: opts.RunDir(),
+		TTL:    DefaultTTL,
+	}
+}
+
+// Acquire claims the first free name of names in pool, e.g. a project id.
+// A run holds at most one lease per pool, the lease it already holds is
+// returned again.
+func (l *Leaser) Acquire(pool string, names []string) (string, error) {
+	leases, err := l.Leases()
+	if err != nil {
+		return "", err
+	}
+	for _, lease := range leases {
+		if lease.Pool == pool {
+			return lease.Name, nil
+		}
+	}
+	if err := os.MkdirAll(l.poolDir(pool), os.ModePerm); err != nil {
+		return "", err
+	}
+	// the lock makes reclaiming an expired lease and claiming it atomic
+	unlock, err := lockDir(l.poolDir(pool))
+	if err != nil {
+		return "", fmt.Errorf("failed to lock pool %s: %w", pool, err)
+	}
+	defer unlock()
+	for _, name := range names {
+		claimed, err := l.claim(pool, name)
+		if err != nil {
+			return "", err
+		}
+		if !claimed {
+			continue
+		}
+		if err := l.record(append(leases, Lease{Pool: pool, Name: name})); err != nil {
+			return "", err
+		}
+		klog.V(1).Infof("Acquired lease %s in pool %s", name, pool)
+		return name, nil
+	}
+	return "", fmt.Errorf("all the %d names of pool %s are leased", len(names), pool)
+}
+
+// Release releases all the leases of the run, it is safe to call more than
+// once
+func (l *Leaser) Release() error {
+	leases, err := l.Leases()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, lease := range leases {
+		path := l.leasePath(lease.Pool, lease.Name)
+		owner, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err)
+			continue
+		}
+		// the lease may have expired and been reclaimed by another run
+		if string(owner) != l.Owner {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err)
+			continue
+		}
+		klog.V(1).Infof("Released lease %s in pool %s", lease.Name, lease.Pool)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to release leases: %w", errors.Join(errs...))
+	}
+	if err := os.Remove(filepath.Join(l.RunDir, recordFile)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Renew extends the leases the run still holds by another TTL, for runs
+// lasting longer than the TTL.
+func (l *Leaser) Renew() error {
+	leases, err := l.Leases()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	now := time.Now()
+	for _, lease := range leases {
+		path := l.leasePath(lease.Pool, lease.Name)
+		owner, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err)
+			continue
+		}
+		if string(owner) != l.Owner {
+			klog.Warningf("Lease %s in pool %s expired and was reclaimed by another run", lease.Name, lease.Pool)
+			continue
+		}
+		if err := os.Chtimes(path, now, now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to renew leases: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// StartRenewal renews the leases of the run every interval until the
+// returned function is called.
+func (l *Leaser) StartRenewal(interval time.Duration) func() {
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if err := l.Renew(); err != nil {
+					klog.Warningf("Failed to renew the leases of the run: %v", err)
+				}
+			}
+		}
+	}()
+	return func() { close(stop) }
+}
+
+// Leases returns the leases recorded in the run dir
+func (l *Leaser) Leases() ([]Lease, error) {
+	data, err := os.ReadFile(filepath.Join(l.RunDir, recordFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var leases []Lease
+	if err := json.Unmarshal(data, &leases); err != nil {
+		return nil, fmt.Errorf("failed to parse the leases of the run: %w", err)
+	}
+	return leases, nil
+}
+
+func (l *Leaser) record(leases []Lease) error {
+	data, err := json.MarshalIndent(leases, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(l.RunDir, os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(l.RunDir, recordFile), data, 0644)
+}
+
+// claim creates the lease file of name, reclaiming it first if it expired,
+// and returns false if another run holds it. The pool must be locked.
+func (l *Leaser) claim(pool, name string) (bool, error) {
+	path := l.leasePath(pool, name)
+	if info, err := os.Stat(path); err == nil && l.TTL > 0 && time.Since(info.ModTime()) > l.TTL {
+		klog.Warningf("Reclaiming the expired lease %s in pool %s", name, pool)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return false, err
+		}
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if os.IsExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if _, err := f.WriteString(l.Owner); err != nil {
+		f.Close()
+		return false, err
+	}
+	return true, f.Close()
+}
+
+func lockPath(poolDir string) string {
+	return filepath.Join(poolDir, lockFile)
+}
+
+func (l *Leaser) poolDir(pool string) string {
+	return filepath.Join(l.Dir, escape(pool))
+}
+
+func (l *Leaser) leasePath(pool, name string) string {
+	return filepath.Join(l.poolDir(pool), escape(name))
+}
+
+// escape makes names like CIDRs usable as file names
+func escape(name string) string {
+	return strings.NewReplacer("/", "_", string(filepath.Separator), "_").Replace(name)
+}
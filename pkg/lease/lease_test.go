@@ -0,0 +1,157 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lease
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestLeaser(dir, owner string) *Leaser {
+	return &Leaser{
+		Dir:    filepath.Join(dir, "leases"),
+		Owner:  owner,
+		RunDir: filepath.Join(dir, owner),
+		TTL:    DefaultTTL,
+	}
+}
+
+func TestAcquireAndRelease(t *testing.T) {
+	dir := t.TempDir()
+	a := newTestLeaser(dir, "run-a")
+	b := newTestLeaser(dir, "run-b")
+	names := []string{"slice-0", "slice-1"}
+
+	name, err := a.Acquire("project", names)
+	if err != nil || name != "slice-0" {
+		t.Fatalf("expected run-a to lease slice-0 but got %q, %v", name, err)
+	}
+	// a run holds one lease per pool
+	if name, err := a.Acquire("project", names); err != nil || name != "slice-0" {
+		t.Fatalf("expected run-a to lease slice-0 again but got %q, %v", name, err)
+	}
+	if name, err := b.Acquire("project", names); err != nil || name != "slice-1" {
+		t.Fatalf("expected run-b to lease slice-1 but got %q, %v", name, err)
+	}
+	c := newTestLeaser(dir, "run-c")
+	if name, err := c.Acquire("project", names); err == nil {
+		t.Fatalf("expected run-c to find no free name but got %q", name)
+	}
+
+	// a later invocation of run-a releases the leases recorded in its run dir
+	if err := newTestLeaser(dir, "run-a").Release(); err != nil {
+		t.Fatalf("failed to release the leases of run-a: %v", err)
+	}
+	if err := a.Release(); err != nil {
+		t.Fatalf("failed to release the leases of run-a twice: %v", err)
+	}
+	if name, err := c.Acquire("project", names); err != nil || name != "slice-0" {
+		t.Fatalf("expected run-c to lease the released slice-0 but got %q, %v", name, err)
+	}
+}
+
+func TestAcquireExpired(t *testing.T) {
+	dir := t.TempDir()
+	a := newTestLeaser(dir, "run-a")
+	b := newTestLeaser(dir, "run-b")
+	if _, err := a.Acquire("project", []string{"slice-0"}); err != nil {
+		t.Fatalf("failed to acquire the lease: %v", err)
+	}
+	expired := time.Now().Add(-2 * DefaultTTL)
+	if err := os.Chtimes(a.leasePath("project", "slice-0"), expired, expired); err != nil {
+		t.Fatalf("failed to expire the lease: %v", err)
+	}
+	if name, err := b.Acquire("project", []string{"slice-0"}); err != nil || name != "slice-0" {
+		t.Fatalf("expected run-b to reclaim slice-0 but got %q, %v", name, err)
+	}
+	// releasing the reclaimed lease leaves it to run-b
+	if err := a.Release(); err != nil {
+		t.Fatalf("failed to release the leases of run-a: %v", err)
+	}
+	owner, err := os.ReadFile(a.leasePath("project", "slice-0"))
+	if err != nil || string(owner) != "run-b" {
+		t.Errorf("expected run-b to hold slice-0 but got %q, %v", owner, err)
+	}
+}
+
+func TestAcquireExpiredConcurrently(t *testing.T) {
+	dir := t.TempDir()
+	a := newTestLeaser(dir, "run-a")
+	if _, err := a.Acquire("project", []string{"slice-0"}); err != nil {
+		t.Fatalf("failed to acquire the lease: %v", err)
+	}
+	expired := time.Now().Add(-2 * DefaultTTL)
+	if err := os.Chtimes(a.leasePath("project", "slice-0"), expired, expired); err != nil {
+		t.Fatalf("failed to expire the lease: %v", err)
+	}
+
+	// only one of the runs reclaims the expired lease
+	var wg sync.WaitGroup
+	var acquired atomic.Int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := newTestLeaser(dir, fmt.Sprintf("run-%d", i)).Acquire("project", []string{"slice-0"}); err == nil {
+				acquired.Add(1)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if acquired.Load() != 1 {
+		t.Errorf("expected one run to reclaim slice-0 but %d did", acquired.Load())
+	}
+}
+
+func TestRenew(t *testing.T) {
+	dir := t.TempDir()
+	a := newTestLeaser(dir, "run-a")
+	b := newTestLeaser(dir, "run-b")
+	if _, err := a.Acquire("project", []string{"slice-0"}); err != nil {
+		t.Fatalf("failed to acquire the lease: %v", err)
+	}
+	expired := time.Now().Add(-2 * DefaultTTL)
+	if err := os.Chtimes(a.leasePath("project", "slice-0"), expired, expired); err != nil {
+		t.Fatalf("failed to expire the lease: %v", err)
+	}
+	if err := a.Renew(); err != nil {
+		t.Fatalf("failed to renew the leases of run-a: %v", err)
+	}
+	if name, err := b.Acquire("project", []string{"slice-0"}); err == nil {
+		t.Errorf("expected run-b not to reclaim the renewed lease but got %q", name)
+	}
+}
+
+func TestAcquireCIDR(t *testing.T) {
+	dir := t.TempDir()
+	a := newTestLeaser(dir, "run-a")
+	b := newTestLeaser(dir, "run-b")
+	for _, l := range []*Leaser{a, b} {
+		if _, err := l.AcquireCIDR("project", "10.128.0.0/12", 20); err != nil {
+			t.Fatalf("failed to acquire a CIDR for %s: %v", l.Owner, err)
+		}
+	}
+	block, err := b.AcquireCIDR("project", "10.128.0.0/12", 20)
+	if err != nil || block.String() != "10.128.16.0/20" {
+		t.Errorf("expected run-b to lease 10.128.16.0/20 but got %v, %v", block, err)
+	}
+}
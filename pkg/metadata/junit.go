@@ -51,6 +51,40 @@ func NewJUnitError(inner error, systemOut string) error {
 	}
 }
 
+// ClassifiedError is an error with a classification of the failure, e.g.
+// "Stockout" for a lack of cloud capacity. If a step returns one, possibly
+// wrapped, kubetest2 records the classification as the type of the JUnit
+// failure, so that infrastructure failures can be told from regressions.
+type ClassifiedError interface {
+	error
+	// FailureType is the classification of the failure
+	FailureType() string
+}
+
+type classifiedError struct {
+	error
+	failureType string
+}
+
+// ensure classifiedError implements ClassifiedError
+var _ ClassifiedError = &classifiedError{}
+
+func (c *classifiedError) FailureType() string {
+	return c.failureType
+}
+
+func (c *classifiedError) Unwrap() error {
+	return c.error
+}
+
+// NewClassifiedError returns an instance of ClassifiedError wrapping inner
+func NewClassifiedError(inner error, failureType string) error {
+	return &classifiedError{
+		error:       inner,
+		failureType: failureType,
+	}
+}
+
 // testSuite holds a slice of TestCase and other summary metadata.
 //
 // A build (column in testgrid) is composed of one or more TestSuites.
@@ -74,7 +108,7 @@ func (t *testSuite) Write(writer io.Writer) error {
 
 func (t *testSuite) AddTestCase(tc testCase) {
 	t.Tests++
-	if tc.Failure != "" {
+	if tc.Failure != nil {
 		t.Failures++
 	}
 	t.Cases = append(t.Cases, tc)
@@ -88,7 +122,13 @@ type testCase struct {
 	Name      string   `xml:"name,attr"`
 	ClassName string   `xml:"classname,attr"`
 	Time      float64  `xml:"time,attr"`
-	Failure   string   `xml:"failure,omitempty"`
+	Failure   *failure `xml:"failure,omitempty"`
 	Skipped   string   `xml:"skipped,omitempty"`
 	SystemOut string   `xml:"system-out,omitempty"`
 }
+
+// failure holds the failure message of a testCase and its classification
+type failure struct {
+	Type    string `xml:"type,attr,omitempty"`
+	Message string `xml:",chardata"`
+}
@@ -20,6 +20,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
+	"sort"
 )
 
 type CustomJSON struct {
@@ -58,3 +60,41 @@ func (m *CustomJSON) Write(writer io.Writer) error {
 	}
 	return err
 }
+
+// AddToFile adds the entries to the metadata JSON file at path, creating it
+// if it doesn't exist. Like Add, it fails if a key already exists.
+func AddToFile(path string, entries map[string]string) error {
+	meta := &CustomJSON{}
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &meta.data); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := meta.Add(key, entries[key]); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := meta.Write(f); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
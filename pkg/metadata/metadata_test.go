@@ -19,6 +19,8 @@ package metadata
 import (
 	"bytes"
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -63,3 +65,32 @@ func TestNewCustomJSON(t *testing.T) {
 		t.Errorf("mismatched metadata bytes, got: %v, want: %v", meta.data, expectedData)
 	}
 }
+
+func TestAddToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metadata.json")
+	if err := AddToFile(path, map[string]string{"kubetest-version": "v1"}); err != nil {
+		t.Fatalf("did not expect an error, but got: %v", err)
+	}
+	if err := AddToFile(path, map[string]string{"tester-version": "v2"}); err != nil {
+		t.Fatalf("did not expect an error, but got: %v", err)
+	}
+	if err := AddToFile(path, map[string]string{"tester-version": "v3"}); err == nil {
+		t.Errorf("expected an error adding an existing key, but got none")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("did not expect an error, but got: %v", err)
+	}
+	meta, err := NewCustomJSON(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("did not expect an error, but got: %v", err)
+	}
+	expectedData := map[string]string{
+		"kubetest-version": "v1",
+		"tester-version":   "v2",
+	}
+	if !reflect.DeepEqual(meta.data, expectedData) {
+		t.Errorf("mismatched metadata, got: %v, want: %v", meta.data, expectedData)
+	}
+}
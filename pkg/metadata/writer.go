@@ -17,15 +17,26 @@ limitations under the License.
 package metadata
 
 import (
+	"errors"
+	"fmt"
 	"io"
+	"sync"
 	"time"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
 )
 
 // Writer manages writing out kubetest2 metadata, namely JUnit
 type Writer struct {
-	suite     testSuite
+	// mu guards suite and runs, steps may be wrapped concurrently by deployers
+	mu    sync.Mutex
+	suite testSuite
+	// runs counts the runs of each step name, see uniqueName
+	runs      map[string]int
 	start     time.Time
 	runnerOut io.Writer
+	// output, if set, is the recent command output excerpted on failures
+	output *exec.OutputRecorder
 	// for faking out time when testing
 	timeNow func() time.Time
 }
@@ -37,16 +48,31 @@ func NewWriter(suiteName string, runnerOut io.Writer) *Writer {
 	suite := testSuite{Name: suiteName}
 	return &Writer{
 		suite:     suite,
+		runs:      map[string]int{},
 		runnerOut: runnerOut,
 		start:     time.Now(),
 		timeNow:   time.Now,
 	}
 }
 
+// RecordOutput makes failed steps include the output of the commands they
+// ran, as recorded by output, in their failure message.
+func (w *Writer) RecordOutput(output *exec.OutputRecorder) {
+	w.output = output
+}
+
 // WrapStep executes doStep and captures the output to be written to the
 // kubetest2 runner metadata. If doStep returns a JUnitError this metadata
-// will be captured
+// will be captured, as will the classification of a ClassifiedError.
+// Steps may be nested and run concurrently, a nested step is recorded
+// before the step wrapping it. A step run more than once is recorded under
+// a unique name, see uniqueName.
 func (w *Writer) WrapStep(name string, doStep func() error) error {
+	name = w.uniqueName(name)
+	var outputOffset int64
+	if w.output != nil {
+		outputOffset = w.output.Written()
+	}
 	start := w.timeNow()
 	err := doStep()
 	finish := w.timeNow()
@@ -56,17 +82,44 @@ func (w *Writer) WrapStep(name string, doStep func() error) error {
 		Time:      finish.Sub(start).Seconds(),
 	}
 	if err != nil {
-		tc.Failure = err.Error()
+		tc.Failure = &failure{Message: err.Error()}
+		var classified ClassifiedError
+		if errors.As(err, &classified) {
+			tc.Failure.Type = classified.FailureType()
+		}
+		if w.output != nil {
+			if output := w.output.Since(outputOffset); output != "" {
+				tc.Failure.Message += "\n\nlast command output:\n" + output
+			}
+		}
 	}
 	if v, ok := err.(JUnitError); ok {
 		tc.SystemOut = v.SystemOut()
 	}
+	w.mu.Lock()
 	w.suite.AddTestCase(tc)
+	w.mu.Unlock()
 	return err
 }
 
+// uniqueName returns the name of the test case of a run of the step name,
+// suffixed with the number of the run from the second one in the order they
+// start, e.g. Up#2, since duplicate test case names break some junit
+// consumers.
+func (w *Writer) uniqueName(name string) string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.runs[name]++
+	if n := w.runs[name]; n > 1 {
+		return fmt.Sprintf("%s#%d", name, n)
+	}
+	return name
+}
+
 // Finish finalizes the metadata (time) and writes it out
 func (w *Writer) Finish() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
 	w.suite.Time = w.timeNow().Sub(w.start).Seconds()
 	return w.suite.Write(w.runnerOut)
 }
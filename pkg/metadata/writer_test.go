@@ -19,9 +19,12 @@ package metadata
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
 )
 
 // fake time.Now that always increments by one second
@@ -153,6 +156,27 @@ func TestWriter(t *testing.T) {
         <failure>on noes</failure>
         <system-out>uh oh</system-out>
     </testcase>
+</testsuite>`,
+				"\n",
+			),
+		},
+		{
+			name: "one failed step with a wrapped classified error",
+			steps: []step{
+				{
+					name: "out of capacity",
+					doStep: func() error {
+						return fmt.Errorf("error creating clusters: %w", NewClassifiedError(errors.New("ZONE_RESOURCE_POOL_EXHAUSTED"), "Stockout"))
+					},
+					expectError: true,
+				},
+			},
+			expectedOutput: strings.TrimPrefix(
+				`
+<?xml version="1.0" encoding="UTF-8"?><testsuite name="kubetest2" failures="1" tests="1" time="3">
+    <testcase name="out of capacity" classname="kubetest2" time="1">
+        <failure type="Stockout">error creating clusters: ZONE_RESOURCE_POOL_EXHAUSTED</failure>
+    </testcase>
 </testsuite>`,
 				"\n",
 			),
@@ -190,3 +214,99 @@ func TestWriter(t *testing.T) {
 		})
 	}
 }
+
+func TestWriterNestedSteps(t *testing.T) {
+	runnerOut := bytes.NewBuffer([]byte{})
+	w := NewWriter("kubetest2", runnerOut)
+	w.timeNow = makeFakeNow()
+	w.start = w.timeNow()
+	err := w.WrapStep("Up", func() error {
+		return w.WrapStep("CreateNetwork", func() error { return nil })
+	})
+	if err != nil {
+		t.Errorf("unexpected error for nested steps %v", err)
+	}
+	if err := w.Finish(); err != nil {
+		t.Errorf("unexpected error for writer.Finish() %v", err)
+	}
+	expectedOutput := strings.TrimPrefix(
+		`
+<?xml version="1.0" encoding="UTF-8"?><testsuite name="kubetest2" failures="0" tests="2" time="5">
+    <testcase name="CreateNetwork" classname="kubetest2" time="1"></testcase>
+    <testcase name="Up" classname="kubetest2" time="3"></testcase>
+</testsuite>`,
+		"\n",
+	)
+	if output := runnerOut.String(); output != expectedOutput {
+		t.Errorf("runnerOut did not match expected \n%v\nVERSUS:\n %v", expectedOutput, output)
+	}
+}
+
+func TestWriterRepeatedSteps(t *testing.T) {
+	runnerOut := bytes.NewBuffer([]byte{})
+	w := NewWriter("kubetest2", runnerOut)
+	w.timeNow = makeFakeNow()
+	w.start = w.timeNow()
+	for i := 0; i < 3; i++ {
+		if err := w.WrapStep("Test", func() error { return nil }); err != nil {
+			t.Errorf("unexpected error for repeated steps %v", err)
+		}
+	}
+	if err := w.WrapStep("Down", func() error { return nil }); err != nil {
+		t.Errorf("unexpected error for repeated steps %v", err)
+	}
+	if err := w.Finish(); err != nil {
+		t.Errorf("unexpected error for writer.Finish() %v", err)
+	}
+	expectedOutput := strings.TrimPrefix(
+		`
+<?xml version="1.0" encoding="UTF-8"?><testsuite name="kubetest2" failures="0" tests="4" time="9">
+    <testcase name="Test" classname="kubetest2" time="1"></testcase>
+    <testcase name="Test#2" classname="kubetest2" time="1"></testcase>
+    <testcase name="Test#3" classname="kubetest2" time="1"></testcase>
+    <testcase name="Down" classname="kubetest2" time="1"></testcase>
+</testsuite>`,
+		"\n",
+	)
+	if output := runnerOut.String(); output != expectedOutput {
+		t.Errorf("runnerOut did not match expected \n%v\nVERSUS:\n %v", expectedOutput, output)
+	}
+}
+
+func TestWriterRecordOutput(t *testing.T) {
+	runnerOut := bytes.NewBuffer([]byte{})
+	w := NewWriter("kubetest2", runnerOut)
+	w.timeNow = makeFakeNow()
+	w.start = w.timeNow()
+	output := exec.NewOutputRecorder(22)
+	w.RecordOutput(output)
+	fmt.Fprint(output, "previous step output\n")
+	err := w.WrapStep("Up", func() error {
+		fmt.Fprint(output, "Creating cluster...\nERROR: quota exceeded\n")
+		return errors.New("exit status 1")
+	})
+	if err == nil {
+		t.Errorf("expected error for failing step and got none")
+	}
+	if err := w.WrapStep("Down", func() error { return errors.New("nothing to delete") }); err == nil {
+		t.Errorf("expected error for failing step and got none")
+	}
+	if err := w.Finish(); err != nil {
+		t.Errorf("unexpected error for writer.Finish() %v", err)
+	}
+	expectedOutput := strings.TrimPrefix(
+		`
+<?xml version="1.0" encoding="UTF-8"?><testsuite name="kubetest2" failures="2" tests="2" time="5">
+    <testcase name="Up" classname="kubetest2" time="1">
+        <failure>exit status 1&#xA;&#xA;last command output:&#xA;ERROR: quota exceeded&#xA;</failure>
+    </testcase>
+    <testcase name="Down" classname="kubetest2" time="1">
+        <failure>nothing to delete</failure>
+    </testcase>
+</testsuite>`,
+		"\n",
+	)
+	if got := runnerOut.String(); got != expectedOutput {
+		t.Errorf("runnerOut did not match expected \n%v\nVERSUS:\n %v", expectedOutput, got)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package runs implements the local registry of kubetest2 runs, so that the
+// clusters of interrupted runs can be found and torn down later.
+package runs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Status of a run
+const (
+	StatusRunning = "running"
+	StatusPassed  = "passed"
+	StatusFailed  = "failed"
+)
+
+// Run is the registry entry of a kubetest2 run
+type Run struct {
+	ID       string `json:"id"`
+	Deployer string `json:"deployer"`
+	// Args are the arguments of the deployer, as passed to kubetest2
+	Args      []string `json:"args"`
+	Status    string   `json:"status"`
+	Artifacts string   `json:"artifacts"`
+	RunDir    string   `json:"runDir"`
+	// ClusterUp is true from the start of Up until Down succeeds, the
+	// cluster of a run interrupted in between may be left behind
+	ClusterUp bool `json:"clusterUp"`
+	// Resources describe the resources Down deletes, if the deployer
+	// reports them
+	Resources []string  `json:"resources,omitempty"`
+	Started   time.Time `json:"started"`
+	Finished  time.Time `json:"finished,omitempty"`
+}
+
+// Dir returns the registry directory under the artifacts base dir
+func Dir(artifactsDir string) string {
+	return filepath.Join(artifactsDir, "runs")
+}
+
+// Save writes the entry of r to the registry in dir
+func Save(dir string, r *Run) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	// write and rename, so that listing never sees a partial entry
+	tmp := filepath.Join(dir, "."+r.ID+".json.tmp")
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, entryPath(dir, r.ID))
+}
+
+// Get returns the entry of the run id in the registry in dir
+func Get(dir, id string) (*Run, error) {
+	data, err := os.ReadFile(entryPath(dir, id))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no run %q in %s", id, dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	r := &Run{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("failed to parse the entry of run %q: %w", id, err)
+	}
+	return r, nil
+}
+
+// List returns the runs in the registry in dir, oldest first
+func List(dir string) ([]*Run, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var list []*Run
+	for _, e := range entries {
+		id, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok || e.IsDir() || strings.HasPrefix(id, ".") {
+			continue
+		}
+		r, err := Get(dir, id)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, r)
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].Started.Before(list[j].Started) })
+	return list, nil
+}
+
+// Remove removes the entry of the run id from the registry in dir
+func Remove(dir, id string) error {
+	if err := os.Remove(entryPath(dir, id)); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no run %q in %s", id, dir)
+		}
+		return err
+	}
+	return nil
+}
+
+func entryPath(dir, id string) string {
+	return filepath.Join(dir, id+".json")
+}
+
+// DownArgs returns the deployer arguments tearing down the cluster of r: the
+// recorded arguments before `--` without the other lifecycle actions, with
+// --down and the run id of r.
+func DownArgs(r *Run) []string {
+	// lifecycle flags dropped from the recorded args, true if they take a
+	// value when not set with =
+	dropped := map[string]bool{
+		"--build": false, "--up": false, "--down": false,
+		"--test": true, "--run-id": true,
+	}
+	args := []string{}
+	for i := 0; i < len(r.Args); i++ {
+		arg := r.Args[i]
+		if arg == "--" {
+			break
+		}
+		name, _, hasValue := strings.Cut(arg, "=")
+		takesValue, drop := dropped[name]
+		if !drop {
+			args = append(args, arg)
+			continue
+		}
+		if takesValue && !hasValue {
+			i++
+		}
+	}
+	return append(args, "--down", "--run-id="+r.ID)
+}
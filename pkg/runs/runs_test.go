@@ -0,0 +1,89 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runs
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRegistry(t *testing.T) {
+	dir := Dir(t.TempDir())
+	started := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	newer := &Run{ID: "newer", Deployer: "kind", Status: StatusRunning, Started: started.Add(time.Hour)}
+	older := &Run{ID: "older", Deployer: "gke", Status: StatusFailed, ClusterUp: true, Started: started}
+
+	list, err := List(dir)
+	if err != nil || len(list) != 0 {
+		t.Fatalf("expected an empty registry but got %v, %v", list, err)
+	}
+	for _, r := range []*Run{newer, older} {
+		if err := Save(dir, r); err != nil {
+			t.Fatalf("failed to save run %s: %v", r.ID, err)
+		}
+	}
+	newer.Status = StatusPassed
+	if err := Save(dir, newer); err != nil {
+		t.Fatalf("failed to update run %s: %v", newer.ID, err)
+	}
+
+	list, err = List(dir)
+	if err != nil {
+		t.Fatalf("failed to list the runs: %v", err)
+	}
+	if !reflect.DeepEqual(list, []*Run{older, newer}) {
+		t.Errorf("expected the runs oldest first but got %+v", list)
+	}
+
+	if err := Remove(dir, "older"); err != nil {
+		t.Fatalf("failed to remove run older: %v", err)
+	}
+	if _, err := Get(dir, "older"); err == nil {
+		t.Errorf("expected no entry for the removed run")
+	}
+	if err := Remove(dir, "older"); err == nil {
+		t.Errorf("expected an error removing the removed run again")
+	}
+}
+
+func TestDownArgs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{
+			name:     "lifecycle flags and tester args",
+			args:     []string{"--build", "--up", "--zone=us-central1-c", "--down", "--test=ginkgo", "--", "--focus-regex=foo"},
+			expected: []string{"--zone=us-central1-c", "--down", "--run-id=abc"},
+		},
+		{
+			name:     "flag values in the next argument",
+			args:     []string{"--up", "--test", "ginkgo", "--run-id", "abc", "--cluster-name", "kt2"},
+			expected: []string{"--cluster-name", "kt2", "--down", "--run-id=abc"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := DownArgs(&Run{ID: "abc", Args: tc.args})
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
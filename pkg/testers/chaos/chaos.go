@@ -0,0 +1,220 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package chaos implements a tester that runs chaos experiments of
+// Chaos Mesh (https://chaos-mesh.org) or LitmusChaos (https://litmuschaos.io)
+// against the cluster, validating the steady state of the cluster before
+// and after each experiment.
+package chaos
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	stdexec "os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/octago/sflags/gen/gpflag"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/artifacts"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/flagdump"
+	"sigs.k8s.io/kubetest2/pkg/metadata"
+	"sigs.k8s.io/kubetest2/pkg/testers"
+	"sigs.k8s.io/kubetest2/pkg/version"
+)
+
+var GitTag string
+
+const (
+	toolkitChaosMesh = "chaos-mesh"
+	toolkitLitmus    = "litmus"
+)
+
+// toolkitChart is the helm chart installing a chaos toolkit
+type toolkitChart struct {
+	repoName  string
+	repoURL   string
+	chart     string
+	namespace string
+}
+
+var toolkitCharts = map[string]toolkitChart{
+	toolkitChaosMesh: {
+		repoName:  "chaos-mesh",
+		repoURL:   "https://charts.chaos-mesh.org",
+		chart:     "chaos-mesh/chaos-mesh",
+		namespace: "chaos-mesh",
+	},
+	toolkitLitmus: {
+		repoName:  "litmuschaos",
+		repoURL:   "https://litmuschaos.github.io/litmus-helm/",
+		chart:     "litmuschaos/litmus-core",
+		namespace: "litmus",
+	},
+}
+
+type Tester struct {
+	Toolkit            string        `desc:"The chaos toolkit running the experiments, one of chaos-mesh or litmus."`
+	ChartVersion       string        `desc:"Version of the helm chart installing the chaos toolkit. Defaults to the latest version."`
+	SkipInstall        bool          `desc:"Skip installing the chaos toolkit, for clusters where it is already installed."`
+	Experiments        []string      `desc:"Comma separated list of paths to the experiment manifests, run sequentially. Chaos Mesh experiments run for --experiment-duration and are then deleted, LitmusChaos experiments are ChaosEngines that run to completion and must have a Pass verdict."`
+	Namespace          string        `desc:"Namespace the experiments are applied in, the experiment manifests must not set a different one."`
+	ExperimentDuration time.Duration `desc:"How long to inject the chaos of each Chaos Mesh experiment, or the maximum time to wait for each LitmusChaos experiment to complete."`
+	SteadyStateCommand string        `desc:"Shell command validating the steady state of the cluster, run before and after each experiment. A non-zero exit code means the cluster is not in its steady state."`
+	SteadyStateTimeout time.Duration `desc:"How long to wait for the cluster to return to its steady state after each experiment."`
+
+	kubectlPath string
+	// cmder creates the commands run by the tester, faked in tests
+	cmder exec.Cmder
+}
+
+func NewDefaultTester() *Tester {
+	return &Tester{
+		Toolkit:            toolkitChaosMesh,
+		Namespace:          "default",
+		ExperimentDuration: 5 * time.Minute,
+		SteadyStateTimeout: 5 * time.Minute,
+		cmder:              exec.DefaultCmder,
+	}
+}
+
+func (t *Tester) validateFlags() error {
+	if _, ok := toolkitCharts[t.Toolkit]; !ok {
+		return fmt.Errorf("--toolkit must be one of %s or %s, got %q", toolkitChaosMesh, toolkitLitmus, t.Toolkit)
+	}
+	if len(t.Experiments) == 0 {
+		return fmt.Errorf("required --experiments")
+	}
+	if t.ExperimentDuration <= 0 {
+		return fmt.Errorf("--experiment-duration must be positive")
+	}
+	return nil
+}
+
+// Test installs the chaos toolkit and runs the experiments, each experiment
+// is recorded as a test case in junit_chaos.xml in the artifacts.
+func (t *Tester) Test() (result error) {
+	kubectlPath, err := stdexec.LookPath("kubectl")
+	if err != nil {
+		return fmt.Errorf("failed to find kubectl: %w", err)
+	}
+	t.kubectlPath = kubectlPath
+
+	if !t.SkipInstall {
+		if err := t.installToolkit(); err != nil {
+			return err
+		}
+	}
+
+	junitFile, err := os.Create(filepath.Join(artifacts.BaseDir(), "junit_chaos.xml"))
+	if err != nil {
+		return fmt.Errorf("could not create junit output: %w", err)
+	}
+	writer := metadata.NewWriter("chaos", junitFile)
+	defer func() {
+		if err := writer.Finish(); err != nil && result == nil {
+			result = err
+		}
+		if err := junitFile.Close(); err != nil && result == nil {
+			result = err
+		}
+	}()
+
+	// run every experiment even if one fails, the failures are in the junit
+	var failed []string
+	for _, experiment := range t.Experiments {
+		name := filepath.Base(experiment)
+		err := writer.WrapStep(name, func() error {
+			return t.runExperiment(experiment)
+		})
+		if err != nil {
+			klog.Errorf("Experiment %s failed: %v", name, err)
+			failed = append(failed, name)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("chaos experiments failed: %v", failed)
+	}
+	return nil
+}
+
+// installToolkit installs the chaos toolkit with helm, waiting for it to be ready
+func (t *Tester) installToolkit() error {
+	chart := toolkitCharts[t.Toolkit]
+	klog.V(0).Infof("Installing %s from %s", t.Toolkit, chart.chart)
+	if err := runWithOutput(t.cmder.Command("helm", "repo", "add", chart.repoName, chart.repoURL, "--force-update")); err != nil {
+		return fmt.Errorf("failed to add the %s helm repo: %w", t.Toolkit, err)
+	}
+	args := []string{"upgrade", "--install", t.Toolkit, chart.chart,
+		"--namespace", chart.namespace,
+		"--create-namespace",
+		"--wait",
+	}
+	if t.ChartVersion != "" {
+		args = append(args, "--version", t.ChartVersion)
+	}
+	if err := runWithOutput(t.cmder.Command("helm", args...)); err != nil {
+		return fmt.Errorf("failed to install %s: %w", t.Toolkit, err)
+	}
+	return nil
+}
+
+func runWithOutput(cmd exec.Cmd) error {
+	exec.InheritOutput(cmd)
+	return cmd.Run()
+}
+
+func (t *Tester) Execute() error {
+	fs, err := gpflag.Parse(t)
+	if err != nil {
+		return fmt.Errorf("failed to initialize tester: %v", err)
+	}
+
+	// initing the klog flags adds them to goflag.CommandLine
+	// they can then be added to the built pflag set
+	klog.InitFlags(nil)
+	fs.AddGoFlagSet(flag.CommandLine)
+
+	help := fs.BoolP("help", "h", false, "")
+	flagdump.PrintIfRequested(fs)
+	if err := fs.Parse(os.Args); err != nil {
+		return fmt.Errorf("failed to parse flags: %v", err)
+	}
+
+	if *help {
+		fs.SetOutput(os.Stdout)
+		fs.PrintDefaults()
+		return nil
+	}
+	if err := t.validateFlags(); err != nil {
+		return fmt.Errorf("failed to validate flags: %v", err)
+	}
+	if err := testers.WriteVersionToMetadata(GitTag); err != nil {
+		return err
+	}
+	return t.Test()
+}
+
+func Main() {
+	version.PrintIfRequested(GitTag)
+	t := NewDefaultTester()
+	if err := t.Execute(); err != nil {
+		klog.Fatalf("failed to run chaos tester: %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+func TestValidateFlags(t *testing.T) {
+	testCases := []struct {
+		name        string
+		modify      func(*Tester)
+		expectError bool
+	}{
+		{
+			name: "chaos mesh",
+		},
+		{
+			name:   "litmus",
+			modify: func(t *Tester) { t.Toolkit = toolkitLitmus },
+		},
+		{
+			name:        "unknown toolkit",
+			modify:      func(t *Tester) { t.Toolkit = "gremlin" },
+			expectError: true,
+		},
+		{
+			name:        "no experiments",
+			modify:      func(t *Tester) { t.Experiments = nil },
+			expectError: true,
+		},
+		{
+			name:        "no experiment duration",
+			modify:      func(t *Tester) { t.ExperimentDuration = 0 },
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tester := NewDefaultTester()
+			tester.Experiments = []string{"pod-kill.yaml"}
+			if tc.modify != nil {
+				tc.modify(tester)
+			}
+			err := tester.validateFlags()
+			if tc.expectError != (err != nil) {
+				t.Errorf("expected error %v but got %v", tc.expectError, err)
+			}
+		})
+	}
+}
+
+func TestInstallToolkit(t *testing.T) {
+	testCases := []struct {
+		name             string
+		toolkit          string
+		chartVersion     string
+		responses        []exec.FakeResponse
+		expectedCommands []string
+		expectError      bool
+	}{
+		{
+			name:    "chaos mesh",
+			toolkit: toolkitChaosMesh,
+			expectedCommands: []string{
+				"helm repo add chaos-mesh https://charts.chaos-mesh.org --force-update",
+				"helm upgrade --install chaos-mesh chaos-mesh/chaos-mesh --namespace chaos-mesh --create-namespace --wait",
+			},
+		},
+		{
+			name:         "litmus chart version",
+			toolkit:      toolkitLitmus,
+			chartVersion: "3.8.0",
+			expectedCommands: []string{
+				"helm repo add litmuschaos https://litmuschaos.github.io/litmus-helm/ --force-update",
+				"helm upgrade --install litmus litmuschaos/litmus-core --namespace litmus --create-namespace --wait --version 3.8.0",
+			},
+		},
+		{
+			name:             "repo add failed",
+			toolkit:          toolkitChaosMesh,
+			responses:        []exec.FakeResponse{{Prefix: "helm repo add", Err: errors.New("exit status 1")}},
+			expectedCommands: []string{"helm repo add chaos-mesh https://charts.chaos-mesh.org --force-update"},
+			expectError:      true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := &exec.FakeCmder{Responses: tc.responses}
+			tester := NewDefaultTester()
+			tester.cmder = cmder
+			tester.Toolkit = tc.toolkit
+			tester.ChartVersion = tc.chartVersion
+			err := tester.installToolkit()
+			if tc.expectError != (err != nil) {
+				t.Errorf("expected error %v but got %v", tc.expectError, err)
+			}
+			if commands := cmder.Commands(); !reflect.DeepEqual(commands, tc.expectedCommands) {
+				t.Errorf("expected commands %v but got %v", tc.expectedCommands, commands)
+			}
+		})
+	}
+}
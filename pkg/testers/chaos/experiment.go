@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+const (
+	// litmusEngineResource is the resource type of LitmusChaos experiments
+	// in the kubectl apply -o name output
+	litmusEngineResource = "chaosengine.litmuschaos.io/"
+	// litmusPassVerdict is the verdict of a passing LitmusChaos experiment
+	litmusPassVerdict = "Pass"
+	// steadyStatePollInterval is how often the steady state is checked while
+	// waiting for the cluster to recover from an experiment
+	steadyStatePollInterval = 10 * time.Second
+)
+
+// runExperiment runs the experiment in the manifest at path, the cluster must
+// be in its steady state before the experiment and return to it afterwards.
+func (t *Tester) runExperiment(path string) error {
+	if err := t.checkSteadyState(); err != nil {
+		return fmt.Errorf("cluster is not in its steady state before the experiment: %w", err)
+	}
+
+	klog.V(0).Infof("Applying experiment %s", path)
+	resources, err := exec.OutputLines(t.kubectl("apply", "-f", path, "-o", "name"))
+	if err != nil {
+		return fmt.Errorf("failed to apply the experiment: %w", err)
+	}
+
+	switch t.Toolkit {
+	case toolkitChaosMesh:
+		klog.V(0).Infof("Injecting chaos for %v", t.ExperimentDuration)
+		time.Sleep(t.ExperimentDuration)
+		// deleting the experiment stops the chaos
+		if err := t.deleteExperiment(path); err != nil {
+			return err
+		}
+	case toolkitLitmus:
+		verdictErr := t.waitForLitmusVerdicts(resources)
+		if err := t.deleteExperiment(path); err != nil {
+			klog.Warningf("Failed to clean up experiment %s: %v", path, err)
+		}
+		if verdictErr != nil {
+			return verdictErr
+		}
+	}
+
+	if err := t.waitForSteadyState(); err != nil {
+		return fmt.Errorf("cluster did not return to its steady state after the experiment: %w", err)
+	}
+	return nil
+}
+
+func (t *Tester) deleteExperiment(path string) error {
+	if err := runWithOutput(t.kubectl("delete", "-f", path, "--wait")); err != nil {
+		return fmt.Errorf("failed to delete the experiment: %w", err)
+	}
+	return nil
+}
+
+// waitForLitmusVerdicts waits for the ChaosEngines in resources to complete
+// and checks that all of their experiments passed.
+func (t *Tester) waitForLitmusVerdicts(resources []string) error {
+	var engines []string
+	for _, resource := range resources {
+		if engine, ok := strings.CutPrefix(strings.TrimSpace(resource), litmusEngineResource); ok {
+			engines = append(engines, engine)
+		}
+	}
+	if len(engines) == 0 {
+		return fmt.Errorf("the experiment has no ChaosEngine")
+	}
+
+	for _, engine := range engines {
+		klog.V(0).Infof("Waiting up to %v for ChaosEngine %s to complete", t.ExperimentDuration, engine)
+		if err := runWithOutput(t.kubectl("wait", "chaosengine/"+engine,
+			"--for=jsonpath={.status.engineStatus}=completed",
+			"--timeout="+t.ExperimentDuration.String())); err != nil {
+			return fmt.Errorf("ChaosEngine %s did not complete: %w", engine, err)
+		}
+		results, err := exec.OutputLines(t.kubectl("get", "chaosresults",
+			"-o", `jsonpath={range .items[*]}{.metadata.name}={.status.experimentStatus.verdict}{"\n"}{end}`))
+		if err != nil {
+			return fmt.Errorf("failed to get the results of ChaosEngine %s: %w", engine, err)
+		}
+		if err := checkLitmusVerdicts(engine, results); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkLitmusVerdicts checks the verdicts of the ChaosResults of the engine,
+// given as <name>=<verdict> lines. ChaosResults are named <engine>-<experiment>.
+func checkLitmusVerdicts(engine string, results []string) error {
+	found := false
+	for _, result := range results {
+		name, verdict, ok := strings.Cut(strings.TrimSpace(result), "=")
+		if !ok || !strings.HasPrefix(name, engine+"-") {
+			continue
+		}
+		found = true
+		if verdict != litmusPassVerdict {
+			return fmt.Errorf("ChaosResult %s verdict is %q", name, verdict)
+		}
+	}
+	if !found {
+		return fmt.Errorf("ChaosEngine %s has no ChaosResult", engine)
+	}
+	return nil
+}
+
+// checkSteadyState runs the --steady-state-command, if set
+func (t *Tester) checkSteadyState() error {
+	if t.SteadyStateCommand == "" {
+		return nil
+	}
+	return runWithOutput(t.cmder.Command("bash", "-c", t.SteadyStateCommand))
+}
+
+// waitForSteadyState polls the steady state until it is met, or until
+// --steady-state-timeout expires.
+func (t *Tester) waitForSteadyState() error {
+	deadline := time.Now().Add(t.SteadyStateTimeout)
+	for {
+		err := t.checkSteadyState()
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %v: %w", t.SteadyStateTimeout, err)
+		}
+		klog.V(1).Infof("Cluster is not in its steady state yet: %v", err)
+		time.Sleep(steadyStatePollInterval)
+	}
+}
+
+// kubectl returns a kubectl command in the experiments namespace
+func (t *Tester) kubectl(args ...string) exec.Cmd {
+	return t.cmder.Command(t.kubectlPath, append([]string{"--namespace=" + t.Namespace}, args...)...)
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chaos
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+func TestCheckLitmusVerdicts(t *testing.T) {
+	testCases := []struct {
+		name        string
+		results     []string
+		expectError bool
+	}{
+		{
+			name:    "all passed",
+			results: []string{"nginx-chaos-pod-delete=Pass", "nginx-chaos-pod-cpu-hog=Pass", "other-chaos-pod-delete=Fail"},
+		},
+		{
+			name:        "one failed",
+			results:     []string{"nginx-chaos-pod-delete=Pass", "nginx-chaos-pod-cpu-hog=Fail"},
+			expectError: true,
+		},
+		{
+			name:        "still running",
+			results:     []string{"nginx-chaos-pod-delete=Awaited"},
+			expectError: true,
+		},
+		{
+			name:        "no results",
+			results:     []string{"other-chaos-pod-delete=Pass", ""},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkLitmusVerdicts("nginx-chaos", tc.results)
+			if tc.expectError && err == nil {
+				t.Errorf("expected an error but got none")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("did not expect an error, but got: %v", err)
+			}
+		})
+	}
+}
+
+// newExperimentTester returns a tester running its commands with cmder, which
+// doesn't wait for the chaos to be injected nor for the steady state
+func newExperimentTester(cmder exec.Cmder, toolkit string) *Tester {
+	tester := NewDefaultTester()
+	tester.cmder = cmder
+	tester.kubectlPath = "kubectl"
+	tester.Toolkit = toolkit
+	tester.ExperimentDuration = time.Millisecond
+	tester.SteadyStateTimeout = 0
+	tester.SteadyStateCommand = "./steady.sh"
+	return tester
+}
+
+func TestRunExperiment(t *testing.T) {
+	steady := "bash -c ./steady.sh"
+	apply := "kubectl --namespace=default apply -f pod-kill.yaml -o name"
+	remove := "kubectl --namespace=default delete -f pod-kill.yaml --wait"
+	wait := "kubectl --namespace=default wait chaosengine/nginx-chaos --for=jsonpath={.status.engineStatus}=completed --timeout=1ms"
+	results := "kubectl --namespace=default get chaosresults"
+	testCases := []struct {
+		name             string
+		toolkit          string
+		responses        []exec.FakeResponse
+		expectedCommands []string
+		expectError      bool
+	}{
+		{
+			name:             "chaos mesh",
+			toolkit:          toolkitChaosMesh,
+			responses:        []exec.FakeResponse{{Prefix: apply, Stdout: "podchaos.chaos-mesh.org/pod-kill\n"}},
+			expectedCommands: []string{steady, apply, remove, steady},
+		},
+		{
+			name:             "not in the steady state before the experiment",
+			toolkit:          toolkitChaosMesh,
+			responses:        []exec.FakeResponse{{Prefix: steady, Err: errors.New("exit status 1")}},
+			expectedCommands: []string{steady},
+			expectError:      true,
+		},
+		{
+			name:    "litmus",
+			toolkit: toolkitLitmus,
+			responses: []exec.FakeResponse{
+				{Prefix: apply, Stdout: "chaosengine.litmuschaos.io/nginx-chaos\n"},
+				{Prefix: results, Stdout: "nginx-chaos-pod-delete=Pass\n"},
+			},
+			expectedCommands: []string{steady, apply, wait, results + ` -o jsonpath={range .items[*]}{.metadata.name}={.status.experimentStatus.verdict}{"\n"}{end}`, remove, steady},
+		},
+		{
+			name:    "failed litmus verdict is cleaned up",
+			toolkit: toolkitLitmus,
+			responses: []exec.FakeResponse{
+				{Prefix: apply, Stdout: "chaosengine.litmuschaos.io/nginx-chaos\n"},
+				{Prefix: results, Stdout: "nginx-chaos-pod-delete=Fail\n"},
+			},
+			expectedCommands: []string{steady, apply, wait, results + ` -o jsonpath={range .items[*]}{.metadata.name}={.status.experimentStatus.verdict}{"\n"}{end}`, remove},
+			expectError:      true,
+		},
+		{
+			name:             "litmus experiment without a ChaosEngine",
+			toolkit:          toolkitLitmus,
+			responses:        []exec.FakeResponse{{Prefix: apply, Stdout: "chaosexperiment.litmuschaos.io/pod-delete\n"}},
+			expectedCommands: []string{steady, apply, remove},
+			expectError:      true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmder := &exec.FakeCmder{Responses: tc.responses}
+			tester := newExperimentTester(cmder, tc.toolkit)
+			err := tester.runExperiment("pod-kill.yaml")
+			if tc.expectError != (err != nil) {
+				t.Errorf("expected error %v but got %v", tc.expectError, err)
+			}
+			if commands := cmder.Commands(); !reflect.DeepEqual(commands, tc.expectedCommands) {
+				t.Errorf("expected commands %q but got %q", tc.expectedCommands, commands)
+			}
+		})
+	}
+}
+
+func TestWaitForSteadyState(t *testing.T) {
+	cmder := &exec.FakeCmder{Responses: []exec.FakeResponse{{Prefix: "bash -c ./steady.sh", Err: errors.New("exit status 1")}}}
+	tester := newExperimentTester(cmder, toolkitChaosMesh)
+	// the timeout has expired after the first check
+	if err := tester.waitForSteadyState(); err == nil {
+		t.Error("expected an error when the steady state is not met before the timeout")
+	}
+
+	tester.SteadyStateCommand = ""
+	if err := tester.waitForSteadyState(); err != nil {
+		t.Errorf("expected no steady state command to always be met, got: %v", err)
+	}
+}
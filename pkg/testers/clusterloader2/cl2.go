@@ -28,8 +28,10 @@ import (
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/flagdump"
 	"sigs.k8s.io/kubetest2/pkg/testers"
 	suite "sigs.k8s.io/kubetest2/pkg/testers/clusterloader2/suite"
+	"sigs.k8s.io/kubetest2/pkg/version"
 )
 
 var GitTag string
@@ -132,6 +134,7 @@ func (t *Tester) Execute() error {
 	fs.AddGoFlagSet(flag.CommandLine)
 
 	help := fs.BoolP("help", "h", false, "")
+	flagdump.PrintIfRequested(fs)
 	if err := fs.Parse(os.Args); err != nil {
 		return fmt.Errorf("failed to parse flags: %v", err)
 	}
@@ -148,6 +151,7 @@ func (t *Tester) Execute() error {
 }
 
 func Main() {
+	version.PrintIfRequested(GitTag)
 	t := NewDefaultTester()
 	if err := t.Execute(); err != nil {
 		klog.Fatalf("failed to run clusterloader2 tester: %v", err)
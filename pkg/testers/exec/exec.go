@@ -24,8 +24,10 @@ import (
 	"github.com/octago/sflags/gen/gpflag"
 	"k8s.io/klog/v2"
 
+	"sigs.k8s.io/kubetest2/pkg/flagdump"
 	"sigs.k8s.io/kubetest2/pkg/process"
 	"sigs.k8s.io/kubetest2/pkg/testers"
+	"sigs.k8s.io/kubetest2/pkg/version"
 )
 
 var GitTag string
@@ -49,13 +51,15 @@ func (t *Tester) Execute() error {
 		fmt.Print(usage)
 	}
 
+	// gracefully handle -h or --help if it is the only argument
+	help := fs.BoolP("help", "h", false, "")
+	flagdump.PrintIfRequested(fs)
+
 	if len(os.Args) < 2 {
 		fs.Usage()
 		return nil
 	}
 
-	// gracefully handle -h or --help if it is the only argument
-	help := fs.BoolP("help", "h", false, "")
 	// we don't care about errors, only if -h / --help was set
 	_ = fs.Parse(os.Args[1:2])
 
@@ -95,6 +99,7 @@ func NewDefaultTester() *Tester {
 }
 
 func Main() {
+	version.PrintIfRequested(GitTag)
 	t := NewDefaultTester()
 	if err := t.Execute(); err != nil {
 		klog.Fatalf("failed to run exec tester: %v", err)
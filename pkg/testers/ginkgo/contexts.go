@@ -0,0 +1,94 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// junitSuiteNameRe matches the name of the test suites in a junit report
+var junitSuiteNameRe = regexp.MustCompile(`(<testsuite\s[^>]*?\bname=")([^"]*)(")`)
+
+// runContext runs the suite against kubeContext with a kubeconfig holding
+// only that context, so that neither e2e.test nor the kubectl it runs can
+// use another one, and names the junit test suites after the context.
+func (t *Tester) runContext(env, extraGingkoArgs, e2eTestArgs []string, reportDir, kubeContext string) error {
+	kubeconfig, err := t.contextKubeconfig(kubeContext)
+	if err != nil {
+		return err
+	}
+	contextReportDir := filepath.Join(reportDir, contextDirName(kubeContext))
+	contextArgs := []string{
+		"--kubeconfig=" + kubeconfig,
+		"--report-dir=" + contextReportDir,
+		"--report-prefix=" + contextDirName(kubeContext),
+	}
+	testErr := t.runGinkgo(env, extraGingkoArgs, append(contextArgs, e2eTestArgs...))
+	if err := nameJUnitSuites(contextReportDir, kubeContext); err != nil {
+		klog.Warningf("Failed to name the junit test suites of context %s: %v", kubeContext, err)
+	}
+	return testErr
+}
+
+// contextKubeconfig writes the kubeconfig of kubeContext alone to the run
+// dir, which unlike the artifacts is not uploaded, and returns its path
+func (t *Tester) contextKubeconfig(kubeContext string) (string, error) {
+	config, err := exec.Output(t.kubectl(kubeContext, "config", "view", "--minify", "--flatten"))
+	if err != nil {
+		return "", fmt.Errorf("failed to get the kubeconfig of context %s: %w", kubeContext, err)
+	}
+	path := filepath.Join(t.runDir, "kubeconfig-"+contextDirName(kubeContext))
+	if err := os.WriteFile(path, config, 0600); err != nil {
+		return "", fmt.Errorf("failed to write the kubeconfig of context %s: %w", kubeContext, err)
+	}
+	return path, nil
+}
+
+// nameJUnitSuites appends the context to the test suite names of the junit
+// reports in dir, so that the results of the contexts can be told apart
+func nameJUnitSuites(dir, kubeContext string) error {
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, "junit") || filepath.Ext(name) != ".xml" {
+			return nil
+		}
+		report, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		suffix := " [" + contextDirName(kubeContext) + "]"
+		report = junitSuiteNameRe.ReplaceAll(report, []byte("${1}${2}"+suffix+"${3}"))
+		return os.WriteFile(path, report, 0644)
+	})
+	// the tests may fail before writing any report
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
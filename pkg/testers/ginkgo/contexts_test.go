@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+func TestNameJUnitSuites(t *testing.T) {
+	testCases := []struct {
+		name     string
+		report   string
+		expected string
+	}{
+		{
+			name:     "ginkgo v2 report",
+			report:   `<testsuites tests="2"><testsuite name="Kubernetes e2e suite" package="/e2e" tests="2"></testsuite></testsuites>`,
+			expected: `<testsuites tests="2"><testsuite name="Kubernetes e2e suite [gke_p_us-central1_c1]" package="/e2e" tests="2"></testsuite></testsuites>`,
+		},
+		{
+			name:     "ginkgo v1 report",
+			report:   `<testsuite tests="2" failures="0" time="12.5" name="Kubernetes e2e suite"></testsuite>`,
+			expected: `<testsuite tests="2" failures="0" time="12.5" name="Kubernetes e2e suite [gke_p_us-central1_c1]"></testsuite>`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "junit_01.xml")
+			if err := os.WriteFile(path, []byte(tc.report), 0644); err != nil {
+				t.Fatalf("failed to write the report: %v", err)
+			}
+			if err := nameJUnitSuites(dir, "gke_p_us-central1_c1"); err != nil {
+				t.Fatalf("did not expect an error, but got: %v", err)
+			}
+			report, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read the report: %v", err)
+			}
+			if string(report) != tc.expected {
+				t.Errorf("expected report %q but got %q", tc.expected, report)
+			}
+		})
+	}
+}
+
+func TestNameJUnitSuitesWithoutReports(t *testing.T) {
+	if err := nameJUnitSuites(filepath.Join(t.TempDir(), "missing"), "ctx"); err != nil {
+		t.Errorf("did not expect an error, but got: %v", err)
+	}
+}
+
+func TestContextKubeconfig(t *testing.T) {
+	defer func(cmder exec.Cmder) { exec.DefaultCmder = cmder }(exec.DefaultCmder)
+	cmder := &exec.FakeCmder{Responses: []exec.FakeResponse{{Prefix: "kubectl", Stdout: "current-context: arn:aws:eks:us-east-1:1:cluster/c1\n"}}}
+	exec.DefaultCmder = cmder
+
+	tester := &Tester{kubectlPath: "kubectl", kubeconfigPath: "/tmp/kubeconfig", runDir: t.TempDir()}
+	path, err := tester.contextKubeconfig("arn:aws:eks:us-east-1:1:cluster/c1")
+	if err != nil {
+		t.Fatalf("did not expect an error, but got: %v", err)
+	}
+	if expected := filepath.Join(tester.runDir, "kubeconfig-arn_aws_eks_us-east-1_1_cluster_c1"); path != expected {
+		t.Errorf("expected kubeconfig %s but got %s", expected, path)
+	}
+	config, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the kubeconfig: %v", err)
+	}
+	if string(config) != "current-context: arn:aws:eks:us-east-1:1:cluster/c1\n" {
+		t.Errorf("unexpected kubeconfig %q", config)
+	}
+	expectedCommands := []string{"kubectl --context=arn:aws:eks:us-east-1:1:cluster/c1 --kubeconfig=/tmp/kubeconfig config view --minify --flatten"}
+	if commands := cmder.Commands(); !reflect.DeepEqual(commands, expectedCommands) {
+		t.Errorf("expected commands %q but got %q", expectedCommands, commands)
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// failuresFile is the name of the failure summary written to the report dir
+const failuresFile = "failures.json"
+
+// failure is a failed spec in failures.json
+type failure struct {
+	Name      string  `json:"name"`
+	ClassName string  `json:"classname,omitempty"`
+	Message   string  `json:"message"`
+	Duration  float64 `json:"durationSeconds"`
+	// Artifacts is the directory of the output of the spec, relative to the
+	// artifacts dir
+	Artifacts string `json:"artifacts"`
+	// JUnit is the junit report listing the spec, relative to the artifacts dir
+	JUnit string `json:"junit"`
+}
+
+// junitReport is the subset of a junit report needed to list the failures and
+// count the skipped specs, the root element is either <testsuites> or a
+// single <testsuite>
+type junitReport struct {
+	Suites    []junitReport   `xml:"testsuite"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure"`
+	Error     *junitFailure `xml:"error"`
+	Skipped   *junitSkipped `xml:"skipped"`
+	SystemOut string        `xml:"system-out"`
+	SystemErr string        `xml:"system-err"`
+}
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Contents string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// testCases returns the test cases of the report and of its nested suites
+func (r *junitReport) testCases() []junitTestCase {
+	testCases := r.TestCases
+	for i := range r.Suites {
+		testCases = append(testCases, r.Suites[i].testCases()...)
+	}
+	return testCases
+}
+
+// parseFailures returns the failed specs of a junit report, the paths of the
+// failures are relative to baseDir
+func parseFailures(baseDir, junitPath string, report []byte) ([]failure, error) {
+	var parsed junitReport
+	if err := xml.Unmarshal(report, &parsed); err != nil {
+		return nil, err
+	}
+	junit, err := filepath.Rel(baseDir, junitPath)
+	if err != nil {
+		return nil, err
+	}
+	var failures []failure
+	for _, tc := range parsed.testCases() {
+		f := tc.Failure
+		if f == nil {
+			f = tc.Error
+		}
+		if f == nil {
+			continue
+		}
+		message := f.Message
+		if message == "" {
+			message = strings.TrimSpace(f.Contents)
+		}
+		failures = append(failures, failure{
+			Name:      tc.Name,
+			ClassName: tc.ClassName,
+			Message:   message,
+			Duration:  tc.Time,
+			Artifacts: specDir(junit, tc.Name),
+			JUnit:     junit,
+		})
+	}
+	return failures, nil
+}
+
+// writeFailures writes the failed specs of the junit reports in reportDir and
+// its sub directories to failures.json in reportDir, for triage tools that
+// would otherwise have to extract them from the build log.
+func writeFailures(baseDir, reportDir string) error {
+	failures := []failure{}
+	err := filepath.WalkDir(reportDir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, "junit") || filepath.Ext(name) != ".xml" {
+			return nil
+		}
+		report, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		reportFailures, err := parseFailures(baseDir, path, report)
+		if err != nil {
+			return err
+		}
+		failures = append(failures, reportFailures...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(failures, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(reportDir, failuresFile), b, 0644)
+}
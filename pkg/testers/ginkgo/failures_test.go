@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// ginkgoV2Report is a trimmed down junit report of ginkgo v2
+const ginkgoV2Report = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" disabled="0" errors="0" failures="2" time="120.5">
+  <testsuite name="Kubernetes e2e suite" package="/" tests="3" skipped="0" failures="2" errors="0" time="120.5">
+    <testcase name="[sig-network] Services should serve a basic endpoint from pods" classname="Kubernetes e2e suite" status="failed" time="61.2">
+      <failure message="failed to get endpoints: timed out" type="failed">[FAILED] failed to get endpoints: timed out
+In [It] at: test/e2e/network/service.go:123</failure>
+    </testcase>
+    <testcase name="[sig-node] Pods should be submitted and removed" classname="Kubernetes e2e suite" status="passed" time="10"></testcase>
+    <testcase name="[sig-apps] Deployment should not panic" classname="Kubernetes e2e suite" status="panicked" time="3.5">
+      <error message="" type="panicked">[PANICKED] runtime error: invalid memory address</error>
+    </testcase>
+  </testsuite>
+</testsuites>`
+
+// ginkgoV1Report is a trimmed down junit report of ginkgo v1
+const ginkgoV1Report = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite tests="1" failures="1" time="30">
+  <testcase name="[sig-storage] Volumes should store data" classname="Kubernetes e2e suite" time="30">
+    <failure type="Failure">volume never got attached</failure>
+  </testcase>
+</testsuite>`
+
+func TestParseFailures(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		report   string
+		expected []failure
+	}{
+		{
+			desc:   "ginkgo v2",
+			report: ginkgoV2Report,
+			expected: []failure{
+				{
+					Name:      "[sig-network] Services should serve a basic endpoint from pods",
+					ClassName: "Kubernetes e2e suite",
+					Message:   "failed to get endpoints: timed out",
+					Duration:  61.2,
+					Artifacts: filepath.Join("ginkgo-0", "ctx", "specs", "sig-network_Services_should_serve_a_basic_endpoint_from_pods"),
+					JUnit:     filepath.Join("ginkgo-0", "ctx", "junit_01.xml"),
+				},
+				{
+					Name:      "[sig-apps] Deployment should not panic",
+					ClassName: "Kubernetes e2e suite",
+					Message:   "[PANICKED] runtime error: invalid memory address",
+					Duration:  3.5,
+					Artifacts: filepath.Join("ginkgo-0", "ctx", "specs", "sig-apps_Deployment_should_not_panic"),
+					JUnit:     filepath.Join("ginkgo-0", "ctx", "junit_01.xml"),
+				},
+			},
+		},
+		{
+			desc:   "ginkgo v1",
+			report: ginkgoV1Report,
+			expected: []failure{
+				{
+					Name:      "[sig-storage] Volumes should store data",
+					ClassName: "Kubernetes e2e suite",
+					Message:   "volume never got attached",
+					Duration:  30,
+					Artifacts: filepath.Join("ginkgo-0", "ctx", "specs", "sig-storage_Volumes_should_store_data"),
+					JUnit:     filepath.Join("ginkgo-0", "ctx", "junit_01.xml"),
+				},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			junitPath := filepath.Join("/artifacts", "ginkgo-0", "ctx", "junit_01.xml")
+			failures, err := parseFailures("/artifacts", junitPath, []byte(tc.report))
+			if err != nil {
+				t.Fatalf("did not expect an error, but got: %v", err)
+			}
+			if !reflect.DeepEqual(failures, tc.expected) {
+				t.Errorf("expected failures %#v, but got %#v", tc.expected, failures)
+			}
+		})
+	}
+}
+
+func TestWriteFailures(t *testing.T) {
+	baseDir := t.TempDir()
+	reportDir, err := newReportDir(baseDir)
+	if err != nil {
+		t.Fatalf("did not expect an error, but got: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(reportDir, "junit_01.xml"), []byte(ginkgoV2Report), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(reportDir, "ctx"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(reportDir, "ctx", "junit_ctx_01.xml"), []byte(ginkgoV1Report), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeFailures(baseDir, reportDir); err != nil {
+		t.Fatalf("did not expect an error, but got: %v", err)
+	}
+	b, err := os.ReadFile(filepath.Join(reportDir, failuresFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var failures []failure
+	if err := json.Unmarshal(b, &failures); err != nil {
+		t.Fatalf("failed to parse %s: %v", failuresFile, err)
+	}
+	var junits []string
+	for _, f := range failures {
+		junits = append(junits, f.JUnit)
+	}
+	expected := []string{
+		filepath.Join("ginkgo-0", "ctx", "junit_ctx_01.xml"),
+		filepath.Join("ginkgo-0", "junit_01.xml"),
+		filepath.Join("ginkgo-0", "junit_01.xml"),
+	}
+	if !reflect.DeepEqual(junits, expected) {
+		t.Errorf("expected failures from %v, but got %v", expected, junits)
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// ginkgoVersionRe matches the output of ginkgo version, e.g.
+// "Ginkgo Version 2.9.5"
+var ginkgoVersionRe = regexp.MustCompile(`Ginkgo Version (\d+)\.`)
+
+// ginkgoFlag is a flag of the ginkgo CLI or, prefixed with "ginkgo.", of the
+// test binary, with its name in ginkgo v1 and v2, empty if the version
+// doesn't have it
+type ginkgoFlag struct {
+	v1, v2 string
+	// v1Test is the full name of the flag of the ginkgo v1 test binary, if
+	// it is not v1 prefixed with "ginkgo."
+	v1Test   string
+	hasValue bool
+}
+
+// ginkgoFlags are the flags renamed or removed between ginkgo v1 and v2,
+// flags with the same name in both, like --nodes or -p, are passed as is
+var ginkgoFlags = []ginkgoFlag{
+	// the ginkgo v1 test binary takes the timeout of go test
+	{v1: "timeout", v2: "timeout", v1Test: "test.timeout", hasValue: true},
+	{v1: "noColor", v2: "no-color"},
+	{v1: "flakeAttempts", v2: "flake-attempts", hasValue: true},
+	{v1: "failFast", v2: "fail-fast"},
+	{v1: "dryRun", v2: "dry-run"},
+	{v1: "slowSpecThreshold", v2: "slow-spec-threshold", hasValue: true},
+	{v1: "randomizeAllSpecs", v2: "randomize-all"},
+	{v1: "skipPackage", v2: "skip-package", hasValue: true},
+	{v1: "keepGoing", v2: "keep-going"},
+	{v1: "untilItFails", v2: "until-it-fails"},
+	{v1: "progress"},
+	{v1: "noisyPendings"},
+	{v1: "noisySkippings"},
+	{v1: "stream"},
+	{v1: "reportPassed"},
+	{v2: "label-filter", hasValue: true},
+	{v2: "poll-progress-after", hasValue: true},
+	{v2: "show-node-events"},
+	{v2: "github-output"},
+	{v2: "silence-skips"},
+	{v2: "json-report", hasValue: true},
+	{v2: "junit-report", hasValue: true},
+	{v2: "output-interceptor-mode", hasValue: true},
+}
+
+// ginkgoMajorVersion returns the major version of the ginkgo binary
+func ginkgoMajorVersion(ginkgoPath string) (int, error) {
+	out, err := exec.Output(exec.Command(ginkgoPath, "version"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to run %s version: %w", ginkgoPath, err)
+	}
+	return parseGinkgoMajorVersion(string(out))
+}
+
+func parseGinkgoMajorVersion(out string) (int, error) {
+	m := ginkgoVersionRe.FindStringSubmatch(out)
+	if m == nil {
+		return 0, fmt.Errorf("unexpected ginkgo version output %q", strings.TrimSpace(out))
+	}
+	return strconv.Atoi(m[1])
+}
+
+// translateGinkgoArgs rewrites the ginkgo flags in args to their names in
+// the given ginkgo major version, so that the same flags work with the test
+// packages of old and new release branches. The flags of the test binary
+// are selected with prefix "ginkgo.", those of the ginkgo CLI with no prefix.
+// Flags the version doesn't have are dropped, except --label-filter which
+// would change the selected specs.
+func translateGinkgoArgs(args []string, prefix string, major int) ([]string, error) {
+	var translated []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		name := strings.TrimLeft(arg, "-")
+		dashes := arg[:len(arg)-len(name)]
+		if dashes == "" || !strings.HasPrefix(name, prefix) {
+			translated = append(translated, arg)
+			continue
+		}
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(name, prefix), "=")
+		flag, ok := findGinkgoFlag(name)
+		if !ok {
+			translated = append(translated, arg)
+			continue
+		}
+		target := flag.v2
+		if major < 2 {
+			target = flag.v1
+		}
+		fullName := prefix + target
+		if major < 2 && prefix != "" && flag.v1Test != "" {
+			fullName = flag.v1Test
+		}
+		if target == "" {
+			if flag.v2 == "label-filter" {
+				return nil, fmt.Errorf("ginkgo v%d does not support %s%s, use a focus or skip regex instead", major, dashes, prefix+name)
+			}
+			klog.Warningf("Dropping %s%s not supported by ginkgo v%d", dashes, prefix+name, major)
+			if flag.hasValue && !hasValue && i+1 < len(args) {
+				// skip the value in the next argument
+				i++
+			}
+			continue
+		}
+		if !hasValue {
+			translated = append(translated, dashes+fullName)
+			continue
+		}
+		if flag.v1 == "slowSpecThreshold" {
+			value = translateSlowSpecThreshold(value, major)
+		}
+		translated = append(translated, dashes+fullName+"="+value)
+	}
+	return translated, nil
+}
+
+func findGinkgoFlag(name string) (ginkgoFlag, bool) {
+	if name == "" {
+		return ginkgoFlag{}, false
+	}
+	for _, flag := range ginkgoFlags {
+		if name == flag.v1 || name == flag.v2 {
+			return flag, true
+		}
+	}
+	return ginkgoFlag{}, false
+}
+
+// translateSlowSpecThreshold converts between the seconds of ginkgo v1 and
+// the duration of ginkgo v2, values already in the right format are kept.
+func translateSlowSpecThreshold(value string, major int) string {
+	if major < 2 {
+		if d, err := time.ParseDuration(value); err == nil {
+			return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+		}
+		return value
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return (time.Duration(seconds * float64(time.Second))).String()
+	}
+	return value
+}
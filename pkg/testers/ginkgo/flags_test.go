@@ -0,0 +1,119 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGinkgoMajorVersion(t *testing.T) {
+	testCases := []struct {
+		out         string
+		expected    int
+		expectError bool
+	}{
+		{out: "Ginkgo Version 1.16.5\n", expected: 1},
+		{out: "Ginkgo Version 2.9.5\n", expected: 2},
+		{out: "flag provided but not defined: -version", expectError: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.out, func(t *testing.T) {
+			actual, err := parseGinkgoMajorVersion(tc.out)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error but got version %d", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != tc.expected {
+				t.Errorf("expected version %d but got %d", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestTranslateGinkgoArgs(t *testing.T) {
+	testCases := []struct {
+		name        string
+		args        []string
+		prefix      string
+		major       int
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:     "test args for v1",
+			args:     []string{"--kubeconfig=/k", "--ginkgo.skip=Slow", "--ginkgo.timeout=24h0m0s", "--ginkgo.flake-attempts=2", "--ginkgo.no-color"},
+			prefix:   "ginkgo.",
+			major:    1,
+			expected: []string{"--kubeconfig=/k", "--ginkgo.skip=Slow", "--test.timeout=24h0m0s", "--ginkgo.flakeAttempts=2", "--ginkgo.noColor"},
+		},
+		{
+			name:     "test args for v2",
+			args:     []string{"--ginkgo.flakeAttempts=2", "--ginkgo.progress", "--ginkgo.slowSpecThreshold=120", "--ginkgo.timeout=1h0m0s"},
+			prefix:   "ginkgo.",
+			major:    2,
+			expected: []string{"--ginkgo.flake-attempts=2", "--ginkgo.slow-spec-threshold=2m0s", "--ginkgo.timeout=1h0m0s"},
+		},
+		{
+			name:     "cli timeout for v1",
+			args:     []string{"--timeout=1h0m0s", "--nodes=25"},
+			major:    1,
+			expected: []string{"--timeout=1h0m0s", "--nodes=25"},
+		},
+		{
+			name:     "cli timeout for v2",
+			args:     []string{"--timeout", "1h0m0s"},
+			major:    2,
+			expected: []string{"--timeout", "1h0m0s"},
+		},
+		{
+			name:     "cli args for v1 with separate values",
+			args:     []string{"-p", "--poll-progress-after", "5m", "--flake-attempts", "3"},
+			major:    1,
+			expected: []string{"-p", "--flakeAttempts", "3"},
+		},
+		{
+			name:        "label filter for v1",
+			args:        []string{"--ginkgo.label-filter=!Slow"},
+			prefix:      "ginkgo.",
+			major:       1,
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := translateGinkgoArgs(tc.args, tc.prefix, tc.major)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error but got %v", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"cloud.google.com/go/storage"
+)
+
+// gcsObject returns the bucket and the object of a gs:// URL
+func gcsObject(url string) (string, string, error) {
+	bucket, object, ok := strings.Cut(strings.TrimPrefix(url, "gs://"), "/")
+	if !ok || bucket == "" || object == "" {
+		return "", "", fmt.Errorf("%s is not a gs://<bucket>/<object> URL", url)
+	}
+	return bucket, object, nil
+}
+
+// openGCS returns a reader of the object of a gs:// URL, read with the
+// application default credentials so that private buckets can be accessed.
+func openGCS(ctx context.Context, url string) (io.ReadCloser, error) {
+	bucket, object, err := gcsObject(url)
+	if err != nil {
+		return nil, err
+	}
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the storage client: %w", err)
+	}
+	r, err := client.Bucket(bucket).Object(object).NewReader(ctx)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to read %s: %w", url, err)
+	}
+	return &gcsReader{Reader: r, client: client}, nil
+}
+
+// gcsReader closes the storage client with the object reader
+type gcsReader struct {
+	*storage.Reader
+	client *storage.Client
+}
+
+func (r *gcsReader) Close() error {
+	return errors.Join(r.Reader.Close(), r.client.Close())
+}
+
+// readGCS returns the contents of the object of a gs:// URL
+func readGCS(url string) (string, error) {
+	r, err := openGCS(context.Background(), url)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", url, err)
+	}
+	return string(b), nil
+}
+
+// downloadGCS saves the object of a gs:// URL to path
+func downloadGCS(url, path string) error {
+	r, err := openGCS(context.Background(), url)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		// a partial download is not the file
+		os.Remove(path)
+		return fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	return f.Close()
+}
+
+// gcsExists returns true if the object of a gs:// URL exists
+func gcsExists(url string) (bool, error) {
+	bucket, object, err := gcsObject(url)
+	if err != nil {
+		return false, err
+	}
+	ctx := context.Background()
+	client, err := storage.NewClient(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to create the storage client: %w", err)
+	}
+	defer client.Close()
+	_, err = client.Bucket(bucket).Object(object).Attrs(ctx)
+	switch {
+	case errors.Is(err, storage.ErrObjectNotExist):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("failed to check %s: %w", url, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeGCS serves the objects of the bucket "private" like the XML API for
+// reads and the JSON API for metadata, through $STORAGE_EMULATOR_HOST
+func fakeGCS(t *testing.T, objects map[string]string) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/storage/v1/b/private/o/")
+		jsonAPI := path != r.URL.Path
+		object, found := objects[strings.TrimPrefix(path, "/private/")]
+		switch {
+		case !found:
+			http.Error(w, `{"error": {"code": 404, "message": "No such object"}}`, http.StatusNotFound)
+		case jsonAPI:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"bucket": "private", "name": "` + path + `"}`))
+		default:
+			w.Write([]byte(object))
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("STORAGE_EMULATOR_HOST", strings.TrimPrefix(server.URL, "http://"))
+}
+
+func TestGCSObject(t *testing.T) {
+	testCases := []struct {
+		url            string
+		expectedBucket string
+		expectedObject string
+		expectError    bool
+	}{
+		{
+			url:            "gs://private/release/v1.31.0/kubernetes-test-linux-amd64.tar.gz",
+			expectedBucket: "private",
+			expectedObject: "release/v1.31.0/kubernetes-test-linux-amd64.tar.gz",
+		},
+		{
+			url:         "gs://private",
+			expectError: true,
+		},
+		{
+			url:         "gs:///release/latest.txt",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.url, func(t *testing.T) {
+			bucket, object, err := gcsObject(tc.url)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error but got %s %s", bucket, object)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if bucket != tc.expectedBucket || object != tc.expectedObject {
+				t.Errorf("expected %s %s but got %s %s", tc.expectedBucket, tc.expectedObject, bucket, object)
+			}
+		})
+	}
+}
+
+func TestGCSURLs(t *testing.T) {
+	fakeGCS(t, map[string]string{"release/latest.txt": "v1.31.0"})
+
+	version, err := readURL("gs://private/release/latest.txt")
+	if err != nil {
+		t.Fatalf("failed to read the object: %v", err)
+	}
+	if version != "v1.31.0" {
+		t.Errorf("expected v1.31.0 but got %q", version)
+	}
+	if _, err := readURL("gs://private/release/missing.txt"); err == nil {
+		t.Error("expected an error for a missing object but got none")
+	}
+
+	path := filepath.Join(t.TempDir(), "latest.txt")
+	if err := downloadURL("gs://private/release/latest.txt", path); err != nil {
+		t.Fatalf("failed to download the object: %v", err)
+	}
+	if b, err := os.ReadFile(path); err != nil || string(b) != "v1.31.0" {
+		t.Errorf("expected the downloaded file to be v1.31.0 but got %q, %v", b, err)
+	}
+	missingPath := filepath.Join(t.TempDir(), "missing.txt")
+	if err := downloadURL("gs://private/release/missing.txt", missingPath); err == nil {
+		t.Error("expected an error for a missing object but got none")
+	}
+	if _, err := os.Stat(missingPath); !os.IsNotExist(err) {
+		t.Errorf("expected no file for a missing object but got %v", err)
+	}
+
+	for url, expected := range map[string]bool{
+		"gs://private/release/latest.txt":  true,
+		"gs://private/release/missing.txt": false,
+	} {
+		exists, err := urlExists(url)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", url, err)
+		}
+		if exists != expected {
+			t.Errorf("expected %s to exist %v but got %v", url, expected, exists)
+		}
+	}
+}
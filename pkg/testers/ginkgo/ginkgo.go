@@ -17,12 +17,15 @@ limitations under the License.
 package ginkgo
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	stdexec "os/exec"
 	"path/filepath"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/kballard/go-shellquote"
@@ -32,7 +35,9 @@ import (
 	"sigs.k8s.io/kubetest2/pkg/artifacts"
 	"sigs.k8s.io/kubetest2/pkg/build"
 	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/flagdump"
 	"sigs.k8s.io/kubetest2/pkg/testers"
+	"sigs.k8s.io/kubetest2/pkg/version"
 )
 
 var GitTag string
@@ -41,16 +46,29 @@ type Tester struct {
 	GinkgoArgs          string        `desc:"Additional arguments supported by the ginkgo binary."`
 	Parallel            int           `desc:"Run this many tests in parallel at once."`
 	SkipRegex           string        `desc:"Regular expression of jobs to skip."`
+	SkipFile            string        `desc:"Path to a file with newline-separated spec names or labels to skip, in addition to --skip-regex. Blank lines and lines starting with # are ignored."`
 	FocusRegex          string        `desc:"Regular expression of jobs to focus on."`
-	TestPackageURL      string        `desc:"The url to download a kubernetes test package from."`
+	LabelFilter         string        `desc:"Ginkgo v2 label filter query of the specs to run, e.g. 'Feature:SELinux && !Slow', in addition to --focus-regex and --skip-regex. Not supported by the ginkgo v1 test packages of old release branches."`
+	TestPackageURL      string        `desc:"The url to download a kubernetes test package from. gs:// URLs are downloaded with the application default credentials, for private buckets."`
 	TestPackageVersion  string        `desc:"The ginkgo tester uses a test package made during the kubernetes build. The tester downloads this test package from one of the release tars published to the Release bucket. Defaults to latest. visit https://kubernetes.io/releases/ to find release names. Example: v1.20.0-alpha.0"`
 	TestPackageDir      string        `desc:"The directory in the bucket which represents the type of release. Default to the release directory."`
 	TestPackageMarker   string        `desc:"The version marker in the directory containing the package version to download when unspecified. Defaults to latest.txt."`
 	TestArgs            string        `desc:"Additional arguments supported by the e2e test framework (https://godoc.org/k8s.io/kubernetes/test/e2e/framework#TestContextType)."`
 	UseBuiltBinaries    bool          `desc:"Look for binaries in _rundir/$KUBETEST2_RUN_DIR instead of extracting from tars downloaded from GCS."`
 	UseBinariesFromPath bool          `desc:"Look for binaries in the $PATH instead of extracting from tars downloaded from GCS."`
-	Timeout             time.Duration `desc:"How long (in golang duration format) to wait for ginkgo tests to complete."`
+	Timeout             time.Duration `desc:"How long (in golang duration format) to wait for ginkgo tests to complete. Capped to the time left before the deadline of the run when kubetest2 sets one with --run-timeout."`
+	RunDeadlineMargin   time.Duration `desc:"How long before the deadline of the run set by kubetest2 --run-timeout the tests time out, leaving time to write the reports and tear down the cluster."`
 	Env                 []string      `desc:"List of env variables to pass to ginkgo libraries"`
+	EnvFromFile         []string      `desc:"List of NAME=PATH pairs, the env variable NAME is set to the contents of the file at PATH for the ginkgo libraries. Keeps secrets out of the command line and logs."`
+	TestRepoListFile    string        `desc:"Path to a YAML file overriding the registries of the e2e test images, passed to e2e.test as KUBE_TEST_REPO_LIST. Lets clusters in restricted networks use mirrored registries."`
+	Contexts            []string      `desc:"Comma separated list of kubeconfig contexts to run the tests against sequentially, with the reports of each context in a sub directory of the artifacts and the context appended to the junit test suite names. Each run gets a kubeconfig holding only its context. Defaults to the current context."`
+	PrepullImages       bool          `desc:"Pull the images used by the e2e tests onto every linux node with a daemonset before running the tests, to avoid image pull flakes in timing sensitive specs. Requires e2e.test --list-images, the tests run anyway if prepulling fails. The images of the invalid and authenticated registries and the windows images are not prepulled, nor waited for once their pull fails."`
+	PrepullTimeout      time.Duration `desc:"How long (in golang duration format) to wait for the images to be prepulled with --prepull-images."`
+	ProviderConfig      string        `desc:"Path to a YAML file mapping e2e framework flags to their values, e.g. provider: gce and gce-zone: us-central1-b, passed to e2e.test before --test-args. Defaults to the provider config built by the deployer from the cluster, if any. Provider specific flags with the skeleton provider are rejected, since e2e.test ignores them. A --provider in --test-args overrides the provider of the deployer, but must match the one of this file."`
+
+	DisableLogDump     bool   `desc:"Pass --disable-log-dump to e2e.test, so that it does not dump the master and node logs into the report dir after the tests, e.g. when the deployer dumps them at down."`
+	LogexporterGCSPath string `desc:"gs:// path e2e.test uploads the node logs to with logexporter after the tests, passed as --logexporter-gcs-path, instead of copying them into the report dir over SSH."`
+	MaxReportFileSize  int64  `desc:"Maximum size in MiB of the files the tests write to the report dir, e.g. the output of the failed specs written to a directory per spec. Larger files are gzip compressed after the tests, and deleted if still larger. The junit reports are never compressed. 0 means no limit."`
 
 	kubeconfigPath string
 	runDir         string
@@ -71,27 +89,121 @@ func (t *Tester) Test() error {
 		return err
 	}
 
+	skipRegex, err := t.skipRegex()
+	if err != nil {
+		return err
+	}
+
+	env, err := t.testEnv()
+	if err != nil {
+		return err
+	}
+
+	// prepull before taking the time left for the suite
+	if t.PrepullImages {
+		if len(t.Contexts) == 0 {
+			t.prepullImages(env, "")
+		}
+		for _, kubeContext := range t.Contexts {
+			t.prepullImages(env, kubeContext)
+		}
+	}
+
+	deadline := os.Getenv(testers.RunDeadlineEnv)
+	timeout, err := suiteTimeout(t.Timeout, t.RunDeadlineMargin, deadline, time.Now())
+	if err != nil {
+		return err
+	}
+
 	e2eTestArgs := []string{
-		"--kubeconfig=" + t.kubeconfigPath,
 		"--kubectl-path=" + t.kubectlPath,
-		"--ginkgo.skip=" + t.SkipRegex,
+		"--ginkgo.skip=" + skipRegex,
 		"--ginkgo.focus=" + t.FocusRegex,
-		"--report-dir=" + artifacts.BaseDir(),
-		"--ginkgo.timeout=" + t.Timeout.String(),
+		"--ginkgo.timeout=" + timeout.String(),
+	}
+	if t.LabelFilter != "" {
+		e2eTestArgs = append(e2eTestArgs, "--ginkgo.label-filter="+t.LabelFilter)
+	}
+	if t.DisableLogDump {
+		e2eTestArgs = append(e2eTestArgs, "--disable-log-dump=true")
+	}
+	if t.LogexporterGCSPath != "" {
+		e2eTestArgs = append(e2eTestArgs, "--logexporter-gcs-path="+t.LogexporterGCSPath)
 	}
 
 	extraE2EArgs, err := shellquote.Split(t.TestArgs)
 	if err != nil {
 		return fmt.Errorf("error parsing --test-args: %v", err)
 	}
+	providerArgs, err := t.providerArgs(extraE2EArgs)
+	if err != nil {
+		return err
+	}
+	e2eTestArgs = append(e2eTestArgs, providerArgs...)
 	e2eTestArgs = append(e2eTestArgs, extraE2EArgs...)
 
 	extraGingkoArgs, err := shellquote.Split(t.GinkgoArgs)
 	if err != nil {
 		return fmt.Errorf("error parsing --gingko-args: %v", err)
 	}
+	extraGingkoArgs = withSuiteTimeout(extraGingkoArgs, deadline, timeout)
+
+	// the test packages of older release branches come with ginkgo v1
+	major, err := ginkgoMajorVersion(t.ginkgoPath)
+	if err != nil {
+		klog.Warningf("Failed to detect the ginkgo version, assuming v2: %v", err)
+		major = 2
+	}
+	if e2eTestArgs, err = translateGinkgoArgs(e2eTestArgs, "ginkgo.", major); err != nil {
+		return fmt.Errorf("error translating --test-args for ginkgo v%d: %w", major, err)
+	}
+	if extraGingkoArgs, err = translateGinkgoArgs(extraGingkoArgs, "", major); err != nil {
+		return fmt.Errorf("error translating --ginkgo-args for ginkgo v%d: %w", major, err)
+	}
+
+	reportDir, err := newReportDir(artifacts.BaseDir())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := writeSpecDirs(reportDir); err != nil {
+			klog.Warningf("failed to write the spec directories of %s: %v", reportDir, err)
+		}
+		if err := writeFailures(artifacts.BaseDir(), reportDir); err != nil {
+			klog.Warningf("failed to write the failure summary of %s: %v", reportDir, err)
+		}
+		if err := recordSkips(artifacts.BaseDir(), reportDir, newSpecFilters(skipRegex, t.FocusRegex, t.LabelFilter)); err != nil {
+			klog.Warningf("failed to count the skipped specs of %s: %v", reportDir, err)
+		}
+		if err := limitReportFiles(reportDir, t.MaxReportFileSize<<20); err != nil {
+			klog.Warningf("failed to limit the size of the files in %s: %v", reportDir, err)
+		}
+		// last, as the steps above read the junit reports in reportDir
+		if err := moveJUnitReports(artifacts.BaseDir(), reportDir); err != nil {
+			klog.Warningf("failed to move the junit reports from %s: %v", reportDir, err)
+		}
+	}()
+	klog.V(0).Infof("Writing ginkgo reports to %s", reportDir)
+
+	if len(t.Contexts) == 0 {
+		return t.runGinkgo(env, extraGingkoArgs, append([]string{"--kubeconfig=" + t.kubeconfigPath, "--report-dir=" + reportDir}, e2eTestArgs...))
+	}
+
+	// run every context even if one fails, so that a single unhealthy
+	// cluster does not hide the results of the others
+	var errs []error
+	for _, kubeContext := range t.Contexts {
+		klog.V(0).Infof("Running ginkgo tests against context %s", kubeContext)
+		if err := t.runContext(env, extraGingkoArgs, e2eTestArgs, reportDir, kubeContext); err != nil {
+			errs = append(errs, fmt.Errorf("tests failed against context %s: %w", kubeContext, err))
+		}
+	}
+	return errors.Join(errs...)
+}
 
-	ginkgoArgs := append(extraGingkoArgs,
+// runGinkgo runs the e2e test binary with ginkgo
+func (t *Tester) runGinkgo(env, extraGingkoArgs, e2eTestArgs []string) error {
+	ginkgoArgs := append(append([]string{}, extraGingkoArgs...),
 		"--nodes="+strconv.Itoa(t.Parallel),
 		t.e2eTestPath,
 		"--")
@@ -99,11 +211,119 @@ func (t *Tester) Test() error {
 
 	klog.V(0).Infof("Running ginkgo test as %s %+v", t.ginkgoPath, ginkgoArgs)
 	cmd := exec.Command(t.ginkgoPath, ginkgoArgs...)
-	cmd.SetEnv(t.Env...)
+	cmd.SetEnv(env...)
 	exec.InheritOutput(cmd)
 	return cmd.Run()
 }
 
+// withSuiteTimeout prepends the suite timeout to the args of the ginkgo CLI,
+// which has a suite timeout of its own, 1h by default, when the run has a
+// deadline the suite must not outlive.
+func withSuiteTimeout(ginkgoArgs []string, deadline string, timeout time.Duration) []string {
+	if deadline == "" {
+		return ginkgoArgs
+	}
+	return append([]string{"--timeout=" + timeout.String()}, ginkgoArgs...)
+}
+
+// suiteTimeout returns timeout, capped to the time left before deadline
+// minus margin. deadline is in RFC 3339 format, empty if the run has none.
+func suiteTimeout(timeout, margin time.Duration, deadline string, now time.Time) (time.Duration, error) {
+	if deadline == "" {
+		return timeout, nil
+	}
+	runDeadline, err := time.Parse(time.RFC3339, deadline)
+	if err != nil {
+		return 0, fmt.Errorf("invalid deadline of the run %q: %w", deadline, err)
+	}
+	remaining := runDeadline.Sub(now) - margin
+	if remaining <= 0 {
+		return 0, fmt.Errorf("no time left for the tests before the deadline of the run %s", deadline)
+	}
+	if remaining < timeout {
+		klog.V(0).Infof("Reducing the suite timeout from %s to %s to finish before the deadline of the run %s", timeout, remaining, deadline)
+		return remaining, nil
+	}
+	return timeout, nil
+}
+
+// testEnv returns the env for ginkgo, --env plus the variables read from
+// the --env-from-file files and KUBE_TEST_REPO_LIST. The values are never logged.
+func (t *Tester) testEnv() ([]string, error) {
+	var extraEnv []string
+	for _, envFromFile := range t.EnvFromFile {
+		name, path, found := strings.Cut(envFromFile, "=")
+		if !found || name == "" || path == "" {
+			return nil, fmt.Errorf("invalid --env-from-file %q, expected NAME=PATH", envFromFile)
+		}
+		value, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read --env-from-file for %s: %w", name, err)
+		}
+		extraEnv = append(extraEnv, name+"="+strings.TrimRight(string(value), "\r\n"))
+	}
+	if t.TestRepoListFile != "" {
+		// ginkgo changes its working directory, so the path must be absolute
+		repoList, err := filepath.Abs(t.TestRepoListFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert --test-repo-list-file to absolute path: %w", err)
+		}
+		if _, err := os.Stat(repoList); err != nil {
+			return nil, fmt.Errorf("failed to validate --test-repo-list-file: %w", err)
+		}
+		extraEnv = append(extraEnv, "KUBE_TEST_REPO_LIST="+repoList)
+	}
+	if len(extraEnv) == 0 {
+		return t.Env, nil
+	}
+	// an empty env means inheriting the environment of the tester
+	env := t.Env
+	if len(env) == 0 {
+		env = os.Environ()
+	}
+	return append(append([]string{}, env...), extraEnv...), nil
+}
+
+// contextDirName returns a file name safe version of the kubeconfig context,
+// context names like EKS ARNs contain path separators
+func contextDirName(kubeContext string) string {
+	return strings.NewReplacer("/", "_", ":", "_").Replace(kubeContext)
+}
+
+// skipRegex returns --skip-regex extended with the entries in --skip-file.
+// Entries are matched literally, so labels like [Feature:Foo] need no escaping.
+func (t *Tester) skipRegex() (string, error) {
+	if t.SkipFile == "" {
+		return t.SkipRegex, nil
+	}
+	data, err := os.ReadFile(t.SkipFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read --skip-file: %w", err)
+	}
+	return joinSkipRegex(t.SkipRegex, string(data))
+}
+
+// joinSkipRegex compiles the newline-separated entries of skipList into
+// an alternation with skipRegex.
+func joinSkipRegex(skipRegex, skipList string) (string, error) {
+	var alternatives []string
+	if skipRegex != "" {
+		alternatives = append(alternatives, skipRegex)
+	}
+	for _, line := range strings.Split(skipList, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		alternatives = append(alternatives, regexp.QuoteMeta(line))
+	}
+	joined := strings.Join(alternatives, "|")
+	if _, err := regexp.Compile(joined); err != nil {
+		return "", fmt.Errorf("invalid skip expression: %w", err)
+	}
+	return joined, nil
+}
+
 func (t *Tester) pretestSetup() error {
 	if config := os.Getenv("KUBECONFIG"); config != "" {
 		// The ginkgo tester errors out if the kubeconfig provided
@@ -194,6 +414,7 @@ func (t *Tester) Execute() error {
 	fs.AddGoFlagSet(flag.CommandLine)
 
 	help := fs.BoolP("help", "h", false, "")
+	flagdump.PrintIfRequested(fs)
 
 	if err := fs.Parse(os.Args); err != nil {
 		return fmt.Errorf("failed to parse flags: %v", err)
@@ -205,6 +426,12 @@ func (t *Tester) Execute() error {
 		return nil
 	}
 
+	return t.Run()
+}
+
+// Run runs the tests with the flags of the tester already set, for testers
+// built on top of the ginkgo tester, which parse the flags themselves
+func (t *Tester) Run() error {
 	if err := t.initKubetest2Info(); err != nil {
 		return err
 	}
@@ -245,11 +472,14 @@ func NewDefaultTester() *Tester {
 		TestPackageDir:    "release",
 		TestPackageMarker: "latest.txt",
 		Timeout:           24 * time.Hour,
+		RunDeadlineMargin: 15 * time.Minute,
+		PrepullTimeout:    10 * time.Minute,
 		Env:               nil,
 	}
 }
 
 func Main() {
+	version.PrintIfRequested(GitTag)
 	t := NewDefaultTester()
 	if err := t.Execute(); err != nil {
 		klog.Fatalf("failed to run ginkgo tester: %v", err)
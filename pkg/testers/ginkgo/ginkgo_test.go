@@ -0,0 +1,232 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestJoinSkipRegex(t *testing.T) {
+	testCases := []struct {
+		name          string
+		skipRegex     string
+		skipList      string
+		expectedRegex string
+		expectError   bool
+	}{
+		{
+			name:          "no skip list",
+			skipRegex:     `\[Slow\]`,
+			expectedRegex: `\[Slow\]`,
+		},
+		{
+			name:          "skip list only",
+			skipList:      "[Feature:Foo]\nsome spec name\n",
+			expectedRegex: `\[Feature:Foo\]|some spec name`,
+		},
+		{
+			name:          "skip regex and skip list with comments and blank lines",
+			skipRegex:     `\[Serial\]`,
+			skipList:      "# flaky on this provider\n\n  [Feature:Bar]  \n",
+			expectedRegex: `\[Serial\]|\[Feature:Bar\]`,
+		},
+		{
+			name:        "invalid skip regex",
+			skipRegex:   `[Serial`,
+			skipList:    "foo",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actualRegex, err := joinSkipRegex(tc.skipRegex, tc.skipList)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect an error, but got: %v", err)
+			}
+			if actualRegex != tc.expectedRegex {
+				t.Errorf("mismatched skip regex: expected %q, but got %q", tc.expectedRegex, actualRegex)
+			}
+		})
+	}
+}
+
+func TestTestEnv(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "api-key")
+	if err := os.WriteFile(secretFile, []byte("s3cr3t\n"), 0600); err != nil {
+		t.Fatalf("failed to write secret file: %v", err)
+	}
+
+	repoListFile := filepath.Join(t.TempDir(), "repo-list.yaml")
+	if err := os.WriteFile(repoListFile, []byte("gcRegistry: mirror.example.com\n"), 0644); err != nil {
+		t.Fatalf("failed to write repo list file: %v", err)
+	}
+
+	testCases := []struct {
+		name         string
+		env          []string
+		envFromFile  []string
+		testRepoList string
+		expectedEnv  []string
+		expectError  bool
+	}{
+		{
+			name:        "env only",
+			env:         []string{"FOO=bar"},
+			expectedEnv: []string{"FOO=bar"},
+		},
+		{
+			name:        "env and env from file",
+			env:         []string{"FOO=bar"},
+			envFromFile: []string{"API_KEY=" + secretFile},
+			expectedEnv: []string{"FOO=bar", "API_KEY=s3cr3t"},
+		},
+		{
+			name:        "malformed env from file",
+			env:         []string{"FOO=bar"},
+			envFromFile: []string{secretFile},
+			expectError: true,
+		},
+		{
+			name:        "missing file",
+			env:         []string{"FOO=bar"},
+			envFromFile: []string{"API_KEY=" + filepath.Join(t.TempDir(), "missing")},
+			expectError: true,
+		},
+		{
+			name:         "test repo list",
+			env:          []string{"FOO=bar"},
+			testRepoList: repoListFile,
+			expectedEnv:  []string{"FOO=bar", "KUBE_TEST_REPO_LIST=" + repoListFile},
+		},
+		{
+			name:         "missing test repo list",
+			env:          []string{"FOO=bar"},
+			testRepoList: filepath.Join(t.TempDir(), "missing.yaml"),
+			expectError:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tester := &Tester{Env: tc.env, EnvFromFile: tc.envFromFile, TestRepoListFile: tc.testRepoList}
+			actualEnv, err := tester.testEnv()
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("did not expect an error, but got: %v", err)
+			}
+			if !reflect.DeepEqual(tc.expectedEnv, actualEnv) {
+				t.Errorf("mismatched env: expected: %v, but got: %v", tc.expectedEnv, actualEnv)
+			}
+		})
+	}
+}
+
+func TestSuiteTimeout(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name            string
+		deadline        string
+		expectedTimeout time.Duration
+		expectError     bool
+	}{
+		{
+			name:            "no deadline",
+			expectedTimeout: 24 * time.Hour,
+		},
+		{
+			name:            "capped to the deadline minus the margin",
+			deadline:        "2026-10-16T14:00:00Z",
+			expectedTimeout: 105 * time.Minute,
+		},
+		{
+			name:            "deadline after the timeout",
+			deadline:        "2026-10-18T12:00:00Z",
+			expectedTimeout: 24 * time.Hour,
+		},
+		{
+			name:        "no time left",
+			deadline:    "2026-10-16T12:10:00Z",
+			expectError: true,
+		},
+		{
+			name:        "malformed deadline",
+			deadline:    "in two hours",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := suiteTimeout(24*time.Hour, 15*time.Minute, tc.deadline, now)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error but got timeout %s", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != tc.expectedTimeout {
+				t.Errorf("expected timeout %s but got %s", tc.expectedTimeout, actual)
+			}
+		})
+	}
+}
+
+func TestWithSuiteTimeout(t *testing.T) {
+	testCases := []struct {
+		name     string
+		deadline string
+		expected []string
+	}{
+		{
+			name:     "no deadline",
+			expected: []string{"--nodes=25"},
+		},
+		{
+			name:     "deadline",
+			deadline: "2026-10-16T14:00:00Z",
+			expected: []string{"--timeout=1h45m0s", "--nodes=25"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := withSuiteTimeout([]string{"--nodes=25"}, tc.deadline, 105*time.Minute)
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected args %q but got %q", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -23,9 +23,12 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 	"k8s.io/klog/v2"
@@ -39,48 +42,293 @@ import (
 func (t *Tester) AcquireTestPackage() error {
 	// first, get the name of the latest release (e.g. v1.20.0-alpha.0)
 	if t.TestPackageVersion == "" {
-		resp, err := resty.New().R().Get(fmt.Sprintf("%s/%s/%s", t.TestPackageURL, t.TestPackageDir, t.TestPackageMarker))
+		version, err := readURL(fmt.Sprintf("%s/%s/%s", t.TestPackageURL, t.TestPackageDir, t.TestPackageMarker))
 		if err != nil {
 			return fmt.Errorf("failed to get latest release name: %s", err)
 		}
-		if resp.String() == "" {
+		if version == "" {
 			return fmt.Errorf("getting latest release name had no output")
 		}
-		t.TestPackageVersion = resp.String()
+		t.TestPackageVersion = version
 
 		klog.V(1).Infof("Test package version was not specified. Defaulting to version from %s: %s", t.TestPackageMarker, t.TestPackageVersion)
 	}
 
-	releaseTar := fmt.Sprintf("kubernetes-test-%s-%s.tar.gz", runtime.GOOS, runtime.GOARCH)
-
 	downloadDir, err := os.UserCacheDir()
 	if err != nil {
 		return fmt.Errorf("failed to get user cache directory: %v", err)
 	}
 
-	downloadPath := filepath.Join(downloadDir, releaseTar)
-
-	if err := t.ensureReleaseTar(downloadPath, releaseTar); err != nil {
-		return err
+	// the binaries of a version are extracted once, the tar is only needed
+	// when they are not cached yet
+	binDir := t.binaryCacheDir(downloadDir)
+	if _, err := os.Stat(filepath.Join(binDir, extractedMarker)); err == nil {
+		klog.V(0).Infof("Using the test binaries of %s cached at %s", t.TestPackageVersion, binDir)
+	} else {
+		// a tar downloaded before spares probing the release for the
+		// test package of the platform
+		pkg, found := t.localTestPackage(downloadDir, runtime.GOOS, runtime.GOARCH)
+		if !found {
+			pkg, err = t.findTestPackage(runtime.GOOS, runtime.GOARCH)
+			if err != nil {
+				return err
+			}
+			if err := t.ensureReleaseTar(filepath.Join(downloadDir, pkg.tar), pkg.tar); err != nil {
+				return err
+			}
+		}
+		downloadPath := filepath.Join(downloadDir, pkg.tar)
+		if err := extractBinaries(downloadPath, pkg.binDir, binDir); err != nil {
+			return err
+		}
 	}
-	if err := t.extractBinaries(downloadPath); err != nil {
+	if err := t.linkBinaries(binDir); err != nil {
 		return err
 	}
+	// the binaries are linked into the run dir, evicting the cache doesn't
+	// affect this run
+	evictBinaryCache(filepath.Join(downloadDir, binaryCacheRoot), binaryCacheMaxAge, time.Now())
 
 	t.kubectlPath = filepath.Join(artifacts.RunDir(), "kubectl")
 	return t.ensureKubectl(t.kubectlPath)
 }
 
-func (t *Tester) extractBinaries(downloadPath string) error {
+// extractedMarker is written to the cache directory of a version once all
+// of its binaries are extracted, so interrupted extractions are not reused
+const extractedMarker = ".extracted"
+
+// testBinaries are the binaries extracted from the test package
+var testBinaries = []string{"e2e.test", "ginkgo"}
+
+// releaseArches are the architectures kubernetes releases are published for
+var releaseArches = []string{"amd64", "arm", "arm64", "ppc64le", "s390x"}
+
+// testPackage is a test package tar of a release, and the directory of the
+// binaries of a platform in it
+type testPackage struct {
+	tar    string
+	binDir string
+}
+
+// testPackages returns the test packages which may hold the binaries of the
+// platform, in order: the tar of the platform, then the tar of all the
+// platforms published by the releases before v1.14.
+func testPackages(goos, goarch string) []testPackage {
+	return []testPackage{
+		{tar: fmt.Sprintf("kubernetes-test-%s-%s.tar.gz", goos, goarch), binDir: "kubernetes/test/bin"},
+		{tar: "kubernetes-test.tar.gz", binDir: fmt.Sprintf("kubernetes/platforms/%s/%s", goos, goarch)},
+	}
+}
+
+// localTestPackage returns the first of testPackages found in downloadDir
+// with the hash published for the version. The tars are not kept per
+// version, so a tar of another version is not used.
+func (t *Tester) localTestPackage(downloadDir, goos, goarch string) (testPackage, bool) {
+	for _, pkg := range testPackages(goos, goarch) {
+		downloadPath := filepath.Join(downloadDir, pkg.tar)
+		if _, err := os.Stat(downloadPath); err != nil {
+			continue
+		}
+		if err := t.compareSHA(downloadPath, t.releaseURL(pkg.tar)); err != nil {
+			klog.V(1).Infof("Not using the existing tar at %v: %v", downloadPath, err)
+			continue
+		}
+		klog.V(0).Infof("Validated hash for existing tar at %v", downloadPath)
+		return pkg, true
+	}
+	return testPackage{}, false
+}
+
+// findTestPackage returns the first of testPackages published for the
+// version, or an error listing the platforms it has test packages for.
+func (t *Tester) findTestPackage(goos, goarch string) (testPackage, error) {
+	for _, pkg := range testPackages(goos, goarch) {
+		exists, err := urlExists(t.releaseURL(pkg.tar))
+		if err != nil {
+			return testPackage{}, err
+		}
+		if exists {
+			return pkg, nil
+		}
+		klog.V(1).Infof("No test package %s in release %s", pkg.tar, t.TestPackageVersion)
+	}
+
+	var available []string
+	for _, arch := range releaseArches {
+		if exists, _ := urlExists(t.releaseURL(testPackages(goos, arch)[0].tar)); exists {
+			available = append(available, goos+"/"+arch)
+		}
+	}
+	if len(available) == 0 {
+		return testPackage{}, fmt.Errorf("no test package for %s/%s in %s, nor for any other architecture, check --test-package-url, --test-package-dir and --test-package-version",
+			goos, goarch, t.releaseURL(""))
+	}
+	return testPackage{}, fmt.Errorf("no test package for %s/%s in %s, it has test packages for %s",
+		goos, goarch, t.releaseURL(""), strings.Join(available, ", "))
+}
+
+// releaseURL returns the URL of a file of the release of the test package
+func (t *Tester) releaseURL(name string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", t.TestPackageURL, t.TestPackageDir, t.TestPackageVersion, name)
+}
+
+// binaryCacheDir returns the directory the binaries of the test package are
+// extracted to under cacheDir, keyed by the location and the version of the
+// package so that runs against the same version reuse them.
+func (t *Tester) binaryCacheDir(cacheDir string) string {
+	location := sha256.Sum256([]byte(t.TestPackageURL + "/" + t.TestPackageDir))
+	return filepath.Join(cacheDir, binaryCacheRoot,
+		hex.EncodeToString(location[:])[:12],
+		t.TestPackageVersion,
+		runtime.GOOS+"-"+runtime.GOARCH)
+}
+
+// linkBinaries hardlinks the cached binaries into the run dir, or copies
+// them if they can't be linked, e.g. across filesystems. Unlike symlinks,
+// they stay valid when the run dir is uploaded with the artifacts or the
+// cache is evicted.
+func (t *Tester) linkBinaries(binDir string) error {
 	// ensure the artifacts dir
 	if err := os.MkdirAll(artifacts.BaseDir(), os.ModePerm); err != nil {
 		return err
 	}
-	// ensure the rundir
-	if err := os.MkdirAll(artifacts.RunDir(), os.ModePerm); err != nil {
+	if err := hardlinkBinaries(binDir, artifacts.RunDir()); err != nil {
 		return err
 	}
+	t.e2eTestPath = filepath.Join(artifacts.RunDir(), "e2e.test")
+	t.ginkgoPath = filepath.Join(artifacts.RunDir(), "ginkgo")
+	return nil
+}
 
+// hardlinkBinaries hardlinks the testBinaries of binDir into destDir, or
+// copies them if they can't be linked, and marks binDir as used now for
+// evictBinaryCache.
+func hardlinkBinaries(binDir, destDir string) error {
+	if err := os.MkdirAll(destDir, os.ModePerm); err != nil {
+		return err
+	}
+	for _, binary := range testBinaries {
+		src := filepath.Join(binDir, binary)
+		dest := filepath.Join(destDir, binary)
+		if err := os.RemoveAll(dest); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", dest, err)
+		}
+		if err := os.Link(src, dest); err != nil {
+			klog.V(1).Infof("Failed to link %s, copying it instead: %v", src, err)
+			if err := copyFile(src, dest); err != nil {
+				return fmt.Errorf("failed to copy %s to the run dir: %w", binary, err)
+			}
+		}
+	}
+	now := time.Now()
+	if err := os.Chtimes(filepath.Join(binDir, extractedMarker), now, now); err != nil {
+		klog.V(1).Infof("Failed to mark %s as used: %v", binDir, err)
+	}
+	return nil
+}
+
+const (
+	// binaryCacheRoot is the directory of the extracted test binaries in
+	// the user cache dir
+	binaryCacheRoot = "kubetest2-test-binaries"
+	// binaryCacheMaxAge is how long the test binaries of a version are kept
+	// in the cache after their last use
+	binaryCacheMaxAge = 14 * 24 * time.Hour
+)
+
+// evictBinaryCache deletes the test binaries in the cache at root not used
+// for maxAge, the extractedMarker of a version is touched at each use.
+// Interrupted extractions, without a marker, are deleted once their
+// directory is older than maxAge. Failures are only logged.
+func evictBinaryCache(root string, maxAge time.Duration, now time.Time) {
+	// root/<location>/<version>/<os-arch>
+	dirs, err := filepath.Glob(filepath.Join(root, "*", "*", "*"))
+	if err != nil {
+		return
+	}
+	for _, dir := range dirs {
+		info, err := os.Stat(filepath.Join(dir, extractedMarker))
+		if os.IsNotExist(err) {
+			info, err = os.Stat(dir)
+		}
+		if err != nil || now.Sub(info.ModTime()) <= maxAge {
+			continue
+		}
+		klog.V(1).Infof("Evicting the test binaries at %s, unused since %s", dir, info.ModTime().Format(time.RFC3339))
+		if err := os.RemoveAll(dir); err != nil {
+			klog.Warningf("Failed to evict %s: %v", dir, err)
+			continue
+		}
+		// the version and location dirs are removed once empty
+		for parent := filepath.Dir(dir); parent != root; parent = filepath.Dir(parent) {
+			if os.Remove(parent) != nil {
+				break
+			}
+		}
+	}
+}
+
+// copyFile copies the executable at src to dest
+func copyFile(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0700)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// extractBinaries extracts the testBinaries in tarDir of the test package tar
+// at downloadPath into binDir. They are extracted into a temporary directory
+// renamed to binDir once complete, other runs may be using the binaries of a
+// binDir extracted before.
+func extractBinaries(downloadPath, tarDir, binDir string) error {
+	if err := os.MkdirAll(filepath.Dir(binDir), os.ModePerm); err != nil {
+		return err
+	}
+	tmpDir, err := os.MkdirTemp(filepath.Dir(binDir), filepath.Base(binDir)+".tmp-")
+	if err != nil {
+		return err
+	}
+	// nothing is left to remove once it is renamed
+	defer os.RemoveAll(tmpDir)
+	klog.V(0).Infof("Extracting the test binaries to %s", binDir)
+	if err := extractBinariesTo(downloadPath, tarDir, tmpDir); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpDir, binDir); err == nil {
+		return nil
+	}
+	// another run completed the extraction first, its binaries are used
+	if _, err := os.Stat(filepath.Join(binDir, extractedMarker)); err == nil {
+		return nil
+	}
+	// binDir is left over by an interrupted extraction, move it aside so
+	// that it is replaced atomically
+	staleDir := tmpDir + ".stale"
+	if err := os.Rename(binDir, staleDir); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to move aside %s: %w", binDir, err)
+	}
+	defer os.RemoveAll(staleDir)
+	if err := os.Rename(tmpDir, binDir); err != nil {
+		return fmt.Errorf("failed to move the test binaries to %s: %w", binDir, err)
+	}
+	return nil
+}
+
+// extractBinariesTo extracts the testBinaries in tarDir of the test package
+// tar at downloadPath into the empty dir binDir, and writes the
+// extractedMarker once they are all extracted.
+func extractBinariesTo(downloadPath, tarDir, binDir string) error {
 	// Extract files from the test package
 	f, err := os.Open(downloadPath)
 	if err != nil {
@@ -96,11 +344,9 @@ func (t *Tester) extractBinaries(downloadPath string) error {
 	tarReader := tar.NewReader(gzf)
 
 	// Map of paths in archive to destination paths
-	t.e2eTestPath = filepath.Join(artifacts.RunDir(), "e2e.test")
-	t.ginkgoPath = filepath.Join(artifacts.RunDir(), "ginkgo")
-	extract := map[string]string{
-		"kubernetes/test/bin/e2e.test": t.e2eTestPath,
-		"kubernetes/test/bin/ginkgo":   t.ginkgoPath,
+	extract := map[string]string{}
+	for _, binary := range testBinaries {
+		extract[tarDir+"/"+binary] = filepath.Join(binDir, binary)
 	}
 	extracted := map[string]bool{}
 
@@ -140,7 +386,7 @@ func (t *Tester) extractBinaries(downloadPath string) error {
 			return fmt.Errorf("failed to find %s in %s", k, downloadPath)
 		}
 	}
-	return nil
+	return os.WriteFile(filepath.Join(binDir, extractedMarker), nil, 0644)
 }
 
 // ensureKubectl checks if the kubectl exists and verifies the hashes
@@ -165,8 +411,7 @@ func (t *Tester) ensureKubectl(downloadPath string) error {
 		klog.Warning(err)
 	}
 
-	_, err := resty.New().R().SetOutput(downloadPath).Get(kubectlPathInURL)
-	if err != nil {
+	if err := downloadURL(kubectlPathInURL, downloadPath); err != nil {
 		return fmt.Errorf("failed to download kubectl for release %s: %s", t.TestPackageVersion, err)
 	}
 	if err := os.Chmod(downloadPath, 0700); err != nil {
@@ -180,13 +425,7 @@ func (t *Tester) ensureKubectl(downloadPath string) error {
 // else downloads it from GCS
 func (t *Tester) ensureReleaseTar(downloadPath, releaseTar string) error {
 
-	releaseTarPathInURL := fmt.Sprintf(
-		"%s/%s/%s/%s",
-		t.TestPackageURL,
-		t.TestPackageDir,
-		t.TestPackageVersion,
-		releaseTar,
-	)
+	releaseTarPathInURL := t.releaseURL(releaseTar)
 
 	if _, err := os.Stat(downloadPath); err == nil {
 		klog.V(0).Infof("Found existing tar at %v", downloadPath)
@@ -199,19 +438,17 @@ func (t *Tester) ensureReleaseTar(downloadPath, releaseTar string) error {
 	}
 
 	klog.V(0).Infof("Downloading test tar ball from: %s", releaseTarPathInURL)
-	_, err := resty.New().R().SetOutput(downloadPath).Get(releaseTarPathInURL)
-	if err != nil {
+	if err := downloadURL(releaseTarPathInURL, downloadPath); err != nil {
 		return fmt.Errorf("failed to download release tar %s for release %s: %s", releaseTar, t.TestPackageVersion, err)
 	}
 	return nil
 }
 
 func (t *Tester) compareSHA(downloadPath string, gcsFilePath string) error {
-	resp, err := resty.New().R().Get(fmt.Sprintf("%s.sha256", gcsFilePath))
+	expectedSHA, err := readURL(fmt.Sprintf("%s.sha256", gcsFilePath))
 	if err != nil {
 		return fmt.Errorf("failed to get sha256 for file %s for release %s: %s", gcsFilePath, t.TestPackageVersion, err)
 	}
-	expectedSHA := resp.String()
 	actualSHA, err := sha256sum(downloadPath)
 	if err != nil {
 		return fmt.Errorf("failed to compute sha256 for %q: %v", downloadPath, err)
@@ -222,6 +459,59 @@ func (t *Tester) compareSHA(downloadPath string, gcsFilePath string) error {
 	return nil
 }
 
+// readURL returns the contents of url.
+// gs:// URLs are read with the storage client and the application default
+// credentials, so that private buckets can be accessed.
+func readURL(url string) (string, error) {
+	if strings.HasPrefix(url, "gs://") {
+		return readGCS(url)
+	}
+	resp, err := resty.New().R().Get(url)
+	if err != nil {
+		return "", err
+	}
+	if resp.IsError() {
+		return "", fmt.Errorf("failed to read %s: %s", url, resp.Status())
+	}
+	return resp.String(), nil
+}
+
+// downloadURL saves the contents of url to path, see readURL for gs:// URLs.
+func downloadURL(url, path string) error {
+	if strings.HasPrefix(url, "gs://") {
+		return downloadGCS(url, path)
+	}
+	resp, err := resty.New().R().SetOutput(path).Get(url)
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		// the error page is not the file
+		os.Remove(path)
+		return fmt.Errorf("failed to download %s: %s", url, resp.Status())
+	}
+	return nil
+}
+
+// urlExists returns true if url exists, see readURL for gs:// URLs.
+func urlExists(url string) (bool, error) {
+	if strings.HasPrefix(url, "gs://") {
+		return gcsExists(url)
+	}
+	resp, err := resty.New().R().Head(url)
+	if err != nil {
+		return false, fmt.Errorf("failed to check %s: %w", url, err)
+	}
+	switch {
+	// buckets without public listing deny reading missing objects
+	case resp.StatusCode() == http.StatusNotFound || resp.StatusCode() == http.StatusForbidden:
+		return false, nil
+	case resp.IsError():
+		return false, fmt.Errorf("failed to check %s: %s", url, resp.Status())
+	}
+	return true, nil
+}
+
 func sha256sum(path string) (string, error) {
 	f, err := os.Open(path)
 	if err != nil {
@@ -0,0 +1,359 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeTestPackage(t *testing.T, path string, files map[string]string) {
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gzw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gzw)
+	for name, contents := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(contents))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(contents)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzw.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtractBinaries(t *testing.T) {
+	dir := t.TempDir()
+	complete := filepath.Join(dir, "complete.tar.gz")
+	writeTestPackage(t, complete, map[string]string{
+		"kubernetes/test/bin/e2e.test": "e2e",
+		"kubernetes/test/bin/ginkgo":   "ginkgo",
+		"kubernetes/test/bin/other":    "other",
+	})
+	incomplete := filepath.Join(dir, "incomplete.tar.gz")
+	writeTestPackage(t, incomplete, map[string]string{
+		"kubernetes/test/bin/e2e.test": "e2e",
+	})
+
+	binDir := filepath.Join(dir, "bin")
+	if err := extractBinaries(complete, "kubernetes/test/bin", binDir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, name := range []string{"e2e.test", "ginkgo", extractedMarker} {
+		if _, err := os.Stat(filepath.Join(binDir, name)); err != nil {
+			t.Errorf("expected %s to be extracted: %v", name, err)
+		}
+	}
+
+	// a failed extraction must not touch the binaries other runs may use
+	if err := extractBinaries(incomplete, "kubernetes/test/bin", binDir); err == nil {
+		t.Error("expected an error for a package without ginkgo but got none")
+	}
+	for _, name := range []string{"e2e.test", "ginkgo", extractedMarker} {
+		if _, err := os.Stat(filepath.Join(binDir, name)); err != nil {
+			t.Errorf("expected %s to be kept after a failed extraction: %v", name, err)
+		}
+	}
+
+	// an extraction completed by another run first is kept
+	if err := extractBinaries(complete, "kubernetes/test/bin", binDir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// a directory left over by an interrupted extraction is replaced
+	staleDir := filepath.Join(dir, "stale")
+	if err := os.MkdirAll(staleDir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(staleDir, "e2e.test"), []byte("partial"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := extractBinaries(complete, "kubernetes/test/bin", staleDir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(staleDir, extractedMarker)); err != nil {
+		t.Errorf("expected %s after replacing an interrupted extraction: %v", extractedMarker, err)
+	}
+
+	// no temporary directories are left behind
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	expected := []string{"bin", "complete.tar.gz", "incomplete.tar.gz", "stale"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected the entries %v but got %v", expected, names)
+	}
+}
+
+func TestFindTestPackage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/release/v1.31.0/kubernetes-test-linux-amd64.tar.gz",
+			"/release/v1.31.0/kubernetes-test-linux-s390x.tar.gz",
+			"/release/v1.13.0/kubernetes-test.tar.gz":
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	testCases := []struct {
+		name          string
+		version       string
+		arch          string
+		expected      testPackage
+		expectedError string
+	}{
+		{
+			name:     "tar of the platform",
+			version:  "v1.31.0",
+			arch:     "s390x",
+			expected: testPackage{tar: "kubernetes-test-linux-s390x.tar.gz", binDir: "kubernetes/test/bin"},
+		},
+		{
+			name:     "tar of all the platforms",
+			version:  "v1.13.0",
+			arch:     "ppc64le",
+			expected: testPackage{tar: "kubernetes-test.tar.gz", binDir: "kubernetes/platforms/linux/ppc64le"},
+		},
+		{
+			name:          "missing platform",
+			version:       "v1.31.0",
+			arch:          "ppc64le",
+			expectedError: "it has test packages for linux/amd64, linux/s390x",
+		},
+		{
+			name:          "missing version",
+			version:       "v0.0.0",
+			arch:          "amd64",
+			expectedError: "nor for any other architecture",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tester := &Tester{TestPackageURL: server.URL, TestPackageDir: "release", TestPackageVersion: tc.version}
+			actual, err := tester.findTestPackage("linux", tc.arch)
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Errorf("expected an error containing %q but got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual != tc.expected {
+				t.Errorf("expected test package %+v but got %+v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestLocalTestPackage(t *testing.T) {
+	dir := t.TempDir()
+	platformTar := filepath.Join(dir, "kubernetes-test-linux-amd64.tar.gz")
+	writeTestPackage(t, platformTar, map[string]string{"kubernetes/test/bin/ginkgo": "ginkgo"})
+	allTar := filepath.Join(dir, "kubernetes-test.tar.gz")
+	writeTestPackage(t, allTar, map[string]string{"kubernetes/platforms/linux/arm64/ginkgo": "ginkgo"})
+	sums := map[string]string{}
+	for _, path := range []string{platformTar, allTar} {
+		sum, err := sha256sum(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sums[filepath.Base(path)] = sum
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/release/v1.31.0/kubernetes-test-linux-amd64.tar.gz.sha256":
+			w.Write([]byte(sums["kubernetes-test-linux-amd64.tar.gz"]))
+			return
+		case "/release/v1.13.0/kubernetes-test.tar.gz.sha256":
+			w.Write([]byte(sums["kubernetes-test.tar.gz"]))
+			return
+		case "/release/v1.32.0/kubernetes-test-linux-amd64.tar.gz.sha256":
+			w.Write([]byte("0000"))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	testCases := []struct {
+		name          string
+		version       string
+		arch          string
+		expected      testPackage
+		expectedFound bool
+	}{
+		{
+			name:          "tar of the platform",
+			version:       "v1.31.0",
+			arch:          "amd64",
+			expected:      testPackage{tar: "kubernetes-test-linux-amd64.tar.gz", binDir: "kubernetes/test/bin"},
+			expectedFound: true,
+		},
+		{
+			name:          "tar of all the platforms",
+			version:       "v1.13.0",
+			arch:          "arm64",
+			expected:      testPackage{tar: "kubernetes-test.tar.gz", binDir: "kubernetes/platforms/linux/arm64"},
+			expectedFound: true,
+		},
+		{
+			name:    "tar of another version",
+			version: "v1.32.0",
+			arch:    "amd64",
+		},
+		{
+			name:    "no tar of the platform",
+			version: "v1.31.0",
+			arch:    "s390x",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tester := &Tester{TestPackageURL: server.URL, TestPackageDir: "release", TestPackageVersion: tc.version}
+			actual, found := tester.localTestPackage(dir, "linux", tc.arch)
+			if found != tc.expectedFound {
+				t.Fatalf("expected found %v but got %v", tc.expectedFound, found)
+			}
+			if actual != tc.expected {
+				t.Errorf("expected test package %+v but got %+v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestBinaryCacheDir(t *testing.T) {
+	a := &Tester{TestPackageURL: "https://dl.k8s.io", TestPackageDir: "release", TestPackageVersion: "v1.31.0"}
+	b := &Tester{TestPackageURL: "gs://private", TestPackageDir: "release", TestPackageVersion: "v1.31.0"}
+	c := &Tester{TestPackageURL: "https://dl.k8s.io", TestPackageDir: "release", TestPackageVersion: "v1.31.1"}
+	if a.binaryCacheDir("/cache") == b.binaryCacheDir("/cache") {
+		t.Error("expected different cache dirs for different package locations")
+	}
+	if a.binaryCacheDir("/cache") == c.binaryCacheDir("/cache") {
+		t.Error("expected different cache dirs for different versions")
+	}
+	if a.binaryCacheDir("/cache") != a.binaryCacheDir("/cache") {
+		t.Error("expected the same cache dir for the same package")
+	}
+}
+
+func TestHardlinkBinaries(t *testing.T) {
+	binDir := t.TempDir()
+	for _, name := range append([]string{extractedMarker}, testBinaries...) {
+		if err := os.WriteFile(filepath.Join(binDir, name), []byte(name), 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(filepath.Join(binDir, extractedMarker), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	runDir := filepath.Join(t.TempDir(), "rundir")
+	if err := hardlinkBinaries(binDir, runDir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, name := range testBinaries {
+		cached, err := os.Stat(filepath.Join(binDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		linked, err := os.Lstat(filepath.Join(runDir, name))
+		if err != nil {
+			t.Fatalf("expected %s in the run dir: %v", name, err)
+		}
+		if linked.Mode()&os.ModeSymlink != 0 || !os.SameFile(cached, linked) {
+			t.Errorf("expected %s to be hardlinked into the run dir", name)
+		}
+	}
+	if info, err := os.Stat(filepath.Join(binDir, extractedMarker)); err != nil || !info.ModTime().After(old) {
+		t.Errorf("expected the cached binaries to be marked as used")
+	}
+}
+
+func TestEvictBinaryCache(t *testing.T) {
+	root := t.TempDir()
+	now := time.Now()
+	dirs := []struct {
+		path    string
+		lastUse time.Time
+		marker  bool
+		evicted bool
+	}{
+		{path: "loc/v1.31.0/linux-amd64", lastUse: now.Add(-time.Hour), marker: true},
+		{path: "loc/v1.30.0/linux-amd64", lastUse: now.Add(-30 * 24 * time.Hour), marker: true, evicted: true},
+		{path: "loc/v1.29.0/linux-amd64", lastUse: now.Add(-30 * 24 * time.Hour), evicted: true},
+	}
+	for _, dir := range dirs {
+		path := filepath.Join(root, dir.path)
+		if err := os.MkdirAll(path, os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if dir.marker {
+			if err := os.WriteFile(filepath.Join(path, extractedMarker), nil, 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chtimes(filepath.Join(path, extractedMarker), dir.lastUse, dir.lastUse); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := os.Chtimes(path, dir.lastUse, dir.lastUse); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	evictBinaryCache(root, binaryCacheMaxAge, now)
+
+	for _, dir := range dirs {
+		_, err := os.Stat(filepath.Join(root, dir.path))
+		if dir.evicted && !os.IsNotExist(err) {
+			t.Errorf("expected %s to be evicted, got %v", dir.path, err)
+		}
+		if !dir.evicted && err != nil {
+			t.Errorf("expected %s to be kept: %v", dir.path, err)
+		}
+		// the emptied version dirs are removed too
+		if _, err := os.Stat(filepath.Dir(filepath.Join(root, dir.path))); dir.evicted && !os.IsNotExist(err) {
+			t.Errorf("expected the version dir of %s to be removed, got %v", dir.path, err)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+const (
+	prepullNamespace = "kubetest2-prepull"
+	prepullName      = "prepull-images"
+	prepullInterval  = 10 * time.Second
+)
+
+// pendingPullReasons are the waiting reasons of containers whose image is
+// not pulled yet.
+var pendingPullReasons = map[string]bool{
+	"":                  true,
+	"ContainerCreating": true,
+}
+
+// failedPullReasons are the waiting reasons of containers whose image
+// failed to pull, which are not waited for. Any other state means the
+// kubelet got the image.
+var failedPullReasons = map[string]bool{
+	"ErrImagePull":      true,
+	"ImagePullBackOff":  true,
+	"InvalidImageName":  true,
+	"ErrImageNeverPull": true,
+}
+
+// unpullableImageMarkers are substrings of the e2e test images which linux
+// nodes can't pull: the images of the default invalid and authenticated
+// registries, used by the tests of pull failures and credentials, and the
+// windows images.
+var unpullableImageMarkers = []string{
+	"invalid.registry.k8s.io",
+	"gcr.io/authenticated-image-pulling",
+	"gcr.io/k8s-authenticated-test",
+	"mcr.microsoft.com",
+	"windows",
+	"nanoserver",
+	"servercore",
+}
+
+// prepullImages pulls the images used by the e2e tests onto every
+// schedulable linux node of the cluster of the kubeconfig context, empty
+// meaning the current one, and waits for them for up to --prepull-timeout.
+// Prepulling only avoids image pull flakes, so failing to prepull is logged
+// and the tests run anyway.
+func (t *Tester) prepullImages(env []string, kubeContext string) {
+	images, err := t.e2eImages(env)
+	if err != nil {
+		klog.Warningf("Skipping prepulling the e2e test images: %v", err)
+		return
+	}
+	images = pullableImages(images)
+	if len(images) == 0 {
+		return
+	}
+	manifest, err := prepullManifest(images)
+	if err != nil {
+		klog.Warningf("Skipping prepulling the e2e test images: %v", err)
+		return
+	}
+	klog.V(0).Infof("Prepulling %d e2e test images", len(images))
+	defer func() {
+		if err := t.kubectl(kubeContext, "delete", "namespace", prepullNamespace, "--ignore-not-found", "--wait=false").Run(); err != nil {
+			klog.Warningf("Failed to delete the prepull namespace %s: %v", prepullNamespace, err)
+		}
+	}()
+	apply := t.kubectl(kubeContext, "apply", "-f", "-")
+	apply.SetStdin(bytes.NewReader(manifest))
+	if err := apply.Run(); err != nil {
+		klog.Warningf("Failed to create the prepull daemonset: %v", err)
+		return
+	}
+
+	deadline := time.Now().Add(t.PrepullTimeout)
+	for {
+		pending, failed, err := t.pendingPrepulls(kubeContext, len(images))
+		if err != nil {
+			klog.Warningf("Failed to check the prepulled images: %v", err)
+		} else if pending == 0 {
+			if failed > 0 {
+				klog.Warningf("Prepulled the e2e test images, %d image pulls failed", failed)
+			} else {
+				klog.V(0).Infof("Prepulled the e2e test images")
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			klog.Warningf("Timed out after %s waiting for %d image pulls, running the tests anyway", t.PrepullTimeout, pending)
+			return
+		}
+		time.Sleep(prepullInterval)
+	}
+}
+
+// e2eImages returns the images used by the e2e tests, with the registries
+// of KUBE_TEST_REPO_LIST when it is set in env.
+func (t *Tester) e2eImages(env []string) ([]string, error) {
+	cmd := exec.Command(t.e2eTestPath, "--list-images")
+	cmd.SetEnv(env...)
+	lines, err := exec.OutputLines(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the e2e test images: %w", err)
+	}
+	var images []string
+	for _, line := range lines {
+		if image := strings.TrimSpace(line); image != "" {
+			images = append(images, image)
+		}
+	}
+	return images, nil
+}
+
+// pullableImages returns the images without the unpullableImageMarkers,
+// whose pulls would keep failing until the prepull timeout
+func pullableImages(images []string) []string {
+	var pullable []string
+	for _, image := range images {
+		if !isUnpullableImage(image) {
+			pullable = append(pullable, image)
+		}
+	}
+	return pullable
+}
+
+func isUnpullableImage(image string) bool {
+	for _, marker := range unpullableImageMarkers {
+		if strings.Contains(image, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// pendingPrepulls returns how many images the prepull pods still have
+// to pull, counting the pods the daemonset has yet to create, and how many
+// failed to pull.
+func (t *Tester) pendingPrepulls(kubeContext string, images int) (pending, failed int, err error) {
+	desired, err := exec.Output(t.kubectl(kubeContext, "get", "daemonset", prepullName,
+		"--namespace="+prepullNamespace,
+		"--output=jsonpath={.status.desiredNumberScheduled}"))
+	if err != nil {
+		return 0, 0, err
+	}
+	pods, err := exec.Output(t.kubectl(kubeContext, "get", "pods",
+		"--namespace="+prepullNamespace,
+		"--selector=app="+prepullName,
+		"--output=json"))
+	if err != nil {
+		return 0, 0, err
+	}
+	return countPendingPulls(pods, strings.TrimSpace(string(desired)), images)
+}
+
+// countPendingPulls returns the number of images the pods in the kubectl
+// pod list have not pulled yet, counting all of them for the pods missing
+// to reach the desired number of pods of the daemonset, and the number of
+// images which failed to pull, which are not pending.
+func countPendingPulls(podList []byte, desired string, images int) (pending, failed int, err error) {
+	var list struct {
+		Items []struct {
+			Status struct {
+				ContainerStatuses []struct {
+					ImageID string `json:"imageID"`
+					State   struct {
+						Waiting *struct {
+							Reason string `json:"reason"`
+						} `json:"waiting"`
+						Running    json.RawMessage `json:"running"`
+						Terminated json.RawMessage `json:"terminated"`
+					} `json:"state"`
+				} `json:"containerStatuses"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(podList, &list); err != nil {
+		return 0, 0, fmt.Errorf("failed to parse the prepull pods: %w", err)
+	}
+	desiredPods := 0
+	if desired != "" {
+		if desiredPods, err = strconv.Atoi(desired); err != nil {
+			return 0, 0, fmt.Errorf("invalid desired number of prepull pods %q: %w", desired, err)
+		}
+	}
+	for _, pod := range list.Items {
+		done := 0
+		for _, status := range pod.Status.ContainerStatuses {
+			state := status.State
+			switch {
+			case status.ImageID != "" || state.Running != nil || state.Terminated != nil:
+				done++
+			case state.Waiting != nil && failedPullReasons[state.Waiting.Reason]:
+				failed++
+				done++
+			case state.Waiting != nil && !pendingPullReasons[state.Waiting.Reason]:
+				done++
+			}
+		}
+		pending += images - done
+	}
+	if missing := desiredPods - len(list.Items); missing > 0 {
+		pending += missing * images
+	}
+	return pending, failed, nil
+}
+
+// prepullManifest returns a kubectl manifest of a namespace and a daemonset
+// with a container per image. The containers run a command that does not
+// exist, so that they pull their image and fail to start without using the
+// resources of the nodes or requiring a shell in the image.
+func prepullManifest(images []string) ([]byte, error) {
+	var containers []map[string]interface{}
+	for i, image := range images {
+		containers = append(containers, map[string]interface{}{
+			"name":            "image-" + strconv.Itoa(i),
+			"image":           image,
+			"imagePullPolicy": "IfNotPresent",
+			"command":         []string{"/kubetest2-prepull"},
+			"resources": map[string]interface{}{
+				"requests": map[string]string{"cpu": "1m", "memory": "1Mi"},
+			},
+		})
+	}
+	labels := map[string]string{"app": prepullName}
+	return json.Marshal(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items": []interface{}{
+			map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Namespace",
+				"metadata":   map[string]string{"name": prepullNamespace},
+			},
+			map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "DaemonSet",
+				"metadata": map[string]interface{}{
+					"name":      prepullName,
+					"namespace": prepullNamespace,
+				},
+				"spec": map[string]interface{}{
+					"selector": map[string]interface{}{"matchLabels": labels},
+					"template": map[string]interface{}{
+						"metadata": map[string]interface{}{"labels": labels},
+						"spec": map[string]interface{}{
+							"nodeSelector":                  map[string]string{"kubernetes.io/os": "linux"},
+							"terminationGracePeriodSeconds": 0,
+							"containers":                    containers,
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+// kubectl returns a kubectl command against the kubeconfig context,
+// empty meaning the current one.
+func (t *Tester) kubectl(kubeContext string, args ...string) exec.Cmd {
+	args = append([]string{"--kubeconfig=" + t.kubeconfigPath}, args...)
+	if kubeContext != "" {
+		args = append([]string{"--context=" + kubeContext}, args...)
+	}
+	return exec.Command(t.kubectlPath, args...)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestCountPendingPulls(t *testing.T) {
+	testCases := []struct {
+		name           string
+		pods           string
+		desired        string
+		expected       int
+		expectedFailed int
+	}{
+		{
+			name:     "no pods yet",
+			pods:     `{"items":[]}`,
+			desired:  "2",
+			expected: 6,
+		},
+		{
+			name:     "unknown desired pods",
+			pods:     `{"items":[]}`,
+			expected: 0,
+		},
+		{
+			name: "pulling",
+			pods: `{"items":[{"status":{"containerStatuses":[
+				{"state":{"waiting":{"reason":"ContainerCreating"}}},
+				{"state":{"waiting":{}}},
+				{"imageID":"sha256:abc","state":{"waiting":{"reason":"CrashLoopBackOff"}}}
+			]}}]}`,
+			desired:  "1",
+			expected: 2,
+		},
+		{
+			name: "failed pulls",
+			pods: `{"items":[{"status":{"containerStatuses":[
+				{"state":{"waiting":{"reason":"ContainerCreating"}}},
+				{"state":{"waiting":{"reason":"ImagePullBackOff"}}},
+				{"state":{"waiting":{"reason":"ErrImagePull"}}}
+			]}}]}`,
+			desired:        "1",
+			expected:       1,
+			expectedFailed: 2,
+		},
+		{
+			name: "pulled",
+			pods: `{"items":[{"status":{"containerStatuses":[
+				{"state":{"waiting":{"reason":"RunContainerError"}}},
+				{"state":{"terminated":{"reason":"StartError"}}},
+				{"state":{"running":{}}}
+			]}}]}`,
+			desired:  "1",
+			expected: 0,
+		},
+		{
+			name:     "pod without statuses",
+			pods:     `{"items":[{"status":{}}]}`,
+			desired:  "2",
+			expected: 6,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, failed, err := countPendingPulls([]byte(tc.pods), tc.desired, 3)
+			if err != nil {
+				t.Fatalf("did not expect an error, but got: %v", err)
+			}
+			if actual != tc.expected {
+				t.Errorf("expected %d pending pulls, but got %d", tc.expected, actual)
+			}
+			if failed != tc.expectedFailed {
+				t.Errorf("expected %d failed pulls, but got %d", tc.expectedFailed, failed)
+			}
+		})
+	}
+}
+
+func TestPullableImages(t *testing.T) {
+	images := []string{
+		"registry.k8s.io/e2e-test-images/agnhost:2.47",
+		"invalid.registry.k8s.io/invalid/alpine:3.1",
+		"gcr.io/authenticated-image-pulling/alpine:3.7",
+		"gcr.io/k8s-authenticated-test/agnhost:2.6",
+		"mcr.microsoft.com/windows/nanoserver:ltsc2022",
+		"registry.k8s.io/e2e-test-images/busybox:1.36.1-1",
+	}
+	expected := []string{
+		"registry.k8s.io/e2e-test-images/agnhost:2.47",
+		"registry.k8s.io/e2e-test-images/busybox:1.36.1-1",
+	}
+	if actual := pullableImages(images); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected images %v, but got %v", expected, actual)
+	}
+}
+
+func TestPrepullManifest(t *testing.T) {
+	manifest, err := prepullManifest([]string{"registry.k8s.io/e2e-test-images/agnhost:2.47", "registry.k8s.io/pause:3.9"})
+	if err != nil {
+		t.Fatalf("did not expect an error, but got: %v", err)
+	}
+	var list struct {
+		Items []struct {
+			Kind string `json:"kind"`
+			Spec struct {
+				Template struct {
+					Spec struct {
+						Containers []struct {
+							Name  string `json:"name"`
+							Image string `json:"image"`
+						} `json:"containers"`
+					} `json:"spec"`
+				} `json:"template"`
+			} `json:"spec"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(manifest, &list); err != nil {
+		t.Fatalf("failed to parse manifest: %v", err)
+	}
+	if len(list.Items) != 2 || list.Items[0].Kind != "Namespace" || list.Items[1].Kind != "DaemonSet" {
+		t.Fatalf("expected a namespace and a daemonset, but got %s", manifest)
+	}
+	containers := list.Items[1].Spec.Template.Spec.Containers
+	if len(containers) != 2 || containers[1].Name != "image-1" || containers[1].Image != "registry.k8s.io/pause:3.9" {
+		t.Errorf("expected a container per image, but got %+v", containers)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// providerConfigEnv is set by kubetest2 to the provider config built by the
+// deployer from the cluster
+const providerConfigEnv = "KUBETEST2_PROVIDER_CONFIG"
+
+// skeletonProvider is the e2e framework provider of clusters without provider
+// specific support, and the default of e2e.test
+const skeletonProvider = "skeleton"
+
+// providerSpecificFlagPrefixes are the prefixes of the e2e framework flags
+// the skeleton provider ignores
+var providerSpecificFlagPrefixes = []string{
+	"gce-",
+	"gke-",
+	"cluster-tag",
+	"master-tag",
+	"node-instance-group",
+}
+
+// providerArgs returns the e2e.test args of --provider-config, or of the
+// provider config of the deployer if unset. testArgs are the --test-args,
+// whose --provider must match the one of --provider-config, and overrides
+// the one of the deployer.
+func (t *Tester) providerArgs(testArgs []string) ([]string, error) {
+	path := t.ProviderConfig
+	fromDeployer := false
+	if path == "" {
+		path = os.Getenv(providerConfigEnv)
+		fromDeployer = true
+	}
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the provider config: %w", err)
+	}
+	return parseProviderConfig(data, testArgs, fromDeployer)
+}
+
+// parseProviderConfig converts the YAML provider config, a map of e2e
+// framework flag names to values, to e2e.test args sorted by flag name. It
+// fails when the config sets provider specific flags for the skeleton
+// provider, which would silently ignore them. A --provider in testArgs
+// other than the one of the config is an error, unless the config is the
+// one of the deployer, whose provider and provider specific flags for the
+// skeleton provider are then dropped.
+func parseProviderConfig(data []byte, testArgs []string, fromDeployer bool) ([]string, error) {
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse the provider config: %w", err)
+	}
+
+	provider, _ := config["provider"].(string)
+	overridden := false
+	if testProvider, ok := flagValue(testArgs, "provider"); ok {
+		if provider != "" && testProvider != provider {
+			if !fromDeployer {
+				return nil, fmt.Errorf("--test-args sets --provider=%s but the provider config is for %s", testProvider, provider)
+			}
+			klog.Warningf("--test-args sets --provider=%s, overriding the provider %s of the deployer", testProvider, provider)
+			overridden = true
+		}
+		provider = testProvider
+	}
+
+	names := make([]string, 0, len(config))
+	for name := range config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var args []string
+	for _, name := range names {
+		if name == "provider" && overridden {
+			continue
+		}
+		if (provider == "" || provider == skeletonProvider) && isProviderSpecificFlag(name) {
+			if overridden {
+				klog.Warningf("Dropping %s of the provider config of the deployer, which the %s provider ignores", name, skeletonProvider)
+				continue
+			}
+			return nil, fmt.Errorf("the provider config sets %s, which the %s provider ignores, set the provider", name, skeletonProvider)
+		}
+		args = append(args, fmt.Sprintf("--%s=%v", name, config[name]))
+	}
+	return args, nil
+}
+
+// isProviderSpecificFlag returns true if the skeleton provider ignores the
+// e2e framework flag name
+func isProviderSpecificFlag(name string) bool {
+	for _, prefix := range providerSpecificFlagPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// flagValue returns the value of the last --name flag in args, in the
+// --name=value or --name value form, and whether it is set
+func flagValue(args []string, name string) (string, bool) {
+	var value string
+	var found bool
+	for i, arg := range args {
+		trimmed := strings.TrimLeft(arg, "-")
+		if trimmed == arg {
+			continue
+		}
+		if v, ok := strings.CutPrefix(trimmed, name+"="); ok {
+			value, found = v, true
+		} else if trimmed == name && i+1 < len(args) {
+			value, found = args[i+1], true
+		}
+	}
+	return value, found
+}
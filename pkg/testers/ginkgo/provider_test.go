@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseProviderConfig(t *testing.T) {
+	testCases := []struct {
+		name         string
+		config       string
+		testArgs     []string
+		fromDeployer bool
+		expected     []string
+		expectError  bool
+	}{
+		{
+			name:     "gce",
+			config:   "provider: gce\ngce-zone: us-central1-b\nnum-nodes: 3\n",
+			expected: []string{"--gce-zone=us-central1-b", "--num-nodes=3", "--provider=gce"},
+		},
+		{
+			name:     "provider from the test args",
+			config:   "gce-project: p\n",
+			testArgs: []string{"--provider", "gce"},
+			expected: []string{"--gce-project=p"},
+		},
+		{
+			name:        "conflicting provider",
+			config:      "provider: gce\n",
+			testArgs:    []string{"--provider=gke"},
+			expectError: true,
+		},
+		{
+			name:         "provider of the deployer overridden by the test args",
+			config:       "provider: gce\ngce-zone: us-central1-b\nnum-nodes: 3\n",
+			testArgs:     []string{"--provider=gke"},
+			fromDeployer: true,
+			expected:     []string{"--gce-zone=us-central1-b", "--num-nodes=3"},
+		},
+		{
+			name:         "provider of the deployer overridden by the skeleton provider",
+			config:       "provider: gce\ngce-zone: us-central1-b\nnum-nodes: 3\n",
+			testArgs:     []string{"--provider", "skeleton"},
+			fromDeployer: true,
+			expected:     []string{"--num-nodes=3"},
+		},
+		{
+			name:         "provider specific flags of the deployer without provider",
+			config:       "gce-zone: us-central1-b\n",
+			fromDeployer: true,
+			expectError:  true,
+		},
+		{
+			name:        "provider specific flags without provider",
+			config:      "gce-zone: us-central1-b\n",
+			expectError: true,
+		},
+		{
+			name:        "provider specific flags with the skeleton provider",
+			config:      "provider: skeleton\nnode-instance-group: kt2-minion-group\n",
+			expectError: true,
+		},
+		{
+			name:     "skeleton provider",
+			config:   "provider: skeleton\nnum-nodes: 3\n",
+			expected: []string{"--num-nodes=3", "--provider=skeleton"},
+		},
+		{
+			name:        "invalid yaml",
+			config:      "- provider\n",
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := parseProviderConfig([]byte(tc.config), tc.testArgs, tc.fromDeployer)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error but got %v", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.expected) {
+				t.Errorf("expected args %v but got %v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// reportDirPrefix is the prefix of the per invocation report directories
+const reportDirPrefix = "ginkgo-"
+
+// newReportDir creates and returns the first unused ginkgo-<index> directory
+// under baseDir, so that the reports of several ginkgo runs sharing the same
+// artifacts do not overwrite each other, even when they run concurrently.
+func newReportDir(baseDir string) (string, error) {
+	if err := os.MkdirAll(baseDir, os.ModePerm); err != nil {
+		return "", err
+	}
+	for i := 0; ; i++ {
+		dir := filepath.Join(baseDir, reportDirPrefix+strconv.Itoa(i))
+		err := os.Mkdir(dir, os.ModePerm)
+		if err == nil {
+			return dir, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return "", fmt.Errorf("failed to create report dir: %w", err)
+		}
+	}
+}
+
+// moveJUnitReports moves the junit reports in reportDir and its sub
+// directories into baseDir, prefixed with the name of reportDir, for CI
+// systems that only collect the junit files at the top of the artifacts.
+// The reports are moved rather than copied or linked, as those systems also
+// read the sub directories and would count every spec twice otherwise.
+func moveJUnitReports(baseDir, reportDir string) error {
+	reportDirName := filepath.Base(reportDir)
+	return filepath.WalkDir(reportDir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, "junit") || filepath.Ext(name) != ".xml" {
+			return nil
+		}
+		rel, err := filepath.Rel(reportDir, path)
+		if err != nil {
+			return err
+		}
+		newPath := filepath.Join(baseDir, junitReportName(reportDirName, rel))
+		klog.V(2).Infof("Moving junit report %s to %s", path, newPath)
+		return os.Rename(path, newPath)
+	})
+}
+
+// junitReportName returns the name in the artifacts of the junit report at
+// rel in the report dir, e.g. ctx/junit_01.xml in ginkgo-1 is
+// junit_ginkgo-1_ctx_01.xml
+func junitReportName(reportDirName, rel string) string {
+	dir, name := filepath.Split(rel)
+	parts := []string{"junit", reportDirName}
+	if dir != "" {
+		parts = append(parts, strings.Split(filepath.Clean(dir), string(filepath.Separator))...)
+	}
+	reportName := strings.Join(parts, "_")
+	suffix := strings.TrimPrefix(strings.TrimPrefix(name, "junit"), "_")
+	if strings.HasPrefix(suffix, ".") {
+		return reportName + suffix
+	}
+	return reportName + "_" + suffix
+}
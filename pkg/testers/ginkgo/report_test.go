@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewReportDir(t *testing.T) {
+	baseDir := t.TempDir()
+	for _, expected := range []string{"ginkgo-0", "ginkgo-1"} {
+		dir, err := newReportDir(baseDir)
+		if err != nil {
+			t.Fatalf("did not expect an error, but got: %v", err)
+		}
+		if dir != filepath.Join(baseDir, expected) {
+			t.Errorf("expected report dir %s, but got %s", filepath.Join(baseDir, expected), dir)
+		}
+	}
+}
+
+func TestJUnitReportName(t *testing.T) {
+	testCases := []struct {
+		rel      string
+		expected string
+	}{
+		{rel: "junit_01.xml", expected: "junit_ginkgo-1_01.xml"},
+		{rel: "junit.xml", expected: "junit_ginkgo-1.xml"},
+		{rel: filepath.Join("ctx", "junit_01.xml"), expected: "junit_ginkgo-1_ctx_01.xml"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.rel, func(t *testing.T) {
+			if actual := junitReportName("ginkgo-1", tc.rel); actual != tc.expected {
+				t.Errorf("expected report name %s, but got %s", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMoveJUnitReports(t *testing.T) {
+	baseDir := t.TempDir()
+	reportDir, err := newReportDir(baseDir)
+	if err != nil {
+		t.Fatalf("failed to create report dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(reportDir, "junit_01.xml"), []byte("<testsuite/>"), 0644); err != nil {
+		t.Fatalf("failed to write junit report: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(reportDir, "e2e.log"), []byte("log"), 0644); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+
+	if err := moveJUnitReports(baseDir, reportDir); err != nil {
+		t.Fatalf("did not expect an error, but got: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(baseDir, "junit_ginkgo-0_01.xml"))
+	if err != nil {
+		t.Fatalf("failed to read moved junit report: %v", err)
+	}
+	if string(data) != "<testsuite/>" {
+		t.Errorf("unexpected moved junit report contents %q", data)
+	}
+	if _, err := os.Lstat(filepath.Join(reportDir, "junit_01.xml")); !os.IsNotExist(err) {
+		t.Errorf("expected the junit report to be removed from the report dir, got: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(reportDir, "e2e.log")); err != nil {
+		t.Errorf("expected the log to be kept in the report dir, got: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(baseDir, "junit_ginkgo-0_e2e.log")); !os.IsNotExist(err) {
+		t.Errorf("expected only junit reports to be moved, got: %v", err)
+	}
+}
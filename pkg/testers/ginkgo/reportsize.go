@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// limitReportFiles gzip compresses the files in reportDir, and in its
+// subdirectories, larger than limit bytes, e.g. the logs the tests gather on
+// failure, and deletes the ones still larger than limit once compressed.
+// The junit reports and the failure summary are kept as is.
+func limitReportFiles(reportDir string, limit int64) error {
+	if limit <= 0 {
+		return nil
+	}
+	var errs []error
+	err := filepath.WalkDir(reportDir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := entry.Name()
+		if !entry.Type().IsRegular() || name == failuresFile || strings.HasPrefix(name, "junit") || strings.HasSuffix(name, ".gz") {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if info.Size() <= limit {
+			return nil
+		}
+		if err := limitReportFile(path, info.Size(), limit); err != nil {
+			errs = append(errs, err)
+		}
+		return nil
+	})
+	return errors.Join(append(errs, err)...)
+}
+
+// limitReportFile replaces the file at path with a gzip compressed copy,
+// deleting both if the copy is still larger than limit bytes
+func limitReportFile(path string, size, limit int64) error {
+	compressedPath := path + ".gz"
+	compressedSize, err := gzipFile(path, compressedPath)
+	if err != nil {
+		os.Remove(compressedPath)
+		return fmt.Errorf("failed to compress %s: %w", path, err)
+	}
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	if compressedSize > limit {
+		klog.Warningf("Deleting %s, %d bytes compressed exceed the limit of %d bytes", path, compressedSize, limit)
+		return os.Remove(compressedPath)
+	}
+	klog.V(2).Infof("Compressed %s from %d to %d bytes", path, size, compressedSize)
+	return nil
+}
+
+// gzipFile writes a gzip compressed copy of src to dst and returns its size
+func gzipFile(src, dst string) (int64, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return 0, err
+	}
+	defer out.Close()
+
+	w := gzip.NewWriter(out)
+	if _, err := io.Copy(w, in); err != nil {
+		return 0, err
+	}
+	if err := w.Close(); err != nil {
+		return 0, err
+	}
+	info, err := out.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"compress/gzip"
+	"crypto/rand"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLimitReportFiles(t *testing.T) {
+	reportDir := t.TempDir()
+	logsDir := filepath.Join(reportDir, "logs")
+	if err := os.MkdirAll(logsDir, os.ModePerm); err != nil {
+		t.Fatalf("failed to create logs dir: %v", err)
+	}
+	random := make([]byte, 2048)
+	if _, err := rand.Read(random); err != nil {
+		t.Fatalf("failed to generate random data: %v", err)
+	}
+	files := map[string][]byte{
+		"junit_01.xml":                           []byte(strings.Repeat("<testsuite/>", 200)),
+		failuresFile:                             []byte(strings.Repeat("[]", 1000)),
+		"e2e.log":                                []byte("small"),
+		filepath.Join(logsDir, "csi-mock-0.log"): []byte(strings.Repeat("compressible ", 200)),
+		filepath.Join(logsDir, "random.bin"):     random,
+	}
+	for name, data := range files {
+		path := name
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(reportDir, name)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	if err := limitReportFiles(reportDir, 1024); err != nil {
+		t.Fatalf("did not expect an error, but got: %v", err)
+	}
+
+	for _, kept := range []string{"junit_01.xml", failuresFile, "e2e.log"} {
+		if _, err := os.Stat(filepath.Join(reportDir, kept)); err != nil {
+			t.Errorf("expected %s to be kept: %v", kept, err)
+		}
+	}
+	for _, deleted := range []string{"csi-mock-0.log", "random.bin", "random.bin.gz"} {
+		if _, err := os.Stat(filepath.Join(logsDir, deleted)); !os.IsNotExist(err) {
+			t.Errorf("expected %s to be deleted, got: %v", deleted, err)
+		}
+	}
+
+	f, err := os.Open(filepath.Join(logsDir, "csi-mock-0.log.gz"))
+	if err != nil {
+		t.Fatalf("expected the compressed log: %v", err)
+	}
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("failed to read the compressed log: %v", err)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("failed to read the compressed log: %v", err)
+	}
+	if string(data) != string(files[filepath.Join(logsDir, "csi-mock-0.log")]) {
+		t.Errorf("unexpected contents of the compressed log")
+	}
+}
+
+func TestLimitReportFilesNoLimit(t *testing.T) {
+	reportDir := t.TempDir()
+	path := filepath.Join(reportDir, "e2e.log")
+	if err := os.WriteFile(path, []byte(strings.Repeat("log ", 1000)), 0644); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+	if err := limitReportFiles(reportDir, 0); err != nil {
+		t.Fatalf("did not expect an error, but got: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expected the log to be kept: %v", err)
+	}
+}
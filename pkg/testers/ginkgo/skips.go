@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/metadata"
+)
+
+// skipCounts counts the skipped specs of the junit reports by the filter
+// which skipped them, so that a regex skipping far more specs than intended
+// does not go unnoticed in a green job
+type skipCounts struct {
+	Total       int
+	Skipped     int
+	SkipRegex   int
+	FocusRegex  int
+	LabelFilter int
+	// Other are the specs skipped at runtime, e.g. by the e2e framework
+	// because the cluster lacks a feature, or pending
+	Other int
+}
+
+// specFilters are the compiled filters the specs were run with, nil when
+// unset
+type specFilters struct {
+	skip        *regexp.Regexp
+	focus       *regexp.Regexp
+	labelFilter bool
+}
+
+func newSpecFilters(skipRegex, focusRegex, labelFilter string) specFilters {
+	var filters specFilters
+	// ginkgo validated the regexes already, a regex it accepts but go's
+	// regexp rejects leaves its specs counted as other
+	if skipRegex != "" {
+		filters.skip, _ = regexp.Compile(skipRegex)
+	}
+	if focusRegex != "" {
+		filters.focus, _ = regexp.Compile(focusRegex)
+	}
+	filters.labelFilter = labelFilter != ""
+	return filters
+}
+
+// add counts the specs of the junit report. The reports don't say which
+// filter skipped a spec, it is inferred by matching the spec name against
+// the filters like ginkgo does, the specs skipped at runtime have a reason.
+func (c *skipCounts) add(report []byte, filters specFilters) error {
+	var parsed junitReport
+	if err := xml.Unmarshal(report, &parsed); err != nil {
+		return err
+	}
+	for _, tc := range parsed.testCases() {
+		c.Total++
+		if tc.Skipped == nil {
+			continue
+		}
+		c.Skipped++
+		name := strings.TrimPrefix(tc.Name, "[It] ")
+		switch {
+		case filters.skip != nil && filters.skip.MatchString(name):
+			c.SkipRegex++
+		case filters.focus != nil && !filters.focus.MatchString(name):
+			c.FocusRegex++
+		case strings.HasPrefix(tc.Skipped.Message, "skipped - "):
+			c.Other++
+		case filters.labelFilter:
+			c.LabelFilter++
+		default:
+			c.Other++
+		}
+	}
+	return nil
+}
+
+// metadata returns the counts as metadata.json entries
+func (c *skipCounts) metadata() map[string]string {
+	return map[string]string{
+		"ginkgo-specs":                         strconv.Itoa(c.Total),
+		"ginkgo-specs-skipped":                 strconv.Itoa(c.Skipped),
+		"ginkgo-specs-skipped-by-skip-regex":   strconv.Itoa(c.SkipRegex),
+		"ginkgo-specs-skipped-by-focus-regex":  strconv.Itoa(c.FocusRegex),
+		"ginkgo-specs-skipped-by-label-filter": strconv.Itoa(c.LabelFilter),
+		"ginkgo-specs-skipped-other":           strconv.Itoa(c.Other),
+	}
+}
+
+// countSkips counts the skipped specs of the junit reports in reportDir and
+// its sub directories
+func countSkips(reportDir string, filters specFilters) (skipCounts, error) {
+	var counts skipCounts
+	err := filepath.WalkDir(reportDir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, "junit") || filepath.Ext(name) != ".xml" {
+			return nil
+		}
+		report, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return counts.add(report, filters)
+	})
+	return counts, err
+}
+
+// recordSkips logs the skipped specs of the junit reports in reportDir by
+// filter, and adds the counts to metadata.json in baseDir
+func recordSkips(baseDir, reportDir string, filters specFilters) error {
+	counts, err := countSkips(reportDir, filters)
+	if err != nil {
+		return err
+	}
+	if counts.Total == 0 {
+		return nil
+	}
+	klog.V(0).Infof("Ran %d of %d specs, skipped %d by --skip-regex, %d by --focus-regex, %d by --label-filter and %d at runtime or pending",
+		counts.Total-counts.Skipped, counts.Total, counts.SkipRegex, counts.FocusRegex, counts.LabelFilter, counts.Other)
+	if counts.Skipped == counts.Total {
+		klog.Warningf("All the %d specs were skipped, check the filters of the job", counts.Total)
+	}
+	return metadata.AddToFile(filepath.Join(baseDir, "metadata.json"), counts.metadata())
+}
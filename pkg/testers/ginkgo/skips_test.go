@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"testing"
+)
+
+// skippedReport is a trimmed down junit report of ginkgo v2 with skipped specs
+const skippedReport = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="5" disabled="0" errors="0" failures="0" time="20">
+  <testsuite name="Kubernetes e2e suite" package="/" tests="5" skipped="4" failures="0" errors="0" time="20">
+    <testcase name="[It] [sig-node] Pods should be submitted and removed [Conformance]" classname="Kubernetes e2e suite" status="passed" time="10"></testcase>
+    <testcase name="[It] [sig-node] Pods should be evicted [Serial]" classname="Kubernetes e2e suite" status="skipped" time="0">
+      <skipped message="skipped"></skipped>
+    </testcase>
+    <testcase name="[It] [sig-storage] Volumes should store data" classname="Kubernetes e2e suite" status="skipped" time="0">
+      <skipped message="skipped"></skipped>
+    </testcase>
+    <testcase name="[It] [sig-node] Pods should run on windows [Conformance]" classname="Kubernetes e2e suite" status="skipped" time="0.1">
+      <skipped message="skipped - Only supported for node OS distro [windows] (not linux)"></skipped>
+    </testcase>
+    <testcase name="[It] [sig-node] Pods should use the feature [Conformance] [Feature:Foo]" classname="Kubernetes e2e suite" status="skipped" time="0">
+      <skipped message="skipped"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>`
+
+func TestSkipCounts(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		filters  specFilters
+		expected skipCounts
+	}{
+		{
+			desc:     "skip regex, focus regex and label filter",
+			filters:  newSpecFilters(`\[Serial\]`, `\[sig-node\]`, "!Feature:Foo"),
+			expected: skipCounts{Total: 5, Skipped: 4, SkipRegex: 1, FocusRegex: 1, LabelFilter: 1, Other: 1},
+		},
+		{
+			desc:     "skip regex only",
+			filters:  newSpecFilters(`\[Serial\]|\[sig-storage\]`, "", ""),
+			expected: skipCounts{Total: 5, Skipped: 4, SkipRegex: 2, Other: 2},
+		},
+		{
+			desc:     "no filters",
+			filters:  newSpecFilters("", "", ""),
+			expected: skipCounts{Total: 5, Skipped: 4, Other: 4},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var counts skipCounts
+			if err := counts.add([]byte(skippedReport), tc.filters); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if counts != tc.expected {
+				t.Errorf("expected %+v but got %+v", tc.expected, counts)
+			}
+		})
+	}
+}
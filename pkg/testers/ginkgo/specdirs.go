@@ -0,0 +1,159 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// specsDir is the directory next to a junit report holding a directory
+	// per failed spec of the report
+	specsDir = "specs"
+	// specOutputFile is the output of a failed spec in its directory
+	specOutputFile = "output.log"
+	// specNamespacesFile lists the test namespaces a failed spec used, one
+	// per line, to find them in the cluster dumps
+	specNamespacesFile = "namespaces.txt"
+	// maxSpecDirNameLength keeps the spec directories well below the file
+	// name limits, the full spec names are in the junit reports
+	maxSpecDirNameLength = 120
+)
+
+var (
+	specDirNameInvalid = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+	// specNamespace matches the namespaces the e2e framework logs as it
+	// creates, dumps and destroys them, e.g. Destroying namespace "pods-1234"
+	specNamespace = regexp.MustCompile(`namespace "([a-z0-9]([-a-z0-9]*[a-z0-9])?)"`)
+)
+
+// specDirName returns the name of the directory of a spec, its name stripped
+// of the characters that are not safe in paths, e.g.
+// "[sig-node] Pods should run" is sig-node_Pods_should_run
+func specDirName(specName string) string {
+	name := strings.Trim(specDirNameInvalid.ReplaceAllString(specName, "_"), "_.")
+	if len(name) > maxSpecDirNameLength {
+		name = strings.TrimRight(name[:maxSpecDirNameLength], "_.")
+	}
+	if name == "" {
+		return "unnamed"
+	}
+	return name
+}
+
+// specDir returns the directory of a failed spec of the junit report at
+// junitPath
+func specDir(junitPath, specName string) string {
+	return filepath.Join(filepath.Dir(junitPath), specsDir, specDirName(specName))
+}
+
+// specNamespaces returns the sorted test namespaces logged in output
+func specNamespaces(output string) []string {
+	seen := map[string]bool{}
+	var namespaces []string
+	for _, match := range specNamespace.FindAllStringSubmatch(output, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			namespaces = append(namespaces, match[1])
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// writeSpecDirs writes the output the e2e framework captured for each failed
+// spec of the junit reports in reportDir and its sub directories, e.g. the
+// events and pod logs of its namespaces gathered on failure, into a directory
+// per spec under specs/ next to the report, along with the namespaces the
+// spec used. The output of retried specs is appended to the same directory.
+func writeSpecDirs(reportDir string) error {
+	return filepath.WalkDir(reportDir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() && entry.Name() == specsDir {
+			return filepath.SkipDir
+		}
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, "junit") || filepath.Ext(name) != ".xml" {
+			return nil
+		}
+		report, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var parsed junitReport
+		if err := xml.Unmarshal(report, &parsed); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		for _, tc := range parsed.testCases() {
+			if tc.Failure == nil && tc.Error == nil {
+				continue
+			}
+			if err := writeSpecDir(specDir(path, tc.Name), tc); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// writeSpecDir appends the failure and the captured output of tc to the
+// output file in dir, and the namespaces found in them to the namespaces file
+func writeSpecDir(dir string, tc junitTestCase) error {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+	var output strings.Builder
+	fmt.Fprintf(&output, "%s\n\n", tc.Name)
+	for _, f := range []*junitFailure{tc.Failure, tc.Error} {
+		if f != nil {
+			fmt.Fprintf(&output, "%s\n\n", strings.TrimSpace(f.Contents))
+		}
+	}
+	for _, captured := range []string{tc.SystemErr, tc.SystemOut} {
+		if captured = strings.TrimSpace(captured); captured != "" {
+			fmt.Fprintf(&output, "%s\n\n", captured)
+		}
+	}
+	if err := appendFile(filepath.Join(dir, specOutputFile), output.String()); err != nil {
+		return err
+	}
+	namespaces := specNamespaces(output.String())
+	if len(namespaces) == 0 {
+		return nil
+	}
+	return appendFile(filepath.Join(dir, specNamespacesFile), strings.Join(namespaces, "\n")+"\n")
+}
+
+func appendFile(path, data string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ginkgo
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// ginkgoV2CapturedReport is a junit report of ginkgo v2 with the output
+// captured for a failed spec that was retried
+const ginkgoV2CapturedReport = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="2" failures="1">
+  <testsuite name="Kubernetes e2e suite" tests="2" failures="1">
+    <testcase name="[sig-storage] CSI mock volume should attach" classname="Kubernetes e2e suite" status="failed" time="61.2">
+      <failure message="timed out" type="failed">[FAILED] timed out waiting for the volume</failure>
+      <system-err>STEP: Collecting events from namespace "csi-mock-volumes-1234".
+pod csi-mockplugin-0 logs: plugin crashed
+STEP: Destroying namespace "csi-mock-volumes-1234" for this suite.</system-err>
+    </testcase>
+    <testcase name="[sig-storage] CSI mock volume should attach" classname="Kubernetes e2e suite" status="failed" time="30">
+      <failure message="timed out" type="failed">[FAILED] timed out again</failure>
+      <system-err>STEP: Collecting events from namespace "csi-mock-volumes-5678".</system-err>
+    </testcase>
+    <testcase name="[sig-node] Pods should be submitted and removed" classname="Kubernetes e2e suite" status="passed" time="10">
+      <system-err>STEP: Destroying namespace "pods-1111" for this suite.</system-err>
+    </testcase>
+  </testsuite>
+</testsuites>`
+
+func TestSpecDirName(t *testing.T) {
+	testCases := []struct {
+		specName string
+		expected string
+	}{
+		{
+			specName: "[sig-node] Pods should be submitted and removed [Conformance]",
+			expected: "sig-node_Pods_should_be_submitted_and_removed_Conformance",
+		},
+		{
+			specName: "[sig-storage] In-tree Volumes [Driver: local][LocalVolumeType: dir-link] should mount ../subpath",
+			expected: "sig-storage_In-tree_Volumes_Driver_local_LocalVolumeType_dir-link_should_mount_.._subpath",
+		},
+		{
+			specName: strings.Repeat("a", 200),
+			expected: strings.Repeat("a", maxSpecDirNameLength),
+		},
+		{
+			specName: "[]",
+			expected: "unnamed",
+		},
+	}
+	for _, tc := range testCases {
+		if name := specDirName(tc.specName); name != tc.expected {
+			t.Errorf("expected the dir of %q to be %q, but got %q", tc.specName, tc.expected, name)
+		}
+	}
+}
+
+func TestWriteSpecDirs(t *testing.T) {
+	reportDir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(reportDir, "ctx"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(reportDir, "ctx", "junit_ctx_01.xml"), []byte(ginkgoV2CapturedReport), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeSpecDirs(reportDir); err != nil {
+		t.Fatalf("did not expect an error, but got: %v", err)
+	}
+
+	specsDirs, err := os.ReadDir(filepath.Join(reportDir, "ctx", specsDir))
+	if err != nil {
+		t.Fatalf("expected the specs dir next to the report: %v", err)
+	}
+	if len(specsDirs) != 1 || specsDirs[0].Name() != "sig-storage_CSI_mock_volume_should_attach" {
+		t.Fatalf("expected a single dir for the failed spec, but got %v", specsDirs)
+	}
+	dir := filepath.Join(reportDir, "ctx", specsDir, specsDirs[0].Name())
+
+	output, err := os.ReadFile(filepath.Join(dir, specOutputFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"[FAILED] timed out waiting for the volume",
+		"pod csi-mockplugin-0 logs: plugin crashed",
+		"[FAILED] timed out again",
+	} {
+		if !strings.Contains(string(output), expected) {
+			t.Errorf("expected the spec output to contain %q, but got:\n%s", expected, output)
+		}
+	}
+
+	namespaces, err := os.ReadFile(filepath.Join(dir, specNamespacesFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"csi-mock-volumes-1234", "csi-mock-volumes-5678"}
+	if got := strings.Fields(string(namespaces)); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected the namespaces %v, but got %v", expected, got)
+	}
+}
@@ -17,7 +17,6 @@ limitations under the License.
 package testers
 
 import (
-	"os"
 	"path/filepath"
 
 	"sigs.k8s.io/kubetest2/pkg/artifacts"
@@ -25,46 +24,8 @@ import (
 )
 
 func WriteVersionToMetadata(version string) error {
-	var meta *metadata.CustomJSON
-	// check existing metadata and initialize it if it exists
-	metadataPath := filepath.Join(artifacts.BaseDir(), "metadata.json")
-	if _, err := os.Stat(metadataPath); err == nil {
-		metadataJSON, err := os.Open(metadataPath)
-		if err != nil {
-			return err
-		}
-		meta, err = metadata.NewCustomJSON(metadataJSON)
-		if err != nil {
-			return err
-		}
-
-		if err := metadataJSON.Sync(); err != nil {
-			return err
-		}
-		if err := metadataJSON.Close(); err != nil {
-			return err
-		}
-	} else {
-		meta, err = metadata.NewCustomJSON(nil)
-		if err != nil {
-			return err
-		}
-	}
-
-	if err := meta.Add("tester-version", version); err != nil {
-		return err
-	}
-
-	metadataJSON, err := os.Create(metadataPath)
-	if err != nil {
-		return err
-	}
-	if err := meta.Write(metadataJSON); err != nil {
-		return err
-	}
-
-	if err := metadataJSON.Sync(); err != nil {
-		return err
-	}
-	return metadataJSON.Close()
+	return metadata.AddToFile(
+		filepath.Join(artifacts.BaseDir(), "metadata.json"),
+		map[string]string{"tester-version": version},
+	)
 }
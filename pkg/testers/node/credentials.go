@@ -0,0 +1,88 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/gcp"
+)
+
+// setupGCPCredentials points gcloud, for the tester and the processes it
+// starts, to a private configuration using the credentials selected by
+// --gcp-service-account or --application-default-credentials, so that
+// the tests neither depend on nor change the active gcloud account of the
+// machine. It returns a function removing the private configuration.
+func (t *Tester) setupGCPCredentials() (func(), error) {
+	noop := func() {}
+	if t.GCPServiceAccount == "" && !t.ApplicationDefaultCredentials {
+		return noop, nil
+	}
+
+	// resolve the credentials before CLOUDSDK_CONFIG changes where the
+	// application default credentials of gcloud are looked up
+	var credentials string
+	var err error
+	if t.GCPServiceAccount != "" {
+		credentials, err = filepath.Abs(t.GCPServiceAccount)
+	} else {
+		credentials, err = gcp.ApplicationDefaultCredentialsFile()
+	}
+	if err != nil {
+		return noop, err
+	}
+
+	configDir, err := os.MkdirTemp("", "kubetest2-node-gcloud")
+	if err != nil {
+		return noop, err
+	}
+	cleanup := func() {
+		if err := os.RemoveAll(configDir); err != nil {
+			klog.Warningf("failed to remove the gcloud configuration %s: %v", configDir, err)
+		}
+	}
+	env := map[string]string{
+		"CLOUDSDK_CONFIG": configDir,
+		// the remote node e2e runner creates the instances with API clients
+		// using the application default credentials
+		"GOOGLE_APPLICATION_CREDENTIALS": credentials,
+	}
+	if t.ApplicationDefaultCredentials {
+		env["CLOUDSDK_AUTH_CREDENTIAL_FILE_OVERRIDE"] = credentials
+	}
+	for key, value := range env {
+		if err := os.Setenv(key, value); err != nil {
+			cleanup()
+			return noop, fmt.Errorf("failed to set %s: %w", key, err)
+		}
+	}
+
+	if t.GCPServiceAccount != "" {
+		klog.V(1).Infof("Activating the service account key %s in %s", credentials, configDir)
+		if err := gcp.ActivateServiceAccount(credentials); err != nil {
+			cleanup()
+			return noop, err
+		}
+	} else {
+		klog.V(1).Infof("Using the application default credentials %s in %s", credentials, configDir)
+	}
+	return cleanup, nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/gcp"
+)
+
+// setInstanceArch defaults the test artifacts to arm64 for ARM instance
+// types, the test binaries copied to the VMs must match their architecture.
+func (t *Tester) setInstanceArch() error {
+	if t.Provider != "gce" || !gcp.IsARMMachineType(t.InstanceType) {
+		return nil
+	}
+	if t.TargetBuildArch == "" {
+		t.TargetBuildArch = "linux/arm64"
+	} else if t.TargetBuildArch != "linux/arm64" {
+		return fmt.Errorf("--target-build-arch must be linux/arm64 for --instance-type %s, got %s", t.InstanceType, t.TargetBuildArch)
+	}
+	if !t.UseDockerizedBuild {
+		klog.Warningf("--instance-type %s has arm64 CPUs, the test artifacts are only cross-built for them with --use-dockerized-build", t.InstanceType)
+	}
+	return nil
+}
+
+// validateInstanceMetadata validates --instance-metadata, a comma separated
+// list of key=value entries, or key<path entries reading the value from the
+// file at path.
+func validateInstanceMetadata(metadata string) error {
+	if metadata == "" {
+		return nil
+	}
+	for _, entry := range strings.Split(metadata, ",") {
+		if i := strings.IndexAny(entry, "=<"); i < 1 {
+			return fmt.Errorf("invalid --instance-metadata entry %q, expected key=value or key<path", entry)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import "testing"
+
+func TestSetInstanceArch(t *testing.T) {
+	testCases := []struct {
+		name            string
+		provider        string
+		instanceType    string
+		targetBuildArch string
+		expectedArch    string
+		expectError     bool
+	}{
+		{
+			name:         "arm instance type",
+			provider:     "gce",
+			instanceType: "t2a-standard-4",
+			expectedArch: "linux/arm64",
+		},
+		{
+			name:            "arm instance type with arm64 artifacts",
+			provider:        "gce",
+			instanceType:    "c4a-highmem-8",
+			targetBuildArch: "linux/arm64",
+			expectedArch:    "linux/arm64",
+		},
+		{
+			name:            "arm instance type with amd64 artifacts",
+			provider:        "gce",
+			instanceType:    "t2a-standard-4",
+			targetBuildArch: "linux/amd64",
+			expectError:     true,
+		},
+		{
+			name:         "amd64 instance type",
+			provider:     "gce",
+			instanceType: "e2-standard-4",
+		},
+		{
+			name:         "ec2 instance type",
+			provider:     "ec2",
+			instanceType: "t2a-standard-4",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tester := &Tester{Provider: tc.provider, InstanceType: tc.instanceType, TargetBuildArch: tc.targetBuildArch}
+			err := tester.setInstanceArch()
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected an error but got --target-build-arch %q", tester.TargetBuildArch)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tester.TargetBuildArch != tc.expectedArch {
+				t.Errorf("expected --target-build-arch %q but got %q", tc.expectedArch, tester.TargetBuildArch)
+			}
+		})
+	}
+}
+
+func TestValidateInstanceMetadata(t *testing.T) {
+	testCases := []struct {
+		metadata    string
+		expectError bool
+	}{
+		{metadata: ""},
+		{metadata: "user-data<cloud-init.yaml"},
+		{metadata: "enable-oslogin=TRUE,user-data<cloud-init.yaml"},
+		{metadata: "enable-oslogin", expectError: true},
+		{metadata: "=TRUE", expectError: true},
+		{metadata: "enable-oslogin=TRUE,", expectError: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.metadata, func(t *testing.T) {
+			err := validateInstanceMetadata(tc.metadata)
+			if tc.expectError != (err != nil) {
+				t.Errorf("expected error %v but got %v", tc.expectError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/artifacts"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+// instanceNamePrefix is the prefix of the names of the test VMs, followed by
+// a suffix unique to each run, see runInstancePrefix.
+const instanceNamePrefix = "tmp-node-e2e-"
+
+// journalUnits are the systemd units whose journal is collected from each test VM.
+var journalUnits = []string{"kubelet", "containerd"}
+
+// runInstancePrefix returns a prefix for the names of the test VMs of a
+// single run, passed to the node e2e remote runner as INSTANCE_PREFIX, so
+// that the VMs of concurrent runs in the same project and zone are told apart.
+func runInstancePrefix() string {
+	return instanceNamePrefix + uuid.New().String()[:8]
+}
+
+// listTestInstances returns the names of the node e2e test VMs created by
+// this run in the tester's project and zone.
+func (t *Tester) listTestInstances() ([]string, error) {
+	cmd := exec.Command("gcloud", "compute", "instances", "list",
+		"--project", t.GCPProject,
+		"--zones", t.GCPZone,
+		"--filter", "name~^"+t.instancePrefix+"-",
+		"--format", "value(name)",
+	)
+	lines, err := exec.OutputLines(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list instances: %w", err)
+	}
+	var names []string
+	for _, line := range lines {
+		if name := strings.TrimSpace(line); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// dumpInstanceLogs writes the serial console output and the kubelet and containerd
+// journals of each instance into $ARTIFACTS/node-logs/<instance>.
+func (t *Tester) dumpInstanceLogs(instances []string) error {
+	var errs []error
+	for _, instance := range instances {
+		klog.V(1).Infof("collecting logs from instance %s", instance)
+		dir := filepath.Join(artifacts.BaseDir(), "node-logs", instance)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			errs = append(errs, fmt.Errorf("failed to create log directory for %s: %w", instance, err))
+			continue
+		}
+
+		serial := exec.Command("gcloud", "compute", "instances", "get-serial-port-output", instance,
+			"--project", t.GCPProject,
+			"--zone", t.GCPZone,
+		)
+		if err := writeCommandOutput(serial, filepath.Join(dir, "serial-console.log")); err != nil {
+			errs = append(errs, fmt.Errorf("failed to get serial console output of %s: %w", instance, err))
+		}
+
+		for _, unit := range journalUnits {
+			journal := exec.Command("gcloud", t.sshArgs(instance, "sudo journalctl --no-pager -u "+unit)...)
+			if err := writeCommandOutput(journal, filepath.Join(dir, unit+".log")); err != nil {
+				errs = append(errs, fmt.Errorf("failed to get %s journal of %s: %w", unit, instance, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deleteInstances deletes the given test VMs.
+func (t *Tester) deleteInstances(instances []string) error {
+	if len(instances) == 0 {
+		return nil
+	}
+	args := []string{"compute", "instances", "delete", "--quiet",
+		"--project", t.GCPProject,
+		"--zone", t.GCPZone,
+	}
+	cmd := exec.Command("gcloud", append(args, instances...)...)
+	exec.InheritOutput(cmd)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to delete instances %v: %w", instances, err)
+	}
+	return nil
+}
+
+// sshArgs returns the gcloud arguments to run command on instance over ssh.
+func (t *Tester) sshArgs(instance, command string) []string {
+	target := instance
+	if t.sshUser != "" {
+		target = t.sshUser + "@" + instance
+	}
+	args := []string{"compute", "ssh", target,
+		"--project", t.GCPProject,
+		"--zone", t.GCPZone,
+		"--command", command,
+	}
+	if t.privateKey != "" {
+		args = append(args, "--ssh-key-file", t.privateKey)
+	}
+	return args
+}
+
+// writeCommandOutput runs cmd and writes its stdout to path.
+func writeCommandOutput(cmd exec.Cmd, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	cmd.SetStdout(f)
+	cmd.SetStderr(os.Stderr)
+	return cmd.Run()
+}
@@ -24,8 +24,8 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/octago/sflags/gen/gpflag"
@@ -34,16 +34,16 @@ import (
 	"sigs.k8s.io/boskos/client"
 	"sigs.k8s.io/kubetest2/pkg/boskos"
 	"sigs.k8s.io/kubetest2/pkg/exec"
-	"sigs.k8s.io/kubetest2/pkg/fs"
+	"sigs.k8s.io/kubetest2/pkg/flagdump"
+	"sigs.k8s.io/kubetest2/pkg/gcp"
 	"sigs.k8s.io/kubetest2/pkg/testers"
+	"sigs.k8s.io/kubetest2/pkg/version"
 )
 
 var GitTag string
 
 const (
-	target          = "test-e2e-node"
-	ciPrivateKeyEnv = "GCE_SSH_PRIVATE_KEY_FILE"
-	ciPublicKeyEnv  = "GCE_SSH_PUBLIC_KEY_FILE"
+	target = "test-e2e-node"
 )
 
 type Tester struct {
@@ -53,6 +53,7 @@ type Tester struct {
 	SkipRegex                      string        `desc:"Regular expression of jobs to skip."`
 	FocusRegex                     string        `desc:"Regular expression of jobs to focus on."`
 	TestArgs                       string        `desc:"A space-separated list of arguments to pass to node e2e test."`
+	FlakeAttempts                  int           `desc:"How many times ginkgo on the hosts runs a failing spec, passed as --ginkgo.flake-attempts with the test args. Specs failing and then passing within their attempts are listed as flaky in node-e2e-results.json in the artifacts, and don't fail the tests with the gce provider if every host wrote its junit report."`
 	LabelFilter                    string        `desc:"Label filter arguments to be passed to ginkgo."`
 	BoskosAcquireTimeoutSeconds    int           `desc:"How long (in seconds) to hang on a request to Boskos to acquire a resource before erroring."`
 	BoskosHeartbeatIntervalSeconds int           `desc:"How often (in seconds) to send a heartbeat to Boskos to hold the acquired resource. 0 means no heartbeat."`
@@ -60,8 +61,8 @@ type Tester struct {
 	ImageConfigFile                string        `desc:"Path to a file containing image configuration."`
 	Images                         string        `desc:"List of images to use when creating instances separated by commas"`
 	ImageProject                   string        `desc:"A GCP Project containing an image to use when creating instances"`
-	InstanceType                   string        `desc:"Machine/Instance type to use on AWS/GCP"`
-	InstanceMetadata               string        `desc:"Instance Metadata to use for creating GCE instance"`
+	InstanceType                   string        `desc:"Machine/Instance type to use on AWS/GCP, passed as INSTANCE_TYPE. On GCP, ARM machine types like t2a-standard-4 default --target-build-arch to linux/arm64, use arm64 --images with them."`
+	InstanceMetadata               string        `desc:"Instance Metadata to use for creating GCE instance, passed as INSTANCE_METADATA. A comma separated list of key=value, or key<path to read the value from a file."`
 	UserDataFile                   string        `desc:"User Data to use for creating EC2 instance"`
 	Provider                       string        `desc:"Cloud Provider to use for node tests. Valid options are ec2 and gce"`
 	UseDockerizedBuild             bool          `desc:"Use dockerized build for test artifacts"`
@@ -72,7 +73,10 @@ type Tester struct {
 	RuntimeConfig                  string        `desc:"The runtime configuration for the API server. Format: a list of key=value pairs."`
 	Timeout                        time.Duration `desc:"How long (in golang duration format) to wait for ginkgo tests to complete."`
 	DeleteInstances                bool          `desc:"Where to delete instances after running the test"`
+	PreserveInstances              bool          `desc:"Keep the test VMs after the tests instead of deleting them, and run the tests on the VMs kept by a previous run of the same $USER with this flag and the same images, if any, instead of creating new ones. Speeds up iterating on node e2e tests, the kept VMs must be deleted by hand. Requires --gcp-project and the gce provider."`
 	NodeEnv                        string        `desc:"Additional metadata keys to add to a gce instance"`
+	GCPServiceAccount              string        `desc:"Path to a service account key file to activate for gcloud and the GCP API clients, instead of the active gcloud account of the machine."`
+	ApplicationDefaultCredentials  bool          `desc:"Use the application default credentials, from GOOGLE_APPLICATION_CREDENTIALS or gcloud auth application-default login, for gcloud and the GCP API clients, instead of the active gcloud account of the machine."`
 
 	// boskos struct field will be non-nil when the deployer is
 	// using boskos to acquire a GCP project
@@ -85,6 +89,13 @@ type Tester struct {
 	// this contains ssh key path
 	privateKey string
 	sshUser    string
+
+	// hosts are the VMs kept by a previous run with --preserve-instances
+	// that the tests run on
+	hosts []string
+
+	// instancePrefix is the prefix of the names of the VMs created by this run
+	instancePrefix string
 }
 
 func NewDefaultTester() *Tester {
@@ -98,6 +109,7 @@ func NewDefaultTester() *Tester {
 		GCPProjectType:                 "gce-project",
 		Provider:                       "gce",
 		DeleteInstances:                true,
+		FlakeAttempts:                  1,
 	}
 }
 
@@ -113,6 +125,7 @@ func (t *Tester) Execute() error {
 	fs.AddGoFlagSet(flag.CommandLine)
 
 	help := fs.BoolP("help", "h", false, "")
+	flagdump.PrintIfRequested(fs)
 	if err := fs.Parse(os.Args); err != nil {
 		return fmt.Errorf("failed to parse flags: %v", err)
 	}
@@ -138,30 +151,30 @@ func (t *Tester) Execute() error {
 	}
 
 	if t.Provider == "gce" {
-		t.maybeSetupSSHKeys()
+		cleanupCredentials, err := t.setupGCPCredentials()
+		if err != nil {
+			return fmt.Errorf("failed to set up GCP credentials: %w", err)
+		}
+		defer cleanupCredentials()
+
+		t.privateKey = gcp.MaybeSetupSSHKeys()
 
 		// try to acquire project from boskos
 		if t.GCPProject == "" {
 			klog.V(1).Info("no GCP project provided, acquiring from Boskos ...")
 
-			boskosClient, err := boskos.NewClient(t.BoskosLocation)
-			if err != nil {
-				return fmt.Errorf("failed to make boskos client: %s", err)
-			}
-			t.boskos = boskosClient
-
-			resource, err := boskos.Acquire(
-				t.boskos,
+			boskosClient, project, err := gcp.AcquireProject(
+				t.BoskosLocation,
 				t.GCPProjectType,
 				time.Duration(t.BoskosAcquireTimeoutSeconds)*time.Second,
 				time.Duration(t.BoskosHeartbeatIntervalSeconds)*time.Second,
 				t.boskosHeartbeatClose,
 			)
-
 			if err != nil {
-				return fmt.Errorf("init failed to get project from boskos: %s", err)
+				return fmt.Errorf("init failed: %s", err)
 			}
-			t.GCPProject = resource.Name
+			t.boskos = boskosClient
+			t.GCPProject = project
 			klog.V(1).Infof("got project %s from boskos", t.GCPProject)
 		}
 	}
@@ -192,51 +205,20 @@ func (t *Tester) validateFlags() error {
 	if t.GCPZone == "" && t.Provider == "gce" {
 		return fmt.Errorf("required --gcp-zone")
 	}
-	return nil
-}
-
-// maybeSetupSSHKeys will best-effort try to setup ssh keys for gcloud to reuse
-// from existing files pointed to by "well-known" environment variables used in CI
-func (t *Tester) maybeSetupSSHKeys() {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		klog.Warningf("failed to get user's home directory")
-		return
-	}
-	// check if there are existing ssh keys, if either exist don't do anything
-	klog.V(2).Info("checking for existing gcloud ssh keys...")
-	t.privateKey = filepath.Join(home, ".ssh", "google_compute_engine")
-	if _, err := os.Stat(t.privateKey); err == nil {
-		klog.V(2).Infof("found existing private key at %s", t.privateKey)
-		return
-	}
-	publicKey := t.privateKey + ".pub"
-	if _, err := os.Stat(publicKey); err == nil {
-		klog.V(2).Infof("found existing public key at %s", publicKey)
-		return
+	if t.GCPServiceAccount != "" && t.ApplicationDefaultCredentials {
+		return fmt.Errorf("--gcp-service-account and --application-default-credentials are mutually exclusive")
 	}
-
-	// no existing keys check for CI variables, create gcloud key files if both exist
-	// note only checks if relevant envs are non-empty, no actual key verification checks
-	maybePrivateKey, privateKeyEnvSet := os.LookupEnv(ciPrivateKeyEnv)
-	if !privateKeyEnvSet {
-		klog.V(2).Infof("%s is not set", ciPrivateKeyEnv)
-		return
-	}
-	maybePublicKey, publicKeyEnvSet := os.LookupEnv(ciPublicKeyEnv)
-	if !publicKeyEnvSet {
-		klog.V(2).Infof("%s is not set", ciPublicKeyEnv)
-		return
+	if t.PreserveInstances && (t.Provider != "gce" || t.GCPProject == "") {
+		// boskos projects are cleaned up after each run
+		return fmt.Errorf("--preserve-instances requires --gcp-project and the gce provider")
 	}
-
-	if err := fs.CopyFile(maybePrivateKey, t.privateKey); err != nil {
-		klog.Warningf("failed to copy %s to %s: %v", maybePrivateKey, t.privateKey, err)
-		return
+	if t.FlakeAttempts < 1 {
+		return fmt.Errorf("--flake-attempts must be at least 1")
 	}
-
-	if err := fs.CopyFile(maybePublicKey, publicKey); err != nil {
-		klog.Warningf("failed to copy %s to %s: %v", maybePublicKey, publicKey, err)
+	if err := validateInstanceMetadata(t.InstanceMetadata); err != nil {
+		return err
 	}
+	return t.setInstanceArch()
 }
 
 func (t *Tester) constructArgs() []string {
@@ -252,14 +234,13 @@ func (t *Tester) constructArgs() []string {
 		"CLOUDSDK_CORE_PROJECT=" + t.GCPProject,
 		// https://github.com/kubernetes/kubernetes/blob/96be00df69390ed41b8ec22facc43bcbb9c88aae/hack/make-rules/test-e2e-node.sh#L113
 		"ZONE=" + t.GCPZone,
-		"TEST_ARGS=" + t.TestArgs,
+		"TEST_ARGS=" + t.testArgs(),
 		"NODE_ENV= " + t.NodeEnv,
-		"DELETE_INSTANCES=" + strconv.FormatBool(t.DeleteInstances),
+		// instances are deleted by the tester after their logs are collected
+		"DELETE_INSTANCES=" + strconv.FormatBool(t.DeleteInstances && !t.collectsInstanceLogs()),
 		"PARALLELISM=" + strconv.Itoa(t.Parallelism),
-		"IMAGE_CONFIG_FILE=" + t.ImageConfigFile,
 		"IMAGE_CONFIG_DIR=" + t.ImageConfigDir,
 		"IMAGE_PROJECT=" + t.ImageProject,
-		"IMAGES=" + t.Images,
 		"INSTANCE_METADATA=" + t.InstanceMetadata,
 		"USER_DATA_FILE=" + t.UserDataFile,
 		"INSTANCE_TYPE=" + t.InstanceType,
@@ -269,6 +250,13 @@ func (t *Tester) constructArgs() []string {
 		"TARGET_BUILD_ARCH=" + t.TargetBuildArch,
 		"TIMEOUT=" + t.Timeout.String(),
 		"LABEL_FILTER=" + t.LabelFilter,
+		"INSTANCE_PREFIX=" + t.instancePrefix,
+	}
+	if len(t.hosts) > 0 {
+		// the images would create new VMs next to the hosts
+		argsFromFlags = append(argsFromFlags, "HOSTS="+strings.Join(t.hosts, ","), "IMAGE_CONFIG_FILE=", "IMAGES=")
+	} else {
+		argsFromFlags = append(argsFromFlags, "IMAGE_CONFIG_FILE="+t.ImageConfigFile, "IMAGES="+t.Images)
 	}
 	if t.RuntimeConfig != "" {
 		argsFromFlags = append(argsFromFlags, "RUNTIME_CONFIG="+t.RuntimeConfig)
@@ -276,17 +264,70 @@ func (t *Tester) constructArgs() []string {
 	return append(defaultArgs, argsFromFlags...)
 }
 
+// testArgs returns the args of the node e2e tests on the hosts
+func (t *Tester) testArgs() string {
+	if t.FlakeAttempts <= 1 {
+		return t.TestArgs
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s --ginkgo.flake-attempts=%d", t.TestArgs, t.FlakeAttempts))
+}
+
+// collectsInstanceLogs returns true if the tester collects logs from the
+// test VMs itself, in which case it is also responsible for deleting them.
+func (t *Tester) collectsInstanceLogs() bool {
+	return t.Provider == "gce"
+}
+
 func (t *Tester) Test() error {
+	t.instancePrefix = runInstancePrefix()
+	if t.PreserveInstances {
+		hosts, err := t.listPreservedInstances()
+		if err != nil {
+			return err
+		}
+		if len(hosts) > 0 {
+			klog.V(0).Infof("running the tests on the instances preserved by a previous run: %v", hosts)
+		}
+		t.hosts = hosts
+	}
+
 	var args []string
 	args = append(args, target)
 	args = append(args, t.constructArgs()...)
 	cmd := exec.Command("make", args...)
 	cmd.SetDir(t.RepoRoot)
 	exec.InheritOutput(cmd)
-	return cmd.Run()
+	started := time.Now()
+	runErr := cmd.Run()
+
+	if !t.collectsInstanceLogs() {
+		return t.checkResults(runErr, started, nil)
+	}
+	instances, err := t.listTestInstances()
+	if err != nil {
+		klog.Warningf("failed to find instances to collect logs from: %v", err)
+		return t.checkResults(runErr, started, nil)
+	}
+	testErr := t.checkResults(runErr, started, append(instances, t.hosts...))
+	if err := t.dumpInstanceLogs(append(instances, t.hosts...)); err != nil {
+		klog.Warningf("failed to collect instance logs: %v", err)
+	}
+	if t.PreserveInstances {
+		if err := t.preserveInstances(instances); err != nil {
+			klog.Errorf("%v", err)
+		}
+		return testErr
+	}
+	if t.DeleteInstances {
+		if err := t.deleteInstances(instances); err != nil {
+			klog.Errorf("%v", err)
+		}
+	}
+	return testErr
 }
 
 func Main() {
+	version.PrintIfRequested(GitTag)
 	t := NewDefaultTester()
 	if err := t.Execute(); err != nil {
 		klog.Fatalf("failed to run ginkgo tester: %v", err)
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/exec"
+)
+
+const (
+	// preservedLabel marks the test VMs kept by --preserve-instances for
+	// reuse by the next run
+	preservedLabel = "kubetest2-preserved"
+	// preservedOwnerLabel is the user who kept the test VMs, other users
+	// sharing the project don't reuse them
+	preservedOwnerLabel = "kubetest2-preserved-by"
+	// preservedImagesLabel identifies the images the test VMs were created
+	// from, runs requesting other images don't reuse them
+	preservedImagesLabel = "kubetest2-preserved-images"
+)
+
+// invalidLabelValueChars are the characters not allowed in GCE label values
+var invalidLabelValueChars = regexp.MustCompile(`[^a-z0-9_-]`)
+
+// labelsForPreserved returns the labels of the test VMs kept for owner with
+// images by --preserve-instances, images is hashed as it may be longer than
+// the 63 characters of a label value.
+func labelsForPreserved(owner, images string) []string {
+	owner = invalidLabelValueChars.ReplaceAllString(strings.ToLower(owner), "-")
+	if owner == "" {
+		owner = "unknown"
+	}
+	if len(owner) > 63 {
+		owner = owner[:63]
+	}
+	sum := sha256.Sum256([]byte(images))
+	return []string{
+		preservedLabel + "=true",
+		preservedOwnerLabel + "=" + owner,
+		preservedImagesLabel + "=" + hex.EncodeToString(sum[:])[:16],
+	}
+}
+
+// preservedInstancesFilter returns the gcloud filter of the running test VMs
+// with labels
+func preservedInstancesFilter(labels []string) string {
+	filter := "name~^" + instanceNamePrefix
+	for _, label := range labels {
+		filter += " AND labels." + label
+	}
+	return filter + " AND status=RUNNING"
+}
+
+// preservedLabels returns the labels of the test VMs kept by this user with
+// the images requested by the flags.
+func (t *Tester) preservedLabels() []string {
+	images := strings.Join([]string{t.ImageProject, t.Images, t.ImageConfigFile, t.ImageConfigDir}, "\n")
+	return labelsForPreserved(os.Getenv("USER"), images)
+}
+
+// listPreservedInstances returns the names of the running test VMs kept by
+// a previous run of the same user with --preserve-instances and the same
+// images in the tester's project and zone.
+func (t *Tester) listPreservedInstances() ([]string, error) {
+	cmd := exec.Command("gcloud", "compute", "instances", "list",
+		"--project", t.GCPProject,
+		"--zones", t.GCPZone,
+		"--filter", preservedInstancesFilter(t.preservedLabels()),
+		"--format", "value(name)",
+	)
+	lines, err := exec.OutputLines(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list preserved instances: %w", err)
+	}
+	var names []string
+	for _, line := range lines {
+		if name := strings.TrimSpace(line); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// preserveInstances labels the given test VMs for reuse by the next run.
+func (t *Tester) preserveInstances(instances []string) error {
+	for _, instance := range instances {
+		klog.V(1).Infof("preserving instance %s for the next run", instance)
+		cmd := exec.Command("gcloud", "compute", "instances", "add-labels", instance,
+			"--project", t.GCPProject,
+			"--zone", t.GCPZone,
+			"--labels", strings.Join(t.preservedLabels(), ","),
+		)
+		exec.InheritOutput(cmd)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to label instance %s: %w", instance, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLabelsForPreserved(t *testing.T) {
+	labels := labelsForPreserved("Jane.Doe", "cos-cloud\ncos-stable")
+	if len(labels) != 3 {
+		t.Fatalf("expected 3 labels but got %v", labels)
+	}
+	if labels[0] != "kubetest2-preserved=true" {
+		t.Errorf("expected the preserved label but got %q", labels[0])
+	}
+	if labels[1] != "kubetest2-preserved-by=jane-doe" {
+		t.Errorf("expected the owner label of jane-doe but got %q", labels[1])
+	}
+	images, ok := strings.CutPrefix(labels[2], "kubetest2-preserved-images=")
+	if !ok || len(images) != 16 {
+		t.Errorf("expected the images label with a 16 character hash but got %q", labels[2])
+	}
+
+	testCases := []struct {
+		name   string
+		owner  string
+		images string
+	}{
+		{name: "other owner", owner: "john", images: "cos-cloud\ncos-stable"},
+		{name: "other images", owner: "Jane.Doe", images: "cos-cloud\ncos-beta"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if other := labelsForPreserved(tc.owner, tc.images); strings.Join(other, ",") == strings.Join(labels, ",") {
+				t.Errorf("expected the labels of %s to differ but got %v", tc.name, other)
+			}
+		})
+	}
+}
+
+func TestPreservedInstancesFilter(t *testing.T) {
+	expected := "name~^tmp-node-e2e- AND labels.kubetest2-preserved=true AND labels.kubetest2-preserved-by=jane AND status=RUNNING"
+	if actual := preservedInstancesFilter([]string{"kubetest2-preserved=true", "kubetest2-preserved-by=jane"}); actual != expected {
+		t.Errorf("expected filter %q but got %q", expected, actual)
+	}
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/artifacts"
+)
+
+// resultsFile is the summary of the spec results written to the artifacts
+const resultsFile = "node-e2e-results.json"
+
+// retryPattern matches the note ginkgo v2 adds to the timeline of a spec for
+// each of its failed attempts which is retried
+var retryPattern = regexp.MustCompile(`Attempt #\d+ Failed\.\s+Retrying`)
+
+// results are the spec results of the junit reports the remote runner
+// copies from the hosts into the results dir
+type results struct {
+	// Hosts are the hosts with a junit report, named after the directory
+	// the remote runner copies their results to
+	Hosts []string `json:"hosts"`
+	// Specs is the number of specs which ran, skipped specs excluded
+	Specs int `json:"specs"`
+	// Failed are the specs which failed all their attempts
+	Failed []string `json:"failed"`
+	// Flaky are the specs which failed some attempts before passing
+	Flaky []string `json:"flaky"`
+}
+
+type junitReport struct {
+	Suites    []junitReport   `xml:"testsuite"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string    `xml:"name,attr"`
+	ClassName string    `xml:"classname,attr"`
+	Failure   *struct{} `xml:"failure"`
+	Error     *struct{} `xml:"error"`
+	Skipped   *struct{} `xml:"skipped"`
+	SystemOut string    `xml:"system-out"`
+	SystemErr string    `xml:"system-err"`
+}
+
+// attempts returns the number of attempts of the spec in its report. ginkgo
+// v1 reports each attempt as a test case while ginkgo v2 reports a single
+// test case, with the retried attempts in its timeline. The timeline of
+// passed specs may be left out of the report, e.g. by the e2e framework, the
+// retries of those are unknown.
+func (tc *junitTestCase) attempts() int {
+	return 1 + len(retryPattern.FindAllStringIndex(tc.SystemErr+tc.SystemOut, -1))
+}
+
+func (r *junitReport) testCases() []junitTestCase {
+	testCases := r.TestCases
+	for i := range r.Suites {
+		testCases = append(testCases, r.Suites[i].testCases()...)
+	}
+	return testCases
+}
+
+// specResult is the result of the attempts of a spec
+type specResult struct {
+	attempts int
+	passed   bool
+}
+
+// parseResults returns the results of the junit reports under dir written
+// since the tests started. The attempts of a spec are in the report of the
+// host it ran on, the same spec in the reports of other hosts is another
+// result. found is false if there are no reports, e.g. the build failed.
+func parseResults(dir string, since time.Time) (res results, found bool, err error) {
+	specs := map[string]*specResult{}
+	hosts := map[string]bool{}
+	err = filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name := entry.Name()
+		// junit_runner.xml is the report of kubetest2 itself
+		if entry.IsDir() || !strings.HasPrefix(name, "junit") || !strings.HasSuffix(name, ".xml") || name == "junit_runner.xml" {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().Before(since) {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var report junitReport
+		if err := xml.Unmarshal(data, &report); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		found = true
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if host, _, ok := strings.Cut(rel, string(filepath.Separator)); ok {
+			hosts[host] = true
+		}
+		for _, tc := range report.testCases() {
+			if tc.Skipped != nil {
+				continue
+			}
+			key := rel + ": " + strings.TrimSpace(tc.ClassName+" "+tc.Name)
+			spec, ok := specs[key]
+			if !ok {
+				spec = &specResult{}
+				specs[key] = spec
+			}
+			// the last attempt is the result of the spec
+			spec.attempts += tc.attempts()
+			spec.passed = tc.Failure == nil && tc.Error == nil
+		}
+		return nil
+	})
+	if err != nil {
+		return results{}, false, err
+	}
+
+	res = results{Hosts: []string{}, Failed: []string{}, Flaky: []string{}}
+	for host := range hosts {
+		res.Hosts = append(res.Hosts, host)
+	}
+	for key, spec := range specs {
+		res.Specs++
+		switch {
+		case !spec.passed:
+			res.Failed = append(res.Failed, key)
+		case spec.attempts > 1:
+			res.Flaky = append(res.Flaky, key)
+		}
+	}
+	sort.Strings(res.Hosts)
+	sort.Strings(res.Failed)
+	sort.Strings(res.Flaky)
+	return res, found, nil
+}
+
+// writeResults writes res to resultsFile in dir
+func writeResults(dir string, res results) error {
+	data, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, resultsFile), data, 0644)
+}
+
+// checkResults parses the results of the tests which started at started on
+// hosts from the results dir of the remote runner, the artifacts dir, and
+// returns the error the tests fail with, see testResult.
+func (t *Tester) checkResults(testErr error, started time.Time, hosts []string) error {
+	res, found, err := parseResults(artifacts.BaseDir(), started)
+	if err != nil {
+		klog.Warningf("failed to parse the test results: %v", err)
+		return testErr
+	}
+	if !found {
+		klog.Warningf("no junit reports of the tests found in %s", artifacts.BaseDir())
+		return testErr
+	}
+	klog.V(0).Infof("%d specs ran, %d failed and %d were flaky", res.Specs, len(res.Failed), len(res.Flaky))
+	if err := writeResults(artifacts.BaseDir(), res); err != nil {
+		klog.Warningf("failed to write %s: %v", resultsFile, err)
+	}
+	return t.testResult(testErr, res, hosts)
+}
+
+// testResult returns the error the tests fail with given the error of the
+// remote runner and the results of the specs on hosts. With flake attempts,
+// the runner may fail on specs which eventually passed, the tests then only
+// fail on the specs which failed all their attempts. The error of the runner
+// is only ignored if every host has a report, a host may have failed before
+// writing it, e.g. when it crashed, and hosts is nil if they are unknown.
+func (t *Tester) testResult(testErr error, res results, hosts []string) error {
+	if len(res.Failed) > 0 {
+		if testErr == nil {
+			testErr = fmt.Errorf("%d of %d specs failed", len(res.Failed), res.Specs)
+		}
+		return testErr
+	}
+	if testErr == nil || t.FlakeAttempts <= 1 || len(res.Flaky) == 0 {
+		return testErr
+	}
+	if len(hosts) == 0 {
+		klog.Warningf("Not ignoring the flakes %v, the hosts the tests ran on are unknown", res.Flaky)
+		return testErr
+	}
+	if missing := missingHosts(hosts, res.Hosts); len(missing) > 0 {
+		klog.Warningf("Not ignoring the flakes %v, the hosts %v have no junit report", res.Flaky, missing)
+		return testErr
+	}
+	klog.Warningf("The tests failed with %v, but all the specs passed within %d attempts, ignoring the flakes: %v", testErr, t.FlakeAttempts, res.Flaky)
+	return nil
+}
+
+// missingHosts returns the hosts which are not in reported
+func missingHosts(hosts, reported []string) []string {
+	found := map[string]bool{}
+	for _, host := range reported {
+		found[host] = true
+	}
+	var missing []string
+	for _, host := range hosts {
+		if !found[host] {
+			missing = append(missing, host)
+		}
+	}
+	return missing
+}
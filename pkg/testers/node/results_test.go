@@ -0,0 +1,214 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// ginkgoV1Flake is a junit report of ginkgo v1 with a spec which failed its
+// first attempt, each attempt is a test case
+const ginkgoV1Flake = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuite tests="3" failures="1" time="30">
+  <testcase name="[sig-node] Pods should restart" classname="E2eNode Suite" time="10">
+    <failure type="Failure">container never restarted</failure>
+  </testcase>
+  <testcase name="[sig-node] Pods should restart" classname="E2eNode Suite" time="10"></testcase>
+  <testcase name="[sig-node] Pods should start" classname="E2eNode Suite" time="10"></testcase>
+</testsuite>`
+
+// ginkgoV2Flake is a junit report of ginkgo v2 with a spec which failed its
+// first attempt, the attempts are in the timeline of a single test case
+const ginkgoV2Flake = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" failures="1">
+  <testsuite name="E2eNode Suite" tests="3" failures="1">
+    <testcase name="[It] [sig-node] Pods should restart" classname="E2eNode Suite" status="passed" time="20">
+      <system-err>STEP: creating the pod
+[FAILED] container never restarted
+Attempt #1 Failed.  Retrying ↺ @ 01/02/26 15:04:05.000
+STEP: creating the pod</system-err>
+    </testcase>
+    <testcase name="[It] [sig-node] Pods should start" classname="E2eNode Suite" status="passed" time="10"></testcase>
+    <testcase name="[It] [sig-node] Pods should run" classname="E2eNode Suite" status="failed" time="10">
+      <failure type="failed">[FAILED] pod never ran</failure>
+    </testcase>
+    <testcase name="[It] [sig-node] Pods should be skipped" classname="E2eNode Suite" status="skipped" time="0">
+      <skipped message="skipped"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>`
+
+func TestParseResults(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		reports       map[string]string
+		expected      results
+		expectedFound bool
+	}{
+		{
+			desc:     "no reports",
+			reports:  map[string]string{"host-a/build.log": "failed"},
+			expected: results{Hosts: []string{}, Failed: []string{}, Flaky: []string{}},
+		},
+		{
+			desc: "ginkgo v1 flake",
+			reports: map[string]string{
+				"host-a/junit_01.xml": ginkgoV1Flake,
+				"junit_runner.xml":    ginkgoV2Flake,
+			},
+			expected: results{
+				Hosts:  []string{"host-a"},
+				Specs:  2,
+				Failed: []string{},
+				Flaky:  []string{"host-a/junit_01.xml: E2eNode Suite [sig-node] Pods should restart"},
+			},
+			expectedFound: true,
+		},
+		{
+			desc: "ginkgo v2 flake and failure on several hosts",
+			reports: map[string]string{
+				"host-a/junit_01.xml": ginkgoV2Flake,
+				"host-b/junit_01.xml": ginkgoV1Flake,
+			},
+			expected: results{
+				Hosts:  []string{"host-a", "host-b"},
+				Specs:  5,
+				Failed: []string{"host-a/junit_01.xml: E2eNode Suite [It] [sig-node] Pods should run"},
+				Flaky: []string{
+					"host-a/junit_01.xml: E2eNode Suite [It] [sig-node] Pods should restart",
+					"host-b/junit_01.xml: E2eNode Suite [sig-node] Pods should restart",
+				},
+			},
+			expectedFound: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			started := time.Now().Add(-time.Minute)
+			for name, report := range tc.reports {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(report), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			// reports of a previous run are ignored
+			stale := filepath.Join(dir, "junit_stale.xml")
+			if err := os.WriteFile(stale, []byte(ginkgoV2Flake), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.Chtimes(stale, started.Add(-time.Hour), started.Add(-time.Hour)); err != nil {
+				t.Fatal(err)
+			}
+
+			res, found, err := parseResults(dir, started)
+			if err != nil {
+				t.Fatalf("did not expect an error, but got: %v", err)
+			}
+			if found != tc.expectedFound {
+				t.Errorf("expected found to be %t, but got %t", tc.expectedFound, found)
+			}
+			if !reflect.DeepEqual(res, tc.expected) {
+				t.Errorf("expected results %+v, but got %+v", tc.expected, res)
+			}
+		})
+	}
+}
+
+func TestTestResult(t *testing.T) {
+	runErr := errors.New("exit status 1")
+	testCases := []struct {
+		desc          string
+		flakeAttempts int
+		runErr        error
+		res           results
+		hosts         []string
+		expectedErr   bool
+	}{
+		{
+			desc:          "passed",
+			flakeAttempts: 2,
+			res:           results{Hosts: []string{"host-a"}, Specs: 1},
+			hosts:         []string{"host-a"},
+		},
+		{
+			desc:          "failed specs fail the tests even if the runner passed",
+			flakeAttempts: 2,
+			res:           results{Hosts: []string{"host-a"}, Specs: 1, Failed: []string{"spec"}},
+			hosts:         []string{"host-a"},
+			expectedErr:   true,
+		},
+		{
+			desc:          "flakes are ignored when every host has a report",
+			flakeAttempts: 2,
+			runErr:        runErr,
+			res:           results{Hosts: []string{"host-a", "host-b"}, Specs: 2, Flaky: []string{"spec"}},
+			hosts:         []string{"host-a", "host-b"},
+		},
+		{
+			desc:          "flakes are not ignored without flake attempts",
+			flakeAttempts: 1,
+			runErr:        runErr,
+			res:           results{Hosts: []string{"host-a"}, Specs: 1, Flaky: []string{"spec"}},
+			hosts:         []string{"host-a"},
+			expectedErr:   true,
+		},
+		{
+			desc:          "flakes are not ignored when a host has no report",
+			flakeAttempts: 2,
+			runErr:        runErr,
+			res:           results{Hosts: []string{"host-a"}, Specs: 1, Flaky: []string{"spec"}},
+			hosts:         []string{"host-a", "host-b"},
+			expectedErr:   true,
+		},
+		{
+			desc:          "flakes are not ignored when the hosts are unknown",
+			flakeAttempts: 2,
+			runErr:        runErr,
+			res:           results{Hosts: []string{"host-a"}, Specs: 1, Flaky: []string{"spec"}},
+			expectedErr:   true,
+		},
+		{
+			desc:          "the runner failed without flakes",
+			flakeAttempts: 2,
+			runErr:        runErr,
+			res:           results{Hosts: []string{"host-a"}, Specs: 1},
+			hosts:         []string{"host-a"},
+			expectedErr:   true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tester := &Tester{FlakeAttempts: tc.flakeAttempts}
+			err := tester.testResult(tc.runErr, tc.res, tc.hosts)
+			if tc.expectedErr && err == nil {
+				t.Error("expected an error, but got none")
+			}
+			if !tc.expectedErr && err != nil {
+				t.Errorf("did not expect an error, but got: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package scorecard implements a tester that runs the operator-sdk scorecard
+// tests (https://sdk.operatorframework.io/docs/testing-operators/scorecard/)
+// of an operator bundle against the cluster, so that operator authors can
+// use kubetest2 and any of its deployers as their e2e harness.
+package scorecard
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	stdexec "os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/octago/sflags/gen/gpflag"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/artifacts"
+	"sigs.k8s.io/kubetest2/pkg/exec"
+	"sigs.k8s.io/kubetest2/pkg/flagdump"
+	"sigs.k8s.io/kubetest2/pkg/testers"
+	"sigs.k8s.io/kubetest2/pkg/version"
+)
+
+var GitTag string
+
+type Tester struct {
+	Bundle         string        `desc:"Path to the operator bundle directory, or the bundle image, to run the scorecard tests of."`
+	OperatorSDK    string        `desc:"Path to the operator-sdk binary. Defaults to the operator-sdk found in PATH."`
+	Config         string        `desc:"Path to the scorecard config file, relative to the bundle. Defaults to the config in the bundle."`
+	Selector       string        `desc:"Label selector of the scorecard tests to run, e.g. suite=olm. Defaults to all the tests."`
+	Namespace      string        `desc:"Namespace the scorecard test pods run in. Defaults to the namespace of the kubeconfig context."`
+	ServiceAccount string        `desc:"Service account the scorecard test pods run as."`
+	WaitTime       time.Duration `desc:"How long to wait for the scorecard tests to complete."`
+	SkipCleanup    bool          `desc:"Do not delete the scorecard test pods after the tests, e.g. to debug them."`
+	InstallOLM     bool          `desc:"Install the Operator Lifecycle Manager before running the tests, unless it is already installed."`
+	OLMVersion     string        `desc:"Version of the Operator Lifecycle Manager installed with --install-olm. Defaults to the latest version."`
+}
+
+func NewDefaultTester() *Tester {
+	return &Tester{
+		WaitTime: 5 * time.Minute,
+	}
+}
+
+func (t *Tester) validateFlags() error {
+	if t.Bundle == "" {
+		return fmt.Errorf("required --bundle")
+	}
+	if t.WaitTime <= 0 {
+		return fmt.Errorf("--wait-time must be positive")
+	}
+	if t.OLMVersion != "" && !t.InstallOLM {
+		return fmt.Errorf("--olm-version requires --install-olm")
+	}
+	return nil
+}
+
+// scorecardArgs returns the operator-sdk args running the scorecard tests,
+// with the results written to stdout in the xunit format
+func (t *Tester) scorecardArgs(kubeconfig string) []string {
+	args := []string{"scorecard", t.Bundle,
+		"--output", "xunit",
+		"--wait-time", t.WaitTime.String(),
+	}
+	if kubeconfig != "" {
+		args = append(args, "--kubeconfig", kubeconfig)
+	}
+	if t.Config != "" {
+		args = append(args, "--config", t.Config)
+	}
+	if t.Selector != "" {
+		args = append(args, "--selector", t.Selector)
+	}
+	if t.Namespace != "" {
+		args = append(args, "--namespace", t.Namespace)
+	}
+	if t.ServiceAccount != "" {
+		args = append(args, "--service-account", t.ServiceAccount)
+	}
+	if t.SkipCleanup {
+		args = append(args, "--skip-cleanup")
+	}
+	return args
+}
+
+// Test runs the scorecard tests of the bundle, the results are written to
+// junit_scorecard.xml in the artifacts.
+func (t *Tester) Test() (result error) {
+	operatorSDK := t.OperatorSDK
+	if operatorSDK == "" {
+		path, err := stdexec.LookPath("operator-sdk")
+		if err != nil {
+			return fmt.Errorf("failed to find operator-sdk: %w", err)
+		}
+		operatorSDK = path
+	}
+
+	if t.InstallOLM {
+		if err := t.installOLM(operatorSDK); err != nil {
+			return err
+		}
+	}
+
+	junitFile, err := os.Create(filepath.Join(artifacts.BaseDir(), "junit_scorecard.xml"))
+	if err != nil {
+		return fmt.Errorf("could not create junit output: %w", err)
+	}
+	defer func() {
+		if err := junitFile.Close(); err != nil && result == nil {
+			result = err
+		}
+	}()
+
+	klog.V(0).Infof("Running the scorecard tests of bundle %s", t.Bundle)
+	cmd := exec.Command(operatorSDK, t.scorecardArgs(os.Getenv("KUBECONFIG"))...)
+	exec.SetOutput(cmd, junitFile, os.Stderr)
+	// operator-sdk exits non-zero when a test fails, the failures are in the junit
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("scorecard tests failed: %w", err)
+	}
+	return nil
+}
+
+// installOLM installs the Operator Lifecycle Manager, unless it is already installed
+func (t *Tester) installOLM(operatorSDK string) error {
+	if err := exec.Command(operatorSDK, "olm", "status").Run(); err == nil {
+		klog.V(0).Infof("The Operator Lifecycle Manager is already installed")
+		return nil
+	}
+	args := []string{"olm", "install"}
+	if t.OLMVersion != "" {
+		args = append(args, "--version", t.OLMVersion)
+	}
+	klog.V(0).Infof("Installing the Operator Lifecycle Manager")
+	cmd := exec.Command(operatorSDK, args...)
+	exec.InheritOutput(cmd)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to install the Operator Lifecycle Manager: %w", err)
+	}
+	return nil
+}
+
+func (t *Tester) Execute() error {
+	fs, err := gpflag.Parse(t)
+	if err != nil {
+		return fmt.Errorf("failed to initialize tester: %v", err)
+	}
+
+	// initing the klog flags adds them to goflag.CommandLine
+	// they can then be added to the built pflag set
+	klog.InitFlags(nil)
+	fs.AddGoFlagSet(flag.CommandLine)
+
+	help := fs.BoolP("help", "h", false, "")
+	flagdump.PrintIfRequested(fs)
+	if err := fs.Parse(os.Args); err != nil {
+		return fmt.Errorf("failed to parse flags: %v", err)
+	}
+
+	if *help {
+		fs.SetOutput(os.Stdout)
+		fs.PrintDefaults()
+		return nil
+	}
+	if err := t.validateFlags(); err != nil {
+		return fmt.Errorf("failed to validate flags: %v", err)
+	}
+	if err := testers.WriteVersionToMetadata(GitTag); err != nil {
+		return err
+	}
+	return t.Test()
+}
+
+func Main() {
+	version.PrintIfRequested(GitTag)
+	t := NewDefaultTester()
+	if err := t.Execute(); err != nil {
+		klog.Fatalf("failed to run scorecard tester: %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scorecard
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestScorecardArgs(t *testing.T) {
+	testCases := []struct {
+		name       string
+		tester     Tester
+		kubeconfig string
+		expected   []string
+	}{
+		{
+			name:     "defaults",
+			tester:   Tester{Bundle: "./bundle", WaitTime: 5 * time.Minute},
+			expected: []string{"scorecard", "./bundle", "--output", "xunit", "--wait-time", "5m0s"},
+		},
+		{
+			name: "all flags",
+			tester: Tester{
+				Bundle:         "quay.io/example/memcached-operator-bundle:v0.0.1",
+				Config:         "tests/scorecard/config.yaml",
+				Selector:       "suite=olm",
+				Namespace:      "scorecard",
+				ServiceAccount: "scorecard",
+				WaitTime:       90 * time.Second,
+				SkipCleanup:    true,
+			},
+			kubeconfig: "/tmp/kubeconfig",
+			expected: []string{"scorecard", "quay.io/example/memcached-operator-bundle:v0.0.1",
+				"--output", "xunit",
+				"--wait-time", "1m30s",
+				"--kubeconfig", "/tmp/kubeconfig",
+				"--config", "tests/scorecard/config.yaml",
+				"--selector", "suite=olm",
+				"--namespace", "scorecard",
+				"--service-account", "scorecard",
+				"--skip-cleanup",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args := tc.tester.scorecardArgs(tc.kubeconfig)
+			if !reflect.DeepEqual(args, tc.expected) {
+				t.Errorf("expected args %v but got %v", tc.expected, args)
+			}
+		})
+	}
+}
+
+func TestValidateFlags(t *testing.T) {
+	testCases := []struct {
+		name        string
+		tester      Tester
+		expectError bool
+	}{
+		{
+			name:   "valid",
+			tester: Tester{Bundle: "./bundle", WaitTime: time.Minute, InstallOLM: true, OLMVersion: "0.28.0"},
+		},
+		{
+			name:        "missing bundle",
+			tester:      Tester{WaitTime: time.Minute},
+			expectError: true,
+		},
+		{
+			name:        "no wait time",
+			tester:      Tester{Bundle: "./bundle"},
+			expectError: true,
+		},
+		{
+			name:        "olm version without install",
+			tester:      Tester{Bundle: "./bundle", WaitTime: time.Minute, OLMVersion: "0.28.0"},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.tester.validateFlags()
+			if tc.expectError && err == nil {
+				t.Errorf("expected an error but got none")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("did not expect an error, but got: %v", err)
+			}
+		})
+	}
+}
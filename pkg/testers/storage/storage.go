@@ -0,0 +1,134 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package storage implements a tester that certifies storage drivers, e.g.
+// CSI drivers, with the external storage testsuites of the kubernetes e2e
+// tests (https://github.com/kubernetes/kubernetes/tree/master/test/e2e/storage/external).
+// It is built on top of the ginkgo tester, whose flags it accepts too, and
+// focuses the tests on the drivers described by --test-driver.
+package storage
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kballard/go-shellquote"
+	"github.com/octago/sflags/gen/gpflag"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubetest2/pkg/flagdump"
+	"sigs.k8s.io/kubetest2/pkg/testers/ginkgo"
+	"sigs.k8s.io/kubetest2/pkg/version"
+)
+
+var GitTag string
+
+const (
+	// defaultFocusRegex selects the external storage testsuites
+	defaultFocusRegex = `External.Storage`
+	// defaultSkipRegex skips the tests of optional features and the
+	// disruptive tests, as recommended for the certification of drivers
+	defaultSkipRegex = `\[Feature:|\[Disruptive\]`
+)
+
+type Tester struct {
+	TestDriver []string `desc:"Comma separated list of paths to the test driver YAML files describing the storage drivers to test and their capabilities, passed to e2e.test as --storage.testdriver. See https://github.com/kubernetes/kubernetes/tree/master/test/e2e/storage/external for the format."`
+
+	ginkgo *ginkgo.Tester
+}
+
+// testDriverArgs returns the e2e.test args of the test drivers, with
+// absolute paths as ginkgo changes its working directory
+func testDriverArgs(testDrivers []string) ([]string, error) {
+	if len(testDrivers) == 0 {
+		return nil, fmt.Errorf("--test-driver is required")
+	}
+	var args []string
+	for _, testDriver := range testDrivers {
+		path, err := filepath.Abs(testDriver)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert test driver %s to absolute path: %w", testDriver, err)
+		}
+		if _, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("failed to find test driver: %w", err)
+		}
+		args = append(args, "--storage.testdriver="+path)
+	}
+	return args, nil
+}
+
+// Test runs the external storage testsuites against the test drivers
+func (t *Tester) Test() error {
+	args, err := testDriverArgs(t.TestDriver)
+	if err != nil {
+		return err
+	}
+	t.ginkgo.TestArgs = strings.TrimSpace(t.ginkgo.TestArgs + " " + shellquote.Join(args...))
+	klog.V(0).Infof("Running the external storage tests for test drivers %v", t.TestDriver)
+	return t.ginkgo.Run()
+}
+
+func (t *Tester) Execute() error {
+	fs, err := gpflag.Parse(t)
+	if err != nil {
+		return fmt.Errorf("failed to initialize tester: %v", err)
+	}
+	ginkgoFlags, err := gpflag.Parse(t.ginkgo)
+	if err != nil {
+		return fmt.Errorf("failed to initialize ginkgo tester: %v", err)
+	}
+	fs.AddFlagSet(ginkgoFlags)
+
+	fs.AddGoFlagSet(flag.CommandLine)
+
+	help := fs.BoolP("help", "h", false, "")
+	flagdump.PrintIfRequested(fs)
+
+	if err := fs.Parse(os.Args); err != nil {
+		return fmt.Errorf("failed to parse flags: %v", err)
+	}
+
+	if *help {
+		fs.SetOutput(os.Stdout)
+		fs.PrintDefaults()
+		return nil
+	}
+
+	return t.Test()
+}
+
+func NewDefaultTester() *Tester {
+	g := ginkgo.NewDefaultTester()
+	g.FocusRegex = defaultFocusRegex
+	g.SkipRegex = defaultSkipRegex
+	return &Tester{
+		ginkgo: g,
+	}
+}
+
+func Main() {
+	version.PrintIfRequested(GitTag)
+	// the ginkgo tester records its version in the metadata, which is the
+	// version of this binary
+	ginkgo.GitTag = GitTag
+	t := NewDefaultTester()
+	if err := t.Execute(); err != nil {
+		klog.Fatalf("failed to run storage tester: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTestDriverArgs(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"hostpath.yaml", "snapshots.yaml"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("StorageClass:\n  FromName: true\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		testDrivers []string
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:        "no test driver",
+			expectError: true,
+		},
+		{
+			name:        "test drivers",
+			testDrivers: []string{filepath.Join(dir, "hostpath.yaml"), filepath.Join(dir, "snapshots.yaml")},
+			expected: []string{
+				"--storage.testdriver=" + filepath.Join(dir, "hostpath.yaml"),
+				"--storage.testdriver=" + filepath.Join(dir, "snapshots.yaml"),
+			},
+		},
+		{
+			name:        "missing test driver",
+			testDrivers: []string{filepath.Join(dir, "missing.yaml")},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			args, err := testDriverArgs(tc.testDrivers)
+			if tc.expectError != (err != nil) {
+				t.Fatalf("expected error %v but got %v", tc.expectError, err)
+			}
+			if !reflect.DeepEqual(args, tc.expected) {
+				t.Errorf("expected args %v but got %v", tc.expected, args)
+			}
+		})
+	}
+}
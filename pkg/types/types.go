@@ -19,6 +19,8 @@ limitations under the License.
 package types
 
 import (
+	"context"
+
 	"github.com/spf13/pflag"
 )
 
@@ -105,6 +107,28 @@ type DeployerWithProvider interface {
 	Provider() string
 }
 
+// DeployerWithProviderConfig adds the ability to describe the cluster with
+// the provider specific flags of the e2e framework, e.g. its GCE zone, which
+// kubetest2 writes to a provider config file for the tester.
+type DeployerWithProviderConfig interface {
+	Deployer
+
+	// ProviderConfig returns the values of the e2e framework flags by flag
+	// name without the leading dashes, e.g. "gce-project".
+	ProviderConfig() (map[string]string, error)
+}
+
+// DeployerWithContext adds the ability to run Up within the time budget of
+// the run, e.g. to stop retrying in other zones when the remaining time
+// cannot fit another attempt and the tests.
+type DeployerWithContext interface {
+	Deployer
+
+	// UpWithContext is called instead of Up. When --run-timeout is set, ctx
+	// has the deadline of the run.
+	UpWithContext(ctx context.Context) error
+}
+
 // DeployerWithPostTester adds the ability to define after-test behavior
 // based on the results of the test.
 type DeployerWithPostTester interface {
@@ -131,6 +155,106 @@ type DeployerWithInit interface {
 	Init() error
 }
 
+// StepRunner runs step, recording it as a JUnit test case with the given name.
+type StepRunner func(name string, step func() error) error
+
+// Run runs step with the StepRunner, or directly if it is nil, so that
+// deployers work the same when they are not run by kubetest2.
+func (r StepRunner) Run(name string, step func() error) error {
+	if r == nil {
+		return step()
+	}
+	return r(name, step)
+}
+
+// DeployerWithSteps adds the ability to report the phases of the lifecycle
+// actions, e.g. "CreateNetwork" during Up, as individual JUnit test cases for
+// finer-grained results than the single step per lifecycle action.
+type DeployerWithSteps interface {
+	Deployer
+
+	// SetStepRunner is called prior to any other lifecycle action with the
+	// StepRunner the deployer should run its named steps with.
+	SetStepRunner(run StepRunner)
+}
+
+// BuildManifest describes the artifacts produced by Build, so that they can
+// be consumed by a separate run, e.g. another job running Up and Test.
+type BuildManifest struct {
+	// Version is the version of the build
+	Version string `json:"version"`
+	// Tars are the local paths of the release tarballs
+	Tars []string `json:"tars,omitempty"`
+	// Images are the tags of the built container images
+	Images []string `json:"images,omitempty"`
+	// StagedURL is where the build was staged to, e.g. gs://bucket/ci/v1.30.0
+	StagedURL string `json:"stagedURL,omitempty"`
+}
+
+// DeployerWithBuildManifest adds the ability to report the artifacts of
+// Build, written out by kubetest2 when only --build is requested.
+type DeployerWithBuildManifest interface {
+	Deployer
+
+	// BuildManifest returns the manifest of the artifacts built by Build,
+	// it is only called after Build succeeds.
+	BuildManifest() (*BuildManifest, error)
+}
+
+// Plan describes the resources a lifecycle action creates or deletes, shown
+// to the user before the action in interactive mode.
+type Plan struct {
+	// Resources are human readable descriptions of the resources, e.g.
+	// "cluster kt2-abc in project my-project (us-central1-c)"
+	Resources []string
+	// Shared is true when the resources live in a project that was not
+	// acquired for the run, e.g. the personal project of the user
+	Shared bool
+}
+
+// DeployerWithPlan adds the ability to describe the resources of Up and
+// Down before they are run.
+type DeployerWithPlan interface {
+	Deployer
+
+	// Plan returns the plan of the lifecycle action, "Up" or "Down". It is
+	// called after Init.
+	Plan(action string) (*Plan, error)
+}
+
+// Capability is a lifecycle action of a Deployer, named after its method
+type Capability string
+
+const (
+	CapabilityBuild Capability = "Build"
+	CapabilityUp    Capability = "Up"
+	CapabilityDown  Capability = "Down"
+	// CapabilityIsUp is not called by kubetest2 itself, it tells programs
+	// embedding kubetest2 whether IsUp reports the state of the cluster
+	CapabilityIsUp Capability = "IsUp"
+)
+
+// DeployerWithCapabilities adds the ability to declare the lifecycle actions
+// the deployer does not support, kubetest2 then rejects the flags requesting
+// them, e.g. --build, instead of calling a method that does nothing.
+type DeployerWithCapabilities interface {
+	Deployer
+
+	// Unsupported returns the lifecycle actions the deployer does not support
+	Unsupported() []Capability
+}
+
+// DeployerWithVerifyDown adds the ability to check that Down deleted all
+// the resources of the run, so that leaks are caught by the job instead of
+// by the janitors.
+type DeployerWithVerifyDown interface {
+	Deployer
+
+	// VerifyDown returns human readable descriptions of the resources left
+	// over by Down, empty if there are none. It is called after Down succeeds.
+	VerifyDown() ([]string, error)
+}
+
 // DeployerWithFinish adds the ability to define finalizer behavior
 type DeployerWithFinish interface {
 	Deployer
@@ -65,5 +65,6 @@ func PseudoUniqueSubstring(uuid string) string {
 	if len(uuid) <= maxResourceNamePrefixLength {
 		return uuid
 	}
-	return uuid[:maxResourceNamePrefixLength]
+	// resource names can't end with a dash
+	return strings.TrimRight(uuid[:maxResourceNamePrefixLength], "-")
 }
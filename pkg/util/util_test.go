@@ -34,6 +34,11 @@ func TestPseudoUniqueSubstring(t *testing.T) {
 			uuid:              "09a2565a-7ac6",
 			expectedSubstring: "09a2565a-7ac6",
 		},
+		{
+			name:              "dash at the end of the substring",
+			uuid:              "bob-09a2565a-7ac6",
+			expectedSubstring: "bob-09a2565a",
+		},
 		{
 			name:              "empty string",
 			uuid:              "",
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package version reports the build information of the kubetest2 binaries
+package version
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+)
+
+// BuildDate is the date the binary was built, set with
+// -ldflags=-X=sigs.k8s.io/kubetest2/pkg/version.BuildDate=...
+// If unset the commit time recorded by the go toolchain is reported instead.
+var BuildDate string
+
+// RequestEnv is set by `kubetest2 version` when it runs the deployers and
+// testers, which then print their Info as JSON instead of running
+const RequestEnv = "KUBETEST2_VERSION_REQUEST"
+
+// Info is the build information of a kubetest2 binary
+type Info struct {
+	Binary    string `json:"binary"`
+	GitTag    string `json:"gitTag"`
+	GoVersion string `json:"goVersion"`
+	BuildDate string `json:"buildDate,omitempty"`
+	// Error is set when the version of the binary could not be determined
+	Error string `json:"error,omitempty"`
+}
+
+// Get returns the Info of the running binary, gitTag is the GitTag the
+// binary was built with
+func Get(gitTag string) Info {
+	return Info{
+		Binary:    filepath.Base(os.Args[0]),
+		GitTag:    gitTag,
+		GoVersion: runtime.Version(),
+		BuildDate: buildDate(),
+	}
+}
+
+func buildDate() string {
+	if BuildDate != "" {
+		return BuildDate
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.time" {
+				return setting.Value
+			}
+		}
+	}
+	return ""
+}
+
+// Requested returns true if the binary was run by `kubetest2 version`
+func Requested() bool {
+	return os.Getenv(RequestEnv) != ""
+}
+
+// Print writes info to w as JSON, as expected by `kubetest2 version`
+func Print(w io.Writer, info Info) error {
+	return json.NewEncoder(w).Encode(info)
+}
+
+// PrintIfRequested prints the Info of the running binary to stdout and exits
+// if the binary was run by `kubetest2 version`, for the tester entrypoints
+func PrintIfRequested(gitTag string) {
+	if !Requested() {
+		return
+	}
+	if err := Print(os.Stdout, Get(gitTag)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// Parse parses the output of a binary run by `kubetest2 version`
+func Parse(output []byte) (Info, error) {
+	var info Info
+	if err := json.Unmarshal(output, &info); err != nil {
+		return Info{}, errors.New("the binary did not report its version")
+	}
+	return info, nil
+}
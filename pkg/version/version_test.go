@@ -0,0 +1,57 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrintParse(t *testing.T) {
+	info := Info{
+		Binary:    "kubetest2-kind",
+		GitTag:    "v20261016-abcdef0",
+		GoVersion: "go1.22.8",
+		BuildDate: "2026-10-16T10:00:00Z",
+	}
+	var buf bytes.Buffer
+	if err := Print(&buf, info); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parsed, err := Parse(buf.Bytes())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if parsed != info {
+		t.Errorf("expected %#v but got %#v", info, parsed)
+	}
+}
+
+func TestParseUsage(t *testing.T) {
+	// binaries which don't know about RequestEnv print their usage
+	if _, err := Parse([]byte("Usage:\n  kubetest2-tester-foo [flags]\n")); err == nil {
+		t.Errorf("expected an error parsing usage but got none")
+	}
+}
+
+func TestBuildDate(t *testing.T) {
+	BuildDate = "2026-10-16T10:00:00Z"
+	defer func() { BuildDate = "" }()
+	if info := Get("v1"); info.BuildDate != BuildDate || info.GitTag != "v1" {
+		t.Errorf("unexpected info %#v", info)
+	}
+}